@@ -11,7 +11,12 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/controllers"
 )
 
-var version = "dev"
+// Build metadata, injected with -ldflags "-X main.version=... -X main.commit=... -X main.date=...".
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
 
 func buildRootCommand(localController *controllers.LocalController) *cobra.Command {
 	bind := localController.GetBind()
@@ -28,7 +33,8 @@ Supports GitHub, GitLab, and Azure DevOps as Git hosting providers.
 Usage modes:
   autoupdate .              Update the current local repository (standalone mode)
   autoupdate /path/to/repo  Update a specific local repository
-  autoupdate run            Batch mode using a config file (cronjob)`,
+  autoupdate run            Batch mode using a config file (cronjob)
+  autoupdate plan           List outdated dependencies without changing anything`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(command *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -49,6 +55,8 @@ Usage modes:
 	cmd.PersistentFlags().BoolP("verbose", "v", false,
 		"Enable verbose output")
 
+	localController.AddFlags(cmd)
+
 	_ = bind // suppress unused warning
 	return cmd
 }
@@ -74,6 +82,15 @@ func addSubcommands(rootCmd *cobra.Command, appContext *internal.AppInternal) {
 		if sc, ok := ctrl.(*controllers.SelfUpdateController); ok {
 			sc.AddFlags(subCmd)
 		}
+		if lc, ok := ctrl.(*controllers.LocalController); ok {
+			lc.AddFlags(subCmd)
+		}
+		if pc, ok := ctrl.(*controllers.PlanController); ok {
+			pc.AddFlags(subCmd)
+		}
+		if vc, ok := ctrl.(*controllers.VersionController); ok {
+			vc.AddFlags(subCmd)
+		}
 
 		rootCmd.AddCommand(subCmd)
 	}
@@ -89,8 +106,10 @@ func main() {
 		logger.SetLevel(logger.DebugLevel)
 	}
 
-	// Bridge build-time version to domain package
+	// Bridge build-time metadata to domain package
 	commands.AutoupdateVersion = version //nolint:reassign // intentional cross-package assignment of build-time version
+	commands.AutoupdateCommit = commit   //nolint:reassign // intentional cross-package assignment of build-time commit
+	commands.AutoupdateBuildDate = date  //nolint:reassign // intentional cross-package assignment of build-time date
 
 	// Inject controllers via DIG
 	localController := injectLocalController()
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+const changesReportFileMode = 0o600
+
+// changesReport is the machine-readable artifact listing every dependency
+// change that made it into a pull request during the run, meant for
+// downstream audit tooling.
+type changesReport struct {
+	Changes []entities.DependencyChange `json:"changes"`
+}
+
+// writeChangesReport writes the run's dependency changes as JSON, replacing
+// any report left at path by a previous run. A run without changes still
+// writes an empty list so consumers can tell it apart from a failed run.
+func writeChangesReport(path string, changes []entities.DependencyChange) error {
+	report := changesReport{Changes: changes}
+	if report.Changes == nil {
+		report.Changes = []entities.DependencyChange{}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode changes report: %w", err)
+	}
+	if writeErr := os.WriteFile(path, append(data, '\n'), changesReportFileMode); writeErr != nil {
+		return fmt.Errorf("failed to write changes report: %w", writeErr)
+	}
+	return nil
+}
+
+// collectDependencyChanges gathers the changes reported by the updaters
+// that contributed to a repository's pull request, tagging each one with
+// the repository and, when the updater left it empty, the updater name.
+func collectDependencyChanges(repoName string, applied []appliedUpdaterResult) []entities.DependencyChange {
+	var changes []entities.DependencyChange
+	for _, a := range applied {
+		for _, change := range a.result.Changes {
+			change.Repository = repoName
+			if change.Ecosystem == "" {
+				change.Ecosystem = a.name
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -19,6 +19,12 @@ func RegisterProviders(container *dig.Container) error {
 	if err := container.Provide(NewSelfUpdateCommand); err != nil {
 		return err
 	}
+	if err := container.Provide(NewUpdatersCommand); err != nil {
+		return err
+	}
+	if err := container.Provide(NewPlanCommand); err != nil {
+		return err
+	}
 
 	// Bind interfaces to implementations
 	if err := container.Provide(func(impl *RunCommand) Run {
@@ -41,6 +47,16 @@ func RegisterProviders(container *dig.Container) error {
 	}); err != nil {
 		return err
 	}
+	if err := container.Provide(func(impl *UpdatersCommand) Updaters {
+		return impl
+	}); err != nil {
+		return err
+	}
+	if err := container.Provide(func(impl *PlanCommand) Plan {
+		return impl
+	}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// plannedUpgrade is one entry of the dry-run report: a dependency version
+// an updater would move if the run were not a dry run.
+type plannedUpgrade struct {
+	Repository     string `json:"repository"`
+	Ecosystem      string `json:"ecosystem"`
+	Dependency     string `json:"dependency"`
+	CurrentVersion string `json:"current_version"`
+	TargetVersion  string `json:"target_version"`
+	File           string `json:"file"`
+}
+
+// writeDryRunReport writes the planned upgrades of a dry run as a JSON array,
+// replacing any report left at path by a previous run. A run with nothing to
+// upgrade writes an empty array, so CI gates can tell it apart from a failure.
+func writeDryRunReport(path string, planned []entities.DependencyChange) error {
+	upgrades := make([]plannedUpgrade, 0, len(planned))
+	for _, change := range planned {
+		upgrades = append(upgrades, plannedUpgrade{
+			Repository:     change.Repository,
+			Ecosystem:      change.Ecosystem,
+			Dependency:     change.Name,
+			CurrentVersion: change.From,
+			TargetVersion:  change.To,
+			File:           change.File,
+		})
+	}
+
+	data, err := json.MarshalIndent(upgrades, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode dry-run report: %w", err)
+	}
+	if writeErr := os.WriteFile(path, append(data, '\n'), changesReportFileMode); writeErr != nil {
+		return fmt.Errorf("failed to write dry-run report: %w", writeErr)
+	}
+	return nil
+}
@@ -3,13 +3,24 @@
 package commands
 
 import (
+	"context"
+	"io"
+
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+	langEntities "github.com/rios0rios0/langforge/pkg/domain/entities"
 )
 
 // ParseRemoteURL exports parseRemoteURL for testing.
 var ParseRemoteURL = parseRemoteURL //nolint:gochecknoglobals // test export
 
+// GoLocalUpgradeOptions exports goLocalUpgradeOptions for testing.
+var GoLocalUpgradeOptions = goLocalUpgradeOptions //nolint:gochecknoglobals // test export
+
+// ResolveRemote exports resolveRemote for testing.
+var ResolveRemote = resolveRemote //nolint:gochecknoglobals // test export
+
 // RemoteInfo exports remoteInfo for testing.
 type RemoteInfo = remoteInfo
 
@@ -27,14 +38,22 @@ func FilterRepositories(
 	return filterRepositories(repos, settings)
 }
 
+// FilterRepositoriesByPattern exports filterRepositoriesByPattern for testing.
+func FilterRepositoriesByPattern(
+	repos []entities.Repository,
+	include, exclude []string,
+) []entities.Repository {
+	return filterRepositoriesByPattern(repos, include, exclude)
+}
+
 // CheckLocalRepoConfigSkip exports checkLocalRepoConfigSkip for testing.
 var CheckLocalRepoConfigSkip = checkLocalRepoConfigSkip //nolint:gochecknoglobals // test export
 
 // IsExcludedByGlobalList exports isExcludedByGlobalList for testing.
 var IsExcludedByGlobalList = isExcludedByGlobalList //nolint:gochecknoglobals // test export
 
-// IsSkippedByRepoConfig exports isSkippedByRepoConfig for testing.
-var IsSkippedByRepoConfig = isSkippedByRepoConfig //nolint:gochecknoglobals // test export
+// ReadRepoConfig exports readRepoConfig for testing.
+var ReadRepoConfig = readRepoConfig //nolint:gochecknoglobals // test export
 
 // RunLocalUpgrade exports runLocalUpgrade for testing.
 var RunLocalUpgrade = runLocalUpgrade //nolint:gochecknoglobals // test export
@@ -42,6 +61,32 @@ var RunLocalUpgrade = runLocalUpgrade //nolint:gochecknoglobals // test export
 // LocalUpgradeHandlers exports localUpgradeHandlers for testing.
 var LocalUpgradeHandlers = localUpgradeHandlers //nolint:gochecknoglobals // test export
 
+// LocalUpgradeHandler exports localUpgradeHandler for testing.
+type LocalUpgradeHandler = localUpgradeHandler
+
+// NewLocalCommandWithHandlers creates a LocalCommand whose upgrades run
+// through the given handlers instead of the real updaters.
+func NewLocalCommandWithHandlers(
+	providerRegistry *infraRepos.ProviderRegistry,
+	handlers map[langEntities.Language]LocalUpgradeHandler,
+) *LocalCommand {
+	return &LocalCommand{providerRegistry: providerRegistry, upgradeHandlers: handlers, applyHandlers: handlers}
+}
+
+// ResolveBaseBranch exports resolveBaseBranch for testing.
+var ResolveBaseBranch = resolveBaseBranch //nolint:gochecknoglobals // test export
+
+// CreateLocalPRForProject exports createLocalPRForProject for testing.
+func (it *LocalCommand) CreateLocalPRForProject(
+	ctx context.Context,
+	providerType, host, token string,
+	repo entities.Repository,
+	info *LocalPRInfoForTest,
+	footer *string,
+) error {
+	return it.createLocalPRForProject(ctx, providerType, host, token, repo, info, footer)
+}
+
 // ServiceTypeToProvider exports serviceTypeToProvider for testing.
 var ServiceTypeToProvider = serviceTypeToProvider //nolint:gochecknoglobals // test export
 
@@ -83,6 +128,9 @@ var BuildAggregatePRTitle = buildAggregatePRTitle //nolint:gochecknoglobals // t
 // BuildAggregatePRDescription exports buildAggregatePRDescription for testing.
 var BuildAggregatePRDescription = buildAggregatePRDescription //nolint:gochecknoglobals // test export
 
+// RenderAggregatePRText exports renderAggregatePRText for testing.
+var RenderAggregatePRText = renderAggregatePRText //nolint:gochecknoglobals // test export
+
 // AnyAutoComplete exports anyAutoComplete for testing.
 var AnyAutoComplete = anyAutoComplete //nolint:gochecknoglobals // test export
 
@@ -94,3 +142,64 @@ var FirstLine = firstLine //nolint:gochecknoglobals // test export
 
 // ResolveAggregateTargetBranch exports resolveAggregateTargetBranch for testing.
 var ResolveAggregateTargetBranch = resolveAggregateTargetBranch //nolint:gochecknoglobals // test export
+
+
+// WriteAggregateChangelog exports writeAggregateChangelog for testing.
+var WriteAggregateChangelog = writeAggregateChangelog //nolint:gochecknoglobals // test export
+
+
+// ResolvePullRequestAssignees exports resolvePullRequestAssignees for testing.
+var ResolvePullRequestAssignees = resolvePullRequestAssignees //nolint:gochecknoglobals // test export
+
+// FindContentDuplicate exports findContentDuplicate for testing.
+var FindContentDuplicate = findContentDuplicate //nolint:gochecknoglobals // test export
+
+// ApplyPullRequestAssignees exports applyPullRequestAssignees for testing.
+var ApplyPullRequestAssignees = applyPullRequestAssignees //nolint:gochecknoglobals // test export
+
+// ApplyPullRequestReviewers exports applyPullRequestReviewers for testing.
+var ApplyPullRequestReviewers = applyPullRequestReviewers //nolint:gochecknoglobals // test export
+
+// CollectAggregateReviewers returns the reviewers of the updaters that
+// produced changes, for testing.
+func CollectAggregateReviewers(applied []AppliedUpdaterResult, updaters []ApplicableUpdater) []string {
+	return collectAggregateUsers(applied, updaters, aggregateReviewers)
+}
+
+// SetAnnotationOutput redirects the GitHub Actions annotation stream of a
+// RunCommand for testing.
+func SetAnnotationOutput(cmd *RunCommand, out io.Writer) {
+	cmd.annotationOut = out
+}
+
+// WriteChangesReport exports writeChangesReport for testing.
+var WriteChangesReport = writeChangesReport //nolint:gochecknoglobals // test export
+
+// WriteDryRunReport exports writeDryRunReport for testing.
+var WriteDryRunReport = writeDryRunReport //nolint:gochecknoglobals // test export
+
+// CollectDependencyChanges exports collectDependencyChanges for testing.
+var CollectDependencyChanges = collectDependencyChanges //nolint:gochecknoglobals // test export
+
+// WriteBuildInfo exports writeBuildInfo for testing.
+var WriteBuildInfo = writeBuildInfo //nolint:gochecknoglobals // test export
+
+// ModuleUpgrade exports moduleUpgrade for testing.
+type ModuleUpgrade = moduleUpgrade
+
+// NewModuleUpgrade constructs a moduleUpgrade fixture for tests.
+func NewModuleUpgrade(dir string, lang langEntities.Language, info *LocalPRInfoForTest) ModuleUpgrade {
+	return moduleUpgrade{module: localModule{Dir: dir, Language: lang}, info: info}
+}
+
+// WriteModulesChangelog exports writeModulesChangelog for testing.
+var WriteModulesChangelog = writeModulesChangelog //nolint:gochecknoglobals // test export
+
+// ModulesCommitPrefix exports modulesCommitPrefix for testing.
+var ModulesCommitPrefix = modulesCommitPrefix //nolint:gochecknoglobals // test export
+
+// ApplyPullRequestLabels exports applyPullRequestLabels for testing.
+var ApplyPullRequestLabels = applyPullRequestLabels //nolint:gochecknoglobals // test export
+
+// CollectAggregateLabels exports collectAggregateLabels for testing.
+var CollectAggregateLabels = collectAggregateLabels //nolint:gochecknoglobals // test export
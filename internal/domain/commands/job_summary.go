@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// JobSummaryEnv is the variable GitHub Actions sets to the file that backs
+// the job summary shown on the workflow run page. When it is present, the
+// run appends a Markdown report of its outcome to that file.
+const JobSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+const jobSummaryFileMode = 0o600
+
+// writeJobSummary appends the Markdown run report to the job summary file.
+// GitHub Actions concatenates every step's summary, so the file is never
+// truncated.
+func writeJobSummary(path string, totals runTotals) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, jobSummaryFileMode)
+	if err != nil {
+		return fmt.Errorf("failed to open job summary: %w", err)
+	}
+	defer file.Close()
+
+	if _, writeErr := file.WriteString(renderJobSummary(totals)); writeErr != nil {
+		return fmt.Errorf("failed to write job summary: %w", writeErr)
+	}
+	return nil
+}
+
+// renderJobSummary builds the Markdown report: a totals table followed by
+// the created pull requests and the skipped and errored repositories.
+func renderJobSummary(totals runTotals) string {
+	var sb strings.Builder
+	sb.WriteString("## autoupdate run summary\n\n")
+	sb.WriteString("| Repositories processed | Pull requests created | Errors |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+	fmt.Fprintf(&sb, "| %d | %d | %d |\n\n", totals.repos, totals.prs, totals.errors)
+
+	if len(totals.createdPRs) > 0 {
+		sb.WriteString("### Pull requests created\n\n")
+		for _, created := range totals.createdPRs {
+			fmt.Fprintf(&sb, "- `%s`: [#%d %s](%s)\n",
+				created.repo, created.pr.ID, escapeMarkdownLinkText(created.pr.Title), created.pr.URL)
+		}
+		sb.WriteString("\n")
+	}
+
+	writeJobSummaryList(&sb, "Skipped repositories (no changes needed)", totals.skippedRepos)
+	writeJobSummaryList(&sb, "Repositories with errors", totals.erroredRepos)
+	writeJobSummaryList(&sb, "Organizations without repositories", totals.emptyOrgs)
+	return sb.String()
+}
+
+// writeJobSummaryList renders a titled bullet list, or nothing when empty.
+func writeJobSummaryList(sb *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "### %s\n\n", title)
+	for _, item := range items {
+		fmt.Fprintf(sb, "- `%s`\n", item)
+	}
+	sb.WriteString("\n")
+}
+
+// escapeMarkdownLinkText escapes the brackets that would end a Markdown
+// link label early.
+func escapeMarkdownLinkText(text string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(text)
+}
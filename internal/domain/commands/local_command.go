@@ -2,15 +2,20 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	logger "github.com/sirupsen/logrus"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
 	goRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/golang"
 	jsRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/javascript"
 	pyRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/python"
@@ -20,6 +25,7 @@ import (
 	globalEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
 	langEntities "github.com/rios0rios0/langforge/pkg/domain/entities"
 	langRegistry "github.com/rios0rios0/langforge/pkg/infrastructure/registry"
+	"github.com/rios0rios0/langforge/pkg/support/fileutil"
 )
 
 const (
@@ -43,6 +49,24 @@ type LocalOptions struct {
 	// is honored in local mode; missing settings means only the per-repo
 	// .autoupdate.yaml controls whether the update runs.
 	Settings *entities.Settings
+	// AllEcosystems upgrades every detected ecosystem with a local updater
+	// (e.g. Go and JavaScript in a polyglot repository), one branch and PR
+	// each, instead of only the first detected one.
+	AllEcosystems bool
+	// Depth, when positive, also looks for projects in subdirectories down
+	// to that many levels and upgrades every module found on one branch,
+	// with one commit and PR. Zero keeps the single-directory behavior.
+	Depth int
+	// Remote names the git remote the repository and provider are detected
+	// from and the upgrade branch is pushed to. Empty means origin.
+	Remote string
+	// BaseBranch is the branch the PR targets and the upgrade branch starts
+	// from. Empty means the checked-out branch; when set, the remote must
+	// already have it.
+	BaseBranch string
+	// PathFilter limits the subdirectories Depth considers to those whose
+	// relative path matches this glob (e.g. "services/*").
+	PathFilter string
 }
 
 // remoteInfo holds the parsed components of a Git remote URL.
@@ -52,6 +76,20 @@ type remoteInfo struct {
 	Org          string
 	Project      string // Azure DevOps only
 	RepoName     string
+	// CustomHost is the host of a remote gitforge does not recognize (e.g.
+	// a self-hosted GitLab), empty for github.com, gitlab.com and Azure.
+	CustomHost string
+}
+
+// providerPublicHosts maps each provider to the host its gitforge API
+// client talks to unless the provider implements
+// repositories.SelfHostedProvider.
+func providerPublicHosts() map[string]string {
+	return map[string]string{
+		providerGitHub:      "github.com",
+		providerGitLab:      "gitlab.com",
+		providerAzureDevOps: "dev.azure.com",
+	}
 }
 
 // serviceTypeToProvider returns a map from gitforge ServiceType to the provider name strings used by autoupdate.
@@ -75,18 +113,24 @@ type localPRInfo struct {
 	PackageManager string // JavaScript only
 	ProjectType    langEntities.Language
 	HasChanges     bool
+	ModuleChanges  []goRepo.ModuleChange // Go only
+	ChangelogEntry string                // set by the apply handlers only
 }
 
 // LocalCommand handles the standalone local mode: upgrades dependencies in
 // a given directory, pushes a branch, and creates a PR.
 type LocalCommand struct {
 	providerRegistry *infraRepos.ProviderRegistry
+	upgradeHandlers  map[langEntities.Language]localUpgradeHandler
+	applyHandlers    map[langEntities.Language]localUpgradeHandler
 }
 
 // NewLocalCommand creates a new LocalCommand with the given provider registry.
 func NewLocalCommand(providerRegistry *infraRepos.ProviderRegistry) *LocalCommand {
 	return &LocalCommand{
 		providerRegistry: providerRegistry,
+		upgradeHandlers:  localUpgradeHandlers(),
+		applyHandlers:    localApplyHandlers(),
 	}
 }
 
@@ -106,7 +150,7 @@ func (it *LocalCommand) Execute(ctx context.Context, opts LocalOptions) error {
 	// Detect Git provider from remote URL — done early so the global
 	// exclude_repos list can short-circuit before paying the cost of
 	// language detection or any updater work.
-	remote, parseErr := parseGitRemote(ctx, repoDir)
+	remote, parseErr := parseGitRemote(ctx, repoDir, opts.Remote, opts.Settings)
 	if parseErr != nil {
 		return fmt.Errorf("failed to detect git provider: %w", parseErr)
 	}
@@ -116,13 +160,31 @@ func (it *LocalCommand) Execute(ctx context.Context, opts LocalOptions) error {
 		return nil
 	}
 
-	// Detect project type using langforge's registry
-	langProvider, detectErr := langRegistry.NewDefaultRegistry().Detect(repoDir)
-	if detectErr != nil {
-		return detectErr
+	// Fail before any upgrade work when the provider cannot open a pull
+	// request on the self-hosted instance.
+	if remote.CustomHost != "" && !opts.DryRun {
+		if _, hostErr := it.localProvider(remote.ProviderType, remote.CustomHost, ""); hostErr != nil {
+			return fmt.Errorf("%w (run with --dry-run to preview the upgrade)", hostErr)
+		}
+	}
+
+	// Detect project types using langforge's registry
+	var projTypes []langEntities.Language
+	var modules []localModule
+	var detectErr error
+	if opts.Depth > 0 {
+		modules, detectErr = it.detectModules(repoDir, opts.Depth, opts.PathFilter)
+		if detectErr != nil {
+			return detectErr
+		}
+		logger.Infof("Detected modules: %s", describeModules(modules))
+	} else {
+		projTypes, detectErr = it.detectProjectTypes(repoDir, opts.AllEcosystems)
+		if detectErr != nil {
+			return detectErr
+		}
+		logger.Infof("Detected project type: %s", joinLanguages(projTypes))
 	}
-	projType := langProvider.Language()
-	logger.Infof("Detected project type: %s", projType)
 
 	// Resolve auth token
 	token := opts.Token
@@ -137,17 +199,65 @@ func (it *LocalCommand) Execute(ctx context.Context, opts LocalOptions) error {
 		)
 	}
 
-	// Detect current branch (used as the PR target / default branch)
-	defaultBranch, branchErr := detectDefaultBranch(ctx, repoDir)
+	// Detect current branch (used as the PR target unless BaseBranch is set)
+	currentBranch, branchErr := detectDefaultBranch(ctx, repoDir)
 	if branchErr != nil {
 		return fmt.Errorf("failed to detect current branch: %w", branchErr)
 	}
+	defaultBranch, baseErr := resolveBaseBranch(ctx, repoDir, opts.Remote, opts.BaseBranch, currentBranch)
+	if baseErr != nil {
+		return baseErr
+	}
 	logger.Infof("Default branch: %s", defaultBranch)
 
-	// Run the appropriate upgrade
-	prInfo, upgradeErr := runLocalUpgrade(ctx, repoDir, projType, remote.ProviderType, token, opts, it.providerRegistry)
-	if upgradeErr != nil {
-		return upgradeErr
+	// Build repository struct for the provider API.
+	repo := entities.Repository{
+		ID:            remote.RepoName,
+		Name:          remote.RepoName,
+		Organization:  remote.Org,
+		Project:       remote.Project,
+		DefaultBranch: defaultBranch,
+	}
+
+	if len(modules) > 0 {
+		return it.upgradeModules(ctx, repoDir, modules, remote.ProviderType, remote.CustomHost, token, repo, opts)
+	}
+
+	// Run the appropriate upgrades. In all-ecosystems mode a failing
+	// ecosystem does not stop the others, and every upgrade starts again
+	// from the checked-out branch so each PR only carries its own changes.
+	var errs []error
+	for i, projType := range projTypes {
+		if i > 0 && !opts.DryRun {
+			if checkoutErr := checkoutLocalBranch(repoDir, currentBranch); checkoutErr != nil {
+				return errors.Join(append(errs, checkoutErr)...)
+			}
+		}
+		upgradeErr := it.upgradeProject(
+			ctx, repoDir, projType, remote.ProviderType, remote.CustomHost, token, repo, opts,
+		)
+		if upgradeErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", projType, upgradeErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// upgradeProject runs the local upgrade of one project type and opens a PR
+// for it when dependencies changed.
+func (it *LocalCommand) upgradeProject(
+	ctx context.Context,
+	repoDir string,
+	projType langEntities.Language,
+	providerType, host, token string,
+	repo entities.Repository,
+	opts LocalOptions,
+) error {
+	prInfo, err := runLocalUpgrade(
+		ctx, it.upgradeHandlers, repoDir, projType, providerType, token, opts, it.providerRegistry,
+	)
+	if err != nil {
+		return err
 	}
 
 	if opts.DryRun {
@@ -155,20 +265,89 @@ func (it *LocalCommand) Execute(ctx context.Context, opts LocalOptions) error {
 	}
 
 	if !prInfo.HasChanges {
-		logger.Info("No dependency changes detected, nothing to do.")
+		logger.Infof("No %s dependency changes detected, nothing to do.", projType)
 		return nil
 	}
 
-	// Build repository struct for the provider API.
-	repo := entities.Repository{
-		ID:            remote.RepoName,
-		Name:          remote.RepoName,
-		Organization:  remote.Org,
-		Project:       remote.Project,
-		DefaultBranch: defaultBranch,
+	var footer *string
+	if opts.Settings != nil {
+		footer = opts.Settings.PRFooter
+	}
+	return it.createLocalPRForProject(ctx, providerType, host, token, repo, prInfo, footer)
+}
+
+// detectProjectTypes returns the project types to upgrade in repoDir: the
+// first one langforge detects or, when all is set, every detected type
+// that has a local upgrade handler, in the registry's detection order.
+// Without all, the other upgradable types are named in a warning so a
+// polyglot repository is never half-upgraded silently.
+func (it *LocalCommand) detectProjectTypes(repoDir string, all bool) ([]langEntities.Language, error) {
+	registry := langRegistry.NewDefaultRegistry()
+	if !all {
+		langProvider, err := registry.Detect(repoDir)
+		if err != nil {
+			return nil, err
+		}
+		first := langProvider.Language()
+		if supported, supportedErr := it.supportedProjectTypes(registry, repoDir); supportedErr == nil {
+			skipped := slices.DeleteFunc(supported, func(lang langEntities.Language) bool { return lang == first })
+			if len(skipped) > 0 {
+				logger.Warnf("Also detected %s; pass --all-ecosystems to upgrade every ecosystem",
+					joinLanguages(skipped))
+			}
+		}
+		return []langEntities.Language{first}, nil
+	}
+
+	projTypes, err := it.supportedProjectTypes(registry, repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(projTypes) == 0 {
+		return nil, fmt.Errorf("no supported language detected in %q", repoDir)
+	}
+	return projTypes, nil
+}
+
+// supportedProjectTypes returns every project type detected in repoDir
+// that has a local upgrade handler, in the registry's detection order.
+func (it *LocalCommand) supportedProjectTypes(
+	registry *langRegistry.LanguageRegistry,
+	repoDir string,
+) ([]langEntities.Language, error) {
+	providers, err := registry.DetectAllWithChecker(fileutil.LocalFileChecker(repoDir))
+	if err != nil {
+		return nil, err
+	}
+	var projTypes []langEntities.Language
+	for _, langProvider := range providers {
+		if it.upgradeHandlers[langProvider.Language()] != nil {
+			projTypes = append(projTypes, langProvider.Language())
+		}
+	}
+	return projTypes, nil
+}
+
+// joinLanguages renders project types as a comma-separated list for logs.
+func joinLanguages(languages []langEntities.Language) string {
+	names := make([]string, 0, len(languages))
+	for _, lang := range languages {
+		names = append(names, string(lang))
 	}
+	return strings.Join(names, ", ")
+}
 
-	return it.createLocalPRForProject(ctx, remote.ProviderType, token, repo, prInfo)
+// checkoutLocalBranch switches repoDir back to branch, leaving the branch
+// the previous upgrade pushed.
+func checkoutLocalBranch(repoDir, branch string) error {
+	gitCtx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+	if err != nil {
+		return err
+	}
+	if err = gitCtx.CheckoutBranch(branch); err != nil {
+		return fmt.Errorf("failed to switch back to %s: %w", branch, err)
+	}
+	return nil
 }
 
 // localUpgradeHandler runs the local upgrade for a specific language and returns PR info.
@@ -198,16 +377,27 @@ func localUpgradeHandlers() map[langEntities.Language]localUpgradeHandler {
 	}
 }
 
+// localApplyHandlers returns a map from langforge Language to the handler
+// that upgrades one module in place, leaving git to the caller.
+func localApplyHandlers() map[langEntities.Language]localUpgradeHandler {
+	handlers := localUpgradeHandlers()
+	handlers[langEntities.LanguageGo] = applyGoLocalUpgrade
+	handlers[langEntities.LanguageNode] = applyJSLocalUpgrade
+	handlers[langEntities.LanguagePython] = applyPythonLocalUpgrade
+	return handlers
+}
+
 // runLocalUpgrade dispatches to the appropriate updater based on project type.
 func runLocalUpgrade(
 	ctx context.Context,
+	handlers map[langEntities.Language]localUpgradeHandler,
 	repoDir string,
 	projType langEntities.Language,
 	providerType, token string,
 	opts LocalOptions,
 	registry *infraRepos.ProviderRegistry,
 ) (*localPRInfo, error) {
-	handler, ok := localUpgradeHandlers()[projType]
+	handler, ok := handlers[projType]
 	if !ok || handler == nil {
 		return nil, fmt.Errorf("unsupported project type: %s", projType)
 	}
@@ -220,23 +410,65 @@ func runGoLocalUpgrade(
 	opts LocalOptions,
 	registry *infraRepos.ProviderRegistry,
 ) (*localPRInfo, error) {
-	result, err := goRepo.RunLocalUpgrade(ctx, repoDir, goRepo.LocalUpgradeOptions{
-		DryRun:       opts.DryRun,
-		Verbose:      opts.Verbose,
-		AuthToken:    token,
-		ProviderName: providerType,
-		PushAuth:     registry,
-	})
+	upgradeOpts := goLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := goRepo.RunLocalUpgrade(ctx, repoDir, upgradeOpts)
 	if err != nil {
 		return nil, err
 	}
+	return goLocalPRInfo(result), nil
+}
+
+func applyGoLocalUpgrade(
+	ctx context.Context,
+	moduleDir, providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) (*localPRInfo, error) {
+	upgradeOpts := goLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := goRepo.ApplyLocalUpgrade(ctx, moduleDir, upgradeOpts)
+	if err != nil {
+		return nil, err
+	}
+	return goLocalPRInfo(result), nil
+}
+
+func goLocalUpgradeOptions(
+	providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) goRepo.LocalUpgradeOptions {
+	var cfg entities.UpdaterConfig
+	if opts.Settings != nil {
+		cfg = opts.Settings.Updaters["golang"]
+	}
+	return goRepo.LocalUpgradeOptions{
+		DryRun:         opts.DryRun,
+		Verbose:        opts.Verbose,
+		AuthToken:      token,
+		ProviderName:   providerType,
+		PushAuth:       registry,
+		Remote:         opts.Remote,
+		BaseBranch:     opts.BaseBranch,
+		Changelog:      localChangelogSettings(opts.Settings),
+		CommitPrefix:   localCommitPrefix(opts.Settings, "golang"),
+		Signing:        localSigning(opts.Settings),
+		ExcludeModules: cfg.Exclude,
+		OnlyModules:    cfg.Only,
+		DirectOnly:     cfg.IsDirectOnly(),
+		AllowRetracted: cfg.IsAllowYanked(),
+	}
+}
+
+func goLocalPRInfo(result *goRepo.LocalResult) *localPRInfo {
 	return &localPRInfo{
 		BranchName:     result.BranchName,
 		LatestVersion:  result.LatestVersion,
 		VersionUpdated: result.GoVersionUpdated,
 		ProjectType:    langEntities.LanguageGo,
 		HasChanges:     result.HasChanges,
-	}, nil
+		ModuleChanges:  result.ModuleChanges,
+		ChangelogEntry: result.ChangelogEntry,
+	}
 }
 
 func runPythonLocalUpgrade(
@@ -245,23 +477,56 @@ func runPythonLocalUpgrade(
 	opts LocalOptions,
 	registry *infraRepos.ProviderRegistry,
 ) (*localPRInfo, error) {
-	result, err := pyRepo.RunLocalUpgrade(ctx, repoDir, pyRepo.LocalUpgradeOptions{
+	upgradeOpts := pythonLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := pyRepo.RunLocalUpgrade(ctx, repoDir, upgradeOpts)
+	if err != nil {
+		return nil, err
+	}
+	return pythonLocalPRInfo(result), nil
+}
+
+func applyPythonLocalUpgrade(
+	ctx context.Context,
+	moduleDir, providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) (*localPRInfo, error) {
+	upgradeOpts := pythonLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := pyRepo.ApplyLocalUpgrade(ctx, moduleDir, upgradeOpts)
+	if err != nil {
+		return nil, err
+	}
+	return pythonLocalPRInfo(result), nil
+}
+
+func pythonLocalUpgradeOptions(
+	providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) pyRepo.LocalUpgradeOptions {
+	return pyRepo.LocalUpgradeOptions{
 		DryRun:       opts.DryRun,
 		Verbose:      opts.Verbose,
 		AuthToken:    token,
 		ProviderName: providerType,
 		PushAuth:     registry,
-	})
-	if err != nil {
-		return nil, err
+		Remote:       opts.Remote,
+		BaseBranch:   opts.BaseBranch,
+		Changelog:    localChangelogSettings(opts.Settings),
+		CommitPrefix: localCommitPrefix(opts.Settings, "python"),
+		Signing:      localSigning(opts.Settings),
 	}
+}
+
+func pythonLocalPRInfo(result *pyRepo.LocalResult) *localPRInfo {
 	return &localPRInfo{
 		BranchName:     result.BranchName,
 		LatestVersion:  result.LatestVersion,
 		VersionUpdated: result.PythonVersionUpdated,
 		ProjectType:    langEntities.LanguagePython,
 		HasChanges:     result.HasChanges,
-	}, nil
+		ChangelogEntry: result.ChangelogEntry,
+	}
 }
 
 func runJSLocalUpgrade(
@@ -270,16 +535,48 @@ func runJSLocalUpgrade(
 	opts LocalOptions,
 	registry *infraRepos.ProviderRegistry,
 ) (*localPRInfo, error) {
-	result, err := jsRepo.RunLocalUpgrade(ctx, repoDir, jsRepo.LocalUpgradeOptions{
+	upgradeOpts := jsLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := jsRepo.RunLocalUpgrade(ctx, repoDir, upgradeOpts)
+	if err != nil {
+		return nil, err
+	}
+	return jsLocalPRInfo(result), nil
+}
+
+func applyJSLocalUpgrade(
+	ctx context.Context,
+	moduleDir, providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) (*localPRInfo, error) {
+	upgradeOpts := jsLocalUpgradeOptions(providerType, token, opts, registry)
+	result, err := jsRepo.ApplyLocalUpgrade(ctx, moduleDir, upgradeOpts)
+	if err != nil {
+		return nil, err
+	}
+	return jsLocalPRInfo(result), nil
+}
+
+func jsLocalUpgradeOptions(
+	providerType, token string,
+	opts LocalOptions,
+	registry *infraRepos.ProviderRegistry,
+) jsRepo.LocalUpgradeOptions {
+	return jsRepo.LocalUpgradeOptions{
 		DryRun:       opts.DryRun,
 		Verbose:      opts.Verbose,
 		AuthToken:    token,
 		ProviderName: providerType,
 		PushAuth:     registry,
-	})
-	if err != nil {
-		return nil, err
+		Remote:       opts.Remote,
+		BaseBranch:   opts.BaseBranch,
+		Changelog:    localChangelogSettings(opts.Settings),
+		CommitPrefix: localCommitPrefix(opts.Settings, "javascript"),
+		Signing:      localSigning(opts.Settings),
 	}
+}
+
+func jsLocalPRInfo(result *jsRepo.LocalResult) *localPRInfo {
 	return &localPRInfo{
 		BranchName:     result.BranchName,
 		LatestVersion:  result.LatestVersion,
@@ -287,22 +584,56 @@ func runJSLocalUpgrade(
 		PackageManager: result.PackageManager,
 		ProjectType:    langEntities.LanguageNode,
 		HasChanges:     result.HasChanges,
-	}, nil
+		ChangelogEntry: result.ChangelogEntry,
+	}
 }
 
 // createLocalPRForProject creates a pull request using the provider API.
 func (it *LocalCommand) createLocalPRForProject(
 	ctx context.Context,
-	providerType, token string,
+	providerType, host, token string,
 	repo entities.Repository,
 	info *localPRInfo,
+	footer *string,
 ) error {
+	prTitle, prDesc := generatePRContent(info)
+	return it.openLocalPR(ctx, providerType, host, token, repo, info.BranchName, prTitle, prDesc, footer)
+}
+
+// localProvider returns the provider of providerType, bound to host when
+// the remote is on a self-hosted instance (host is empty otherwise).
+func (it *LocalCommand) localProvider(providerType, host, token string) (repositories.ProviderRepository, error) {
 	provider, err := it.providerRegistry.Get(providerType, token)
 	if err != nil {
-		return fmt.Errorf("failed to create provider: %w", err)
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	if host == "" {
+		return provider, nil
 	}
+	selfHosted, ok := provider.(repositories.SelfHostedProvider)
+	if !ok {
+		return nil, fmt.Errorf(
+			"the %s provider cannot reach %s: its API client only supports %s",
+			providerType, host, providerPublicHosts()[providerType],
+		)
+	}
+	return selfHosted.ForHost(host)
+}
 
-	prTitle, prDesc := generatePRContent(info)
+// openLocalPR opens a pull request from branchName into the repository's
+// default branch using the provider API of host, or of the public host
+// when host is empty.
+func (it *LocalCommand) openLocalPR(
+	ctx context.Context,
+	providerType, host, token string,
+	repo entities.Repository,
+	branchName, prTitle, prDesc string,
+	footer *string,
+) error {
+	provider, err := it.localProvider(providerType, host, token)
+	if err != nil {
+		return err
+	}
 
 	targetBranch := repo.DefaultBranch
 	if !strings.HasPrefix(targetBranch, "refs/heads/") {
@@ -310,10 +641,10 @@ func (it *LocalCommand) createLocalPRForProject(
 	}
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
-		SourceBranch: "refs/heads/" + info.BranchName,
+		SourceBranch: "refs/heads/" + branchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, footer),
 	})
 	if createErr != nil {
 		return fmt.Errorf("failed to create PR: %w", createErr)
@@ -338,7 +669,7 @@ func prContentGenerators() map[langEntities.Language]prContentGenerator {
 				)
 			}
 			desc := goRepo.GenerateGoPRDescription(
-				info.LatestVersion, false, info.VersionUpdated,
+				info.LatestVersion, false, info.VersionUpdated, info.ModuleChanges,
 			)
 			return title, desc
 		},
@@ -390,23 +721,192 @@ func generatePRContent(info *localPRInfo) (string, string) {
 	return generator(info)
 }
 
-// parseGitRemote runs `git remote get-url origin` and parses the result.
-func parseGitRemote(ctx context.Context, repoDir string) (*remoteInfo, error) {
-	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", "origin")
+// resolveBaseBranch returns the branch the PR targets: base when set, once
+// the remote-tracking ref shows remoteName has it, or else current.
+func resolveBaseBranch(ctx context.Context, repoDir, remoteName, base, current string) (string, error) {
+	if base == "" {
+		return current, nil
+	}
+	if remoteName == "" {
+		remoteName = gitlocal.DefaultRemote
+	}
+
+	ref := fmt.Sprintf("refs/remotes/%s/%s", remoteName, base)
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", ref)
+	cmd.Dir = repoDir
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(
+			"base branch %q not found on remote %q; run `git fetch %s` if it was created recently",
+			base, remoteName, remoteName,
+		)
+	}
+	return base, nil
+}
+
+// parseGitRemote runs `git remote get-url <remoteName>` (origin when empty)
+// and parses the result. A missing remote is reported along with the
+// remotes the repository does have.
+func parseGitRemote(
+	ctx context.Context,
+	repoDir, remoteName string,
+	settings *entities.Settings,
+) (*remoteInfo, error) {
+	if remoteName == "" {
+		remoteName = gitlocal.DefaultRemote
+	}
+
+	listCmd := exec.CommandContext(ctx, "git", "remote")
+	listCmd.Dir = repoDir
+	listOutput, err := listCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git remote: %w", err)
+	}
+	remotes := strings.Fields(string(listOutput))
+	if !slices.Contains(remotes, remoteName) {
+		if len(remotes) == 0 {
+			return nil, fmt.Errorf("remote %q not found: the repository has no remotes", remoteName)
+		}
+		return nil, fmt.Errorf(
+			"remote %q not found; available remotes: %s", remoteName, strings.Join(remotes, ", "),
+		)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "remote", "get-url", remoteName)
 	cmd.Dir = repoDir
 
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("git remote get-url origin: %w", err)
+		return nil, fmt.Errorf("git remote get-url %s: %w", remoteName, err)
+	}
+
+	return resolveRemote(strings.TrimSpace(string(output)), settings)
+}
+
+// resolveRemote parses a Git remote URL like parseRemoteURL, but when the
+// host is not recognized (e.g. a self-hosted GitLab on a custom domain) and
+// customHostProvider can tell its provider, it reads the org and repo from
+// the URL path.
+func resolveRemote(rawURL string, settings *entities.Settings) (*remoteInfo, error) {
+	remote, err := parseRemoteURL(rawURL)
+	if err == nil && remote.ProviderType != "" {
+		return remote, nil
+	}
+
+	providerType, reason := customHostProvider(rawURL, settings)
+	if providerType == "" {
+		if err != nil {
+			return nil, err
+		}
+		return remote, nil
+	}
+
+	fallback, ok := parseCustomHostRemote(rawURL, providerType)
+	if !ok {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unsupported git remote URL: %s", rawURL)
+	}
+	logger.Infof("Remote host of %s is not recognized, %s", rawURL, reason)
+	return fallback, nil
+}
+
+// customHostProvider picks the provider of a remote on a host gitforge does
+// not recognize, in order: the provider listing an organization URL on the
+// same host, GitLab when a label of the host name is "gitlab" (as in
+// gitlab.mycorp.com), or the only configured provider. It returns "" when
+// none applies, and otherwise a description of the choice for the logs.
+func customHostProvider(rawURL string, settings *entities.Settings) (string, string) {
+	host := remoteHost(rawURL)
+	if host == "" {
+		return "", ""
+	}
+
+	if settings != nil {
+		for _, provCfg := range settings.Providers {
+			for _, org := range provCfg.Organizations {
+				if remoteHost(org) == host {
+					return provCfg.Type, fmt.Sprintf(
+						"using provider %q, which lists an organization on %s", provCfg.Type, host,
+					)
+				}
+			}
+		}
+	}
+
+	if slices.Contains(strings.Split(host, "."), providerGitLab) {
+		return providerGitLab, fmt.Sprintf("assuming a self-hosted GitLab from the host name %s", host)
+	}
+
+	if settings != nil && len(settings.Providers) == 1 {
+		providerType := settings.Providers[0].Type
+		return providerType, fmt.Sprintf("using the only configured provider %q", providerType)
+	}
+	return "", ""
+}
+
+// remoteHost returns the lower-cased host name of an HTTPS, ssh:// or
+// scp-like URL, without the port, or "" when rawURL is none of them.
+func remoteHost(rawURL string) string {
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		return strings.ToLower(parsed.Hostname())
+	}
+	if before, _, found := strings.Cut(rawURL, ":"); found && strings.Contains(before, "@") {
+		return strings.ToLower(before[strings.LastIndex(before, "@")+1:])
+	}
+	return ""
+}
+
+// parseCustomHostRemote reads the org (or nested group) and repo name from
+// the path of an HTTPS, ssh:// or scp-like remote URL on any host. Azure
+// DevOps Server paths ({collection}/{project}/_git/{repo}) also yield the
+// project.
+func parseCustomHostRemote(rawURL, providerType string) (*remoteInfo, bool) {
+	var path string
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		path = parsed.Path
+	} else if _, after, found := strings.Cut(rawURL, ":"); found && strings.Contains(rawURL, "@") {
+		path = after
+	} else {
+		return nil, false
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimSuffix(path, ".git"), "/"), "/")
+	if len(segments) < 2 || slices.Contains(segments, "") { //nolint:mnd // org/repo
+		return nil, false
 	}
 
-	return parseRemoteURL(strings.TrimSpace(string(output)))
+	remote := &remoteInfo{
+		ProviderType: providerType,
+		ServiceType:  serviceTypeFor(providerType),
+		CustomHost:   remoteHost(rawURL),
+	}
+	if i := slices.Index(segments, "_git"); i >= 2 && i == len(segments)-2 { //nolint:mnd // org/project/_git/repo
+		remote.Org = segments[i-2]
+		remote.Project = segments[i-1]
+		remote.RepoName = segments[i+1]
+		return remote, true
+	}
+	remote.Org = strings.Join(segments[:len(segments)-1], "/")
+	remote.RepoName = segments[len(segments)-1]
+	return remote, true
+}
+
+// serviceTypeFor returns the gitforge ServiceType of an autoupdate provider
+// name, or UNKNOWN when the name is not a supported provider.
+func serviceTypeFor(providerType string) globalEntities.ServiceType {
+	for serviceType, name := range serviceTypeToProvider() {
+		if name != "" && name == providerType {
+			return serviceType
+		}
+	}
+	return globalEntities.UNKNOWN
 }
 
 // parseRemoteURL extracts provider, org, project, and repo name from a Git remote URL.
 // Delegates to gitforge's ParseRemoteURL and converts the result to autoupdate's remoteInfo.
 func parseRemoteURL(rawURL string) (*remoteInfo, error) {
-	parsed, err := gitInfra.ParseRemoteURL(rawURL)
+	parsed, err := gitInfra.ParseRemoteURL(normalizeSSHURL(rawURL))
 	if err != nil {
 		return nil, fmt.Errorf("unsupported git remote URL: %w", err)
 	}
@@ -425,6 +925,23 @@ func parseRemoteURL(rawURL string) (*remoteInfo, error) {
 	}, nil
 }
 
+// normalizeSSHURL rewrites an ssh:// remote URL, with or without a port,
+// into the scp-like form (git@host:path) gitforge parses. The port only
+// matters to the transport, not to identifying the repository, so it is
+// dropped. Other URLs are returned unchanged.
+func normalizeSSHURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "ssh" || parsed.Hostname() == "" {
+		return rawURL
+	}
+
+	user := "git"
+	if parsed.User != nil && parsed.User.Username() != "" {
+		user = parsed.User.Username()
+	}
+	return fmt.Sprintf("%s@%s:%s", user, parsed.Hostname(), strings.TrimPrefix(parsed.Path, "/"))
+}
+
 // checkLocalRepoConfigSkip reads the per-repository .autoupdate.yaml from
 // disk and reports whether the user requested that this project be
 // skipped. A missing file is not an error; a malformed file is, because
@@ -447,6 +964,35 @@ func checkLocalRepoConfigSkip(repoDir string) (bool, error) {
 	return true, nil
 }
 
+// localChangelogSettings returns the configured changelog settings, or the
+// defaults (CHANGELOG.md, "### Changed") when local mode runs without a
+// config file.
+func localChangelogSettings(settings *entities.Settings) entities.ChangelogSettings {
+	if settings == nil {
+		return entities.ChangelogSettings{}
+	}
+	return settings.Changelog
+}
+
+// localCommitPrefix returns the commit prefix configured for the named
+// updater, or the default "chore(deps)" when no Settings were loaded.
+func localCommitPrefix(settings *entities.Settings, updater string) string {
+	if settings == nil {
+		return entities.FormatCommitPrefix("", "")
+	}
+	cfg := settings.Updaters[updater]
+	return entities.FormatCommitPrefix(cfg.CommitType, cfg.CommitScope)
+}
+
+// localSigning returns the configured commit signing key, or none (the git
+// config decides) when no Settings were loaded.
+func localSigning(settings *entities.Settings) entities.Signing {
+	if settings == nil {
+		return entities.Signing{}
+	}
+	return settings.Signing
+}
+
 // isExcludedByGlobalList reports whether the parsed remote matches a
 // pattern in the user's global exclude_repos list. The check is a no-op
 // when no Settings were loaded (i.e. the user invoked local mode without
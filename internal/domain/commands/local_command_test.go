@@ -7,14 +7,19 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	logger "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/commands"
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/forge"
+	doubles "github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
 	globalEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
 	langEntities "github.com/rios0rios0/langforge/pkg/domain/entities"
 )
@@ -149,6 +154,213 @@ func TestParseRemoteURL(t *testing.T) {
 		assert.Nil(t, info)
 		assert.Contains(t, err.Error(), "unsupported remote URL format")
 	})
+
+	t.Run("should parse GitHub ssh:// URL with a port", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "ssh://git@github.com:22/myorg/myrepo.git"
+
+		// when
+		info, err := commands.ParseRemoteURL(url)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "github", info.ProviderType)
+		assert.Equal(t, "myorg", info.Org)
+		assert.Equal(t, "myrepo", info.RepoName)
+	})
+
+	t.Run("should parse GitLab ssh:// URL with a custom port", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "ssh://git@gitlab.com:2222/mygroup/mysubgroup/myrepo.git"
+
+		// when
+		info, err := commands.ParseRemoteURL(url)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab", info.ProviderType)
+		assert.Equal(t, "mygroup/mysubgroup", info.Org)
+		assert.Equal(t, "myrepo", info.RepoName)
+	})
+
+	t.Run("should parse Azure DevOps ssh:// URL with a port", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "ssh://git@ssh.dev.azure.com:22/v3/myorg/myproject/myrepo"
+
+		// when
+		info, err := commands.ParseRemoteURL(url)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "azuredevops", info.ProviderType)
+		assert.Equal(t, "myorg", info.Org)
+		assert.Equal(t, "myproject", info.Project)
+		assert.Equal(t, "myrepo", info.RepoName)
+	})
+}
+
+func TestResolveRemote(t *testing.T) {
+	t.Parallel()
+
+	singleProvider := func(providerType string) *entities.Settings {
+		return &entities.Settings{Providers: []entities.ProviderConfig{
+			{Type: providerType, Token: "tok", Organizations: []string{"org"}},
+		}}
+	}
+
+	t.Run("should use the only configured provider for an unrecognized HTTPS host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://git.example.com/platform/infra/network.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, singleProvider("gitlab"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab", info.ProviderType)
+		assert.Equal(t, "platform/infra", info.Org)
+		assert.Equal(t, "network", info.RepoName)
+	})
+
+	t.Run("should use the only configured provider for an unrecognized SSH host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "git@code.example.com:myorg/myrepo.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, singleProvider("github"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "github", info.ProviderType)
+		assert.Equal(t, "myorg", info.Org)
+		assert.Equal(t, "myrepo", info.RepoName)
+	})
+
+	t.Run("should read the project from an Azure DevOps Server path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://tfs.example.com/DefaultCollection/Platform/_git/infra"
+
+		// when
+		info, err := commands.ResolveRemote(url, singleProvider("azuredevops"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "azuredevops", info.ProviderType)
+		assert.Equal(t, "DefaultCollection", info.Org)
+		assert.Equal(t, "Platform", info.Project)
+		assert.Equal(t, "infra", info.RepoName)
+	})
+
+	t.Run("should keep the detected provider for a recognized host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://github.com/myorg/myrepo.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, singleProvider("gitlab"))
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "github", info.ProviderType)
+	})
+
+	t.Run("should return error for an unrecognized host with several providers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://git.example.com/myorg/myrepo.git"
+		settings := &entities.Settings{Providers: []entities.ProviderConfig{
+			{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			{Type: "gitlab", Token: "tok", Organizations: []string{"group"}},
+		}}
+
+		// when
+		info, err := commands.ResolveRemote(url, settings)
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, info)
+		assert.Contains(t, err.Error(), "unsupported git remote URL")
+	})
+
+	t.Run("should return error for an unrecognized host without settings", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://git.example.com/myorg/myrepo.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, nil)
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, info)
+	})
+
+	t.Run("should detect a self-hosted GitLab with subgroups from the host name", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://gitlab.mycorp.com/group/subgroup/team/repo.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab", info.ProviderType)
+		assert.Equal(t, globalEntities.GITLAB, info.ServiceType)
+		assert.Equal(t, "group/subgroup/team", info.Org)
+		assert.Equal(t, "repo", info.RepoName)
+		assert.Equal(t, "gitlab.mycorp.com", info.CustomHost)
+	})
+
+	t.Run("should use the provider with an organization URL on the remote host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "git@code.mycorp.com:platform/infra/network.git"
+		settings := &entities.Settings{Providers: []entities.ProviderConfig{
+			{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			{Type: "gitlab", Token: "tok", Organizations: []string{"https://code.mycorp.com/platform"}},
+		}}
+
+		// when
+		info, err := commands.ResolveRemote(url, settings)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "gitlab", info.ProviderType)
+		assert.Equal(t, "platform/infra", info.Org)
+		assert.Equal(t, "network", info.RepoName)
+	})
+
+	t.Run("should return error when the path has no org", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		url := "https://git.example.com/repo.git"
+
+		// when
+		info, err := commands.ResolveRemote(url, singleProvider("gitlab"))
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, info)
+	})
 }
 
 func TestGeneratePRContent(t *testing.T) {
@@ -248,6 +460,51 @@ func TestGeneratePRContent(t *testing.T) {
 	})
 }
 
+func TestGoLocalUpgradeOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should carry the Go updater module filters of the config file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		enabled := true
+		settings := &entities.Settings{Updaters: map[string]entities.UpdaterConfig{
+			"golang": {
+				Exclude:     []string{"github.com/held/a"},
+				Only:        []string{"github.com/focus/b"},
+				DirectOnly:  &enabled,
+				AllowYanked: &enabled,
+			},
+		}}
+
+		// when
+		upgradeOpts := commands.GoLocalUpgradeOptions(
+			"github", "token", commands.LocalOptions{Settings: settings}, infraRepos.NewProviderRegistry(),
+		)
+
+		// then
+		assert.Equal(t, []string{"github.com/held/a"}, upgradeOpts.ExcludeModules)
+		assert.Equal(t, []string{"github.com/focus/b"}, upgradeOpts.OnlyModules)
+		assert.True(t, upgradeOpts.DirectOnly)
+		assert.True(t, upgradeOpts.AllowRetracted)
+	})
+
+	t.Run("should leave the module filters unset without a config file", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		upgradeOpts := commands.GoLocalUpgradeOptions(
+			"github", "token", commands.LocalOptions{}, infraRepos.NewProviderRegistry(),
+		)
+
+		// then
+		assert.Empty(t, upgradeOpts.ExcludeModules)
+		assert.Empty(t, upgradeOpts.OnlyModules)
+		assert.False(t, upgradeOpts.DirectOnly)
+		assert.False(t, upgradeOpts.AllowRetracted)
+	})
+}
+
 func TestLocalUpgradeHandlers(t *testing.T) {
 	t.Parallel()
 
@@ -417,6 +674,135 @@ func TestDetectDefaultBranch(t *testing.T) {
 	})
 }
 
+func TestResolveBaseBranch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the current branch when no base branch is given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "feat/login")
+
+		// when
+		branch, err := commands.ResolveBaseBranch(context.Background(), repoDir, "", "", "feat/login")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "feat/login", branch)
+	})
+
+	t.Run("should return the base branch when the remote has it", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "feat/login")
+		runGit(t, repoDir, "update-ref", "refs/remotes/upstream/develop", "HEAD")
+
+		// when
+		branch, err := commands.ResolveBaseBranch(context.Background(), repoDir, "upstream", "develop", "feat/login")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "develop", branch)
+	})
+
+	t.Run("should return error when the remote does not have the base branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "feat/login")
+		runGit(t, repoDir, "update-ref", "refs/remotes/origin/main", "HEAD")
+
+		// when
+		_, err := commands.ResolveBaseBranch(context.Background(), repoDir, "", "develop", "feat/login")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `base branch "develop" not found on remote "origin"`)
+	})
+}
+
+func TestCreateLocalPRForProject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should target the base branch of the repository", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+		cmd := commands.NewLocalCommand(providerRegistry)
+		repo := entities.Repository{Name: "autoupdate", Organization: "rios0rios0", DefaultBranch: "develop"}
+		info := &commands.LocalPRInfoForTest{
+			BranchName:  "chore/upgrade-deps",
+			ProjectType: langEntities.LanguageGo,
+		}
+
+		// when
+		err := cmd.CreateLocalPRForProject(context.Background(), "github", "", "token", repo, info, nil)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, spy.PRInputs, 1)
+		assert.Equal(t, "refs/heads/develop", spy.PRInputs[0].TargetBranch)
+		assert.Equal(t, "refs/heads/chore/upgrade-deps", spy.PRInputs[0].SourceBranch)
+		assert.Empty(t, spy.Hosts)
+	})
+
+	t.Run("should open the PR through the provider bound to the self-hosted host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("gitlab", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+		cmd := commands.NewLocalCommand(providerRegistry)
+		repo := entities.Repository{Name: "network", Organization: "platform", DefaultBranch: "main"}
+		info := &commands.LocalPRInfoForTest{
+			BranchName:  "chore/upgrade-deps",
+			ProjectType: langEntities.LanguageGo,
+		}
+
+		// when
+		err := cmd.CreateLocalPRForProject(
+			context.Background(), "gitlab", "gitlab.mycorp.com", "token", repo, info, nil,
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gitlab.mycorp.com"}, spy.Hosts)
+		require.Len(t, spy.PRInputs, 1)
+	})
+
+	t.Run("should fail when the provider cannot reach the self-hosted host", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("azuredevops", func(_ string) repositories.ProviderRepository {
+			return &doubles.DummyProviderRepository{}
+		})
+		cmd := commands.NewLocalCommand(providerRegistry)
+		repo := entities.Repository{Name: "network", Organization: "collection", Project: "platform"}
+		info := &commands.LocalPRInfoForTest{BranchName: "chore/upgrade-deps", ProjectType: langEntities.LanguageGo}
+
+		// when
+		err := cmd.CreateLocalPRForProject(
+			context.Background(), "azuredevops", "ado.mycorp.com", "token", repo, info, nil,
+		)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot reach ado.mycorp.com")
+		assert.Contains(t, err.Error(), "dev.azure.com")
+	})
+}
+
 func TestParseGitRemote(t *testing.T) {
 	t.Parallel()
 
@@ -428,7 +814,7 @@ func TestParseGitRemote(t *testing.T) {
 		runGit(t, repoDir, "remote", "add", "origin", "git@github.com:testorg/testrepo.git")
 
 		// when
-		info, err := commands.ParseGitRemote(context.Background(), repoDir)
+		info, err := commands.ParseGitRemote(context.Background(), repoDir, "", nil)
 
 		// then
 		require.NoError(t, err)
@@ -444,7 +830,7 @@ func TestParseGitRemote(t *testing.T) {
 		repoDir := initTestGitRepo(t, "main")
 
 		// when
-		_, err := commands.ParseGitRemote(context.Background(), repoDir)
+		_, err := commands.ParseGitRemote(context.Background(), repoDir, "", nil)
 
 		// then
 		require.Error(t, err)
@@ -458,7 +844,7 @@ func TestParseGitRemote(t *testing.T) {
 		runGit(t, repoDir, "remote", "add", "origin", "https://github.com/anotherorg/anotherrepo.git")
 
 		// when
-		info, err := commands.ParseGitRemote(context.Background(), repoDir)
+		info, err := commands.ParseGitRemote(context.Background(), repoDir, "", nil)
 
 		// then
 		require.NoError(t, err)
@@ -475,7 +861,7 @@ func TestParseGitRemote(t *testing.T) {
 		runGit(t, repoDir, "remote", "add", "origin", "git@ssh.dev.azure.com:v3/myorg/myproject/myrepo")
 
 		// when
-		info, err := commands.ParseGitRemote(context.Background(), repoDir)
+		info, err := commands.ParseGitRemote(context.Background(), repoDir, "", nil)
 
 		// then
 		require.NoError(t, err)
@@ -484,6 +870,40 @@ func TestParseGitRemote(t *testing.T) {
 		assert.Equal(t, "myproject", info.Project)
 		assert.Equal(t, "myrepo", info.RepoName)
 	})
+
+	t.Run("should parse the named remote instead of origin", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		runGit(t, repoDir, "remote", "add", "origin", "git@github.com:forkuser/testrepo.git")
+		runGit(t, repoDir, "remote", "add", "upstream", "git@github.com:upstreamorg/testrepo.git")
+
+		// when
+		info, err := commands.ParseGitRemote(context.Background(), repoDir, "upstream", nil)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "upstreamorg", info.Org)
+		assert.Equal(t, "testrepo", info.RepoName)
+	})
+
+	t.Run("should list the available remotes when the named remote does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		runGit(t, repoDir, "remote", "add", "origin", "git@github.com:forkuser/testrepo.git")
+		runGit(t, repoDir, "remote", "add", "mirror", "git@github.com:mirrororg/testrepo.git")
+
+		// when
+		_, err := commands.ParseGitRemote(context.Background(), repoDir, "upstream", nil)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `remote "upstream" not found`)
+		assert.Contains(t, err.Error(), "available remotes: mirror, origin")
+	})
 }
 
 func TestCheckLocalRepoConfigSkip(t *testing.T) {
@@ -688,6 +1108,72 @@ func TestLocalCommandExecute(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("should accept a self-hosted GitLab remote outside dry runs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		runGit(t, repoDir, "remote", "add", "origin", "https://gitlab.mycorp.com/group/repo.git")
+
+		registry := infraRepos.NewProviderRegistry()
+		registry.RegisterFactory("gitlab", forge.NewGitLabProviderRepository)
+		cmd := commands.NewLocalCommand(registry)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{RepoDir: repoDir})
+
+		// then
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "cannot reach")
+		assert.NotContains(t, err.Error(), "--dry-run")
+	})
+
+	t.Run("should fail on a self-hosted remote the provider cannot reach outside dry runs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		runGit(t, repoDir, "remote", "add", "origin", "https://ado.mycorp.com/collection/platform/_git/network")
+		settings := &entities.Settings{Providers: []entities.ProviderConfig{{
+			Type: "azuredevops", Organizations: []string{"https://ado.mycorp.com/collection"},
+		}}}
+
+		registry := infraRepos.NewProviderRegistry()
+		registry.Register("azuredevops", func(_ string) repositories.ProviderRepository {
+			return &doubles.DummyProviderRepository{}
+		})
+		cmd := commands.NewLocalCommand(registry)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{RepoDir: repoDir, Settings: settings})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ado.mycorp.com")
+		assert.Contains(t, err.Error(), "--dry-run")
+	})
+
+	t.Run("should accept a remote resolved by the only configured provider outside dry runs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		runGit(t, repoDir, "remote", "add", "origin", "git@code.mycorp.com:platform/network.git")
+		settings := &entities.Settings{Providers: []entities.ProviderConfig{{Type: "github", Token: "tok"}}}
+
+		registry := infraRepos.NewProviderRegistry()
+		registry.RegisterFactory("github", forge.NewGitHubProviderRepository)
+		cmd := commands.NewLocalCommand(registry)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{RepoDir: repoDir, Settings: settings})
+
+		// then
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "cannot reach")
+		assert.NotContains(t, err.Error(), "--dry-run")
+	})
+
 	t.Run("should propagate parse errors from .autoupdate.yaml", func(t *testing.T) {
 		t.Parallel()
 
@@ -711,10 +1197,344 @@ func TestLocalCommandExecute(t *testing.T) {
 		// then
 		require.Error(t, err)
 	})
+
+	t.Run("should upgrade every detected ecosystem when AllEcosystems is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initPolyglotRepo(t)
+		var upgraded []langEntities.Language
+		cmd := commands.NewLocalCommandWithHandlers(infraRepos.NewProviderRegistry(), recordingHandlers(&upgraded))
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir:       repoDir,
+			DryRun:        true,
+			AllEcosystems: true,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []langEntities.Language{
+			langEntities.LanguageGo, langEntities.LanguageNode,
+		}, upgraded)
+	})
+
+	t.Run("should upgrade only the first detected ecosystem by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initPolyglotRepo(t)
+		var upgraded []langEntities.Language
+		cmd := commands.NewLocalCommandWithHandlers(infraRepos.NewProviderRegistry(), recordingHandlers(&upgraded))
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir: repoDir,
+			DryRun:  true,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, upgraded, 1)
+	})
+
+	t.Run("should warn about the ecosystems it skips by default", func(t *testing.T) {
+		// given
+		hook := captureLogs(t)
+		repoDir := initPolyglotRepo(t)
+		var upgraded []langEntities.Language
+		cmd := commands.NewLocalCommandWithHandlers(infraRepos.NewProviderRegistry(), recordingHandlers(&upgraded))
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir: repoDir,
+			DryRun:  true,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, upgraded, 1)
+		warnings := strings.Join(logMessages(hook, logger.WarnLevel), "\n")
+		assert.Contains(t, warnings, "--all-ecosystems")
+		assert.NotContains(t, warnings, "Also detected "+string(upgraded[0]))
+	})
+
+	t.Run("should upgrade every module found down to Depth", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initNestedRepo(t)
+		var upgraded []string
+		cmd := commands.NewLocalCommandWithHandlers(
+			infraRepos.NewProviderRegistry(), recordingModuleHandlers(t, repoDir, &upgraded),
+		)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir: repoDir,
+			DryRun:  true,
+			Depth:   2,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"services/api:go", "web:node"}, upgraded)
+	})
+
+	t.Run("should skip modules deeper than Depth", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initNestedRepo(t)
+		var upgraded []string
+		cmd := commands.NewLocalCommandWithHandlers(
+			infraRepos.NewProviderRegistry(), recordingModuleHandlers(t, repoDir, &upgraded),
+		)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir: repoDir,
+			DryRun:  true,
+			Depth:   1,
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"web:node"}, upgraded)
+	})
+
+	t.Run("should only consider subdirectories matching PathFilter", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initNestedRepo(t)
+		var upgraded []string
+		cmd := commands.NewLocalCommandWithHandlers(
+			infraRepos.NewProviderRegistry(), recordingModuleHandlers(t, repoDir, &upgraded),
+		)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir:    repoDir,
+			DryRun:     true,
+			Depth:      2,
+			PathFilter: "services/*",
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"services/api:go"}, upgraded)
+	})
+
+	t.Run("should return error when no module matches PathFilter", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initNestedRepo(t)
+		var upgraded []string
+		cmd := commands.NewLocalCommandWithHandlers(
+			infraRepos.NewProviderRegistry(), recordingModuleHandlers(t, repoDir, &upgraded),
+		)
+
+		// when
+		err := cmd.Execute(context.Background(), commands.LocalOptions{
+			RepoDir:    repoDir,
+			DryRun:     true,
+			Depth:      2,
+			PathFilter: "docs/*",
+		})
+
+		// then
+		require.Error(t, err)
+		assert.Empty(t, upgraded)
+	})
+}
+
+func TestWriteModulesChangelog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should insert every module entry once into the root changelog", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		changelog := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte(changelog), 0o600))
+		runGit(t, repoDir, "add", "CHANGELOG.md")
+		runGit(t, repoDir, "commit", "-m", "add changelog")
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "go.sum"), []byte("changed\n"), 0o600))
+		goEntry := "- changed the Go module dependencies to their latest versions"
+		jsEntry := "- changed the JavaScript dependencies to their latest versions"
+		upgrades := []commands.ModuleUpgrade{
+			commands.NewModuleUpgrade("services/api", langEntities.LanguageGo,
+				&commands.LocalPRInfoForTest{ChangelogEntry: goEntry}),
+			commands.NewModuleUpgrade("services/worker", langEntities.LanguageGo,
+				&commands.LocalPRInfoForTest{ChangelogEntry: goEntry}),
+			commands.NewModuleUpgrade("web", langEntities.LanguageNode,
+				&commands.LocalPRInfoForTest{ChangelogEntry: jsEntry}),
+		}
+
+		// when
+		commands.WriteModulesChangelog(context.Background(), repoDir, upgrades, entities.ChangelogSettings{})
+
+		// then
+		content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(content), goEntry))
+		assert.Equal(t, 1, strings.Count(string(content), jsEntry))
+	})
+
+	t.Run("should leave the changelog alone when no module changed a file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := initTestGitRepo(t, "main")
+		changelog := "# Changelog\n\n## [Unreleased]\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte(changelog), 0o600))
+		runGit(t, repoDir, "add", "CHANGELOG.md")
+		runGit(t, repoDir, "commit", "-m", "add changelog")
+		upgrades := []commands.ModuleUpgrade{
+			commands.NewModuleUpgrade("web", langEntities.LanguageNode,
+				&commands.LocalPRInfoForTest{ChangelogEntry: "- changed the JavaScript dependencies"}),
+		}
+
+		// when
+		commands.WriteModulesChangelog(context.Background(), repoDir, upgrades, entities.ChangelogSettings{})
+
+		// then
+		content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		assert.Equal(t, changelog, string(content))
+	})
+}
+
+func TestModulesCommitPrefix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should use the prefix every module's updater shares", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{Updaters: map[string]entities.UpdaterConfig{
+			"golang":     {CommitType: "build"},
+			"javascript": {CommitType: "build"},
+		}}
+		upgrades := []commands.ModuleUpgrade{
+			commands.NewModuleUpgrade("api", langEntities.LanguageGo, &commands.LocalPRInfoForTest{}),
+			commands.NewModuleUpgrade("web", langEntities.LanguageNode, &commands.LocalPRInfoForTest{}),
+		}
+
+		// when
+		prefix := commands.ModulesCommitPrefix(settings, upgrades)
+
+		// then
+		assert.Equal(t, "build(deps)", prefix)
+	})
+
+	t.Run("should fall back to the default prefix when the updaters differ", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{Updaters: map[string]entities.UpdaterConfig{
+			"golang": {CommitType: "build"},
+		}}
+		upgrades := []commands.ModuleUpgrade{
+			commands.NewModuleUpgrade("api", langEntities.LanguageGo, &commands.LocalPRInfoForTest{}),
+			commands.NewModuleUpgrade("web", langEntities.LanguageNode, &commands.LocalPRInfoForTest{}),
+		}
+
+		// when
+		prefix := commands.ModulesCommitPrefix(settings, upgrades)
+
+		// then
+		assert.Equal(t, "chore(deps)", prefix)
+	})
 }
 
 // --- test helpers ---
 
+// initNestedRepo creates a git repository with a Go module in services/api,
+// a JavaScript package in web, one in node_modules and a Go module three
+// levels deep.
+func initNestedRepo(t *testing.T) string {
+	t.Helper()
+
+	repoDir := initTestGitRepo(t, "main")
+	runGit(t, repoDir, "remote", "add", "origin", "git@github.com:rios0rios0/autoupdate.git")
+	files := map[string]string{
+		"services/api/go.mod":             "module example.com/api\n\ngo 1.25.0\n",
+		"web/package.json":                `{"name": "web", "version": "1.0.0"}`,
+		"web/node_modules/x/package.json": `{"name": "x", "version": "1.0.0"}`,
+		"tools/cmd/lint/go.mod":           "module example.com/lint\n\ngo 1.25.0\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return repoDir
+}
+
+// recordingModuleHandlers returns Go, Node and Python upgrade handlers that
+// only record the module directory, relative to repoDir, and the language
+// they were called for.
+func recordingModuleHandlers(
+	t *testing.T,
+	repoDir string,
+	upgraded *[]string,
+) map[langEntities.Language]commands.LocalUpgradeHandler {
+	t.Helper()
+
+	handlers := make(map[langEntities.Language]commands.LocalUpgradeHandler)
+	for _, lang := range []langEntities.Language{
+		langEntities.LanguageGo, langEntities.LanguageNode, langEntities.LanguagePython,
+	} {
+		handlers[lang] = func(
+			_ context.Context, moduleDir, _, _ string, _ commands.LocalOptions, _ *infraRepos.ProviderRegistry,
+		) (*commands.LocalPRInfoForTest, error) {
+			rel, err := filepath.Rel(repoDir, moduleDir)
+			require.NoError(t, err)
+			*upgraded = append(*upgraded, filepath.ToSlash(rel)+":"+string(lang))
+			return &commands.LocalPRInfoForTest{ProjectType: lang}, nil
+		}
+	}
+	return handlers
+}
+
+// initPolyglotRepo creates a git repository holding both a Go module and a
+// JavaScript package.
+func initPolyglotRepo(t *testing.T) string {
+	t.Helper()
+
+	repoDir := initTestGitRepo(t, "main")
+	runGit(t, repoDir, "remote", "add", "origin", "git@github.com:rios0rios0/autoupdate.git")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(repoDir, "go.mod"), []byte("module example.com/polyglot\n\ngo 1.25.0\n"), 0o600,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(repoDir, "package.json"), []byte(`{"name": "polyglot", "version": "1.0.0"}`), 0o600,
+	))
+	return repoDir
+}
+
+// recordingHandlers returns Go, Node and Python upgrade handlers that only
+// record which project types they were called for.
+func recordingHandlers(upgraded *[]langEntities.Language) map[langEntities.Language]commands.LocalUpgradeHandler {
+	handlers := make(map[langEntities.Language]commands.LocalUpgradeHandler)
+	for _, lang := range []langEntities.Language{
+		langEntities.LanguageGo, langEntities.LanguageNode, langEntities.LanguagePython,
+	} {
+		handlers[lang] = func(
+			_ context.Context, _, _, _ string, _ commands.LocalOptions, _ *infraRepos.ProviderRegistry,
+		) (*commands.LocalPRInfoForTest, error) {
+			*upgraded = append(*upgraded, lang)
+			return &commands.LocalPRInfoForTest{ProjectType: lang}, nil
+		}
+	}
+	return handlers
+}
+
 // initTestGitRepo creates a temporary git repo with an initial commit using exec.Command.
 func initTestGitRepo(t *testing.T, branchName string) string {
 	t.Helper()
@@ -0,0 +1,277 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
+	"github.com/rios0rios0/autoupdate/internal/support"
+	langEntities "github.com/rios0rios0/langforge/pkg/domain/entities"
+	langRegistry "github.com/rios0rios0/langforge/pkg/infrastructure/registry"
+	"github.com/rios0rios0/langforge/pkg/support/fileutil"
+)
+
+// localModule is a directory of the repository holding a project type that
+// has a local updater.
+type localModule struct {
+	Dir      string // slash-separated, relative to the repository root ("." for the root)
+	Language langEntities.Language
+}
+
+// localUpdaterNames maps the languages with a local apply handler to the
+// updater whose settings they follow.
+var localUpdaterNames = map[langEntities.Language]string{
+	langEntities.LanguageGo:     "golang",
+	langEntities.LanguageNode:   "javascript",
+	langEntities.LanguagePython: "python",
+}
+
+// moduleUpgrade pairs a module with the outcome of its upgrade.
+type moduleUpgrade struct {
+	module localModule
+	info   *localPRInfo
+}
+
+// detectModules walks repoDir down to depth directory levels and returns
+// every project type with a local apply handler, directory by directory in
+// walk order. Hidden directories and entities.DefaultExcludedDirs are never
+// entered. A non-empty filter keeps only the directories whose relative
+// path matches it (path.Match syntax, e.g. "services/*").
+func (it *LocalCommand) detectModules(repoDir string, depth int, filter string) ([]localModule, error) {
+	if filter != "" {
+		if _, err := path.Match(filter, ""); err != nil {
+			return nil, fmt.Errorf("invalid path filter %q: %w", filter, err)
+		}
+	}
+
+	registry := langRegistry.NewDefaultRegistry()
+	var modules []localModule
+	walkErr := filepath.WalkDir(repoDir, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(repoDir, dir)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "." {
+			if strings.HasPrefix(entry.Name(), ".") || slices.Contains(entities.DefaultExcludedDirs, entry.Name()) {
+				return filepath.SkipDir
+			}
+			if strings.Count(rel, "/")+1 > depth {
+				return filepath.SkipDir
+			}
+		}
+		if filter != "" {
+			if matched, _ := path.Match(filter, rel); !matched {
+				return nil
+			}
+		}
+
+		providers, detectErr := registry.DetectAllWithChecker(fileutil.LocalFileChecker(dir))
+		if detectErr != nil {
+			return detectErr
+		}
+		for _, langProvider := range providers {
+			if it.applyHandlers[langProvider.Language()] != nil {
+				modules = append(modules, localModule{Dir: rel, Language: langProvider.Language()})
+			}
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return nil, fmt.Errorf("failed to scan %q for modules: %w", repoDir, walkErr)
+	}
+
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no supported language detected in %q down to depth %d", repoDir, depth)
+	}
+	return modules, nil
+}
+
+// describeModules renders modules as a comma-separated list for logs.
+func describeModules(modules []localModule) string {
+	names := make([]string, 0, len(modules))
+	for _, module := range modules {
+		names = append(names, fmt.Sprintf("%s (%s)", module.Dir, module.Language))
+	}
+	return strings.Join(names, ", ")
+}
+
+// upgradeModules upgrades every module on a single branch, commits the
+// combined changes once and opens one PR for them. A failing module stops
+// the run before anything is committed, leaving the branch and its changes
+// in place for inspection.
+func (it *LocalCommand) upgradeModules(
+	ctx context.Context,
+	repoDir string,
+	modules []localModule,
+	providerType, host, token string,
+	repo entities.Repository,
+	opts LocalOptions,
+) error {
+	if opts.DryRun {
+		var errs []error
+		for _, module := range modules {
+			if _, err := it.applyModule(ctx, repoDir, module, providerType, token, opts); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	gitCtx, err := gitlocal.NewLocalGitContext(repoDir, it.providerRegistry)
+	if err != nil {
+		return err
+	}
+	gitCtx.UseRemote(opts.Remote)
+	gitCtx.UseBaseBranch(opts.BaseBranch)
+	gitCtx.UseSigning(localSigning(opts.Settings))
+	originalBranch, err := gitCtx.CurrentBranch()
+	if err != nil {
+		return fmt.Errorf("failed to determine current branch: %w", err)
+	}
+	stashed, stashErr := gitCtx.StashIfDirty()
+	if stashErr != nil {
+		return stashErr
+	}
+	if stashed {
+		defer func() {
+			if checkoutErr := gitCtx.CheckoutBranch(originalBranch); checkoutErr != nil {
+				logger.Warnf("Failed to switch back to %s: %v", originalBranch, checkoutErr)
+			}
+			if restoreErr := gitCtx.RestoreStash(); restoreErr != nil {
+				logger.Warnf("Failed to restore stash: %v", restoreErr)
+			}
+		}()
+	}
+
+	branchName := buildAggregateBranchName(time.Now())
+	if err = gitCtx.CreateBranch(branchName); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", branchName, err)
+	}
+
+	upgrades := make([]moduleUpgrade, 0, len(modules))
+	for _, module := range modules {
+		info, applyErr := it.applyModule(ctx, repoDir, module, providerType, token, opts)
+		if applyErr != nil {
+			return applyErr
+		}
+		upgrades = append(upgrades, moduleUpgrade{module: module, info: info})
+	}
+
+	writeModulesChangelog(ctx, repoDir, upgrades, localChangelogSettings(opts.Settings))
+
+	prTitle, prDesc := generateModulesPRContent(upgrades)
+	commitMsg := entities.WithCommitPrefix(prTitle, modulesCommitPrefix(opts.Settings, upgrades))
+	pushed, pushErr := gitCtx.StageCommitAndPush(branchName, commitMsg, token)
+	if pushErr != nil {
+		return pushErr
+	}
+	if !pushed {
+		logger.Info("No dependency changes detected in any module, nothing to do.")
+		return nil
+	}
+
+	var footer *string
+	if opts.Settings != nil {
+		footer = opts.Settings.PRFooter
+	}
+	return it.openLocalPR(ctx, providerType, host, token, repo, branchName, prTitle, prDesc, footer)
+}
+
+// applyModule runs the apply handler of module inside its directory.
+func (it *LocalCommand) applyModule(
+	ctx context.Context,
+	repoDir string,
+	module localModule,
+	providerType, token string,
+	opts LocalOptions,
+) (*localPRInfo, error) {
+	logger.Infof("Upgrading %s dependencies in %s", module.Language, module.Dir)
+	info, err := runLocalUpgrade(
+		ctx, it.applyHandlers, filepath.Join(repoDir, filepath.FromSlash(module.Dir)),
+		module.Language, providerType, token, opts, it.providerRegistry,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s): %w", module.Dir, module.Language, err)
+	}
+	return info, nil
+}
+
+// writeModulesChangelog inserts the changelog entries of every upgraded
+// module into the root changelog at once, as writeAggregateChangelog does in
+// run mode. Nothing is written when no module changed a file.
+func writeModulesChangelog(
+	ctx context.Context,
+	repoDir string,
+	upgrades []moduleUpgrade,
+	changelog entities.ChangelogSettings,
+) {
+	if _, err := os.Stat(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath()))); err != nil {
+		return
+	}
+	if !support.HasUncommittedChanges(ctx, repoDir) {
+		return
+	}
+
+	var entries []string
+	for _, upgrade := range upgrades {
+		if entry := upgrade.info.ChangelogEntry; entry != "" && !slices.Contains(entries, entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) > 0 {
+		support.LocalChangelogUpdate(repoDir, entries, changelog)
+	}
+}
+
+// modulesCommitPrefix returns the commit prefix configured for the updaters
+// of every upgraded module, or the default "chore(deps)" when they differ.
+func modulesCommitPrefix(settings *entities.Settings, upgrades []moduleUpgrade) string {
+	var shared string
+	for _, upgrade := range upgrades {
+		prefix := localCommitPrefix(settings, localUpdaterNames[upgrade.module.Language])
+		if shared != "" && prefix != shared {
+			return entities.FormatCommitPrefix("", "")
+		}
+		shared = prefix
+	}
+	return shared
+}
+
+// generateModulesPRContent returns the title and description of the PR
+// bundling several module upgrades. A single module keeps the PR content
+// of a regular local upgrade.
+func generateModulesPRContent(upgrades []moduleUpgrade) (string, string) {
+	if len(upgrades) == 1 {
+		return generatePRContent(upgrades[0].info)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("This pull request upgrades the dependencies of every module detected in the repository.\n\n")
+	for _, upgrade := range upgrades {
+		title, desc := generatePRContent(upgrade.info)
+		fmt.Fprintf(&sb, "## `%s` (%s)\n\n**%s**\n\n%s\n\n", upgrade.module.Dir, upgrade.module.Language, title, desc)
+	}
+
+	title := fmt.Sprintf("chore(deps): updated dependencies in %d modules", len(upgrades))
+	return title, strings.TrimRight(sb.String(), "\n")
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// Plan is the interface for the plan command.
+type Plan interface {
+	Execute(ctx context.Context, settings *entities.Settings, opts PlanOptions, out io.Writer) error
+}
+
+// PlanOptions selects the repositories and updaters a plan covers. The
+// fields mirror the filters of RunOptions.
+type PlanOptions struct {
+	Verbose      bool
+	ProviderName string
+	OrgOverride  string
+	UpdaterName  string
+	IncludeRepos []string
+	ExcludeRepos []string
+	Workers      int
+}
+
+// PlanCommand lists the outdated dependencies of the discovered repositories
+// without cloning them or creating anything. It runs the discovery and
+// detection of the run command as a dry run, so only the updaters that
+// implement UpdatePlanner, working through the provider API alone, report
+// upgrades.
+type PlanCommand struct {
+	run *RunCommand
+}
+
+func NewPlanCommand(run *RunCommand) *PlanCommand {
+	return &PlanCommand{run: run}
+}
+
+// Execute writes one row per planned upgrade to out, in discovery order.
+func (it *PlanCommand) Execute(
+	ctx context.Context,
+	settings *entities.Settings,
+	opts PlanOptions,
+	out io.Writer,
+) error {
+	if opts.Verbose {
+		logger.SetLevel(logger.DebugLevel)
+	}
+
+	runOpts := RunOptions{
+		DryRun:       true,
+		Verbose:      opts.Verbose,
+		ProviderName: opts.ProviderName,
+		OrgOverride:  opts.OrgOverride,
+		UpdaterName:  opts.UpdaterName,
+		IncludeRepos: opts.IncludeRepos,
+		ExcludeRepos: opts.ExcludeRepos,
+		Workers:      opts.Workers,
+	}
+
+	var totals runTotals
+	for _, provCfg := range settings.Providers {
+		if opts.ProviderName != "" && provCfg.Type != opts.ProviderName {
+			continue
+		}
+
+		totals.add(it.run.processProvider(ctx, provCfg, settings, runOpts))
+	}
+
+	logger.Infof("Plan complete: %d repos scanned, %d upgrades planned, %d errors",
+		totals.repos, len(totals.changes), totals.errors)
+
+	return writePlanTable(out, totals.changes)
+}
+
+// writePlanTable writes the planned upgrades as an aligned table with a
+// header row, or a single line saying everything is up to date.
+func writePlanTable(out io.Writer, planned []entities.DependencyChange) error {
+	if len(planned) == 0 {
+		_, err := fmt.Fprintln(out, "All dependencies are up to date.")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, tabwriterPadding, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REPOSITORY\tECOSYSTEM\tDEPENDENCY\tCURRENT\tLATEST\tFILE")
+	for _, change := range planned {
+		file := change.File
+		if file == "" {
+			file = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			change.Repository, change.Ecosystem, change.Name, change.From, change.To, file)
+	}
+	return w.Flush()
+}
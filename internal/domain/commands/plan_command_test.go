@@ -0,0 +1,60 @@
+//go:build unit
+
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/commands"
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+)
+
+func TestPlanCommandExecute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the planned Terraform upgrades without creating anything", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		run, settings, spy := newTerraformDryRunCommand()
+		cmd := commands.NewPlanCommand(run)
+		var out bytes.Buffer
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.PlanOptions{}, &out)
+
+		// then
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, []string{"REPOSITORY", "ECOSYSTEM", "DEPENDENCY", "CURRENT", "LATEST", "FILE"},
+			strings.Fields(lines[0]))
+		assert.Equal(t, []string{"test-org/infra", "terraform", "network", "v1.0.0", "v1.2.0", "main.tf"},
+			strings.Fields(lines[1]))
+		assert.Empty(t, spy.BranchInputs)
+		assert.Empty(t, spy.PRInputs)
+	})
+
+	t.Run("should say everything is up to date when nothing is planned", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		run := commands.NewRunCommand(infraRepos.NewProviderRegistry(), infraRepos.NewUpdaterRegistry())
+		cmd := commands.NewPlanCommand(run)
+		var out bytes.Buffer
+
+		// when
+		err := cmd.Execute(context.Background(), &entities.Settings{}, commands.PlanOptions{}, &out)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "All dependencies are up to date.\n", out.String())
+	})
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// writePRURLs writes the URL of every pull request created by the run, for
+// CI steps that only need the links: a JSON array when path ends in ".json",
+// otherwise one URL per line. A run without pull requests writes an empty
+// list, replacing the output of a previous run.
+func writePRURLs(path string, created []repoPullRequest) error {
+	urls := make([]string, 0, len(created))
+	for _, item := range created {
+		urls = append(urls, item.pr.URL)
+	}
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.Marshal(urls)
+		if err != nil {
+			return fmt.Errorf("failed to encode PR URLs: %w", err)
+		}
+		data = append(encoded, '\n')
+	} else if len(urls) > 0 {
+		data = []byte(strings.Join(urls, "\n") + "\n")
+	}
+
+	if err := os.WriteFile(path, data, changesReportFileMode); err != nil {
+		return fmt.Errorf("failed to write PR URLs: %w", err)
+	}
+	return nil
+}
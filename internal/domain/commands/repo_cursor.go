@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// repoCursor records, per organization ("provider:org"), the key of the last
+// repository a run limited by RunOptions.MaxRepos processed, so the next run
+// continues with the repositories after it.
+type repoCursor map[string]string
+
+// loadRepoCursor reads the cursor file at path. A missing file is an empty
+// cursor, which starts every organization from its first repository.
+func loadRepoCursor(path string) (repoCursor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return repoCursor{}, nil
+		}
+		return nil, fmt.Errorf("failed to read cursor file: %w", err)
+	}
+
+	cursor := repoCursor{}
+	if unmarshalErr := json.Unmarshal(data, &cursor); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse cursor file: %w", unmarshalErr)
+	}
+	return cursor, nil
+}
+
+// writeRepoCursor writes the cursor file, replacing the previous one.
+func writeRepoCursor(path string, cursor repoCursor) error {
+	data, err := json.MarshalIndent(cursor, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cursor file: %w", err)
+	}
+	if writeErr := os.WriteFile(path, append(data, '\n'), changesReportFileMode); writeErr != nil {
+		return fmt.Errorf("failed to write cursor file: %w", writeErr)
+	}
+	return nil
+}
+
+// cursorKey identifies an organization in the cursor file.
+func cursorKey(providerName, org string) string {
+	return providerName + ":" + org
+}
+
+// selectRepoWindow returns the next maxRepos repositories after last, in
+// repository key order, wrapping around to the first repository at the
+// end of the list. A last key that is no longer discovered resumes from the
+// repository that would follow it.
+func selectRepoWindow(repos []entities.Repository, last string, maxRepos int) []entities.Repository {
+	if maxRepos <= 0 || len(repos) <= maxRepos {
+		return repos
+	}
+
+	sorted := slices.Clone(repos)
+	slices.SortFunc(sorted, func(a, b entities.Repository) int {
+		return strings.Compare(entities.RepoKey(a), entities.RepoKey(b))
+	})
+
+	start := 0
+	if last != "" {
+		start = len(sorted)
+		for i, repo := range sorted {
+			if entities.RepoKey(repo) > last {
+				start = i
+				break
+			}
+		}
+	}
+
+	window := make([]entities.Repository, 0, maxRepos)
+	for i := range maxRepos {
+		window = append(window, sorted[(start+i)%len(sorted)])
+	}
+	return window
+}
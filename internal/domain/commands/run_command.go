@@ -4,7 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/transport"
@@ -30,6 +36,138 @@ type RunOptions struct {
 	ProviderName string // If set, only process this provider (CLI override)
 	OrgOverride  string // If set, only process this org (CLI override)
 	UpdaterName  string // If set, only run this updater (CLI override)
+	Annotations  bool   // If set, also emit GitHub Actions workflow annotations to stdout
+	Strict       bool   // If set, fail the run when a configured org yields zero repositories
+	// IncludeRepos, when set, keeps only the discovered repositories whose
+	// name matches one of these globs (e.g. "terraform-*").
+	IncludeRepos []string
+	// ExcludeRepos drops the discovered repositories whose name matches one
+	// of these globs. It takes precedence over IncludeRepos.
+	ExcludeRepos []string
+	// JobSummaryPath, when set, receives a Markdown report of the run
+	// (the GitHub Actions $GITHUB_STEP_SUMMARY file).
+	JobSummaryPath string
+	// ChangesReportPath, when set, receives a JSON report of every
+	// dependency change included in the created pull requests.
+	ChangesReportPath string
+	// ReportPath, when set on a dry run, receives a JSON array of the
+	// upgrades the updaters that implement UpdatePlanner would make.
+	ReportPath string
+	// Canary, when set to a repository key ("org/name", or "org/project/name"
+	// on Azure DevOps), is processed on its own before anything else; a
+	// missing or failing canary aborts the run.
+	Canary string
+	// CanaryProceed continues with the full run once the canary succeeds;
+	// otherwise the run stops after the canary.
+	CanaryProceed bool
+	// MetricsPath, when set, receives the counts of the run in the
+	// Prometheus text format (for the node_exporter textfile collector).
+	MetricsPath string
+	// PRURLsPath, when set, receives the URL of every created pull request,
+	// one per line (or a JSON array for a ".json" path).
+	PRURLsPath string
+	// Workers bounds how many repositories of an organization are processed
+	// concurrently. Zero or less means DefaultWorkers.
+	Workers int
+	// MaxRepos, when positive, caps how many repositories of each
+	// organization a run processes. Successive runs continue where the
+	// previous one stopped, wrapping around, when CursorPath is set.
+	MaxRepos int
+	// CursorPath is the file that remembers, per organization, the last
+	// repository processed by a run limited by MaxRepos.
+	CursorPath string
+
+	// cursor is the content of CursorPath when the run started.
+	cursor repoCursor
+}
+
+// DefaultWorkers is the number of repositories processed concurrently when
+// RunOptions.Workers is not set.
+const DefaultWorkers = 4
+
+// ErrNoRepositoriesDiscovered is returned by Execute in strict mode when
+// discovery finds no repositories for at least one configured organization.
+var ErrNoRepositoriesDiscovered = errors.New("no repositories discovered")
+
+// ErrCanaryFailed is returned by Execute when the canary repository could
+// not be found or finished with errors, in which case the full run is skipped.
+var ErrCanaryFailed = errors.New("canary run failed")
+
+// runTotals accumulates the outcome of a run across providers and organizations.
+type runTotals struct {
+	prs       int
+	repos     int
+	errors    int
+	emptyOrgs []string
+
+	// Per-repository outcomes, keyed by "org/name", used for the job summary.
+	createdPRs   []repoPullRequest
+	skippedRepos []string
+	erroredRepos []string
+
+	// changes lists the dependency changes of the created pull requests,
+	// used for the changes report. On a dry run it lists the planned ones.
+	changes []entities.DependencyChange
+	// ecosystemRepos counts, per updater name, the repositories the updater
+	// applied to, used for the metrics file.
+	ecosystemRepos map[string]int
+	// cursor holds the last repository processed in each organization that
+	// was limited by RunOptions.MaxRepos, saved to RunOptions.CursorPath.
+	cursor repoCursor
+}
+
+// repoPullRequest pairs a created pull request with the repository it targets.
+type repoPullRequest struct {
+	repo string
+	pr   entities.PullRequest
+}
+
+func (t *runTotals) add(other runTotals) {
+	t.prs += other.prs
+	t.repos += other.repos
+	t.errors += other.errors
+	t.emptyOrgs = append(t.emptyOrgs, other.emptyOrgs...)
+	t.createdPRs = append(t.createdPRs, other.createdPRs...)
+	t.skippedRepos = append(t.skippedRepos, other.skippedRepos...)
+	t.erroredRepos = append(t.erroredRepos, other.erroredRepos...)
+	t.changes = append(t.changes, other.changes...)
+	for ecosystem, count := range other.ecosystemRepos {
+		if t.ecosystemRepos == nil {
+			t.ecosystemRepos = make(map[string]int)
+		}
+		t.ecosystemRepos[ecosystem] += count
+	}
+	for org, last := range other.cursor {
+		if t.cursor == nil {
+			t.cursor = make(repoCursor)
+		}
+		t.cursor[org] = last
+	}
+}
+
+// addRepository records the outcome of processing a single repository.
+func (t *runTotals) addRepository(repo entities.Repository, outcome repoOutcome) {
+	t.repos++
+	t.prs += len(outcome.prs)
+	t.errors += outcome.errs
+	t.changes = append(t.changes, outcome.changes...)
+	for _, ecosystem := range outcome.ecosystems {
+		if t.ecosystemRepos == nil {
+			t.ecosystemRepos = make(map[string]int)
+		}
+		t.ecosystemRepos[ecosystem]++
+	}
+
+	fullName := repo.Organization + "/" + repo.Name
+	for _, pr := range outcome.prs {
+		t.createdPRs = append(t.createdPRs, repoPullRequest{repo: fullName, pr: pr})
+	}
+	switch {
+	case outcome.errs > 0:
+		t.erroredRepos = append(t.erroredRepos, fullName)
+	case len(outcome.prs) == 0:
+		t.skippedRepos = append(t.skippedRepos, fullName)
+	}
 }
 
 // RunCommand orchestrates the full dependency update flow:
@@ -37,6 +175,7 @@ type RunOptions struct {
 type RunCommand struct {
 	providerRegistry *infraRepos.ProviderRegistry
 	updaterRegistry  *infraRepos.UpdaterRegistry
+	annotationOut    io.Writer
 }
 
 // NewRunCommand creates a new RunCommand with the given registries.
@@ -47,6 +186,7 @@ func NewRunCommand(
 	return &RunCommand{
 		providerRegistry: providerRegistry,
 		updaterRegistry:  updaterRegistry,
+		annotationOut:    os.Stdout,
 	}
 }
 
@@ -60,28 +200,178 @@ func (it *RunCommand) Execute(
 		logger.SetLevel(logger.DebugLevel)
 	}
 
+	if runOpts.Annotations {
+		defer enableAnnotations(it.annotationOut)()
+	}
+
 	gitlocal.CleanupStaleTempDirs()
 
-	totalPRs := 0
-	totalRepos := 0
-	totalErrors := 0
+	if runOpts.MaxRepos > 0 && runOpts.CursorPath != "" {
+		cursor, cursorErr := loadRepoCursor(runOpts.CursorPath)
+		if cursorErr != nil {
+			logger.Warnf("Failed to load the cursor from %s, starting over: %v", runOpts.CursorPath, cursorErr)
+			cursor = repoCursor{}
+		}
+		runOpts.cursor = cursor
+	}
+
+	started := time.Now()
+	var totals runTotals
+	var canaryErr error
+	if runOpts.Canary != "" {
+		totals, canaryErr = it.runCanary(ctx, settings, runOpts)
+	}
+	if runOpts.Canary == "" || (canaryErr == nil && runOpts.CanaryProceed) {
+		for _, provCfg := range settings.Providers {
+			if runOpts.ProviderName != "" && provCfg.Type != runOpts.ProviderName {
+				continue
+			}
+
+			totals.add(it.processProvider(ctx, provCfg, settings, runOpts))
+		}
+	}
+
+	logger.Infof(
+		"Run complete: %d repos processed, %d PRs created, %d errors",
+		totals.repos, totals.prs, totals.errors,
+	)
 
+	if runOpts.JobSummaryPath != "" {
+		if summaryErr := writeJobSummary(runOpts.JobSummaryPath, totals); summaryErr != nil {
+			logger.Warnf("Failed to write the job summary to %s: %v", runOpts.JobSummaryPath, summaryErr)
+		}
+	}
+
+	// A dry run creates no pull requests, so its changes are only planned.
+	created := totals.changes
+	if runOpts.DryRun {
+		created = nil
+	}
+	if runOpts.ChangesReportPath != "" {
+		if reportErr := writeChangesReport(runOpts.ChangesReportPath, created); reportErr != nil {
+			logger.Warnf("Failed to write the changes report to %s: %v", runOpts.ChangesReportPath, reportErr)
+		}
+	}
+
+	if runOpts.ReportPath != "" {
+		if !runOpts.DryRun {
+			logger.Warnf("The dry-run report is only written on a dry run, ignoring %s", runOpts.ReportPath)
+		} else if reportErr := writeDryRunReport(runOpts.ReportPath, totals.changes); reportErr != nil {
+			logger.Warnf("Failed to write the dry-run report to %s: %v", runOpts.ReportPath, reportErr)
+		}
+	}
+
+	if runOpts.cursor != nil && len(totals.cursor) > 0 {
+		maps.Copy(runOpts.cursor, totals.cursor)
+		if cursorErr := writeRepoCursor(runOpts.CursorPath, runOpts.cursor); cursorErr != nil {
+			logger.Warnf("Failed to save the cursor to %s: %v", runOpts.CursorPath, cursorErr)
+		}
+	}
+
+	if runOpts.PRURLsPath != "" {
+		if urlsErr := writePRURLs(runOpts.PRURLsPath, totals.createdPRs); urlsErr != nil {
+			logger.Warnf("Failed to write the PR URLs to %s: %v", runOpts.PRURLsPath, urlsErr)
+		}
+	}
+
+	if runOpts.MetricsPath != "" {
+		if metricsErr := writeMetricsFile(runOpts.MetricsPath, totals); metricsErr != nil {
+			logger.Warnf("Failed to write the metrics file to %s: %v", runOpts.MetricsPath, metricsErr)
+		}
+	}
+
+	if settings.StatsD != "" {
+		if metricsErr := sendRunMetrics(settings.StatsD, totals, time.Since(started)); metricsErr != nil {
+			logger.Warnf("Failed to push the run metrics: %v", metricsErr)
+		}
+	}
+
+	if webhookURL := settings.Notifications.SlackWebhookURL; webhookURL != "" && len(totals.createdPRs) > 0 {
+		if notifyErr := notifyCreatedPRs(ctx, webhookURL, totals.createdPRs); notifyErr != nil {
+			logger.Warnf("Failed to send the Slack notification: %v", notifyErr)
+		}
+	}
+	if webhook := settings.Notifications.Webhook; webhook.URL != "" {
+		if notifyErr := notifyWebhook(ctx, webhook, totals); notifyErr != nil {
+			logger.Warnf("Failed to send the run summary to the webhook: %v", notifyErr)
+		}
+	}
+
+	if canaryErr != nil {
+		return canaryErr
+	}
+	if runOpts.Strict && len(totals.emptyOrgs) > 0 {
+		return fmt.Errorf("%w in: %s", ErrNoRepositoriesDiscovered, strings.Join(totals.emptyOrgs, ", "))
+	}
+	return nil
+}
+
+// runCanary looks up the canary repository across the selected providers and
+// organizations and processes it alone. The canary succeeds when it finishes
+// without errors, whether it opened a pull request or was already up to date.
+func (it *RunCommand) runCanary(
+	ctx context.Context,
+	settings *entities.Settings,
+	runOpts RunOptions,
+) (runTotals, error) {
+	want := strings.ToLower(runOpts.Canary)
 	for _, provCfg := range settings.Providers {
 		if runOpts.ProviderName != "" && provCfg.Type != runOpts.ProviderName {
 			continue
 		}
 
-		prs, repos, errs := it.processProvider(ctx, provCfg, settings, runOpts)
-		totalPRs += prs
-		totalRepos += repos
-		totalErrors += errs
+		provider, err := it.providerRegistry.Get(provCfg.Type, provCfg.Token)
+		if err != nil {
+			logger.Warnf("Failed to initialize provider %q while looking for the canary: %v", provCfg.Type, err)
+			continue
+		}
+
+		for _, org := range provCfg.Organizations {
+			if runOpts.OrgOverride != "" && org != runOpts.OrgOverride {
+				continue
+			}
+
+			repos, discoverErr := provider.DiscoverRepositories(ctx, org)
+			if discoverErr != nil {
+				logger.Warnf("Failed to discover repos in %q while looking for the canary: %v", org, discoverErr)
+				continue
+			}
+
+			for _, repo := range filterRepositories(repos, settings) {
+				if entities.RepoKey(repo) != want {
+					continue
+				}
+
+				logger.Infof("Running canary on %s...", want)
+				var totals runTotals
+				outcome := it.processRepository(ctx, provider, repo, settings, runOpts)
+				totals.addRepository(repo, outcome)
+				if outcome.errs > 0 {
+					return totals, fmt.Errorf(
+						"%w: %s finished with %d error(s)", ErrCanaryFailed, runOpts.Canary, outcome.errs,
+					)
+				}
+				logger.Infof("Canary %s succeeded", want)
+				return totals, nil
+			}
+		}
 	}
 
-	logger.Infof(
-		"Run complete: %d repos processed, %d PRs created, %d errors",
-		totalRepos, totalPRs, totalErrors,
+	return runTotals{}, fmt.Errorf(
+		"%w: %s was not found among the discovered repositories", ErrCanaryFailed, runOpts.Canary,
 	)
-	return nil
+}
+
+// enableAnnotations registers a GitHub Actions annotation hook on the
+// standard logger and returns a function that restores the previous hooks.
+func enableAnnotations(out io.Writer) func() {
+	std := logger.StandardLogger()
+	previous := make(logger.LevelHooks, len(std.Hooks))
+	for level, hooks := range std.Hooks {
+		previous[level] = append([]logger.Hook(nil), hooks...)
+	}
+	std.AddHook(support.NewGitHubAnnotationHook(out))
+	return func() { std.ReplaceHooks(previous) }
 }
 
 // processProvider initializes a single provider and processes all its organizations.
@@ -90,58 +380,118 @@ func (it *RunCommand) processProvider(
 	provCfg entities.ProviderConfig,
 	settings *entities.Settings,
 	runOpts RunOptions,
-) (int, int, int) {
+) runTotals {
 	provider, err := it.providerRegistry.Get(provCfg.Type, provCfg.Token)
 	if err != nil {
 		logger.Errorf("Failed to initialize provider %q: %v", provCfg.Type, err)
-		return 0, 0, 1
+		return runTotals{errors: 1}
 	}
 
 	logger.Infof("Processing provider: %s", provider.Name())
 
-	totalPRs, totalRepos, totalErrors := 0, 0, 0
+	var totals runTotals
 	for _, org := range provCfg.Organizations {
 		if runOpts.OrgOverride != "" && org != runOpts.OrgOverride {
 			continue
 		}
 
-		prs, repos, errs := it.processOrganization(ctx, provider, org, settings, runOpts)
-		totalPRs += prs
-		totalRepos += repos
-		totalErrors += errs
+		totals.add(it.processOrganization(ctx, provider, org, settings, runOpts))
 	}
 
-	return totalPRs, totalRepos, totalErrors
+	return totals
 }
 
 // processOrganization discovers repositories in an organization and processes each one.
+// A 401/403 from discovery means the token has no access to the organization, a 404
+// that it does not exist (or is hidden from the token), and an empty result that it
+// has no repositories; each is reported apart so misconfiguration does not go unnoticed.
 func (it *RunCommand) processOrganization(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	org string,
 	settings *entities.Settings,
 	runOpts RunOptions,
-) (int, int, int) {
+) runTotals {
 	logger.Infof("Discovering repositories in %q...", org)
 
 	repos, discoverErr := provider.DiscoverRepositories(ctx, org)
 	if discoverErr != nil {
-		logger.Errorf("Failed to discover repos in %q: %v", org, discoverErr)
-		return 0, 0, 1
+		switch status := support.HTTPStatus(discoverErr); status {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			logger.Errorf("The %s token has no access to %q (HTTP %d): %v",
+				provider.Name(), org, status, discoverErr)
+		case http.StatusNotFound:
+			logger.Errorf("Organization %q does not exist or is not visible to the %s token: %v",
+				org, provider.Name(), discoverErr)
+		default:
+			logger.Errorf("Failed to discover repos in %q: %v", org, discoverErr)
+		}
+		return runTotals{errors: 1}
+	}
+
+	if len(repos) == 0 {
+		logger.Warnf("Organization %q is empty: the %s token can read it but it lists no repositories",
+			org, provider.Name())
+		return runTotals{emptyOrgs: []string{org}}
 	}
 
 	repos = filterRepositories(repos, settings)
+	repos = filterRepositoriesByPattern(repos, runOpts.IncludeRepos, runOpts.ExcludeRepos)
 	logger.Infof("Found %d repositories in %q", len(repos), org)
 
-	totalPRs, totalRepos, totalErrors := 0, 0, 0
-	for _, repo := range repos {
-		totalRepos++
-		prs, errs := it.processRepository(ctx, provider, repo, settings, runOpts)
-		totalPRs += len(prs)
-		totalErrors += errs
+	var cursor repoCursor
+	if runOpts.MaxRepos > 0 && len(repos) > runOpts.MaxRepos {
+		key := cursorKey(provider.Name(), org)
+		repos = selectRepoWindow(repos, runOpts.cursor[key], runOpts.MaxRepos)
+		cursor = repoCursor{key: entities.RepoKey(repos[len(repos)-1])}
+		logger.Infof("Processing %d repositories of %q this run (--max-repos)", len(repos), org)
 	}
 
-	return totalPRs, totalRepos, totalErrors
+	// Repositories are independent, so up to runOpts.Workers of them are
+	// processed at once. The provider is shared by the workers: its clients
+	// are safe for concurrent use. A failing repository does not stop the
+	// others, and the outcomes are recorded in discovery order.
+	workers := runOpts.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	outcomes := make([]*repoOutcome, len(repos))
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		// The canary was already processed before the full run started.
+		if runOpts.Canary != "" && entities.RepoKey(repo) == strings.ToLower(runOpts.Canary) {
+			continue
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			outcome := it.processRepository(ctx, provider, repo, settings, runOpts)
+			outcomes[i] = &outcome
+		}()
+	}
+	wg.Wait()
+
+	totals := runTotals{cursor: cursor}
+	for i, repo := range repos {
+		if outcome := outcomes[i]; outcome != nil {
+			totals.addRepository(repo, *outcome)
+		}
+	}
+
+	return totals
+}
+
+// repoOutcome is the result of processing one repository.
+type repoOutcome struct {
+	prs        []entities.PullRequest
+	changes    []entities.DependencyChange
+	errs       int
+	ecosystems []string // names of the updaters that applied to the repository
 }
 
 // filterRepositories removes repositories that match the exclusion criteria
@@ -176,25 +526,53 @@ func filterRepositories(
 	return filtered
 }
 
-// isSkippedByRepoConfig reads the target repository's .autoupdate.yaml
-// via the provider API. It returns true only when the file exists and
-// requests an explicit skip; transient fetch or parse errors fail open
-// (proceed with the update) so a flaky API call cannot silently disable
-// every update.
-func isSkippedByRepoConfig(
+// filterRepositoriesByPattern applies the --include-repo and --exclude-repo
+// globs of a run. The globs follow exclude_repos semantics, so a bare
+// pattern such as "terraform-*" is matched against the repository name.
+// A repository matching both lists is excluded.
+func filterRepositoriesByPattern(
+	repos []entities.Repository,
+	include, exclude []string,
+) []entities.Repository {
+	if len(include) == 0 && len(exclude) == 0 {
+		return repos
+	}
+
+	filtered := make([]entities.Repository, 0, len(repos))
+	for _, repo := range repos {
+		key := entities.RepoKey(repo)
+		if excluded, pattern := entities.MatchesExcludePattern(repo, exclude); excluded {
+			logger.Infof("Skipping %s: matched --exclude-repo pattern %q", key, pattern)
+			continue
+		}
+		if included, _ := entities.MatchesExcludePattern(repo, include); len(include) > 0 && !included {
+			logger.Debugf("Skipping %s: matches no --include-repo pattern", key)
+			continue
+		}
+		filtered = append(filtered, repo)
+	}
+	return filtered
+}
+
+// readRepoConfig reads the target repository's .autoupdate.yaml via the
+// provider API. It reports a skip only when the file exists and requests
+// one; transient fetch or parse errors fail open (proceed with the update
+// and an empty config) so a flaky API call cannot silently disable every
+// update.
+func readRepoConfig(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
-) bool {
+) (*entities.RepoConfig, bool) {
 	key := entities.RepoKey(repo)
 	cfg, err := support.LoadRemoteRepoConfig(ctx, provider, repo)
 	if err != nil {
 		logger.Warnf("Could not read %s for %s: %v (continuing without it)",
 			entities.RepoConfigFile, key, err)
-		return false
+		return &entities.RepoConfig{}, false
 	}
 	if !cfg.IsSkipped() {
-		return false
+		return cfg, false
 	}
 
 	if cfg.Reason != "" {
@@ -204,7 +582,7 @@ func isSkippedByRepoConfig(
 		logger.Infof("Skipping %s: %s requested skip",
 			key, entities.RepoConfigFile)
 	}
-	return true
+	return cfg, true
 }
 
 // applicableUpdater holds an updater and its resolved options.
@@ -216,26 +594,36 @@ type applicableUpdater struct {
 // processRepository runs all applicable updaters on a single repository.
 // Updaters that implement LocalUpdater get the clone-based pipeline (clone once,
 // branch per updater, signed commit, transport-detected push).
-// Legacy updaters fall back to CreateUpdatePRs.
+// Legacy updaters fall back to CreateUpdatePRs. Besides the created PRs and
+// the error count the outcome carries the dependency changes those PRs carry,
+// which only the local pipeline reports (or on a dry run the planned changes),
+// and the ecosystems detected in the repository.
 func (it *RunCommand) processRepository(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	settings *entities.Settings,
 	runOpts RunOptions,
-) ([]entities.PullRequest, int) {
-	if isSkippedByRepoConfig(ctx, provider, repo) {
-		return nil, 0
+) repoOutcome {
+	repoCfg, skipped := readRepoConfig(ctx, provider, repo)
+	if skipped {
+		return repoOutcome{}
 	}
 
-	localUpdaters, legacyUpdaters := it.collectApplicableUpdaters(ctx, provider, repo, settings, runOpts)
+	localUpdaters, legacyUpdaters := it.collectApplicableUpdaters(ctx, provider, repo, repoCfg, settings, runOpts)
 
 	var allPRs []entities.PullRequest
+	var changes []entities.DependencyChange
 	errorCount := 0
+	ecosystems := make([]string, 0, len(localUpdaters)+len(legacyUpdaters))
+	for _, au := range slices.Concat(localUpdaters, legacyUpdaters) {
+		ecosystems = append(ecosystems, au.updater.Name())
+	}
 
 	if len(localUpdaters) > 0 {
-		prs, errs := it.processLocalUpdaters(ctx, provider, repo, settings, localUpdaters)
+		prs, localChanges, errs := it.processLocalUpdaters(ctx, provider, repo, settings, localUpdaters)
 		allPRs = append(allPRs, prs...)
+		changes = localChanges
 		errorCount += errs
 	}
 
@@ -251,19 +639,26 @@ func (it *RunCommand) processRepository(
 		}
 
 		for _, pr := range prs {
-			logger.Infof("  Created PR #%d: %s (%s)", pr.ID, pr.Title, pr.URL)
+			logger.WithField(support.AnnotationField, support.AnnotationNotice).
+				Infof("[%s] Created PR #%d for %s/%s: %s (%s)",
+					au.updater.Name(), pr.ID, repo.Organization, repo.Name, pr.Title, pr.URL)
+			applyPullRequestLabels(ctx, provider, repo, pr, au.opts.Labels)
+			applyPullRequestReviewers(ctx, provider, repo, pr, au.opts.Reviewers)
+			applyPullRequestAssignees(ctx, provider, repo, pr, au.opts.Assignees)
 		}
 		allPRs = append(allPRs, prs...)
 	}
 
-	return allPRs, errorCount
+	return repoOutcome{prs: allPRs, changes: changes, errs: errorCount, ecosystems: ecosystems}
 }
 
-// collectApplicableUpdaters partitions detected updaters into local and legacy groups.
+// collectApplicableUpdaters partitions detected updaters into local and legacy
+// groups, leaving out the updaters the repository's .autoupdate.yaml ignores.
 func (it *RunCommand) collectApplicableUpdaters(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
+	repoCfg *entities.RepoConfig,
 	settings *entities.Settings,
 	runOpts RunOptions,
 ) ([]applicableUpdater, []applicableUpdater) {
@@ -273,10 +668,23 @@ func (it *RunCommand) collectApplicableUpdaters(
 			continue
 		}
 
-		if updaterCfg, ok := settings.Updaters[u.Name()]; ok && !updaterCfg.IsEnabled() {
+		if repoCfg.IsUpdaterIgnored(u.Name()) {
+			logger.Infof("[%s] Skipping %s/%s: ignored by %s",
+				u.Name(), repo.Organization, repo.Name, entities.RepoConfigFile)
 			continue
 		}
 
+		if updaterCfg, ok := settings.Updaters[u.Name()]; ok {
+			if !updaterCfg.IsEnabled() {
+				continue
+			}
+			if !updaterCfg.IsInRollout(repo.Organization + "/" + repo.Name) {
+				logger.Debugf("[%s] %s/%s is outside the %s rollout, skipping",
+					u.Name(), repo.Organization, repo.Name, updaterCfg.Rollout)
+				continue
+			}
+		}
+
 		if !u.Detect(ctx, provider, repo) {
 			continue
 		}
@@ -284,14 +692,57 @@ func (it *RunCommand) collectApplicableUpdaters(
 		logger.Infof("[%s] Detected in %s/%s", u.Name(), repo.Organization, repo.Name)
 
 		opts := entities.UpdateOptions{
-			DryRun:  runOpts.DryRun,
-			Verbose: runOpts.Verbose,
+			DryRun:            runOpts.DryRun,
+			Verbose:           runOpts.Verbose,
+			ExcludedDirs:      settings.ExcludedDirs,
+			IgnoreMajor:       settings.IgnoreMajor,
+			PRFooter:          settings.PRFooter,
+			ChangelogConflict: settings.ChangelogConflict,
+			Changelog:         settings.Changelog,
+			CommitAuthor:      settings.CommitAuthor,
+			Signing:           settings.Signing,
+		}
+		if settings.VersionPolicy != nil {
+			opts.MaxVersions = settings.VersionPolicy.MaxVersions
 		}
 		if updaterCfg, ok := settings.Updaters[u.Name()]; ok {
 			opts.AutoComplete = updaterCfg.IsAutoComplete()
 			if updaterCfg.TargetBranch != "" {
 				opts.TargetBranch = updaterCfg.TargetBranch
 			}
+			opts.Paths = updaterCfg.Paths
+			opts.TrackBranches = updaterCfg.TrackBranches
+			opts.ImageSources = updaterCfg.ImageSources
+			opts.TagPattern = updaterCfg.TagPattern
+			opts.PRTitleTemplate = updaterCfg.PRTitleTemplate
+			opts.PRBodyTemplate = updaterCfg.PRBodyTemplate
+			opts.CommitType = updaterCfg.CommitType
+			opts.CommitScope = updaterCfg.CommitScope
+			opts.UpgradeLocalComments = updaterCfg.IsUpgradeLocalComments()
+			opts.Concurrency = updaterCfg.Concurrency
+			opts.AuditFix = updaterCfg.IsAuditFix()
+			opts.AuditFixForce = updaterCfg.IsAuditFixForce()
+			opts.MaxVersion = updaterCfg.MaxVersion
+			opts.ExcludeModules = updaterCfg.Exclude
+			opts.OnlyModules = updaterCfg.Only
+			opts.CIFiles = updaterCfg.CIFiles
+			opts.Labels = updaterCfg.Labels
+			opts.Reviewers = updaterCfg.Reviewers
+			opts.Assignees = updaterCfg.Assignees
+			opts.DockerfileImages = updaterCfg.DockerfileImages
+			opts.DirectOnly = updaterCfg.IsDirectOnly()
+			opts.BumpLowerBounds = updaterCfg.IsBumpLowerBounds()
+			opts.RunFmt = updaterCfg.IsRunFmt()
+			opts.UpgradeRegistryModules = updaterCfg.IsUpgradeRegistryModules()
+			opts.IgnoreMajor = updaterCfg.IsIgnoreMajor(settings.IgnoreMajor)
+			opts.RefreshLockfile = updaterCfg.IsRefreshLockfile()
+			opts.UpdateRanges = updaterCfg.IsUpdateRanges()
+			opts.AllowYanked = updaterCfg.IsAllowYanked()
+			opts.SupersedeStale = updaterCfg.IsSupersedeStale()
+			opts.FreezeMode = updaterCfg.FreezeMode
+			opts.NodeChannel = updaterCfg.NodeChannel
+			opts.VersionConflict = updaterCfg.VersionConflict
+			opts.Series = updaterCfg.Series
 		}
 
 		au := applicableUpdater{updater: u, opts: opts}
@@ -317,16 +768,18 @@ type appliedUpdaterResult struct {
 // local updater against a single aggregate branch, producing one signed
 // commit and one pull request that bundles all the changes. Per-updater
 // failure isolation is handled via snapshot commits on BatchGitContext.
+// The dependency changes are returned only when the pull request is created.
 func (it *RunCommand) processLocalUpdaters(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	settings *entities.Settings,
 	updaters []applicableUpdater,
-) ([]entities.PullRequest, int) {
+) ([]entities.PullRequest, []entities.DependencyChange, int) {
 	if allDryRun(updaters) {
 		logAggregateDryRun(updaters, repo)
-		return nil, 0
+		planned, planErrs := planUpdates(ctx, provider, repo, updaters)
+		return nil, planned, planErrs
 	}
 
 	// Same-day idempotency: short-circuit before touching git if the aggregate
@@ -340,7 +793,7 @@ func (it *RunCommand) processLocalUpdaters(
 	} else if exists {
 		logger.Infof("[autoupdate] PR already exists for %s/%s on branch %q, skipping",
 			repo.Organization, repo.Name, aggregateBranch)
-		return nil, 0
+		return nil, nil, 0
 	}
 
 	// The clone base ref and the PR target ref must match so the aggregate
@@ -362,21 +815,21 @@ func (it *RunCommand) processLocalUpdaters(
 	)
 	if err != nil {
 		logger.Errorf("Failed to clone %s/%s: %v", repo.Organization, repo.Name, err)
-		return nil, 1
+		return nil, nil, 1
 	}
 	defer batchCtx.Close()
 
 	if branchErr := batchCtx.CreateBranchFromDefault(aggregateBranch); branchErr != nil {
 		logger.Errorf("[autoupdate] Failed to create branch %s for %s/%s: %v",
 			aggregateBranch, repo.Organization, repo.Name, branchErr)
-		return nil, 1
+		return nil, nil, 1
 	}
 
 	applied, errorCount := it.runUpdatersOnBranch(ctx, batchCtx, updaters, provider, repo)
 	if len(applied) == 0 {
 		logger.Infof("[autoupdate] %s/%s: no updaters produced changes",
 			repo.Organization, repo.Name)
-		return nil, errorCount
+		return nil, nil, errorCount
 	}
 
 	pr, pushErrs := it.commitPushAndOpenPR(
@@ -384,9 +837,10 @@ func (it *RunCommand) processLocalUpdaters(
 		aggregateBranch, applied, updaters,
 	)
 	if pr == nil {
-		return nil, errorCount + pushErrs
+		return nil, nil, errorCount + pushErrs
 	}
-	return []entities.PullRequest{*pr}, errorCount + pushErrs
+	changes := collectDependencyChanges(repo.Organization+"/"+repo.Name, applied)
+	return []entities.PullRequest{*pr}, changes, errorCount + pushErrs
 }
 
 // runUpdatersOnBranch runs each applicable LocalUpdater against the shared
@@ -502,6 +956,28 @@ func (it *RunCommand) commitPushAndOpenPR(
 		return nil, 1
 	}
 
+	title, description := renderAggregatePRText(
+		settings, applied, buildAggregatePRTitle(applied), buildAggregatePRDescription(applied),
+	)
+	description = entities.ApplyPRFooter(description, settings.PRFooter)
+	if settings.DedupeByContent {
+		changes, changesErr := batchCtx.WorktreeChanges()
+		if changesErr != nil {
+			logger.Warnf("[autoupdate] Failed to read the changes to hash for %s/%s: %v",
+				repo.Organization, repo.Name, changesErr)
+		} else {
+			hash := support.ContentHash(changes)
+			if duplicate := findContentDuplicate(ctx, provider, repo, hash); duplicate != nil {
+				logger.Infof("[autoupdate] PR #%d for %s/%s already carries the same changes, skipping",
+					duplicate.ID, repo.Organization, repo.Name)
+				return nil, 0
+			}
+			branchName = support.ContentHashBranch(branchName, hash)
+		}
+	}
+
+	writeAggregateChangelog(batchCtx.RepoDir(), applied, settings.Changelog)
+
 	commitMsg := buildAggregateCommitMessage(applied)
 
 	pushed, pushErr := batchCtx.CommitSignedAndPush(branchName, commitMsg, settings, authMethods)
@@ -519,8 +995,8 @@ func (it *RunCommand) commitPushAndOpenPR(
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + branchName,
 		TargetBranch: resolveAggregateTargetBranch(repo, updaters),
-		Title:        buildAggregatePRTitle(applied),
-		Description:  buildAggregatePRDescription(applied),
+		Title:        title,
+		Description:  description,
 		AutoComplete: anyAutoComplete(updaters),
 	})
 	if createErr != nil {
@@ -529,8 +1005,29 @@ func (it *RunCommand) commitPushAndOpenPR(
 		return nil, 1
 	}
 
-	logger.Infof("[autoupdate] Created PR #%d for %s/%s: %s",
-		pr.ID, repo.Organization, repo.Name, pr.URL)
+	logger.WithField(support.AnnotationField, support.AnnotationNotice).
+		Infof("[autoupdate] Created PR #%d for %s/%s: %s",
+			pr.ID, repo.Organization, repo.Name, pr.URL)
+
+	labels := collectAggregateLabels(applied, updaters)
+	if sizeLabel := resolveSizeLabel(batchCtx, settings.SizeLabels); sizeLabel != "" {
+		labels = append(labels, sizeLabel)
+	}
+	applyPullRequestLabels(ctx, provider, repo, *pr, labels)
+
+	var changedPaths []string
+	if settings.AssignLastCommitter {
+		paths, pathsErr := batchCtx.HeadChangedFiles()
+		if pathsErr != nil {
+			logger.Warnf("[autoupdate] Failed to list the changed files for the assignees: %v", pathsErr)
+		}
+		changedPaths = paths
+	}
+	assignees := resolvePullRequestAssignees(ctx, provider, repo, settings, changedPaths)
+	assignees = appendUnique(assignees, collectAggregateUsers(applied, updaters, aggregateAssignees)...)
+	applyPullRequestAssignees(ctx, provider, repo, *pr, assignees)
+	applyPullRequestReviewers(ctx, provider, repo, *pr,
+		collectAggregateUsers(applied, updaters, aggregateReviewers))
 
 	if switchErr := batchCtx.SwitchToDefault(); switchErr != nil {
 		logger.Warnf("[autoupdate] Failed to switch back to default branch: %v", switchErr)
@@ -539,6 +1036,25 @@ func (it *RunCommand) commitPushAndOpenPR(
 	return pr, 0
 }
 
+// findContentDuplicate looks for an open aggregate PR whose branch carries
+// the content hash of the computed changes, so a re-run on a later day does
+// not open a second PR for the same changes under a new branch name. A
+// failed lookup is logged and treated as no duplicate.
+func findContentDuplicate(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	hash string,
+) *entities.PullRequestDetail {
+	duplicate, err := support.FindPullRequestByContentHash(ctx, provider, repo, aggregateBranchPrefix, hash)
+	if err != nil {
+		logger.Warnf("[autoupdate] Failed to look for a PR with the same changes in %s/%s: %v",
+			repo.Organization, repo.Name, err)
+		return nil
+	}
+	return duplicate
+}
+
 // aggregateBranchPrefix is the prefix used for every consolidated branch
 // name produced by the aggregate pipeline. The full name is
 // `chore/autoupdate-YYYY-MM-DD` (UTC), making same-day re-runs idempotent
@@ -563,13 +1079,32 @@ func buildAggregateCommitMessage(applied []appliedUpdaterResult) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("chore(deps): bumped dependencies via autoupdate\n\n")
+	sb.WriteString(aggregateCommitPrefix(applied) + ": bumped dependencies via autoupdate\n\n")
 	for _, a := range applied {
 		fmt.Fprintf(&sb, "- [%s] %s\n", a.name, firstLine(a.result.CommitMessage))
 	}
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// aggregateCommitPrefix returns the conventional-commit prefix the applied
+// updaters' commit messages share, so a commit_type or commit_scope set on
+// every one of them carries over. Mixed prefixes fall back to "chore(deps)".
+func aggregateCommitPrefix(applied []appliedUpdaterResult) string {
+	fallback := entities.FormatCommitPrefix("", "")
+	var shared string
+	for _, a := range applied {
+		prefix, _, found := strings.Cut(firstLine(a.result.CommitMessage), ":")
+		if !found || (shared != "" && prefix != shared) {
+			return fallback
+		}
+		shared = prefix
+	}
+	if shared == "" {
+		return fallback
+	}
+	return shared
+}
+
 // buildAggregatePRTitle returns the PR title. For a single updater it is
 // that updater's original title; for multiple it lists the contributing
 // updater names so reviewers can see at a glance which ecosystems moved.
@@ -584,6 +1119,55 @@ func buildAggregatePRTitle(applied []appliedUpdaterResult) string {
 	return fmt.Sprintf("chore(deps): bumped dependencies (%s)", strings.Join(names, ", "))
 }
 
+// renderAggregatePRText applies the pr_title_template and pr_body_template
+// that every applied updater shares to a PR bundling several of them,
+// rendered with their combined changes and comma-separated names as the
+// ecosystem. A single updater has already rendered its own templates, and a
+// template the updaters do not share keeps the generated text.
+func renderAggregatePRText(
+	settings *entities.Settings,
+	applied []appliedUpdaterResult,
+	title, description string,
+) (string, string) {
+	if len(applied) < 2 {
+		return title, description
+	}
+
+	names := make([]string, 0, len(applied))
+	var changes []entities.DependencyChange
+	for _, a := range applied {
+		names = append(names, a.name)
+		changes = append(changes, a.result.Changes...)
+	}
+	opts := entities.UpdateOptions{
+		PRTitleTemplate: sharedUpdaterSetting(settings, names, func(cfg entities.UpdaterConfig) string {
+			return cfg.PRTitleTemplate
+		}),
+		PRBodyTemplate: sharedUpdaterSetting(settings, names, func(cfg entities.UpdaterConfig) string {
+			return cfg.PRBodyTemplate
+		}),
+	}
+	return opts.RenderPRText(entities.NewPRTemplateData(strings.Join(names, ", "), changes), title, description)
+}
+
+// sharedUpdaterSetting returns the value get reads from the config of every
+// named updater, or "" when any of them differs.
+func sharedUpdaterSetting(
+	settings *entities.Settings,
+	names []string,
+	get func(cfg entities.UpdaterConfig) string,
+) string {
+	var shared string
+	for i, name := range names {
+		value := get(settings.Updaters[name])
+		if i > 0 && value != shared {
+			return ""
+		}
+		shared = value
+	}
+	return shared
+}
+
 // buildAggregatePRDescription renders the PR body with one section per
 // contributing updater, preserving each updater's original PR description
 // so reviewers see the full context for every change.
@@ -615,6 +1199,238 @@ func buildAggregatePRDescription(applied []appliedUpdaterResult) string {
 	return strings.TrimRight(sb.String(), "\n")
 }
 
+// writeAggregateChangelog inserts the CHANGELOG entries of every
+// contributing updater into CHANGELOG.md with a single InsertChangelogEntry
+// call, so a combined PR lists all ecosystem bumps in one grouped block
+// instead of one insert per updater. The block goes under the configured
+// section of the configured changelog file.
+func writeAggregateChangelog(repoDir string, applied []appliedUpdaterResult, changelog entities.ChangelogSettings) {
+	entries := collectAggregateChangelogEntries(applied)
+	if len(entries) == 0 {
+		return
+	}
+	support.LocalChangelogUpdate(repoDir, entries, changelog)
+}
+
+// collectAggregateChangelogEntries returns the de-duplicated CHANGELOG
+// entries of every contributing updater, in updater order.
+func collectAggregateChangelogEntries(applied []appliedUpdaterResult) []string {
+	seen := make(map[string]bool)
+	var entries []string
+	for _, a := range applied {
+		for _, entry := range a.result.ChangelogEntries {
+			if entry == "" || seen[entry] {
+				continue
+			}
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// collectAggregateLabels returns the de-duplicated union of the `labels`
+// configured for every contributing updater, in first-seen order. Updaters
+// that produced no changes add none.
+func collectAggregateLabels(applied []appliedUpdaterResult, updaters []applicableUpdater) []string {
+	configured := make(map[string][]string, len(updaters))
+	for _, au := range updaters {
+		configured[au.updater.Name()] = au.opts.Labels
+	}
+
+	seen := make(map[string]bool)
+	var labels []string
+	for _, a := range applied {
+		for _, label := range configured[a.name] {
+			if label == "" || seen[label] {
+				continue
+			}
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// resolveSizeLabel picks the size_labels bucket matching the number of
+// lines changed by the pushed aggregate commit. It returns "" when no
+// buckets are configured or the diff cannot be measured.
+func resolveSizeLabel(batchCtx *gitlocal.BatchGitContext, sizeLabels []entities.SizeLabel) string {
+	if len(sizeLabels) == 0 {
+		return ""
+	}
+	lines, err := batchCtx.HeadChangedLines()
+	if err != nil {
+		logger.Warnf("[autoupdate] Failed to count the changed lines for the size label: %v", err)
+		return ""
+	}
+	return entities.SelectSizeLabel(sizeLabels, lines)
+}
+
+// applyPullRequestLabels attaches labels to a freshly created PR when the
+// provider supports it. Labelling is best-effort: a failure is logged but
+// does not fail the run because the PR itself already exists.
+func applyPullRequestLabels(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	labels []string,
+) {
+	if len(labels) == 0 {
+		return
+	}
+	labeler, ok := provider.(repositories.PullRequestLabeler)
+	if !ok {
+		logger.Warnf("[autoupdate] Provider %q cannot label pull requests, skipping labels %v for %s/%s",
+			provider.Name(), labels, repo.Organization, repo.Name)
+		return
+	}
+	if err := labeler.AddPullRequestLabels(ctx, repo, pr, labels); err != nil {
+		logger.Warnf("[autoupdate] Failed to label PR #%d for %s/%s: %v",
+			pr.ID, repo.Organization, repo.Name, err)
+	}
+}
+
+// resolvePullRequestAssignees picks who to assign to a freshly created PR.
+// With assign_last_committer enabled it asks the provider for the last
+// author of each changed path; when that yields nobody, it falls back to
+// the configured assignees.
+func resolvePullRequestAssignees(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	settings *entities.Settings,
+	changedPaths []string,
+) []string {
+	if settings.AssignLastCommitter {
+		if authors := resolveLastAuthors(ctx, provider, repo, changedPaths); len(authors) > 0 {
+			return authors
+		}
+	}
+	return settings.Assignees
+}
+
+// resolveLastAuthors returns the distinct last authors of the given paths,
+// in the order they are first seen. Lookups are best-effort: a failure is
+// logged and the path is skipped.
+func resolveLastAuthors(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	paths []string,
+) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	resolver, ok := provider.(repositories.LastAuthorResolver)
+	if !ok {
+		logger.Warnf("[autoupdate] Provider %q cannot resolve file authors, using the configured assignees for %s/%s",
+			provider.Name(), repo.Organization, repo.Name)
+		return nil
+	}
+	seen := make(map[string]bool)
+	var authors []string
+	for _, path := range paths {
+		author, err := resolver.LastAuthor(ctx, repo, path)
+		if err != nil {
+			logger.Warnf("[autoupdate] Failed to resolve the last author of %s in %s/%s: %v",
+				path, repo.Organization, repo.Name, err)
+			continue
+		}
+		if author == "" || seen[author] {
+			continue
+		}
+		seen[author] = true
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// applyPullRequestAssignees assigns users to a freshly created PR when the
+// provider supports it. Like labelling, assignment is best-effort.
+func applyPullRequestAssignees(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	assignees []string,
+) {
+	if len(assignees) == 0 {
+		return
+	}
+	assigner, ok := provider.(repositories.PullRequestAssigner)
+	if !ok {
+		logger.Warnf("[autoupdate] Provider %q cannot assign pull requests, skipping assignees %v for %s/%s",
+			provider.Name(), assignees, repo.Organization, repo.Name)
+		return
+	}
+	if err := assigner.AddPullRequestAssignees(ctx, repo, pr, assignees); err != nil {
+		logger.Warnf("[autoupdate] Failed to assign PR #%d for %s/%s: %v",
+			pr.ID, repo.Organization, repo.Name, err)
+	}
+}
+
+// aggregateReviewers picks the reviewers configured for an updater.
+func aggregateReviewers(opts entities.UpdateOptions) []string { return opts.Reviewers }
+
+// aggregateAssignees picks the assignees configured for an updater.
+func aggregateAssignees(opts entities.UpdateOptions) []string { return opts.Assignees }
+
+// collectAggregateUsers returns the de-duplicated union of the users picked
+// from the options of every updater that produced changes, in first-seen
+// order.
+func collectAggregateUsers(
+	applied []appliedUpdaterResult,
+	updaters []applicableUpdater,
+	pick func(entities.UpdateOptions) []string,
+) []string {
+	configured := make(map[string][]string, len(updaters))
+	for _, au := range updaters {
+		configured[au.updater.Name()] = pick(au.opts)
+	}
+
+	var users []string
+	for _, a := range applied {
+		users = appendUnique(users, configured[a.name]...)
+	}
+	return users
+}
+
+// appendUnique appends the values missing from dst, skipping blanks.
+func appendUnique(dst []string, values ...string) []string {
+	for _, value := range values {
+		if value != "" && !slices.Contains(dst, value) {
+			dst = append(dst, value)
+		}
+	}
+	return dst
+}
+
+// applyPullRequestReviewers requests reviews on a freshly created PR when
+// the provider supports it. Like labelling, it is best-effort.
+func applyPullRequestReviewers(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	reviewers []string,
+) {
+	if len(reviewers) == 0 {
+		return
+	}
+	requester, ok := provider.(repositories.PullRequestReviewerRequester)
+	if !ok {
+		logger.Warnf("[autoupdate] Provider %q cannot request reviews, skipping reviewers %v for %s/%s",
+			provider.Name(), reviewers, repo.Organization, repo.Name)
+		return
+	}
+	if err := requester.RequestPullRequestReviewers(ctx, repo, pr, reviewers); err != nil {
+		logger.Warnf("[autoupdate] Failed to request reviews on PR #%d for %s/%s: %v",
+			pr.ID, repo.Organization, repo.Name, err)
+	}
+}
+
 // resolveAggregateTargetBranch picks the target branch for the aggregate
 // PR. All enabled updaters should agree (they read the same settings); if
 // any one overrides TargetBranch we honor the first non-empty override
@@ -671,6 +1487,46 @@ func logAggregateDryRun(updaters []applicableUpdater, repo entities.Repository)
 	)
 }
 
+// planUpdates asks the dry-run updaters that implement UpdatePlanner for the
+// upgrades they would make, tagging each change with the repository and,
+// when the updater left it empty, the updater name. The other updaters can
+// only tell what they would change from a clone, so they contribute nothing.
+func planUpdates(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	updaters []applicableUpdater,
+) ([]entities.DependencyChange, int) {
+	var planned []entities.DependencyChange
+	errorCount := 0
+	for _, au := range updaters {
+		planner, ok := au.updater.(repositories.UpdatePlanner)
+		if !ok {
+			continue
+		}
+
+		name := au.updater.Name()
+		changes, err := planner.PlanUpdates(ctx, provider, repo, au.opts)
+		if err != nil {
+			logger.Errorf("[%s] Failed to plan updates for %s/%s: %v",
+				name, repo.Organization, repo.Name, err)
+			errorCount++
+			continue
+		}
+
+		for _, change := range changes {
+			logger.Infof("[%s] [DRY RUN] Would upgrade %s in %s/%s: %s -> %s",
+				name, change.Name, repo.Organization, repo.Name, change.From, change.To)
+			change.Repository = repo.Organization + "/" + repo.Name
+			if change.Ecosystem == "" {
+				change.Ecosystem = name
+			}
+			planned = append(planned, change)
+		}
+	}
+	return planned, errorCount
+}
+
 // firstLine returns the first newline-delimited segment of s, or s itself
 // when there is no newline. Used to extract subject lines from multi-line
 // commit messages and PR titles.
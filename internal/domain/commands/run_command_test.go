@@ -3,12 +3,25 @@
 package commands_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/google/go-github/v66/github"
+	logger "github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -16,6 +29,8 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/terraform"
+	"github.com/rios0rios0/autoupdate/internal/support"
 	entitybuilders "github.com/rios0rios0/autoupdate/test/domain/entitybuilders"
 	doubles "github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
 )
@@ -1137,9 +1152,12 @@ func TestRunCommandExecute(t *testing.T) {
 
 		// then
 		require.NoError(t, err)
-		assert.Len(t, updaterSpy.DetectedRepos, 2)
-		assert.Equal(t, "repo-alpha", updaterSpy.DetectedRepos[0].Name)
-		assert.Equal(t, "repo-beta", updaterSpy.DetectedRepos[1].Name)
+		require.Len(t, updaterSpy.DetectedRepos, 2)
+		// Repositories are processed concurrently, so only the set is stable.
+		assert.ElementsMatch(t,
+			[]string{"repo-alpha", "repo-beta"},
+			[]string{updaterSpy.DetectedRepos[0].Name, updaterSpy.DetectedRepos[1].Name},
+		)
 		assert.Len(t, updaterSpy.CreatePRsCalls, 2)
 	})
 
@@ -1511,6 +1529,63 @@ func TestRunCommandExecute(t *testing.T) {
 	})
 }
 
+func TestFilterRepositoriesByPattern(t *testing.T) {
+	t.Parallel()
+
+	repos := []entities.Repository{
+		{Organization: "org", Name: "terraform-network"},
+		{Organization: "org", Name: "terraform-legacy"},
+		{Organization: "org", Name: "api"},
+	}
+	names := func(repos []entities.Repository) []string {
+		result := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			result = append(result, repo.Name)
+		}
+		return result
+	}
+
+	t.Run("should return all repos when no pattern is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := commands.FilterRepositoriesByPattern(repos, nil, nil)
+
+		// then
+		assert.Equal(t, []string{"terraform-network", "terraform-legacy", "api"}, names(result))
+	})
+
+	t.Run("should keep only the repos matching an include glob", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := commands.FilterRepositoriesByPattern(repos, []string{"terraform-*"}, nil)
+
+		// then
+		assert.Equal(t, []string{"terraform-network", "terraform-legacy"}, names(result))
+	})
+
+	t.Run("should drop the repos matching an exclude glob", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := commands.FilterRepositoriesByPattern(repos, nil, []string{"terraform-*"})
+
+		// then
+		assert.Equal(t, []string{"api"}, names(result))
+	})
+
+	t.Run("should let excludes take precedence over includes", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := commands.FilterRepositoriesByPattern(repos, []string{"terraform-*"}, []string{"*-legacy"})
+
+		// then
+		assert.Equal(t, []string{"terraform-network"}, names(result))
+	})
+}
+
 func TestFilterRepositories(t *testing.T) {
 	t.Parallel()
 
@@ -1749,9 +1824,92 @@ func TestRunCommandRunsRepoConfigSkip(t *testing.T) {
 		assert.Empty(t, updaterSpy.DetectedRepos,
 			"Detect must not be called for a repo that opted out via .autoupdate.yaml")
 	})
+
+	t.Run("should not invoke updaters on a repo that set enabled: false", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		cmd, settings := newRepoConfigRunCommand("enabled: false\n", updaterSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, updaterSpy.DetectedRepos)
+		assert.Empty(t, updaterSpy.CreatePRsCalls)
+	})
+
+	t.Run("should only skip the updaters the repo ignores", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		terraformSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		golangSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("golang").
+			WithDetectResult(true).
+			BuildSpy()
+		cmd, settings := newRepoConfigRunCommand("ignore: [terraform]\n", terraformSpy, golangSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, terraformSpy.DetectedRepos)
+		assert.Empty(t, terraformSpy.CreatePRsCalls)
+		assert.Len(t, golangSpy.CreatePRsCalls, 1)
+	})
+}
+
+// newRepoConfigRunCommand builds a RunCommand for a single repository whose
+// .autoupdate.yaml holds repoConfig.
+func newRepoConfigRunCommand(
+	repoConfig string,
+	updaters ...*doubles.SpyUpdaterRepository,
+) (*commands.RunCommand, *entities.Settings) {
+	repo := entitybuilders.NewRepositoryBuilder().
+		WithName("repo").
+		WithOrganization("org").
+		WithDefaultBranch("refs/heads/main").
+		BuildRepository()
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories([]entities.Repository{repo}).
+		WithExistingFiles(map[string]bool{entities.RepoConfigFile: true}).
+		WithFileContents(map[string]string{entities.RepoConfigFile: repoConfig}).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	updaterRegistry := infraRepos.NewUpdaterRegistry()
+	for _, updater := range updaters {
+		updaterRegistry.Register(updater)
+	}
+
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"org"}).
+				BuildProviderConfig(),
+		}).
+		BuildSettings()
+	return commands.NewRunCommand(providerRegistry, updaterRegistry), settings
 }
 
-func TestIsSkippedByRepoConfig(t *testing.T) {
+func TestReadRepoConfig(t *testing.T) {
 	t.Parallel()
 
 	repo := entities.Repository{Organization: "org", Name: "repo"}
@@ -1765,7 +1923,7 @@ func TestIsSkippedByRepoConfig(t *testing.T) {
 			BuildSpy()
 
 		// when
-		skipped := commands.IsSkippedByRepoConfig(context.Background(), spy, repo)
+		_, skipped := commands.ReadRepoConfig(context.Background(), spy, repo)
 
 		// then
 		assert.False(t, skipped)
@@ -1783,7 +1941,7 @@ func TestIsSkippedByRepoConfig(t *testing.T) {
 			BuildSpy()
 
 		// when
-		skipped := commands.IsSkippedByRepoConfig(context.Background(), spy, repo)
+		_, skipped := commands.ReadRepoConfig(context.Background(), spy, repo)
 
 		// then
 		assert.True(t, skipped)
@@ -1801,12 +1959,30 @@ func TestIsSkippedByRepoConfig(t *testing.T) {
 			BuildSpy()
 
 		// when
-		skipped := commands.IsSkippedByRepoConfig(context.Background(), spy, repo)
+		_, skipped := commands.ReadRepoConfig(context.Background(), spy, repo)
 
 		// then
 		assert.False(t, skipped)
 	})
 
+	t.Run("should return true when remote .autoupdate.yaml sets enabled: false", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{entities.RepoConfigFile: true}).
+			WithFileContents(map[string]string{
+				entities.RepoConfigFile: "enabled: false\n",
+			}).
+			BuildSpy()
+
+		// when
+		_, skipped := commands.ReadRepoConfig(context.Background(), spy, repo)
+
+		// then
+		assert.True(t, skipped)
+	})
+
 	t.Run("should fail open and continue when GetFileContent errors", func(t *testing.T) {
 		t.Parallel()
 
@@ -1817,10 +1993,12 @@ func TestIsSkippedByRepoConfig(t *testing.T) {
 			BuildSpy()
 
 		// when
-		skipped := commands.IsSkippedByRepoConfig(context.Background(), spy, repo)
+		cfg, skipped := commands.ReadRepoConfig(context.Background(), spy, repo)
 
 		// then
 		assert.False(t, skipped)
+		require.NotNil(t, cfg)
+		assert.False(t, cfg.IsUpdaterIgnored("terraform"))
 	})
 }
 
@@ -1904,6 +2082,46 @@ func TestBuildAggregateCommitMessage(t *testing.T) {
 		assert.Contains(t, msg, "- [dockerfile] chore(deps): upgraded `golang` from `1.26.1-alpine` to `1.26.2-alpine`")
 		assert.NotContains(t, msg, "body", "only the first line of each source message should be included")
 	})
+
+	t.Run("should keep the commit prefix shared by every updater", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("csharp", &repositories.LocalUpdateResult{
+				CommitMessage: "build(deps): updated NuGet dependencies",
+			}),
+			commands.NewAppliedUpdaterResult("java", &repositories.LocalUpdateResult{
+				CommitMessage: "build(deps): updated Java dependencies",
+			}),
+		}
+
+		// when
+		msg := commands.BuildAggregateCommitMessage(applied)
+
+		// then
+		assert.True(t, strings.HasPrefix(msg, "build(deps): bumped dependencies via autoupdate\n\n"))
+	})
+
+	t.Run("should fall back to the default prefix when the updaters disagree", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("csharp", &repositories.LocalUpdateResult{
+				CommitMessage: "build(deps): updated NuGet dependencies",
+			}),
+			commands.NewAppliedUpdaterResult("ruby", &repositories.LocalUpdateResult{
+				CommitMessage: "chore(gems): updated Ruby gem dependencies",
+			}),
+		}
+
+		// when
+		msg := commands.BuildAggregateCommitMessage(applied)
+
+		// then
+		assert.True(t, strings.HasPrefix(msg, "chore(deps): bumped dependencies via autoupdate\n\n"))
+	})
 }
 
 func TestBuildAggregatePRTitle(t *testing.T) {
@@ -1943,6 +2161,54 @@ func TestBuildAggregatePRTitle(t *testing.T) {
 	})
 }
 
+func TestRenderAggregatePRText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the templates every applied updater shares", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{Updaters: map[string]entities.UpdaterConfig{
+			"golang":     {PRTitleTemplate: "build(deps): {{.Count}} upgrades in {{.Ecosystem}}"},
+			"dockerfile": {PRTitleTemplate: "build(deps): {{.Count}} upgrades in {{.Ecosystem}}"},
+		}}
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("golang", &repositories.LocalUpdateResult{
+				Changes: []entities.DependencyChange{{Name: "a"}, {Name: "b"}},
+			}),
+			commands.NewAppliedUpdaterResult("dockerfile", &repositories.LocalUpdateResult{
+				Changes: []entities.DependencyChange{{Name: "golang"}},
+			}),
+		}
+
+		// when
+		title, description := commands.RenderAggregatePRText(settings, applied, "generated", "body")
+
+		// then
+		assert.Equal(t, "build(deps): 3 upgrades in golang, dockerfile", title)
+		assert.Equal(t, "body", description)
+	})
+
+	t.Run("should keep the generated text when the templates differ", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{Updaters: map[string]entities.UpdaterConfig{
+			"golang": {PRTitleTemplate: "build(deps): {{.Ecosystem}}"},
+		}}
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("golang", &repositories.LocalUpdateResult{}),
+			commands.NewAppliedUpdaterResult("dockerfile", &repositories.LocalUpdateResult{}),
+		}
+
+		// when
+		title, _ := commands.RenderAggregatePRText(settings, applied, "generated", "body")
+
+		// then
+		assert.Equal(t, "generated", title)
+	})
+}
+
 func TestBuildAggregatePRDescription(t *testing.T) {
 	t.Parallel()
 
@@ -2130,72 +2396,1849 @@ func TestAllDryRun(t *testing.T) {
 	})
 }
 
-func TestResolveAggregateTargetBranch(t *testing.T) {
+func TestCollectAggregateLabels(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should fall back to the repo default branch when no override is set", func(t *testing.T) {
+	t.Run("should merge the labels of the updaters that produced changes without duplicates", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		repo := entities.Repository{DefaultBranch: "refs/heads/main"}
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("javascript", &repositories.LocalUpdateResult{}),
+			commands.NewAppliedUpdaterResult("python", &repositories.LocalUpdateResult{}),
+		}
 		updaters := []commands.ApplicableUpdater{
 			commands.NewApplicableUpdaterForTest(
-				&doubles.DummyUpdaterRepository{}, entities.UpdateOptions{}),
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("javascript").BuildSpy(),
+				entities.UpdateOptions{Labels: []string{"dependencies", "javascript"}},
+			),
+			commands.NewApplicableUpdaterForTest(
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("python").BuildSpy(),
+				entities.UpdateOptions{Labels: []string{"dependencies", "python"}},
+			),
 		}
 
 		// when
-		target := commands.ResolveAggregateTargetBranch(repo, updaters)
+		labels := commands.CollectAggregateLabels(applied, updaters)
 
 		// then
-		assert.Equal(t, "refs/heads/main", target)
+		assert.Equal(t, []string{"dependencies", "javascript", "python"}, labels)
 	})
 
-	t.Run("should honor the first non-empty TargetBranch override", func(t *testing.T) {
+	t.Run("should skip the labels of updaters that produced no changes", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		repo := entities.Repository{DefaultBranch: "refs/heads/main"}
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("javascript", &repositories.LocalUpdateResult{}),
+		}
 		updaters := []commands.ApplicableUpdater{
 			commands.NewApplicableUpdaterForTest(
-				&doubles.DummyUpdaterRepository{},
-				entities.UpdateOptions{TargetBranch: "develop"},
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("javascript").BuildSpy(),
+				entities.UpdateOptions{Labels: []string{"dependencies"}},
+			),
+			commands.NewApplicableUpdaterForTest(
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("golang").BuildSpy(),
+				entities.UpdateOptions{Labels: []string{"go"}},
 			),
 		}
 
 		// when
-		target := commands.ResolveAggregateTargetBranch(repo, updaters)
+		labels := commands.CollectAggregateLabels(applied, updaters)
 
 		// then
-		assert.Equal(t, "refs/heads/develop", target)
+		assert.Equal(t, []string{"dependencies"}, labels)
 	})
 }
 
-func TestFirstLine(t *testing.T) {
+func TestWriteAggregateChangelog(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should return the first newline-delimited segment", func(t *testing.T) {
+	t.Run("should group the Go and JavaScript entries into one changelog block", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		s := "subject line\nbody line 1\nbody line 2"
+		repoDir := t.TempDir()
+		changelog := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n\n### Added\n\n- initial release\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte(changelog), 0o600))
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("golang", &repositories.LocalUpdateResult{
+				ChangelogEntries: []string{"- changed the Go module dependencies to their latest versions"},
+			}),
+			commands.NewAppliedUpdaterResult("javascript", &repositories.LocalUpdateResult{
+				ChangelogEntries: []string{"- changed the JavaScript dependencies to their latest versions"},
+			}),
+		}
 
 		// when
-		result := commands.FirstLine(s)
+		commands.WriteAggregateChangelog(repoDir, applied, entities.ChangelogSettings{})
 
 		// then
-		assert.Equal(t, "subject line", result)
+		data, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		unreleased, _, _ := strings.Cut(string(data), "## [1.0.0]")
+		assert.Equal(t, 1, strings.Count(unreleased, "### Changed"))
+		assert.Contains(t, unreleased,
+			"- changed the Go module dependencies to their latest versions\n"+
+				"- changed the JavaScript dependencies to their latest versions\n")
 	})
 
-	t.Run("should return the whole string when there is no newline", func(t *testing.T) {
+	t.Run("should insert an entry shared by several updaters only once", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		s := "single line"
+		repoDir := t.TempDir()
+		changelog := "# Changelog\n\n## [Unreleased]\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte(changelog), 0o600))
+		entry := "- changed the Docker base image `golang` from `1.23` to `1.24`"
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("dockerfile", &repositories.LocalUpdateResult{
+				ChangelogEntries: []string{entry},
+			}),
+			commands.NewAppliedUpdaterResult("pipeline", &repositories.LocalUpdateResult{
+				ChangelogEntries: []string{entry},
+			}),
+		}
 
 		// when
-		result := commands.FirstLine(s)
+		commands.WriteAggregateChangelog(repoDir, applied, entities.ChangelogSettings{})
 
 		// then
-		assert.Equal(t, "single line", result)
+		data, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(data), entry))
+	})
+
+	t.Run("should leave the changelog untouched when no updater reported entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		changelog := "# Changelog\n\n## [Unreleased]\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte(changelog), 0o600))
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("golang", &repositories.LocalUpdateResult{}),
+		}
+
+		// when
+		commands.WriteAggregateChangelog(repoDir, applied, entities.ChangelogSettings{})
+
+		// then
+		data, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+		require.NoError(t, err)
+		assert.Equal(t, changelog, string(data))
+	})
+}
+
+func TestResolvePullRequestAssignees(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should assign the last author of the changed paths", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.LastAuthors = map[string]string{"go.mod": "alice", "go.sum": "alice", "Dockerfile": "bob"}
+		settings := &entities.Settings{AssignLastCommitter: true, Assignees: []string{"platform-bot"}}
+		pr := entities.PullRequest{ID: 7}
+
+		// when
+		assignees := commands.ResolvePullRequestAssignees(
+			t.Context(), provider, entities.Repository{}, settings, []string{"go.mod", "go.sum", "Dockerfile"},
+		)
+		commands.ApplyPullRequestAssignees(t.Context(), provider, entities.Repository{}, pr, assignees)
+
+		// then
+		assert.Equal(t, []string{"alice", "bob"}, provider.PRAssignees[7])
+	})
+
+	t.Run("should fall back to the configured assignees when no author is found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.LastAuthorErr = errors.New("blame unavailable")
+		settings := &entities.Settings{AssignLastCommitter: true, Assignees: []string{"platform-bot"}}
+
+		// when
+		assignees := commands.ResolvePullRequestAssignees(
+			t.Context(), provider, entities.Repository{}, settings, []string{"go.mod"},
+		)
+
+		// then
+		assert.Equal(t, []string{"platform-bot"}, assignees)
+	})
+
+	t.Run("should use the configured assignees when the heuristic is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.LastAuthors = map[string]string{"go.mod": "alice"}
+		settings := &entities.Settings{Assignees: []string{"platform-bot"}}
+
+		// when
+		assignees := commands.ResolvePullRequestAssignees(
+			t.Context(), provider, entities.Repository{}, settings, []string{"go.mod"},
+		)
+
+		// then
+		assert.Equal(t, []string{"platform-bot"}, assignees)
+	})
+}
+
+func TestFindContentDuplicate(t *testing.T) {
+	t.Parallel()
+
+	hash := support.ContentHash([]entities.FileChange{{Path: "go.mod", Content: "go 1.26\n", ChangeType: "edit"}})
+
+	t.Run("should return the open PR whose branch carries the same content hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			{
+				PullRequest:  entities.PullRequest{ID: 9},
+				SourceBranch: support.ContentHashBranch("chore/autoupdate-2026-10-01", hash),
+			},
+		}
+
+		// when
+		duplicate := commands.FindContentDuplicate(t.Context(), provider, entities.Repository{}, hash)
+
+		// then
+		require.NotNil(t, duplicate)
+		assert.Equal(t, 9, duplicate.ID)
+	})
+
+	t.Run("should not report a duplicate when the changes differ", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			{
+				PullRequest:  entities.PullRequest{ID: 9},
+				SourceBranch: support.ContentHashBranch("chore/autoupdate-2026-10-01", "stale"),
+			},
+		}
+
+		// when
+		duplicate := commands.FindContentDuplicate(t.Context(), provider, entities.Repository{}, hash)
+
+		// then
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("should not report a duplicate when the provider cannot list pull requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &doubles.DummyProviderRepository{}
+
+		// when
+		duplicate := commands.FindContentDuplicate(t.Context(), provider, entities.Repository{}, hash)
+
+		// then
+		assert.Nil(t, duplicate)
+	})
+}
+
+func TestApplyPullRequestAssignees(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not call the provider when there are no assignees", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+
+		// when
+		commands.ApplyPullRequestAssignees(
+			t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7}, nil,
+		)
+
+		// then
+		assert.Empty(t, provider.PRAssignees)
+	})
+
+	t.Run("should not panic when the provider cannot assign PRs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &doubles.DummyProviderRepository{}
+
+		// when / then
+		assert.NotPanics(t, func() {
+			commands.ApplyPullRequestAssignees(
+				t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7}, []string{"alice"},
+			)
+		})
+	})
+}
+
+func TestApplyPullRequestReviewers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should request reviews from the given reviewers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+
+		// when
+		commands.ApplyPullRequestReviewers(
+			t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7},
+			[]string{"org/platform", "alice"},
+		)
+
+		// then
+		assert.Equal(t, []string{"org/platform", "alice"}, provider.PRReviewers[7])
+	})
+
+	t.Run("should not call the provider when there are no reviewers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+
+		// when
+		commands.ApplyPullRequestReviewers(
+			t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7}, nil,
+		)
+
+		// then
+		assert.Empty(t, provider.PRReviewers)
+	})
+
+	t.Run("should not panic when the review request fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := doubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.RequestReviewersErr = errors.New("unknown user")
+
+		// when / then
+		assert.NotPanics(t, func() {
+			commands.ApplyPullRequestReviewers(
+				t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7}, []string{"alice"},
+			)
+		})
+	})
+
+	t.Run("should not panic when the provider cannot request reviews", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &doubles.DummyProviderRepository{}
+
+		// when / then
+		assert.NotPanics(t, func() {
+			commands.ApplyPullRequestReviewers(
+				t.Context(), provider, entities.Repository{}, entities.PullRequest{ID: 7}, []string{"alice"},
+			)
+		})
+	})
+}
+
+func TestCollectAggregateReviewers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should merge the reviewers of the updaters that produced changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("javascript", &repositories.LocalUpdateResult{}),
+			commands.NewAppliedUpdaterResult("python", &repositories.LocalUpdateResult{}),
+		}
+		updaters := []commands.ApplicableUpdater{
+			commands.NewApplicableUpdaterForTest(
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("javascript").BuildSpy(),
+				entities.UpdateOptions{Reviewers: []string{"org/frontend", "alice"}},
+			),
+			commands.NewApplicableUpdaterForTest(
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("python").BuildSpy(),
+				entities.UpdateOptions{Reviewers: []string{"alice", "bob"}},
+			),
+			commands.NewApplicableUpdaterForTest(
+				doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("golang").BuildSpy(),
+				entities.UpdateOptions{Reviewers: []string{"carol"}},
+			),
+		}
+
+		// when
+		reviewers := commands.CollectAggregateReviewers(applied, updaters)
+
+		// then
+		assert.Equal(t, []string{"org/frontend", "alice", "bob"}, reviewers)
+	})
+}
+
+func TestResolveAggregateTargetBranch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should fall back to the repo default branch when no override is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repo := entities.Repository{DefaultBranch: "refs/heads/main"}
+		updaters := []commands.ApplicableUpdater{
+			commands.NewApplicableUpdaterForTest(
+				&doubles.DummyUpdaterRepository{}, entities.UpdateOptions{}),
+		}
+
+		// when
+		target := commands.ResolveAggregateTargetBranch(repo, updaters)
+
+		// then
+		assert.Equal(t, "refs/heads/main", target)
+	})
+
+	t.Run("should honor the first non-empty TargetBranch override", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repo := entities.Repository{DefaultBranch: "refs/heads/main"}
+		updaters := []commands.ApplicableUpdater{
+			commands.NewApplicableUpdaterForTest(
+				&doubles.DummyUpdaterRepository{},
+				entities.UpdateOptions{TargetBranch: "develop"},
+			),
+		}
+
+		// when
+		target := commands.ResolveAggregateTargetBranch(repo, updaters)
+
+		// then
+		assert.Equal(t, "refs/heads/develop", target)
+	})
+}
+
+func TestFirstLine(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the first newline-delimited segment", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		s := "subject line\nbody line 1\nbody line 2"
+
+		// when
+		result := commands.FirstLine(s)
+
+		// then
+		assert.Equal(t, "subject line", result)
+	})
+
+	t.Run("should return the whole string when there is no newline", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		s := "single line"
+
+		// when
+		result := commands.FirstLine(s)
+
+		// then
+		assert.Equal(t, "single line", result)
+	})
+}
+
+// TestRunCommandAnnotations is intentionally not parallel: annotations are
+// emitted through a hook on the global logrus logger.
+func TestRunCommandAnnotations(t *testing.T) {
+	t.Run("should emit a notice annotation for each created PR", func(t *testing.T) {
+		// given
+		repo := entitybuilders.NewRepositoryBuilder().
+			WithID("repo-1").
+			WithName("test-repo").
+			WithOrganization("test-org").
+			WithDefaultBranch("refs/heads/main").
+			BuildRepository()
+		spy := doubles.NewSpyProviderRepositoryBuilder().
+			WithProviderName("github").
+			WithToken("test-token").
+			WithRepositories([]entities.Repository{repo}).
+			BuildSpy()
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+			BuildSpy()
+
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+		updaterRegistry := infraRepos.NewUpdaterRegistry()
+		updaterRegistry.Register(updaterSpy)
+
+		cmd := commands.NewRunCommand(providerRegistry, updaterRegistry)
+		var out bytes.Buffer
+		commands.SetAnnotationOutput(cmd, &out)
+
+		settings := entitybuilders.NewSettingsBuilder().
+			WithProviders([]entities.ProviderConfig{
+				entitybuilders.NewProviderConfigBuilder().
+					WithType("github").
+					WithToken("test-token").
+					WithOrganizations([]string{"test-org"}).
+					BuildProviderConfig(),
+			}).
+			BuildSettings()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Annotations: true})
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(),
+			"::notice title=autoupdate::[terraform] Created PR #42 for test-org/test-repo: "+
+				"Update dep (https://example.com/pr/42)",
+		)
+	})
+
+	t.Run("should emit a warning annotation when an error is logged", func(t *testing.T) {
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().
+			WithProviderName("github").
+			WithToken("test-token").
+			WithDiscoverErr(errors.New("network error")).
+			BuildSpy()
+
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+
+		cmd := commands.NewRunCommand(providerRegistry, infraRepos.NewUpdaterRegistry())
+		var out bytes.Buffer
+		commands.SetAnnotationOutput(cmd, &out)
+
+		settings := entitybuilders.NewSettingsBuilder().
+			WithProviders([]entities.ProviderConfig{
+				entitybuilders.NewProviderConfigBuilder().
+					WithType("github").
+					WithToken("test-token").
+					WithOrganizations([]string{"test-org"}).
+					BuildProviderConfig(),
+			}).
+			BuildSettings()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Annotations: true})
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(),
+			`::warning title=autoupdate::Failed to discover repos in "test-org": network error`,
+		)
+	})
+
+	t.Run("should not emit annotations when the mode is disabled", func(t *testing.T) {
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().
+			WithProviderName("github").
+			WithToken("test-token").
+			WithDiscoverErr(errors.New("network error")).
+			BuildSpy()
+
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+
+		cmd := commands.NewRunCommand(providerRegistry, infraRepos.NewUpdaterRegistry())
+		var out bytes.Buffer
+		commands.SetAnnotationOutput(cmd, &out)
+
+		settings := entitybuilders.NewSettingsBuilder().
+			WithProviders([]entities.ProviderConfig{
+				entitybuilders.NewProviderConfigBuilder().
+					WithType("github").
+					WithToken("test-token").
+					WithOrganizations([]string{"test-org"}).
+					BuildProviderConfig(),
+			}).
+			BuildSettings()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+}
+
+// captureLogs attaches a test hook to the standard logger and restores the
+// previous hooks when the test finishes.
+func captureLogs(t *testing.T) *logtest.Hook {
+	t.Helper()
+	std := logger.StandardLogger()
+	previous := make(logger.LevelHooks, len(std.Hooks))
+	for level, hooks := range std.Hooks {
+		previous[level] = append([]logger.Hook(nil), hooks...)
+	}
+	t.Cleanup(func() { std.ReplaceHooks(previous) })
+	return logtest.NewLocal(std)
+}
+
+func newEmptyOrgRunCommand(discoverErr error) *commands.RunCommand {
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories([]entities.Repository{}).
+		WithDiscoverErr(discoverErr).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	return commands.NewRunCommand(providerRegistry, infraRepos.NewUpdaterRegistry())
+}
+
+func TestRunCommandEmptyOrganization(t *testing.T) {
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"empty-org"}).
+				BuildProviderConfig(),
+		}).
+		BuildSettings()
+
+	t.Run("should log an empty organization warning when discovery returns zero repositories", func(t *testing.T) {
+		// given
+		hook := captureLogs(t)
+		cmd := newEmptyOrgRunCommand(nil)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		var warnings []string
+		for _, entry := range hook.AllEntries() {
+			if entry.Level == logger.WarnLevel {
+				warnings = append(warnings, entry.Message)
+			}
+		}
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], `Organization "empty-org" is empty`)
+		assert.Contains(t, warnings[0], "github token can read it")
+	})
+
+	t.Run("should return an error in strict mode when discovery returns zero repositories", func(t *testing.T) {
+		// given
+		captureLogs(t)
+		cmd := newEmptyOrgRunCommand(nil)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Strict: true})
+
+		// then
+		require.ErrorIs(t, err, commands.ErrNoRepositoriesDiscovered)
+		assert.Contains(t, err.Error(), "empty-org")
+	})
+
+	t.Run("should report a forbidden discovery as missing access instead of an empty org", func(t *testing.T) {
+		// given
+		hook := captureLogs(t)
+		cmd := newEmptyOrgRunCommand(fmt.Errorf("failed to list org repos: %w",
+			&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}))
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Strict: true})
+
+		// then
+		require.NoError(t, err)
+		errs := logMessages(hook, logger.ErrorLevel)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], `The github token has no access to "empty-org" (HTTP 403)`)
+		assert.Empty(t, logMessages(hook, logger.WarnLevel))
+	})
+
+	t.Run("should report a not found discovery as a missing organization", func(t *testing.T) {
+		// given
+		hook := captureLogs(t)
+		cmd := newEmptyOrgRunCommand(fmt.Errorf("failed to list org repos: %w",
+			&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}))
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		errs := logMessages(hook, logger.ErrorLevel)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], `Organization "empty-org" does not exist or is not visible to the github token`)
+		assert.Empty(t, logMessages(hook, logger.WarnLevel))
+	})
+
+	t.Run("should report any other discovery failure as a generic error", func(t *testing.T) {
+		// given
+		hook := captureLogs(t)
+		cmd := newEmptyOrgRunCommand(errors.New("dial tcp: connection refused"))
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		errs := logMessages(hook, logger.ErrorLevel)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0], `Failed to discover repos in "empty-org"`)
+	})
+}
+
+// logMessages returns the messages the hook captured at the given level.
+func logMessages(hook *logtest.Hook, level logger.Level) []string {
+	var messages []string
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == level {
+			messages = append(messages, entry.Message)
+		}
+	}
+	return messages
+}
+
+func newSummaryRunCommand(updaters ...*doubles.SpyUpdaterRepository) (*commands.RunCommand, *entities.Settings) {
+	repo := entitybuilders.NewRepositoryBuilder().
+		WithID("repo-1").
+		WithName("test-repo").
+		WithOrganization("test-org").
+		WithDefaultBranch("refs/heads/main").
+		BuildRepository()
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories([]entities.Repository{repo}).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	updaterRegistry := infraRepos.NewUpdaterRegistry()
+	for _, updater := range updaters {
+		updaterRegistry.Register(updater)
+	}
+
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"test-org"}).
+				BuildProviderConfig(),
+		}).
+		BuildSettings()
+	return commands.NewRunCommand(providerRegistry, updaterRegistry), settings
+}
+
+func TestRunCommandJobSummary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the created PR links to the job summary", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		summaryPath := filepath.Join(t.TempDir(), "summary.md")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{JobSummaryPath: summaryPath})
+
+		// then
+		require.NoError(t, err)
+		summary, readErr := os.ReadFile(summaryPath)
+		require.NoError(t, readErr)
+		assert.Contains(t, string(summary), "## autoupdate run summary")
+		assert.Contains(t, string(summary), "| 1 | 1 | 0 |")
+		assert.Contains(t, string(summary), "- `test-org/test-repo`: [#42 Update dep](https://example.com/pr/42)")
+		assert.NotContains(t, string(summary), "### Repositories with errors")
+	})
+
+	t.Run("should list errored repositories in the job summary", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		summaryPath := filepath.Join(t.TempDir(), "summary.md")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithCreatePRsErr(errors.New("boom")).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{JobSummaryPath: summaryPath})
+
+		// then
+		require.NoError(t, err)
+		summary, readErr := os.ReadFile(summaryPath)
+		require.NoError(t, readErr)
+		assert.Contains(t, string(summary), "### Repositories with errors\n\n- `test-org/test-repo`")
+	})
+
+	t.Run("should list repositories without changes as skipped", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		summaryPath := filepath.Join(t.TempDir(), "summary.md")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{JobSummaryPath: summaryPath})
+
+		// then
+		require.NoError(t, err)
+		summary, readErr := os.ReadFile(summaryPath)
+		require.NoError(t, readErr)
+		assert.Contains(t, string(summary), "### Skipped repositories (no changes needed)\n\n- `test-org/test-repo`")
+	})
+
+	t.Run("should not write a job summary when no path is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		summaryPath := filepath.Join(t.TempDir(), "summary.md")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.NoFileExists(t, summaryPath)
+	})
+}
+
+func TestRunCommandCanary(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should abort the full run when the canary fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithCreatePRsErr(errors.New("boom")).
+			BuildSpy()
+		cmd, settings := newCanaryRunCommand(updaterSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{
+			Canary:        "test-org/canary",
+			CanaryProceed: true,
+		})
+
+		// then
+		require.ErrorIs(t, err, commands.ErrCanaryFailed)
+		require.Len(t, updaterSpy.DetectedRepos, 1)
+		assert.Equal(t, "canary", updaterSpy.DetectedRepos[0].Name)
+	})
+
+	t.Run("should abort the run when the canary repository is not found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		cmd, settings := newCanaryRunCommand(updaterSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{
+			Canary:        "test-org/missing",
+			CanaryProceed: true,
+		})
+
+		// then
+		require.ErrorIs(t, err, commands.ErrCanaryFailed)
+		assert.Contains(t, err.Error(), "test-org/missing")
+		assert.Empty(t, updaterSpy.DetectedRepos)
+	})
+
+	t.Run("should proceed to the full run without reprocessing a successful canary", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithPRs([]entities.PullRequest{{ID: 1, Title: "Update", URL: "https://example.com/pr/1"}}).
+			BuildSpy()
+		cmd, settings := newCanaryRunCommand(updaterSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{
+			Canary:        "Test-Org/Canary",
+			CanaryProceed: true,
+		})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, updaterSpy.DetectedRepos, 2)
+		assert.Equal(t, "canary", updaterSpy.DetectedRepos[0].Name)
+		assert.Equal(t, "other", updaterSpy.DetectedRepos[1].Name)
+	})
+
+	t.Run("should stop after a successful canary when not asked to proceed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updaterSpy := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		cmd, settings := newCanaryRunCommand(updaterSpy)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Canary: "test-org/canary"})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, updaterSpy.DetectedRepos, 1)
+		assert.Equal(t, "canary", updaterSpy.DetectedRepos[0].Name)
+	})
+}
+
+// newCanaryRunCommand builds a RunCommand over a single organization holding
+// a "canary" and an "other" repository, both handled by the given updater.
+func newCanaryRunCommand(
+	updater *doubles.SpyUpdaterRepository,
+) (*commands.RunCommand, *entities.Settings) {
+	repos := []entities.Repository{
+		entitybuilders.NewRepositoryBuilder().
+			WithID("1").
+			WithName("canary").
+			WithOrganization("test-org").
+			WithDefaultBranch("refs/heads/main").
+			BuildRepository(),
+		entitybuilders.NewRepositoryBuilder().
+			WithID("2").
+			WithName("other").
+			WithOrganization("test-org").
+			WithDefaultBranch("refs/heads/main").
+			BuildRepository(),
+	}
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories(repos).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	updaterRegistry := infraRepos.NewUpdaterRegistry()
+	updaterRegistry.Register(updater)
+
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"test-org"}).
+				BuildProviderConfig(),
+		}).
+		BuildSettings()
+
+	return commands.NewRunCommand(providerRegistry, updaterRegistry), settings
+}
+
+func TestRunCommandStatsD(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should push the run metrics to the configured StatsD server", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		conn, listenErr := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, listenErr)
+		defer conn.Close()
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+			BuildSpy())
+		settings.StatsD = conn.LocalAddr().String()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		buf := make([]byte, 1024)
+		n, _, readErr := conn.ReadFrom(buf)
+		require.NoError(t, readErr)
+		payload := string(buf[:n])
+		assert.Contains(t, payload, "autoupdate.repos_scanned:1|c")
+		assert.Contains(t, payload, "autoupdate.prs_created:1|c")
+		assert.Contains(t, payload, "autoupdate.errors:0|c")
+		assert.Regexp(t, `autoupdate\.run_duration:\d+\|ms`, payload)
+	})
+
+	t.Run("should not fail the run when the StatsD address cannot be used", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+		settings.StatsD = "invalid-address"
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestRunCommandSlackNotification(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should post every created PR in a single message", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var mu sync.Mutex
+		var payloads []map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			mu.Lock()
+			payloads = append(payloads, payload)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		cmd, settings := newSummaryRunCommand(
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("terraform").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+				BuildSpy(),
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("golang").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 43, Title: "Bump <go>", URL: "https://example.com/pr/43"}}).
+				BuildSpy(),
+		)
+		settings.Notifications.SlackWebhookURL = server.URL
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+		text := payloads[0]["text"]
+		assert.Contains(t, text, "autoupdate opened 2 pull requests:")
+		assert.Contains(t, text, "test-org/test-repo: <https://example.com/pr/42|Update dep>")
+		assert.Contains(t, text, "test-org/test-repo: <https://example.com/pr/43|Bump &lt;go&gt;>")
+	})
+
+	t.Run("should not post when the run created no PRs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		posted := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			posted = true
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+		settings.Notifications.SlackWebhookURL = server.URL
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, posted)
+	})
+
+	t.Run("should not fail the run when the webhook rejects the message", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+			BuildSpy())
+		settings.Notifications.SlackWebhookURL = server.URL
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+	})
+}
+
+func TestRunCommandRollout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should skip an updater for a repository outside its rollout", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		rolledOut := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("golang").
+			WithDetectResult(true).
+			BuildSpy()
+		enabled := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		cmd, settings := newSummaryRunCommand(rolledOut, enabled)
+		settings.Updaters = map[string]entities.UpdaterConfig{"golang": {Rollout: "0%"}}
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, rolledOut.DetectedRepos)
+		assert.Empty(t, rolledOut.CreatePRsCalls)
+		assert.Len(t, enabled.CreatePRsCalls, 1)
+	})
+}
+
+func TestRunCommandWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should post the run summary with the configured headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var authorization string
+		var payload map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		cmd, settings := newSummaryRunCommand(
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("terraform").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+				BuildSpy(),
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("golang").
+				WithDetectResult(true).
+				WithCreatePRsErr(errors.New("go get failed")).
+				BuildSpy(),
+		)
+		settings.Notifications.Webhook = entities.Webhook{
+			URL:     server.URL,
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		}
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", authorization)
+		assert.InDelta(t, 1, payload["repos_processed"], 0)
+		assert.InDelta(t, 1, payload["prs_created"], 0)
+		assert.InDelta(t, 1, payload["errors"], 0)
+		assert.Equal(t, []any{"test-org/test-repo"}, payload["errored_repositories"])
+		assert.Equal(t, []any{}, payload["skipped_repositories"])
+		assert.Equal(t, []any{map[string]any{
+			"repository": "test-org/test-repo",
+			"id":         float64(42),
+			"title":      "Update dep",
+			"url":        "https://example.com/pr/42",
+		}}, payload["pull_requests"])
+	})
+
+	t.Run("should not fail the run when the webhook answers with a server error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+		settings.Notifications.Webhook = entities.Webhook{URL: server.URL}
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 1, requests)
+	})
+}
+
+func TestRunCommandPRURLsOutput(t *testing.T) {
+	t.Parallel()
+
+	newCommand := func() (*commands.RunCommand, *entities.Settings) {
+		return newSummaryRunCommand(
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("terraform").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+				BuildSpy(),
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("golang").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 43, Title: "Bump Go", URL: "https://example.com/pr/43"}}).
+				BuildSpy(),
+		)
+	}
+
+	t.Run("should write one URL per line for every created PR", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		outPath := filepath.Join(t.TempDir(), "pr-urls.txt")
+		cmd, settings := newCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{PRURLsPath: outPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(outPath)
+		require.NoError(t, readErr)
+		assert.ElementsMatch(t,
+			[]string{"https://example.com/pr/42", "https://example.com/pr/43"},
+			strings.Fields(string(data)))
+	})
+
+	t.Run("should write a JSON array when the path ends in .json", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		outPath := filepath.Join(t.TempDir(), "pr-urls.json")
+		cmd, settings := newCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{PRURLsPath: outPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(outPath)
+		require.NoError(t, readErr)
+		var urls []string
+		require.NoError(t, json.Unmarshal(data, &urls))
+		assert.ElementsMatch(t, []string{"https://example.com/pr/42", "https://example.com/pr/43"}, urls)
+	})
+
+	t.Run("should write an empty JSON array when no PR was created", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		outPath := filepath.Join(t.TempDir(), "pr-urls.json")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{PRURLsPath: outPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(outPath)
+		require.NoError(t, readErr)
+		assert.Equal(t, "[]\n", string(data))
+	})
+}
+
+func TestRunCommandMaxRepos(t *testing.T) {
+	t.Parallel()
+
+	// newCursorRunCommand builds a RunCommand over the repositories alpha,
+	// bravo and charlie of test-org, each detected by the returned spy.
+	newCursorRunCommand := func() (*commands.RunCommand, *entities.Settings, *doubles.SpyUpdaterRepository) {
+		var repos []entities.Repository
+		for _, name := range []string{"charlie", "alpha", "bravo"} {
+			repos = append(repos, entitybuilders.NewRepositoryBuilder().
+				WithID(name).
+				WithName(name).
+				WithOrganization("test-org").
+				WithDefaultBranch("refs/heads/main").
+				BuildRepository())
+		}
+		spy := doubles.NewSpyProviderRepositoryBuilder().
+			WithProviderName("github").
+			WithToken("test-token").
+			WithRepositories(repos).
+			BuildSpy()
+		providerRegistry := infraRepos.NewProviderRegistry()
+		providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+			return spy
+		})
+		updater := doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy()
+		updaterRegistry := infraRepos.NewUpdaterRegistry()
+		updaterRegistry.Register(updater)
+
+		settings := entitybuilders.NewSettingsBuilder().
+			WithProviders([]entities.ProviderConfig{
+				entitybuilders.NewProviderConfigBuilder().
+					WithType("github").
+					WithToken("test-token").
+					WithOrganizations([]string{"test-org"}).
+					BuildProviderConfig(),
+			}).
+			BuildSettings()
+		return commands.NewRunCommand(providerRegistry, updaterRegistry), settings, updater
+	}
+
+	t.Run("should process a different repository on each run and wrap around", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		runOpts := commands.RunOptions{
+			MaxRepos:   1,
+			CursorPath: filepath.Join(t.TempDir(), "cursor.json"),
+		}
+		var processed []string
+
+		// when
+		for range 4 {
+			cmd, settings, updater := newCursorRunCommand()
+			require.NoError(t, cmd.Execute(context.Background(), settings, runOpts))
+			require.Len(t, updater.DetectedRepos, 1)
+			processed = append(processed, updater.DetectedRepos[0].Name)
+		}
+
+		// then
+		assert.Equal(t, []string{"alpha", "bravo", "charlie", "alpha"}, processed)
+		data, readErr := os.ReadFile(runOpts.CursorPath)
+		require.NoError(t, readErr)
+		assert.JSONEq(t, `{"github:test-org": "test-org/alpha"}`, string(data))
+	})
+
+	t.Run("should process every repository when the organization is within the limit", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cursorPath := filepath.Join(t.TempDir(), "cursor.json")
+		cmd, settings, updater := newCursorRunCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{MaxRepos: 3, CursorPath: cursorPath})
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, updater.DetectedRepos, 3)
+		assert.NoFileExists(t, cursorPath)
+	})
+}
+
+func TestRunCommandMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should count the created PR and the error of the run", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		metricsPath := filepath.Join(t.TempDir(), "autoupdate.prom")
+		cmd, settings := newSummaryRunCommand(
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("terraform").
+				WithDetectResult(true).
+				WithPRs([]entities.PullRequest{{ID: 42, Title: "Update dep", URL: "https://example.com/pr/42"}}).
+				BuildSpy(),
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("golang").
+				WithDetectResult(true).
+				WithCreatePRsErr(errors.New("go get failed")).
+				BuildSpy(),
+			doubles.NewSpyUpdaterRepositoryBuilder().
+				WithUpdaterName("python").
+				WithDetectResult(false).
+				BuildSpy(),
+		)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{MetricsPath: metricsPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(metricsPath)
+		require.NoError(t, readErr)
+		metrics := string(data)
+		assert.Contains(t, metrics, "# TYPE autoupdate_repos_scanned gauge\nautoupdate_repos_scanned 1\n")
+		assert.Contains(t, metrics, "# TYPE autoupdate_prs_created gauge\nautoupdate_prs_created 1\n")
+		assert.Contains(t, metrics, "# TYPE autoupdate_errors_total counter\nautoupdate_errors_total 1\n")
+		assert.Contains(t, metrics, `autoupdate_ecosystem_repos_detected{ecosystem="golang"} 1`+"\n")
+		assert.Contains(t, metrics, `autoupdate_ecosystem_repos_detected{ecosystem="terraform"} 1`+"\n")
+		assert.NotContains(t, metrics, `ecosystem="python"`)
+		assert.NoFileExists(t, metricsPath+".tmp")
+	})
+
+	t.Run("should count the planned changes per ecosystem on a dry run", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		metricsPath := filepath.Join(t.TempDir(), "autoupdate.prom")
+		cmd, settings, _ := newTerraformDryRunCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{DryRun: true, MetricsPath: metricsPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(metricsPath)
+		require.NoError(t, readErr)
+		assert.Contains(t, string(data), `autoupdate_ecosystem_dependency_changes{ecosystem="terraform"} 1`+"\n")
+		assert.Contains(t, string(data), "autoupdate_prs_created 0\n")
+	})
+}
+
+func TestRunCommandWorkers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should process every repository with at most the configured number of workers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updater := &concurrencyTrackingUpdater{}
+		cmd, settings := newWorkersRunCommand(updater, 6)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Workers: 2})
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t,
+			[]string{"repo-0", "repo-1", "repo-2", "repo-3", "repo-4", "repo-5"}, updater.detected)
+		assert.Equal(t, 2, updater.maxInFlight)
+	})
+
+	t.Run("should process repositories one at a time with a single worker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updater := &concurrencyTrackingUpdater{}
+		cmd, settings := newWorkersRunCommand(updater, 3)
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{Workers: 1})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"repo-0", "repo-1", "repo-2"}, updater.detected)
+		assert.Equal(t, 1, updater.maxInFlight)
+	})
+}
+
+// concurrencyTrackingUpdater records the repositories it detects and the
+// highest number of Detect calls running at the same time.
+type concurrencyTrackingUpdater struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	detected    []string
+}
+
+func (u *concurrencyTrackingUpdater) Name() string { return "terraform" }
+
+func (u *concurrencyTrackingUpdater) Detect(
+	_ context.Context, _ repositories.ProviderRepository, repo entities.Repository,
+) bool {
+	u.mu.Lock()
+	u.inFlight++
+	u.maxInFlight = max(u.maxInFlight, u.inFlight)
+	u.detected = append(u.detected, repo.Name)
+	u.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	u.mu.Lock()
+	u.inFlight--
+	u.mu.Unlock()
+	return false
+}
+
+func (u *concurrencyTrackingUpdater) CreateUpdatePRs(
+	_ context.Context, _ repositories.ProviderRepository, _ entities.Repository, _ entities.UpdateOptions,
+) ([]entities.PullRequest, error) {
+	return nil, nil
+}
+
+// newWorkersRunCommand builds a RunCommand over a single organization
+// holding count repositories named repo-0, repo-1, and so on.
+func newWorkersRunCommand(
+	updater repositories.UpdaterRepository,
+	count int,
+) (*commands.RunCommand, *entities.Settings) {
+	repos := make([]entities.Repository, 0, count)
+	for i := range count {
+		repos = append(repos, entitybuilders.NewRepositoryBuilder().
+			WithID(strconv.Itoa(i)).
+			WithName("repo-"+strconv.Itoa(i)).
+			WithOrganization("test-org").
+			WithDefaultBranch("refs/heads/main").
+			BuildRepository())
+	}
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories(repos).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	updaterRegistry := infraRepos.NewUpdaterRegistry()
+	updaterRegistry.Register(updater)
+
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"test-org"}).
+				BuildProviderConfig(),
+		}).
+		BuildSettings()
+
+	return commands.NewRunCommand(providerRegistry, updaterRegistry), settings
+}
+
+func TestCollectDependencyChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should tag the changes of every applied updater with the repository", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("terraform", &repositories.LocalUpdateResult{
+				Changes: []entities.DependencyChange{{
+					Ecosystem: "terraform", Name: "network", From: "v1.0.0", To: "v1.2.0", File: "main.tf",
+				}},
+			}),
+			commands.NewAppliedUpdaterResult("golang", &repositories.LocalUpdateResult{
+				Changes: []entities.DependencyChange{{
+					Name: "golang.org/x/mod", From: "v0.20.0", To: "v0.22.0", File: "go.mod",
+				}},
+			}),
+		}
+
+		// when
+		changes := commands.CollectDependencyChanges("test-org/test-repo", applied)
+
+		// then
+		assert.Equal(t, []entities.DependencyChange{
+			{
+				Repository: "test-org/test-repo", Ecosystem: "terraform",
+				Name: "network", From: "v1.0.0", To: "v1.2.0", File: "main.tf",
+			},
+			{
+				Repository: "test-org/test-repo", Ecosystem: "golang",
+				Name: "golang.org/x/mod", From: "v0.20.0", To: "v0.22.0", File: "go.mod",
+			},
+		}, changes)
+	})
+
+	t.Run("should return nothing when no updater reported changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		applied := []commands.AppliedUpdaterResult{
+			commands.NewAppliedUpdaterResult("python", &repositories.LocalUpdateResult{PRTitle: "Python bump"}),
+		}
+
+		// when
+		changes := commands.CollectDependencyChanges("test-org/test-repo", applied)
+
+		// then
+		assert.Empty(t, changes)
+	})
+}
+
+func TestWriteChangesReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write an entry for every dependency change", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reportPath := filepath.Join(t.TempDir(), "changes.json")
+		changes := []entities.DependencyChange{
+			{
+				Repository: "test-org/api", Ecosystem: "dockerfile",
+				Name: "golang", From: "1.24-alpine", To: "1.25-alpine", File: "Dockerfile",
+			},
+			{
+				Repository: "test-org/infra", Ecosystem: "terraform",
+				Name: "network", From: "v1.0.0", To: "v1.2.0", File: "main.tf",
+			},
+		}
+
+		// when
+		err := commands.WriteChangesReport(reportPath, changes)
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(reportPath)
+		require.NoError(t, readErr)
+		var report struct {
+			Changes []map[string]string `json:"changes"`
+		}
+		require.NoError(t, json.Unmarshal(data, &report))
+		require.Len(t, report.Changes, 2)
+		assert.Equal(t, map[string]string{
+			"repository": "test-org/api", "ecosystem": "dockerfile",
+			"name": "golang", "from": "1.24-alpine", "to": "1.25-alpine", "file": "Dockerfile",
+		}, report.Changes[0])
+		assert.Equal(t, "network", report.Changes[1]["name"])
+		assert.Equal(t, "v1.2.0", report.Changes[1]["to"])
+	})
+
+	t.Run("should write an empty list when the run changed nothing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reportPath := filepath.Join(t.TempDir(), "changes.json")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{ChangesReportPath: reportPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(reportPath)
+		require.NoError(t, readErr)
+		assert.JSONEq(t, `{"changes": []}`, string(data))
+	})
+}
+
+func TestWriteDryRunReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write an empty array when nothing is planned", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reportPath := filepath.Join(t.TempDir(), "plan.json")
+
+		// when
+		err := commands.WriteDryRunReport(reportPath, nil)
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(reportPath)
+		require.NoError(t, readErr)
+		assert.JSONEq(t, `[]`, string(data))
+	})
+}
+
+func TestRunCommandDryRunReport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the planned Terraform upgrades of a dry run", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reportPath := filepath.Join(t.TempDir(), "plan.json")
+		cmd, settings, spy := newTerraformDryRunCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{DryRun: true, ReportPath: reportPath})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(reportPath)
+		require.NoError(t, readErr)
+		var report []map[string]string
+		require.NoError(t, json.Unmarshal(data, &report))
+		assert.Equal(t, []map[string]string{{
+			"repository":      "test-org/infra",
+			"ecosystem":       "terraform",
+			"dependency":      "network",
+			"current_version": "v1.0.0",
+			"target_version":  "v1.2.0",
+			"file":            "main.tf",
+		}}, report)
+		assert.Empty(t, spy.BranchInputs)
+		assert.Empty(t, spy.PRInputs)
+	})
+
+	t.Run("should leave the planned upgrades out of the changes report", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changesPath := filepath.Join(t.TempDir(), "changes.json")
+		cmd, settings, _ := newTerraformDryRunCommand()
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{
+			DryRun:            true,
+			ChangesReportPath: changesPath,
+		})
+
+		// then
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(changesPath)
+		require.NoError(t, readErr)
+		assert.JSONEq(t, `{"changes": []}`, string(data))
+	})
+
+	t.Run("should not write the report when the run is not a dry run", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		reportPath := filepath.Join(t.TempDir(), "plan.json")
+		cmd, settings := newSummaryRunCommand(doubles.NewSpyUpdaterRepositoryBuilder().
+			WithUpdaterName("terraform").
+			WithDetectResult(true).
+			BuildSpy())
+
+		// when
+		err := cmd.Execute(context.Background(), settings, commands.RunOptions{ReportPath: reportPath})
+
+		// then
+		require.NoError(t, err)
+		assert.NoFileExists(t, reportPath)
+	})
+}
+
+// newTerraformDryRunCommand wires the real Terraform updater to a spy
+// provider serving one repository whose main.tf pins org/network at v1.0.0,
+// while org/network has released v1.2.0.
+func newTerraformDryRunCommand() (*commands.RunCommand, *entities.Settings, *doubles.SpyProviderRepository) {
+	repo := entitybuilders.NewRepositoryBuilder().
+		WithID("repo-1").
+		WithName("infra").
+		WithOrganization("test-org").
+		WithDefaultBranch("refs/heads/main").
+		BuildRepository()
+	spy := doubles.NewSpyProviderRepositoryBuilder().
+		WithProviderName("github").
+		WithToken("test-token").
+		WithRepositories([]entities.Repository{repo, {Organization: "org", Name: "network"}}).
+		WithFiles([]entities.File{{Path: "main.tf"}}).
+		WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+		WithFileContents(map[string]string{
+			"main.tf": `module "network" {
+  source = "git::https://github.com/org/network?ref=v1.0.0"
+}
+`,
+			"CHANGELOG.md": "# Changelog\n\n## [Unreleased]\n\n## [1.2.0] - 2026-03-01\n",
+		}).
+		WithTags([]string{"v1.2.0", "v1.0.0"}).
+		BuildSpy()
+
+	providerRegistry := infraRepos.NewProviderRegistry()
+	providerRegistry.Register("github", func(_ string) repositories.ProviderRepository {
+		return spy
+	})
+	updaterRegistry := infraRepos.NewUpdaterRegistry()
+	updaterRegistry.Register(terraform.NewUpdaterRepository())
+
+	settings := entitybuilders.NewSettingsBuilder().
+		WithProviders([]entities.ProviderConfig{
+			entitybuilders.NewProviderConfigBuilder().
+				WithType("github").
+				WithToken("test-token").
+				WithOrganizations([]string{"test-org"}).
+				BuildProviderConfig(),
+		}).
+		// the module repository is only there for tag resolution
+		WithExcludeRepos([]string{"org/network"}).
+		BuildSettings()
+	return commands.NewRunCommand(providerRegistry, updaterRegistry), settings, spy
+}
+
+func TestApplyPullRequestLabels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the labels through a provider that supports them", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().WithProviderName("github").BuildSpy()
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		commands.ApplyPullRequestLabels(t.Context(), spy, repo, entities.PullRequest{ID: 7}, []string{"size/S"})
+
+		// then
+		assert.Equal(t, map[int][]string{7: {"size/S"}}, spy.PRLabels)
+	})
+
+	t.Run("should not call the provider when there are no labels", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().WithProviderName("github").BuildSpy()
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		commands.ApplyPullRequestLabels(t.Context(), spy, repo, entities.PullRequest{ID: 7}, nil)
+
+		// then
+		assert.Empty(t, spy.PRLabels)
+	})
+
+	t.Run("should keep going when the provider fails to add the labels", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		spy := doubles.NewSpyProviderRepositoryBuilder().WithProviderName("github").BuildSpy()
+		spy.AddLabelsErr = errors.New("forbidden")
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when / then
+		assert.NotPanics(t, func() {
+			commands.ApplyPullRequestLabels(t.Context(), spy, repo, entities.PullRequest{ID: 7}, []string{"size/S"})
+		})
+		assert.Empty(t, spy.PRLabels)
 	})
 }
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+// statsDPrefix namespaces every metric autoupdate pushes to StatsD.
+const statsDPrefix = "autoupdate"
+
+// metricsFileMode lets the node_exporter textfile collector, which usually
+// runs as another user, read the metrics file.
+const metricsFileMode = 0o644
+
+// sendRunMetrics pushes the outcome of a run to the StatsD server at address.
+func sendRunMetrics(address string, totals runTotals, duration time.Duration) error {
+	return support.SendStatsD(address, statsDPrefix, []support.StatsDMetric{
+		support.StatsDCounter("repos_scanned", totals.repos),
+		support.StatsDCounter("prs_created", totals.prs),
+		support.StatsDCounter("errors", totals.errors),
+		support.StatsDTimer("run_duration", duration),
+	})
+}
+
+// writeMetricsFile writes the outcome of a run in the Prometheus text format,
+// for the node_exporter textfile collector. The file is written next to path
+// and renamed into place, so the collector never reads a partial dump.
+func writeMetricsFile(path string, totals runTotals) error {
+	tmpPath := path + ".tmp"
+	//nolint:gosec // the textfile collector must be able to read the file
+	if err := os.WriteFile(tmpPath, []byte(renderPrometheusMetrics(totals)), metricsFileMode); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to move metrics file into place: %w", err)
+	}
+	return nil
+}
+
+// renderPrometheusMetrics renders the run totals as Prometheus text format
+// samples: the run-wide counts, then per-ecosystem counts labelled with the
+// updater name, in name order so consecutive dumps diff cleanly.
+func renderPrometheusMetrics(totals runTotals) string {
+	changesByEcosystem := make(map[string]int)
+	for _, change := range totals.changes {
+		changesByEcosystem[change.Ecosystem]++
+	}
+
+	var sb strings.Builder
+	writePrometheusSample(&sb, "autoupdate_repos_scanned", "gauge",
+		"Repositories processed by the last run.", totals.repos)
+	writePrometheusSample(&sb, "autoupdate_prs_created", "gauge",
+		"Pull requests created by the last run.", totals.prs)
+	writePrometheusSample(&sb, "autoupdate_errors_total", "counter",
+		"Errors hit by the last run.", totals.errors)
+	writePrometheusEcosystemSamples(&sb, "autoupdate_ecosystem_repos_detected",
+		"Repositories of the last run each updater applied to.", totals.ecosystemRepos)
+	writePrometheusEcosystemSamples(&sb, "autoupdate_ecosystem_dependency_changes",
+		"Dependency changes of the last run per updater, planned ones on a dry run.", changesByEcosystem)
+	return sb.String()
+}
+
+func writePrometheusSample(sb *strings.Builder, name, metricType, help string, value int) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", name, help, name, metricType, name, value)
+}
+
+func writePrometheusEcosystemSamples(sb *strings.Builder, name, help string, counts map[string]int) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	for _, ecosystem := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Fprintf(sb, "%s{ecosystem=%q} %d\n", name, ecosystem, counts[ecosystem])
+	}
+}
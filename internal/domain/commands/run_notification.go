@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+// slackMessage is the payload of a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// slackEscaper escapes the characters Slack's mrkdwn reserves for links and
+// mentions.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// notifyCreatedPRs posts a single Slack message listing every pull request
+// created by the run.
+func notifyCreatedPRs(ctx context.Context, webhookURL string, created []repoPullRequest) error {
+	return support.PostWebhook(ctx, webhookURL, nil, slackMessage{Text: renderSlackMessage(created)})
+}
+
+// renderSlackMessage renders one line per created pull request, linking its
+// title to the pull request URL.
+func renderSlackMessage(created []repoPullRequest) string {
+	var sb strings.Builder
+	noun := "pull request"
+	if len(created) != 1 {
+		noun += "s"
+	}
+	fmt.Fprintf(&sb, "autoupdate opened %d %s:", len(created), noun)
+	for _, item := range created {
+		fmt.Fprintf(&sb, "\n• %s: <%s|%s>",
+			slackEscaper.Replace(item.repo), item.pr.URL, slackEscaper.Replace(item.pr.Title))
+	}
+	return sb.String()
+}
+
+// runSummaryPayload is the JSON body of the generic webhook: the outcome of
+// the whole run.
+type runSummaryPayload struct {
+	ReposProcessed int                  `json:"repos_processed"`
+	PRsCreated     int                  `json:"prs_created"`
+	Errors         int                  `json:"errors"`
+	PullRequests   []pullRequestPayload `json:"pull_requests"`
+	ErroredRepos   []string             `json:"errored_repositories"`
+	SkippedRepos   []string             `json:"skipped_repositories"`
+}
+
+// pullRequestPayload describes one pull request created by the run.
+type pullRequestPayload struct {
+	Repository string `json:"repository"`
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+}
+
+// notifyWebhook posts the summary of the run to the generic webhook.
+func notifyWebhook(ctx context.Context, webhook entities.Webhook, totals runTotals) error {
+	return support.PostWebhook(ctx, webhook.URL, webhook.Headers, buildRunSummaryPayload(totals))
+}
+
+// buildRunSummaryPayload converts the run totals into the webhook body. The
+// lists are never null, so receivers can iterate them unconditionally.
+func buildRunSummaryPayload(totals runTotals) runSummaryPayload {
+	payload := runSummaryPayload{
+		ReposProcessed: totals.repos,
+		PRsCreated:     totals.prs,
+		Errors:         totals.errors,
+		PullRequests:   make([]pullRequestPayload, 0, len(totals.createdPRs)),
+		ErroredRepos:   append([]string{}, totals.erroredRepos...),
+		SkippedRepos:   append([]string{}, totals.skippedRepos...),
+	}
+	for _, item := range totals.createdPRs {
+		payload.PullRequests = append(payload.PullRequests, pullRequestPayload{
+			Repository: item.repo,
+			ID:         item.pr.ID,
+			Title:      item.pr.Title,
+			URL:        item.pr.URL,
+		})
+	}
+	return payload
+}
@@ -0,0 +1,7 @@
+package commands
+
+import "io"
+
+type Updaters interface {
+	Execute(out io.Writer) error
+}
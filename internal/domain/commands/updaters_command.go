@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+)
+
+const tabwriterPadding = 2
+
+// UpdatersCommand lists the updaters registered in this build, along with the
+// files each one looks for and a short description.
+type UpdatersCommand struct {
+	updaterRegistry *infraRepos.UpdaterRegistry
+}
+
+func NewUpdatersCommand(updaterRegistry *infraRepos.UpdaterRegistry) *UpdatersCommand {
+	return &UpdatersCommand{updaterRegistry: updaterRegistry}
+}
+
+// Execute writes one row per registered updater, sorted by name, to out.
+func (it *UpdatersCommand) Execute(out io.Writer) error {
+	updaters := it.updaterRegistry.All()
+	sort.Slice(updaters, func(i, j int) bool {
+		return updaters[i].Name() < updaters[j].Name()
+	})
+
+	w := tabwriter.NewWriter(out, 0, 0, tabwriterPadding, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tDESCRIPTION\tDETECTS")
+	for _, u := range updaters {
+		description, detects := "-", "-"
+		if d, ok := u.(repositories.UpdaterDescriber); ok {
+			if files := d.DetectionFiles(); len(files) > 0 {
+				detects = strings.Join(files, ", ")
+			}
+			if d.Description() != "" {
+				description = d.Description()
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", u.Name(), description, detects)
+	}
+	return w.Flush()
+}
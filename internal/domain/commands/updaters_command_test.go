@@ -0,0 +1,80 @@
+//go:build unit
+
+package commands_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/commands"
+	infraRepos "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
+	doubles "github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
+)
+
+func TestUpdatersCommandExecute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list every built-in updater with the files it detects", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		container := dig.New()
+		require.NoError(t, infraRepos.RegisterProviders(container))
+		var registry *infraRepos.UpdaterRegistry
+		require.NoError(t, container.Invoke(func(r *infraRepos.UpdaterRegistry) { registry = r }))
+		cmd := commands.NewUpdatersCommand(registry)
+		var out bytes.Buffer
+
+		// when
+		err := cmd.Execute(&out)
+
+		// then
+		require.NoError(t, err)
+		rows := map[string]string{}
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n")[1:] {
+			fields := strings.Fields(line)
+			rows[fields[0]] = line
+		}
+		expected := map[string][]string{
+			"terraform":  {"*.tf", "*.hcl", "*.tfvars"},
+			"golang":     {"go.mod"},
+			"python":     {"pyproject.toml", "requirements.txt"},
+			"javascript": {"package.json"},
+			"ruby":       {"Gemfile"},
+			"java":       {"build.gradle", "pom.xml"},
+			"csharp":     {"*.csproj"},
+			"pipeline":   {},
+			"dockerfile": {"Dockerfile"},
+			"nix":        {"flake.nix", "flake.lock"},
+		}
+		assert.Len(t, rows, len(expected))
+		for name, files := range expected {
+			require.Contains(t, rows, name)
+			for _, file := range files {
+				assert.Contains(t, rows[name], file, "updater %q", name)
+			}
+		}
+	})
+
+	t.Run("should fall back to a dash for updaters without a description", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		registry := infraRepos.NewUpdaterRegistry()
+		registry.Register(doubles.NewSpyUpdaterRepositoryBuilder().WithUpdaterName("custom").BuildSpy())
+		cmd := commands.NewUpdatersCommand(registry)
+		var out bytes.Buffer
+
+		// when
+		err := cmd.Execute(&out)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"custom", "-", "-"}, strings.Fields(strings.Split(out.String(), "\n")[1]))
+	})
+}
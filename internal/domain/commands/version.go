@@ -1,5 +1,7 @@
 package commands
 
+import "io"
+
 type Version interface {
-	Execute()
+	Execute(out io.Writer, asJSON bool) error
 }
@@ -1,12 +1,28 @@
 package commands
 
-import logger "github.com/sirupsen/logrus"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
 
-// AutoupdateVersion is set at build time via ldflags through the main package bridge.
-// During development (`go run`), it defaults to "dev".
+// AutoupdateVersion, AutoupdateCommit and AutoupdateBuildDate are set at
+// build time via ldflags through the main package bridge. During development
+// (`go run`), they keep these defaults.
 //
-//nolint:gochecknoglobals // Version set at build time via ldflags
-var AutoupdateVersion = "dev"
+//nolint:gochecknoglobals // Build metadata set at build time via ldflags
+var (
+	AutoupdateVersion   = "dev"
+	AutoupdateCommit    = "none"
+	AutoupdateBuildDate = "unknown"
+)
+
+// BuildInfo describes the running autoupdate build.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
 
 type VersionCommand struct{}
 
@@ -14,6 +30,20 @@ func NewVersionCommand() *VersionCommand {
 	return &VersionCommand{}
 }
 
-func (c *VersionCommand) Execute() {
-	logger.Infof("autoupdate version: %s", AutoupdateVersion)
+// Execute writes the build metadata to out, as a JSON object when asJSON is set.
+func (c *VersionCommand) Execute(out io.Writer, asJSON bool) error {
+	return writeBuildInfo(out, BuildInfo{
+		Version: AutoupdateVersion,
+		Commit:  AutoupdateCommit,
+		Date:    AutoupdateBuildDate,
+	}, asJSON)
+}
+
+func writeBuildInfo(out io.Writer, info BuildInfo, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(out).Encode(info)
+	}
+	_, err := fmt.Fprintf(out, "autoupdate version: %s\ncommit: %s\nbuilt: %s\n",
+		info.Version, info.Commit, info.Date)
+	return err
 }
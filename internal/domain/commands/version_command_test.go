@@ -0,0 +1,75 @@
+//go:build unit
+
+package commands_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/commands"
+)
+
+func TestVersionCommandExecute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should print the build metadata of the running build", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cmd := commands.NewVersionCommand()
+		var out bytes.Buffer
+
+		// when
+		err := cmd.Execute(&out, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "autoupdate version: "+commands.AutoupdateVersion)
+		assert.Contains(t, out.String(), "commit: "+commands.AutoupdateCommit)
+		assert.Contains(t, out.String(), "built: "+commands.AutoupdateBuildDate)
+	})
+}
+
+func TestWriteBuildInfo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should print the injected values as text", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		info := commands.BuildInfo{Version: "1.4.0", Commit: "abc1234", Date: "2026-10-16T08:00:00Z"}
+		var out bytes.Buffer
+
+		// when
+		err := commands.WriteBuildInfo(&out, info, false)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "autoupdate version: 1.4.0\ncommit: abc1234\nbuilt: 2026-10-16T08:00:00Z\n", out.String())
+	})
+
+	t.Run("should print the injected values as a JSON object", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		info := commands.BuildInfo{Version: "1.4.0", Commit: "abc1234", Date: "2026-10-16T08:00:00Z"}
+		var out bytes.Buffer
+
+		// when
+		err := commands.WriteBuildInfo(&out, info, true)
+
+		// then
+		require.NoError(t, err)
+		var decoded map[string]string
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, map[string]string{
+			"version": "1.4.0",
+			"commit":  "abc1234",
+			"date":    "2026-10-16T08:00:00Z",
+		}, decoded)
+	})
+}
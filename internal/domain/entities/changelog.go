@@ -1,10 +1,145 @@
 package entities
 
 import (
+	"regexp"
+	"strings"
+
 	changelogEntities "github.com/rios0rios0/gitforge/pkg/changelog/domain/entities"
 )
 
-// InsertChangelogEntry delegates to gitforge's changelog module.
+// canonicalUnreleasedHeading is the Keep-a-Changelog heading gitforge's
+// changelog module inserts entries under.
+const canonicalUnreleasedHeading = "## [Unreleased]"
+
+// DefaultChangelogPath is where the changelog lives when no other path is
+// configured, relative to the repository root.
+const DefaultChangelogPath = "CHANGELOG.md"
+
+// DefaultChangelogSection is the subsection of the unreleased section that
+// entries are inserted under when no other one is configured.
+const DefaultChangelogSection = "Changed"
+
+const (
+	// sectionHeadingPrefix starts a Keep-a-Changelog subsection heading.
+	sectionHeadingPrefix = "### "
+	// stashedSectionHeading temporarily replaces an existing "### Changed"
+	// heading while entries are inserted under another section.
+	stashedSectionHeading = "### \x00" + DefaultChangelogSection
+)
+
+// unreleasedHeadingPattern matches the unreleased section headings found in
+// the wild: "## [Unreleased]", "## Unreleased", "## [unreleased]" and the
+// linked form "## [Unreleased](https://...)", in any letter case.
+var unreleasedHeadingPattern = regexp.MustCompile(`(?i)^##\s+(?:\[unreleased\](?:\(\S*\))?|unreleased)\s*$`)
+
+// InsertChangelogEntry delegates to gitforge's changelog module. The
+// repository's own unreleased heading is detected first, so entries land
+// under "## Unreleased" (or another variant of "## [Unreleased]") and the
+// heading is written back exactly as it was. Entries whose bullet is already
+// in the unreleased section are skipped, so regenerating the changelog of an
+// existing PR branch does not repeat them.
 func InsertChangelogEntry(content string, entries []string) string {
-	return changelogEntities.InsertChangelogEntry(content, entries)
+	lines := strings.Split(content, "\n")
+	idx := findUnreleasedHeading(lines)
+	entries = newChangelogEntries(lines, idx, entries)
+	if len(entries) == 0 {
+		return content
+	}
+	if idx < 0 || strings.TrimSpace(lines[idx]) == canonicalUnreleasedHeading {
+		return changelogEntities.InsertChangelogEntry(content, entries)
+	}
+
+	original := lines[idx]
+	lines[idx] = canonicalUnreleasedHeading
+	result := strings.Split(changelogEntities.InsertChangelogEntry(strings.Join(lines, "\n"), entries), "\n")
+	for i, line := range result {
+		if line == canonicalUnreleasedHeading {
+			result[i] = original
+			break
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
+// InsertChangelogEntryInSection inserts the entries under the "### section"
+// subsection of the unreleased section, creating the subsection when it is
+// missing. An empty section means DefaultChangelogSection. gitforge only
+// knows "### Changed", so the headings are swapped around its insertion.
+func InsertChangelogEntryInSection(content string, entries []string, section string) string {
+	section = strings.TrimSpace(section)
+	if section == "" || section == DefaultChangelogSection {
+		return InsertChangelogEntry(content, entries)
+	}
+
+	defaultHeading := sectionHeadingPrefix + DefaultChangelogSection
+	heading := sectionHeadingPrefix + section
+	swapped := swapUnreleasedSectionHeadings(content, map[string]string{
+		defaultHeading: stashedSectionHeading,
+		heading:        defaultHeading,
+	})
+	inserted := InsertChangelogEntry(swapped, entries)
+	if inserted == swapped {
+		return content
+	}
+	return swapUnreleasedSectionHeadings(inserted, map[string]string{
+		defaultHeading:        heading,
+		stashedSectionHeading: defaultHeading,
+	})
+}
+
+// swapUnreleasedSectionHeadings rewrites the subsection headings of the
+// unreleased section found in replacements, leaving released sections alone.
+func swapUnreleasedSectionHeadings(content string, replacements map[string]string) string {
+	lines := strings.Split(content, "\n")
+	idx := findUnreleasedHeading(lines)
+	if idx < 0 {
+		return content
+	}
+	for i := idx + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "## ") {
+			break
+		}
+		if replacement, ok := replacements[trimmed]; ok {
+			lines[i] = replacement
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newChangelogEntries drops the entries whose trimmed line already appears in
+// the unreleased section starting at idx, and repeats within entries itself.
+func newChangelogEntries(lines []string, idx int, entries []string) []string {
+	existing := make(map[string]bool)
+	if idx >= 0 {
+		for _, line := range lines[idx+1:] {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "## ") {
+				break
+			}
+			existing[trimmed] = true
+		}
+	}
+
+	fresh := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" || existing[trimmed] {
+			continue
+		}
+		existing[trimmed] = true
+		fresh = append(fresh, entry)
+	}
+	return fresh
+}
+
+// findUnreleasedHeading returns the index of the first unreleased section
+// heading, or -1 when the changelog has none.
+func findUnreleasedHeading(lines []string) int {
+	for i, line := range lines {
+		if unreleasedHeadingPattern.MatchString(strings.TrimSpace(line)) {
+			return i
+		}
+	}
+	return -1
 }
@@ -14,5 +14,16 @@ type Dependency struct {
 	Line       int    // Line number in the file
 }
 
+// DependencyChange records one dependency version moved by an updater. It
+// is the entry type of the machine-readable changes report.
+type DependencyChange struct {
+	Repository string `json:"repository,omitempty"` // "org/name", filled in by the run command
+	Ecosystem  string `json:"ecosystem"`            // Updater name (e.g. "terraform")
+	Name       string `json:"name"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	File       string `json:"file,omitempty"` // File the version was changed in
+}
+
 // FileChange is re-exported from gitforge.
 type FileChange = gitforgeEntities.FileChange
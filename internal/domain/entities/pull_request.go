@@ -1,6 +1,11 @@
 package entities
 
 import (
+	"strings"
+	"text/template"
+
+	logger "github.com/sirupsen/logrus"
+
 	gitforgeEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
 )
 
@@ -12,3 +17,103 @@ type PullRequestInput = gitforgeEntities.PullRequestInput
 
 // PullRequest is re-exported from gitforge.
 type PullRequest = gitforgeEntities.PullRequest
+
+// PullRequestDetail is re-exported from gitforge.
+type PullRequestDetail = gitforgeEntities.PullRequestDetail
+
+// DefaultPRFooter is the attribution line that closes every generated PR
+// description, below a "---" separator.
+const DefaultPRFooter = "*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*"
+
+// ApplyPRFooter replaces the default attribution footer of a generated PR
+// description with the configured one. A nil footer keeps the default, an
+// empty footer removes it together with its separator, and any other text
+// takes its place.
+func ApplyPRFooter(description string, footer *string) string {
+	if footer == nil {
+		return description
+	}
+	replacement := ""
+	if *footer != "" {
+		replacement = "\n---\n" + *footer + "\n"
+	}
+	return strings.ReplaceAll(description, "\n---\n"+DefaultPRFooter+"\n", replacement)
+}
+
+// PRTemplateData is what the pr_title_template and pr_body_template of an
+// updater are rendered with. Module, OldVersion and NewVersion describe the
+// upgrade of a pull request that holds exactly one, and are empty otherwise.
+type PRTemplateData struct {
+	Count      int
+	Ecosystem  string
+	Module     string
+	OldVersion string
+	NewVersion string
+}
+
+// NewPRTemplateData summarizes the dependency changes of a pull request
+// opened by the given ecosystem's updater.
+func NewPRTemplateData(ecosystem string, changes []DependencyChange) PRTemplateData {
+	data := PRTemplateData{Count: len(changes), Ecosystem: ecosystem}
+	if len(changes) == 1 {
+		data.Module = changes[0].Name
+		data.OldVersion = changes[0].From
+		data.NewVersion = changes[0].To
+	}
+	return data
+}
+
+// RenderPRTemplate renders a `text/template` PR title or description with
+// data. It returns fallback, the generated text, when tmpl is empty or does
+// not render, logging a warning in the latter case.
+func RenderPRTemplate(tmpl string, data PRTemplateData, fallback string) string {
+	if tmpl == "" {
+		return fallback
+	}
+	rendered, err := executePRTemplate(tmpl, data)
+	if err != nil {
+		logger.Warnf("Failed to render PR template %q, keeping the generated text: %v", tmpl, err)
+		return fallback
+	}
+	return rendered
+}
+
+func executePRTemplate(tmpl string, data PRTemplateData) (string, error) {
+	parsed, err := template.New("pr").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if execErr := parsed.Execute(&sb, data); execErr != nil {
+		return "", execErr
+	}
+	return sb.String(), nil
+}
+
+// SizeLabel is one bucket of the size_labels setting: pull requests
+// changing at most MaxLines lines (added plus deleted) get the label Name.
+// A MaxLines of 0 has no upper bound and catches every larger change.
+type SizeLabel struct {
+	Name     string `yaml:"name"`
+	MaxLines int    `yaml:"max_lines"`
+}
+
+// SelectSizeLabel returns the name of the smallest bucket that fits
+// changedLines, whatever the order of the configured buckets, or "" when
+// none does (no buckets, or every bucket is bounded below changedLines).
+func SelectSizeLabel(labels []SizeLabel, changedLines int) string {
+	selected := ""
+	selectedMax := 0
+	for _, label := range labels {
+		switch {
+		case label.MaxLines == 0:
+			if selected == "" {
+				selected = label.Name
+			}
+		case changedLines <= label.MaxLines && (selectedMax == 0 || label.MaxLines < selectedMax):
+			selected = label.Name
+			selectedMax = label.MaxLines
+		}
+	}
+	return selected
+}
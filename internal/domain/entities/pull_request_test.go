@@ -0,0 +1,190 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+func TestApplyPRFooter(t *testing.T) {
+	t.Parallel()
+
+	description := "## Summary\n\nUpdated dependencies.\n\n---\n" + entities.DefaultPRFooter + "\n"
+
+	t.Run("should keep the default footer when no footer is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := entities.ApplyPRFooter(description, nil)
+
+		// then
+		assert.Equal(t, description, result)
+	})
+
+	t.Run("should omit the footer and its separator when the footer is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		footer := ""
+
+		// when
+		result := entities.ApplyPRFooter(description, &footer)
+
+		// then
+		assert.Equal(t, "## Summary\n\nUpdated dependencies.\n", result)
+		assert.NotContains(t, result, "autoupdate")
+	})
+
+	t.Run("should replace the footer with the configured text", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		footer := "*Opened by the platform team's dependency bot*"
+
+		// when
+		result := entities.ApplyPRFooter(description, &footer)
+
+		// then
+		assert.Equal(t, "## Summary\n\nUpdated dependencies.\n\n---\n"+footer+"\n", result)
+	})
+
+	t.Run("should replace every footer of an aggregated description", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		footer := ""
+		aggregated := "## golang\n\n" + description + "\n\n## python\n\n" + description
+
+		// when
+		result := entities.ApplyPRFooter(aggregated, &footer)
+
+		// then
+		assert.NotContains(t, result, entities.DefaultPRFooter)
+		assert.NotContains(t, result, "---")
+	})
+}
+
+func TestSelectSizeLabel(t *testing.T) {
+	t.Parallel()
+
+	sizeLabels := []entities.SizeLabel{
+		{Name: "size/XL"},
+		{Name: "size/L", MaxLines: 500},
+		{Name: "size/S", MaxLines: 10},
+		{Name: "size/M", MaxLines: 100},
+	}
+
+	tests := []struct {
+		name         string
+		labels       []entities.SizeLabel
+		changedLines int
+		expected     string
+	}{
+		{
+			name:         "should pick the smallest bucket for a small change",
+			labels:       sizeLabels,
+			changedLines: 4,
+			expected:     "size/S",
+		},
+		{
+			name:         "should include the bucket boundary",
+			labels:       sizeLabels,
+			changedLines: 100,
+			expected:     "size/M",
+		},
+		{
+			name:         "should pick the unbounded bucket for a large change",
+			labels:       sizeLabels,
+			changedLines: 2400,
+			expected:     "size/XL",
+		},
+		{
+			name:         "should return empty when every bucket is too small",
+			labels:       []entities.SizeLabel{{Name: "size/S", MaxLines: 10}},
+			changedLines: 50,
+			expected:     "",
+		},
+		{
+			name:         "should return empty when no buckets are configured",
+			labels:       nil,
+			changedLines: 5,
+			expected:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			labels := tt.labels
+
+			// when
+			result := entities.SelectSizeLabel(labels, tt.changedLines)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRenderPRTemplate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render the template with the data of a single upgrade", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		data := entities.NewPRTemplateData("terraform", []entities.DependencyChange{
+			{Name: "network", From: "v1.0.0", To: "v1.2.0"},
+		})
+
+		// when
+		result := entities.RenderPRTemplate(
+			"{{.Ecosystem}}: {{.Module}} {{.OldVersion}} -> {{.NewVersion}} ({{.Count}})", data, "fallback",
+		)
+
+		// then
+		assert.Equal(t, "terraform: network v1.0.0 -> v1.2.0 (1)", result)
+	})
+
+	t.Run("should leave the module fields empty for several upgrades", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		data := entities.NewPRTemplateData("terraform", []entities.DependencyChange{
+			{Name: "network", From: "v1.0.0", To: "v1.2.0"},
+			{Name: "storage", From: "v2.0.0", To: "v2.1.0"},
+		})
+
+		// when
+		result := entities.RenderPRTemplate("bump {{.Count}} deps{{.Module}}", data, "fallback")
+
+		// then
+		assert.Equal(t, "bump 2 deps", result)
+	})
+
+	t.Run("should return the fallback when no template is set", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		result := entities.RenderPRTemplate("", entities.PRTemplateData{}, "chore(deps): generated")
+
+		// then
+		assert.Equal(t, "chore(deps): generated", result)
+	})
+
+	t.Run("should return the fallback when the template does not render", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		result := entities.RenderPRTemplate("{{.Unknown}}", entities.PRTemplateData{}, "chore(deps): generated")
+
+		// then
+		assert.Equal(t, "chore(deps): generated", result)
+	})
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"fmt"
+	"slices"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,17 +13,26 @@ const RepoConfigFile = ".autoupdate.yaml"
 
 // RepoConfig is the schema for a target repository's .autoupdate.yaml.
 // It lets a project opt out of automated updates without touching the
-// global autoupdate configuration. When Skip is true the repository is
-// short-circuited before any updater runs.
+// global autoupdate configuration. When Skip is true (or Enabled is
+// false) the repository is short-circuited before any updater runs; Ignore
+// names the updaters (e.g. "terraform") that must not touch it.
 type RepoConfig struct {
-	Skip   bool   `yaml:"skip"`
-	Reason string `yaml:"reason"`
+	Skip    bool     `yaml:"skip"`
+	Enabled *bool    `yaml:"enabled"`
+	Ignore  []string `yaml:"ignore"`
+	Reason  string   `yaml:"reason"`
 }
 
 // IsSkipped reports whether the repository configuration requests that
 // autoupdate skip this project entirely.
 func (c *RepoConfig) IsSkipped() bool {
-	return c != nil && c.Skip
+	return c != nil && (c.Skip || (c.Enabled != nil && !*c.Enabled))
+}
+
+// IsUpdaterIgnored reports whether the repository configuration opts out of
+// the named updater.
+func (c *RepoConfig) IsUpdaterIgnored(name string) bool {
+	return c != nil && slices.Contains(c.Ignore, name)
 }
 
 // ParseRepoConfig decodes raw YAML bytes into a RepoConfig. Empty input
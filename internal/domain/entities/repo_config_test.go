@@ -52,6 +52,63 @@ func TestRepoConfigIsSkipped(t *testing.T) {
 		// then
 		assert.True(t, result)
 	})
+
+	t.Run("should return true when Enabled is false", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		enabled := false
+		cfg := &entities.RepoConfig{Enabled: &enabled}
+
+		// when
+		result := cfg.IsSkipped()
+
+		// then
+		assert.True(t, result)
+	})
+}
+
+func TestRepoConfigIsUpdaterIgnored(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return true for an updater listed in Ignore", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cfg := &entities.RepoConfig{Ignore: []string{"terraform"}}
+
+		// when
+		result := cfg.IsUpdaterIgnored("terraform")
+
+		// then
+		assert.True(t, result)
+	})
+
+	t.Run("should return false for an updater not listed in Ignore", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cfg := &entities.RepoConfig{Ignore: []string{"terraform"}}
+
+		// when
+		result := cfg.IsUpdaterIgnored("golang")
+
+		// then
+		assert.False(t, result)
+	})
+
+	t.Run("should return false when config is nil", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var cfg *entities.RepoConfig
+
+		// when
+		result := cfg.IsUpdaterIgnored("terraform")
+
+		// then
+		assert.False(t, result)
+	})
 }
 
 func TestParseRepoConfig(t *testing.T) {
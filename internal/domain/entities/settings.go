@@ -3,9 +3,16 @@ package entities
 import (
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"maps"
+	"net"
+	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	configEntities "github.com/rios0rios0/gitforge/pkg/config/domain/entities"
@@ -16,6 +23,55 @@ import (
 const DefaultConfigURL = "https://raw.githubusercontent.com/rios0rios0/autoupdate/" +
 	"main/configs/autoupdate.yaml"
 
+// Values of the python updater's freeze_mode setting.
+const (
+	// FreezeModeFull writes every installed package, transitive ones
+	// included, back to requirements.txt.
+	FreezeModeFull = "full"
+	// FreezeModeTopLevel writes back only the packages requirements.txt
+	// declared before the upgrade.
+	FreezeModeTopLevel = "top-level"
+)
+
+// Values of the javascript updater's node_channel setting.
+const (
+	// NodeChannelLTS targets the newest Long Term Support release.
+	NodeChannelLTS = "lts"
+	// NodeChannelCurrent targets the newest release, LTS or not.
+	NodeChannelCurrent = "current"
+)
+
+// Values of the changelog_conflict setting.
+const (
+	// ChangelogConflictRetry refetches a CHANGELOG.md that changed since it
+	// was read and re-inserts the entries into the newer content.
+	ChangelogConflictRetry = "retry"
+	// ChangelogConflictSkip leaves a CHANGELOG.md that changed since it was
+	// read out of the pull request.
+	ChangelogConflictSkip = "skip"
+)
+
+// Values of the signing.format setting.
+const (
+	// SigningFormatGPG signs commits with an OpenPGP key.
+	SigningFormatGPG = "gpg"
+	// SigningFormatSSH signs commits with an SSH key.
+	SigningFormatSSH = "ssh"
+)
+
+// commitTypePattern and commitScopePattern bound the commit_type and
+// commit_scope settings to conventional-commit types and scopes.
+var (
+	commitTypePattern  = regexp.MustCompile(`^[a-z]+$`)
+	commitScopePattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+)
+
+// pathScopedUpdaters are the updaters honouring the `paths` setting.
+var pathScopedUpdaters = []string{"terraform", "javascript", "golang", "python"}
+
+// concurrentUpdaters are the updaters honouring the `concurrency` setting.
+var concurrentUpdaters = []string{"terraform", "javascript"}
+
 // ProviderConfig is a type alias for gitforge's ProviderConfig, preserving backward compatibility.
 type ProviderConfig = configEntities.ProviderConfig
 
@@ -31,22 +87,303 @@ type Settings struct {
 	GitHubAccessToken      string                   `yaml:"github_access_token"`
 	GitLabAccessToken      string                   `yaml:"gitlab_access_token"`
 	AzureDevOpsAccessToken string                   `yaml:"azure_devops_access_token"`
+	VersionPolicyPath      string                   `yaml:"version_policy"`
+	ExcludedDirs           []string                 `yaml:"excluded_dirs"`
+	IgnoreMajor            bool                     `yaml:"ignore_major"`
+	ChangesReportPath      string                   `yaml:"changes_report"`
+	PRFooter               *string                  `yaml:"pr_footer"`
+	SizeLabels             []SizeLabel              `yaml:"size_labels"`
+	ChangelogConflict      string                   `yaml:"changelog_conflict"`
+	Changelog              ChangelogSettings        `yaml:"changelog"`
+	Assignees              []string                 `yaml:"assignees"`
+	AssignLastCommitter    bool                     `yaml:"assign_last_committer"`
+	DedupeByContent        bool                     `yaml:"dedupe_by_content"`
+	CommitAuthor           CommitAuthor             `yaml:"commit_author"`
+	Signing                Signing                  `yaml:"signing"`
+	StatsD                 string                   `yaml:"statsd"`
+	Notifications          Notifications            `yaml:"notifications"`
 	GitLabCIJobToken       string                   `yaml:"-"`
+	VersionPolicy          *VersionPolicy           `yaml:"-"`
+}
+
+// ChangelogSettings configures the changelog entries of the updaters. Path
+// is the changelog file relative to the repository root, DefaultChangelogPath
+// when empty. Section is the "### <Section>" subsection of the unreleased
+// section the entries go under, DefaultChangelogSection when empty.
+type ChangelogSettings struct {
+	Path    string `yaml:"path"`
+	Section string `yaml:"section"`
+}
+
+// FilePath returns the configured changelog path in its clean slash form,
+// DefaultChangelogPath when none is set.
+func (c ChangelogSettings) FilePath() string {
+	if c.Path == "" {
+		return DefaultChangelogPath
+	}
+	return path.Clean(c.Path)
+}
+
+// CommitAuthor is the git identity autoupdate commits with. Empty fields
+// keep the identity from the git config, then `autoupdate[bot]`.
+type CommitAuthor struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+// Signing configures the key the updater scripts sign their commits with.
+// An empty Key leaves commits unsigned; an empty Format means gpg.
+type Signing struct {
+	Key    string `yaml:"key"`
+	Format string `yaml:"format"`
+}
+
+// Notifications configures where autoupdate announces the outcome of a run.
+// An empty SlackWebhookURL or Webhook.URL sends nothing.
+type Notifications struct {
+	SlackWebhookURL string  `yaml:"slack_webhook_url"`
+	Webhook         Webhook `yaml:"webhook"`
+}
+
+// Webhook is a generic endpoint that receives the JSON summary of every run.
+// Headers are sent as-is, e.g. {"Authorization": "Bearer ${TOKEN}"}.
+type Webhook struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
 }
 
 // UpdaterConfig holds per-updater settings.
 type UpdaterConfig struct {
-	Enabled      *bool  `yaml:"enabled"`
-	AutoComplete *bool  `yaml:"auto_complete"`
-	TargetBranch string `yaml:"target_branch"`
+	Enabled      *bool    `yaml:"enabled"`
+	AutoComplete *bool    `yaml:"auto_complete"`
+	TargetBranch string   `yaml:"target_branch"`
+	Paths        []string `yaml:"paths"`
+	// TrackBranches maps a dependency source (the module repository name) to
+	// a branch whose current tag is used as the target version instead of
+	// the highest semver tag, e.g. {"network-module": "stable"}.
+	TrackBranches map[string]string `yaml:"track_branches"`
+	// ImageSources maps a container image name pinned in .hcl/.tfvars files
+	// to the repository it is built from, as "name" or "org/name", for images
+	// not named after their repository, e.g. {"relayer-http": "service-relayer"}.
+	ImageSources map[string]string `yaml:"image_sources"`
+	// TagPattern restricts the tags considered when resolving the latest
+	// version of a dependency to those matching a glob (`path.Match`
+	// syntax, so `*` does not cross `/`), e.g. "v*" to ignore "nightly-*"
+	// and "api/v*" tags. Empty considers every tag.
+	TagPattern string `yaml:"tag_pattern"`
+	// PRTitleTemplate and PRBodyTemplate are Go `text/template`s replacing
+	// the generated PR title and description, rendered with PRTemplateData,
+	// e.g. "build(deps): bump {{.Module}} to {{.NewVersion}}".
+	PRTitleTemplate string `yaml:"pr_title_template"`
+	PRBodyTemplate  string `yaml:"pr_body_template"`
+	// CommitType and CommitScope replace the "chore" type and "deps" scope
+	// of the upgrade commit messages, e.g. "build" for `build(deps): ...`.
+	CommitType  string `yaml:"commit_type"`
+	CommitScope string `yaml:"commit_scope"`
+	// UpgradeLocalComments lets the Terraform updater bump the trailing
+	// `# ref vX.Y.Z` comment of local-path module sources.
+	UpgradeLocalComments *bool `yaml:"upgrade_local_comments"`
+	// Concurrency bounds how many `paths` the JavaScript updater upgrades at
+	// once (sequentially when zero) and how many files and tag lookups the
+	// Terraform updater runs at once (8 when zero). Other updaters reject it.
+	Concurrency int `yaml:"concurrency"`
+	// AuditFix switches the JavaScript updater to running `npm audit fix`,
+	// producing a lockfile-only security PR instead of the regular
+	// dependency upgrade.
+	AuditFix *bool `yaml:"audit_fix"`
+	// AuditFixForce adds `--force` to `npm audit fix`, allowing semver-major
+	// fixes that may also rewrite package.json.
+	AuditFixForce *bool `yaml:"audit_fix_force"`
+	// MaxVersion caps the language version the updater upgrades to
+	// (e.g. "1.24" for the Go updater). Empty means the latest stable.
+	MaxVersion string `yaml:"max_version"`
+	// Series keeps the language version on a release series (e.g. "3.12"
+	// for the Python updater), moving only to its latest patch.
+	Series string `yaml:"series"`
+	// Exclude lists dependencies the updater must hold at their current
+	// version (e.g. Go module paths for the Go updater).
+	Exclude []string `yaml:"exclude"`
+	// Only lists the Go module paths the Go updater bumps to their latest
+	// version, leaving every other module alone, for focused upgrade PRs.
+	Only []string `yaml:"only"`
+	// CIFiles lists glob patterns of CI files whose `go-version:` fields
+	// follow a Go version bump. Empty means ".github/workflows/*.y*ml".
+	CIFiles []string `yaml:"ci_files"`
+	// Labels are attached to every pull request the updater contributes
+	// to (e.g. "dependencies"), when the provider supports labels.
+	Labels []string `yaml:"labels"`
+	// Reviewers are asked to review every pull request the updater
+	// contributes to, when the provider supports review requests.
+	Reviewers []string `yaml:"reviewers"`
+	// Assignees are assigned to every pull request the updater contributes
+	// to, in addition to the top-level assignees.
+	Assignees []string `yaml:"assignees"`
+	// DockerfileImages lists the image names whose tags follow a runtime
+	// version bump (e.g. "ghcr.io/org/golang"). Empty keeps the official one.
+	DockerfileImages []string `yaml:"dockerfile_images"`
+	// DirectOnly makes the Go updater upgrade only direct module
+	// requirements instead of running `go get -u` on every dependency.
+	DirectOnly *bool `yaml:"direct_only"`
+	// BumpLowerBounds lets the Python updater raise `>=` lower bounds in
+	// pyproject.toml, which are otherwise left alone.
+	BumpLowerBounds *bool `yaml:"bump_lower_bounds"`
+	// RunFmt makes the Go updater run gofmt (and goimports, when installed)
+	// after upgrading, so API changes leave the sources formatted.
+	RunFmt *bool `yaml:"run_fmt"`
+	// UpgradeRegistryModules lets the Terraform updater advance the
+	// `version` constraint of registry modules to the latest version the
+	// constraint allows (e.g. "~> 2.1" to "~> 2.9", never to 3.0).
+	UpgradeRegistryModules *bool `yaml:"upgrade_registry_modules"`
+	// IgnoreMajor overrides the global ignore_major setting for this
+	// updater, skipping candidate versions with a newer major version.
+	IgnoreMajor *bool `yaml:"ignore_major"`
+	// RefreshLockfile regenerates the lockfile even when every version is
+	// current, opening a pull request only when the lockfile changed.
+	RefreshLockfile *bool `yaml:"refresh_lockfile"`
+	// UpdateRanges lets the JavaScript updater move the package.json ranges
+	// to the latest releases, majors included, instead of updating only
+	// within them.
+	UpdateRanges *bool `yaml:"update_ranges"`
+	// AllowYanked lets the updater pick versions its registry flags as
+	// unfit (retracted Go module versions, deprecated npm releases), which
+	// are skipped by default when resolving the latest version.
+	AllowYanked *bool `yaml:"allow_yanked"`
+	// SupersedeStale lets an updater close its own open PR for a
+	// dependency, and delete the branch, once it opens a PR moving that
+	// dependency to a newer version.
+	SupersedeStale *bool `yaml:"supersede_stale"`
+	// FreezeMode controls how the Python updater rewrites requirements.txt
+	// from `pip freeze`: FreezeModeFull (the default) or FreezeModeTopLevel.
+	FreezeMode string `yaml:"freeze_mode"`
+	// NodeChannel selects the Node.js release line the JavaScript updater
+	// targets: NodeChannelLTS (the default) or NodeChannelCurrent.
+	NodeChannel string `yaml:"node_channel"`
+	// VersionConflict decides the current runtime version when the version
+	// file disagrees with other declarations (a Dockerfile image tag,
+	// `requires-python`): one of the VersionConflict* values. Empty keeps
+	// VersionConflictVersionFileWins.
+	VersionConflict string `yaml:"version_conflict"`
+	// Rollout limits the updater to a stable percentage of the repositories
+	// (e.g. "20%"), picked by a hash of the repository name, to pilot a new
+	// updater before enabling it everywhere. Empty means every repository.
+	Rollout string `yaml:"rollout"`
 }
 
+// maxRolloutPercent is a rollout that covers every repository.
+const maxRolloutPercent = 100
+
 // IsEnabled returns whether the updater is enabled.
 // When Enabled is nil (not set in config), it defaults to true.
 func (c UpdaterConfig) IsEnabled() bool {
 	return c.Enabled == nil || *c.Enabled
 }
 
+// IsInRollout returns whether the repository ("org/name") falls within the
+// updater's Rollout percentage. A repository always hashes to the same
+// bucket, so it stays selected across runs and when the percentage grows.
+// An empty or invalid Rollout selects every repository.
+func (c UpdaterConfig) IsInRollout(repoFullName string) bool {
+	percent, err := parseRolloutPercent(c.Rollout)
+	if err != nil || percent >= maxRolloutPercent {
+		return true
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(repoFullName))
+	return int(h.Sum32()%maxRolloutPercent) < percent
+}
+
+// parseRolloutPercent parses a rollout such as "20%" (or "20"). An empty
+// rollout is 100%.
+func parseRolloutPercent(rollout string) (int, error) {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(rollout), "%")
+	if trimmed == "" {
+		return maxRolloutPercent, nil
+	}
+	percent, err := strconv.Atoi(trimmed)
+	if err != nil || percent < 0 || percent > maxRolloutPercent {
+		return 0, errors.New("must be a percentage between 0% and 100%")
+	}
+	return percent, nil
+}
+
+// IsUpgradeLocalComments returns whether version comments on local module
+// sources should be upgraded. When UpgradeLocalComments is nil, it defaults to false.
+func (c UpdaterConfig) IsUpgradeLocalComments() bool {
+	return c.UpgradeLocalComments != nil && *c.UpgradeLocalComments
+}
+
+// IsAuditFix returns whether the audit-fix mode is enabled.
+// When AuditFix is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsAuditFix() bool {
+	return c.AuditFix != nil && *c.AuditFix
+}
+
+// IsAuditFixForce returns whether `npm audit fix --force` is allowed.
+// When AuditFixForce is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsAuditFixForce() bool {
+	return c.AuditFixForce != nil && *c.AuditFixForce
+}
+
+// IsDirectOnly returns whether only direct dependencies should be upgraded.
+// When DirectOnly is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsDirectOnly() bool {
+	return c.DirectOnly != nil && *c.DirectOnly
+}
+
+// IsBumpLowerBounds returns whether `>=` lower bounds should be raised.
+// When BumpLowerBounds is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsBumpLowerBounds() bool {
+	return c.BumpLowerBounds != nil && *c.BumpLowerBounds
+}
+
+// IsRunFmt returns whether sources should be reformatted after upgrading.
+// When RunFmt is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsRunFmt() bool {
+	return c.RunFmt != nil && *c.RunFmt
+}
+
+// IsUpgradeRegistryModules returns whether registry module constraints should
+// be advanced. When UpgradeRegistryModules is nil (not set in config), it
+// defaults to false.
+func (c UpdaterConfig) IsUpgradeRegistryModules() bool {
+	return c.UpgradeRegistryModules != nil && *c.UpgradeRegistryModules
+}
+
+// IsSupersedeStale returns whether stale PRs should be closed when a newer
+// one replaces them. When SupersedeStale is nil (not set in config), it
+// defaults to false.
+func (c UpdaterConfig) IsSupersedeStale() bool {
+	return c.SupersedeStale != nil && *c.SupersedeStale
+}
+
+// IsRefreshLockfile returns whether the lockfile should be regenerated.
+// When RefreshLockfile is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsRefreshLockfile() bool {
+	return c.RefreshLockfile != nil && *c.RefreshLockfile
+}
+
+// IsUpdateRanges returns whether declared ranges should move to the latest
+// releases. When UpdateRanges is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsUpdateRanges() bool {
+	return c.UpdateRanges != nil && *c.UpdateRanges
+}
+
+// IsAllowYanked returns whether retracted or deprecated versions may be
+// picked. When AllowYanked is nil (not set in config), it defaults to false.
+func (c UpdaterConfig) IsAllowYanked() bool {
+	return c.AllowYanked != nil && *c.AllowYanked
+}
+
+// IsIgnoreMajor returns whether major version bumps should be skipped.
+// When IgnoreMajor is nil (not set in config), it falls back to the global
+// setting.
+func (c UpdaterConfig) IsIgnoreMajor(global bool) bool {
+	if c.IgnoreMajor != nil {
+		return *c.IgnoreMajor
+	}
+	return global
+}
+
 // IsAutoComplete returns whether auto-complete is enabled.
 // When AutoComplete is nil (not set in config), it defaults to false.
 func (c UpdaterConfig) IsAutoComplete() bool {
@@ -77,6 +414,11 @@ func NewSettings(path string) (*Settings, error) {
 	settings.GitHubAccessToken = configEntities.ResolveToken(settings.GitHubAccessToken)
 	settings.GitLabAccessToken = configEntities.ResolveToken(settings.GitLabAccessToken)
 	settings.AzureDevOpsAccessToken = configEntities.ResolveToken(settings.AzureDevOpsAccessToken)
+	settings.Notifications.SlackWebhookURL = configEntities.ResolveToken(settings.Notifications.SlackWebhookURL)
+	settings.Notifications.Webhook.URL = configEntities.ResolveToken(settings.Notifications.Webhook.URL)
+	for name, value := range settings.Notifications.Webhook.Headers {
+		settings.Notifications.Webhook.Headers[name] = configEntities.ResolveToken(value)
+	}
 
 	settings.GitLabCIJobToken = os.Getenv("CI_JOB_TOKEN")
 
@@ -84,6 +426,19 @@ func NewSettings(path string) (*Settings, error) {
 		settings.GpgKeyPassphrase = os.Getenv("GPG_PASSPHRASE")
 	}
 
+	// The policy path is relative to the config file unless absolute.
+	if settings.VersionPolicyPath != "" {
+		policyPath := settings.VersionPolicyPath
+		if !filepath.IsAbs(policyPath) {
+			policyPath = filepath.Join(filepath.Dir(path), policyPath)
+		}
+		policy, policyErr := LoadVersionPolicy(policyPath)
+		if policyErr != nil {
+			return nil, policyErr
+		}
+		settings.VersionPolicy = policy
+	}
+
 	if validateErr := ValidateSettings(&settings); validateErr != nil {
 		return nil, validateErr
 	}
@@ -139,12 +494,143 @@ func ValidateSettings(settings *Settings) error {
 		}
 	}
 
+	unbounded := 0
+	for i, label := range settings.SizeLabels {
+		if strings.TrimSpace(label.Name) == "" {
+			return fmt.Errorf("size_labels[%d]: name is required", i)
+		}
+		if label.MaxLines < 0 {
+			return fmt.Errorf("size_labels[%d] %q: max_lines must not be negative", i, label.Name)
+		}
+		if label.MaxLines == 0 {
+			unbounded++
+		}
+	}
+	if unbounded > 1 {
+		return errors.New("size_labels: only one label may omit max_lines")
+	}
+
+	switch settings.ChangelogConflict {
+	case "", ChangelogConflictRetry, ChangelogConflictSkip:
+	default:
+		return fmt.Errorf("changelog_conflict %q: must be %q or %q",
+			settings.ChangelogConflict, ChangelogConflictRetry, ChangelogConflictSkip)
+	}
+
+	for i, assignee := range settings.Assignees {
+		if strings.TrimSpace(assignee) == "" {
+			return fmt.Errorf("assignees[%d]: username is required", i)
+		}
+	}
+
+	if email := settings.CommitAuthor.Email; email != "" && !strings.Contains(email, "@") {
+		return fmt.Errorf("commit_author.email %q: must be an email address", email)
+	}
+
+	if p := settings.Changelog.Path; p != "" && !isRepoRelativePath(p) {
+		return fmt.Errorf("changelog.path %q: must be a file path relative to the repository root", p)
+	}
+
+	if section := settings.Changelog.Section; strings.ContainsAny(section, "\n#") {
+		return fmt.Errorf("changelog.section %q: must be a plain heading text such as \"Dependencies\"", section)
+	}
+
+	switch settings.Signing.Format {
+	case "", SigningFormatGPG, SigningFormatSSH:
+	default:
+		return fmt.Errorf("signing.format %q: must be %q or %q",
+			settings.Signing.Format, SigningFormatGPG, SigningFormatSSH)
+	}
+	if settings.Signing.Format != "" && settings.Signing.Key == "" {
+		return errors.New("signing.key: is required when signing.format is set")
+	}
+
+	if settings.StatsD != "" {
+		if _, _, err := net.SplitHostPort(settings.StatsD); err != nil {
+			return fmt.Errorf("statsd %q: must be host:port: %w", settings.StatsD, err)
+		}
+	}
+
+	if webhookURL := settings.Notifications.SlackWebhookURL; webhookURL != "" && !isHTTPURL(webhookURL) {
+		return errors.New("notifications.slack_webhook_url: must be an http(s) URL")
+	}
+	webhook := settings.Notifications.Webhook
+	if webhook.URL != "" && !isHTTPURL(webhook.URL) {
+		return errors.New("notifications.webhook.url: must be an http(s) URL")
+	}
+	if webhook.URL == "" && len(webhook.Headers) > 0 {
+		return errors.New("notifications.webhook.url: is required when headers are set")
+	}
+
+	for name, cfg := range settings.Updaters {
+		switch cfg.FreezeMode {
+		case "", FreezeModeFull, FreezeModeTopLevel:
+		default:
+			return fmt.Errorf("updaters.%s.freeze_mode %q: must be %q or %q",
+				name, cfg.FreezeMode, FreezeModeFull, FreezeModeTopLevel)
+		}
+		switch cfg.NodeChannel {
+		case "", NodeChannelLTS, NodeChannelCurrent:
+		default:
+			return fmt.Errorf("updaters.%s.node_channel %q: must be %q or %q",
+				name, cfg.NodeChannel, NodeChannelLTS, NodeChannelCurrent)
+		}
+		switch cfg.VersionConflict {
+		case "", VersionConflictVersionFileWins, VersionConflictHighest,
+			VersionConflictLowest, VersionConflictWarnAndSkip:
+		default:
+			return fmt.Errorf("updaters.%s.version_conflict %q: must be %q, %q, %q or %q",
+				name, cfg.VersionConflict, VersionConflictVersionFileWins, VersionConflictHighest,
+				VersionConflictLowest, VersionConflictWarnAndSkip)
+		}
+		if len(cfg.Paths) > 0 && !slices.Contains(pathScopedUpdaters, name) {
+			return fmt.Errorf("updaters.%s.paths: only supported by the %s updaters",
+				name, joinNames(pathScopedUpdaters))
+		}
+		if cfg.Concurrency != 0 && !slices.Contains(concurrentUpdaters, name) {
+			return fmt.Errorf("updaters.%s.concurrency: only supported by the %s updaters",
+				name, joinNames(concurrentUpdaters))
+		}
+		if _, err := parseRolloutPercent(cfg.Rollout); err != nil {
+			return fmt.Errorf("updaters.%s.rollout %q: %w", name, cfg.Rollout, err)
+		}
+		if _, err := path.Match(cfg.TagPattern, "probe"); err != nil {
+			return fmt.Errorf("updaters.%s.tag_pattern %q: invalid glob pattern: %w",
+				name, cfg.TagPattern, err)
+		}
+		if _, err := executePRTemplate(cfg.PRTitleTemplate, PRTemplateData{}); err != nil {
+			return fmt.Errorf("updaters.%s.pr_title_template: %w", name, err)
+		}
+		if _, err := executePRTemplate(cfg.PRBodyTemplate, PRTemplateData{}); err != nil {
+			return fmt.Errorf("updaters.%s.pr_body_template: %w", name, err)
+		}
+		if cfg.CommitType != "" && !commitTypePattern.MatchString(cfg.CommitType) {
+			return fmt.Errorf("updaters.%s.commit_type %q: must be a lowercase word such as %q",
+				name, cfg.CommitType, "build")
+		}
+		if cfg.CommitScope != "" && !commitScopePattern.MatchString(cfg.CommitScope) {
+			return fmt.Errorf("updaters.%s.commit_scope %q: must contain only letters, digits, '.', '_', '/' or '-'",
+				name, cfg.CommitScope)
+		}
+		for i, reviewer := range cfg.Reviewers {
+			if strings.TrimSpace(reviewer) == "" {
+				return fmt.Errorf("updaters.%s.reviewers[%d]: username is required", name, i)
+			}
+		}
+		for i, assignee := range cfg.Assignees {
+			if strings.TrimSpace(assignee) == "" {
+				return fmt.Errorf("updaters.%s.assignees[%d]: username is required", name, i)
+			}
+		}
+	}
+
 	return nil
 }
 
 // MergeUpdatersConfig deep-merges user updater overrides into defaults.
 // For each updater: nil pointer fields in the override keep the default value;
-// non-nil pointer fields replace the default. Non-zero string fields replace defaults.
+// non-nil pointer fields replace the default. Non-zero string fields and
+// non-empty slices or maps replace defaults.
 // New updater names not present in defaults are added wholesale.
 func MergeUpdatersConfig(
 	defaults, overrides map[string]UpdaterConfig,
@@ -165,12 +651,136 @@ func MergeUpdatersConfig(
 		if override.AutoComplete != nil {
 			base.AutoComplete = override.AutoComplete
 		}
+		if override.UpgradeLocalComments != nil {
+			base.UpgradeLocalComments = override.UpgradeLocalComments
+		}
+		if override.AuditFix != nil {
+			base.AuditFix = override.AuditFix
+		}
+		if override.AuditFixForce != nil {
+			base.AuditFixForce = override.AuditFixForce
+		}
+		if override.DirectOnly != nil {
+			base.DirectOnly = override.DirectOnly
+		}
+		if override.BumpLowerBounds != nil {
+			base.BumpLowerBounds = override.BumpLowerBounds
+		}
+		if override.RunFmt != nil {
+			base.RunFmt = override.RunFmt
+		}
+		if override.UpgradeRegistryModules != nil {
+			base.UpgradeRegistryModules = override.UpgradeRegistryModules
+		}
+		if override.IgnoreMajor != nil {
+			base.IgnoreMajor = override.IgnoreMajor
+		}
+		if override.RefreshLockfile != nil {
+			base.RefreshLockfile = override.RefreshLockfile
+		}
+		if override.UpdateRanges != nil {
+			base.UpdateRanges = override.UpdateRanges
+		}
+		if override.AllowYanked != nil {
+			base.AllowYanked = override.AllowYanked
+		}
+		if override.SupersedeStale != nil {
+			base.SupersedeStale = override.SupersedeStale
+		}
 		if override.TargetBranch != "" {
 			base.TargetBranch = override.TargetBranch
 		}
+		if override.MaxVersion != "" {
+			base.MaxVersion = override.MaxVersion
+		}
+		if override.Series != "" {
+			base.Series = override.Series
+		}
+		if override.FreezeMode != "" {
+			base.FreezeMode = override.FreezeMode
+		}
+		if override.NodeChannel != "" {
+			base.NodeChannel = override.NodeChannel
+		}
+		if override.VersionConflict != "" {
+			base.VersionConflict = override.VersionConflict
+		}
+		if override.Rollout != "" {
+			base.Rollout = override.Rollout
+		}
+		if override.TagPattern != "" {
+			base.TagPattern = override.TagPattern
+		}
+		if len(override.Paths) > 0 {
+			base.Paths = override.Paths
+		}
+		if len(override.Exclude) > 0 {
+			base.Exclude = override.Exclude
+		}
+		if len(override.Only) > 0 {
+			base.Only = override.Only
+		}
+		if len(override.CIFiles) > 0 {
+			base.CIFiles = override.CIFiles
+		}
+		if len(override.Labels) > 0 {
+			base.Labels = override.Labels
+		}
+		if len(override.Reviewers) > 0 {
+			base.Reviewers = override.Reviewers
+		}
+		if len(override.Assignees) > 0 {
+			base.Assignees = override.Assignees
+		}
+		if len(override.DockerfileImages) > 0 {
+			base.DockerfileImages = override.DockerfileImages
+		}
+		if override.Concurrency > 0 {
+			base.Concurrency = override.Concurrency
+		}
+		if len(override.TrackBranches) > 0 {
+			base.TrackBranches = override.TrackBranches
+		}
+		if len(override.ImageSources) > 0 {
+			base.ImageSources = override.ImageSources
+		}
+		if override.PRTitleTemplate != "" {
+			base.PRTitleTemplate = override.PRTitleTemplate
+		}
+		if override.PRBodyTemplate != "" {
+			base.PRBodyTemplate = override.PRBodyTemplate
+		}
+		if override.CommitType != "" {
+			base.CommitType = override.CommitType
+		}
+		if override.CommitScope != "" {
+			base.CommitScope = override.CommitScope
+		}
 
 		result[name] = base
 	}
 
 	return result
 }
+
+// isRepoRelativePath reports whether p names a file inside the repository:
+// relative, not the root itself and not escaping it through "..".
+func isRepoRelativePath(p string) bool {
+	cleaned := path.Clean(filepath.ToSlash(p))
+	return !path.IsAbs(cleaned) && cleaned != "." && cleaned != ".." && !strings.HasPrefix(cleaned, "../")
+}
+
+// isHTTPURL reports whether rawURL is an absolute http or https URL. The URL
+// may embed a secret, so callers do not echo it in their errors.
+func isHTTPURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// joinNames lists names for an error message: "a and b", or "a, b and c".
+func joinNames(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
@@ -3,7 +3,9 @@
 package entities_test
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,6 +53,76 @@ func TestIsEnabled(t *testing.T) {
 	})
 }
 
+func TestIsInRollout(t *testing.T) {
+	t.Parallel()
+
+	repoNames := make([]string, 1000)
+	for i := range repoNames {
+		repoNames[i] = fmt.Sprintf("org/service-%d", i)
+	}
+
+	t.Run("should select every repository when Rollout is empty", func(t *testing.T) {
+		// given
+		cfg := entities.UpdaterConfig{}
+
+		// when
+		result := cfg.IsInRollout("org/repo")
+
+		// then
+		assert.True(t, result)
+	})
+
+	t.Run("should select roughly the configured share of repositories", func(t *testing.T) {
+		// given
+		cfg := entities.UpdaterConfig{Rollout: "20%"}
+
+		// when
+		selected := 0
+		for _, name := range repoNames {
+			if cfg.IsInRollout(name) {
+				selected++
+			}
+		}
+
+		// then
+		assert.InDelta(t, 200, selected, 40)
+	})
+
+	t.Run("should select the same repositories on every run", func(t *testing.T) {
+		// given
+		first := entities.UpdaterConfig{Rollout: "20%"}
+		second := entities.UpdaterConfig{Rollout: "20"}
+
+		// when / then
+		for _, name := range repoNames {
+			assert.Equal(t, first.IsInRollout(name), second.IsInRollout(name), name)
+		}
+	})
+
+	t.Run("should keep the selected repositories when the rollout grows", func(t *testing.T) {
+		// given
+		pilot := entities.UpdaterConfig{Rollout: "20%"}
+		wider := entities.UpdaterConfig{Rollout: "50%"}
+
+		// when / then
+		for _, name := range repoNames {
+			if pilot.IsInRollout(name) {
+				assert.True(t, wider.IsInRollout(name), name)
+			}
+		}
+	})
+
+	t.Run("should select no repository at 0%", func(t *testing.T) {
+		// given
+		cfg := entities.UpdaterConfig{Rollout: "0%"}
+
+		// when / then
+		for _, name := range repoNames {
+			assert.False(t, cfg.IsInRollout(name), name)
+		}
+	})
+}
+
 func TestIsAutoComplete(t *testing.T) {
 	t.Parallel()
 
@@ -88,6 +160,32 @@ func TestIsAutoComplete(t *testing.T) {
 	})
 }
 
+func TestIsIgnoreMajor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should fall back to the global setting when IgnoreMajor is nil", func(t *testing.T) {
+		// given
+		cfg := entities.UpdaterConfig{}
+
+		// when
+		result := cfg.IsIgnoreMajor(true)
+
+		// then
+		assert.True(t, result)
+	})
+
+	t.Run("should let the updater override the global setting", func(t *testing.T) {
+		// given
+		cfg := entities.UpdaterConfig{IgnoreMajor: boolPtr(false)}
+
+		// when
+		result := cfg.IsIgnoreMajor(true)
+
+		// then
+		assert.False(t, result)
+	})
+}
+
 func TestNewSettings(t *testing.T) {
 	t.Parallel()
 
@@ -134,6 +232,55 @@ func TestNewSettings(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "at least one provider")
 	})
+
+	t.Run("should load the version policy relative to the config file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		dir := t.TempDir()
+		config := `providers:
+  - type: github
+    token: my-token
+    organizations:
+      - my-org
+version_policy: policy.yaml
+`
+		policy := `max_versions:
+  network-module: v2.3.0
+`
+		require.NoError(t, os.WriteFile(dir+"/autoupdate.yaml", []byte(config), 0o600))
+		require.NoError(t, os.WriteFile(dir+"/policy.yaml", []byte(policy), 0o600))
+
+		// when
+		settings, err := entities.NewSettings(dir + "/autoupdate.yaml")
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, settings.VersionPolicy)
+		assert.Equal(t, map[string]string{"network-module": "v2.3.0"}, settings.VersionPolicy.MaxVersions)
+	})
+
+	t.Run("should return error when the version policy cannot be read", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		dir := t.TempDir()
+		config := `providers:
+  - type: github
+    token: my-token
+    organizations:
+      - my-org
+version_policy: missing.yaml
+`
+		require.NoError(t, os.WriteFile(dir+"/autoupdate.yaml", []byte(config), 0o600))
+
+		// when
+		_, err := entities.NewSettings(dir + "/autoupdate.yaml")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read version policy")
+	})
 }
 
 func TestDecodeSettings(t *testing.T) {
@@ -239,75 +386,83 @@ func TestValidateSettings(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
-	t.Run("should return error when no providers configured", func(t *testing.T) {
+	t.Run("should return error for an unknown freeze_mode", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		settings := &entities.Settings{}
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{"python": {FreezeMode: "partial"}},
+		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "at least one provider")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.python.freeze_mode")
 	})
 
-	t.Run("should return error when provider type is missing", func(t *testing.T) {
+	t.Run("should return error for a size label without a name", func(t *testing.T) {
 		t.Parallel()
 
 		// given
 		settings := &entities.Settings{
 			Providers: []entities.ProviderConfig{
-				{Token: "tok", Organizations: []string{"org"}},
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
+			SizeLabels: []entities.SizeLabel{{Name: "size/S", MaxLines: 10}, {MaxLines: 100}},
 		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "type is required")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "size_labels[1]")
 	})
 
-	t.Run("should return error when provider token is missing", func(t *testing.T) {
+	t.Run("should return error for more than one unbounded size label", func(t *testing.T) {
 		t.Parallel()
 
 		// given
 		settings := &entities.Settings{
 			Providers: []entities.ProviderConfig{
-				{Type: "github", Organizations: []string{"org"}},
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
+			SizeLabels: []entities.SizeLabel{{Name: "size/L"}, {Name: "size/XL"}},
 		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "token is required")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "only one label may omit max_lines")
 	})
 
-	t.Run("should return error when provider organizations are empty", func(t *testing.T) {
+	t.Run("should return error for an unknown changelog_conflict policy", func(t *testing.T) {
 		t.Parallel()
 
 		// given
 		settings := &entities.Settings{
 			Providers: []entities.ProviderConfig{
-				{Type: "github", Token: "tok"},
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
+			ChangelogConflict: "overwrite",
 		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "organizations must have at least one entry")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "changelog_conflict")
 	})
 
-	t.Run("should accept valid exclude_repos patterns", func(t *testing.T) {
+	t.Run("should return error for a blank assignee", func(t *testing.T) {
 		t.Parallel()
 
 		// given
@@ -315,22 +470,18 @@ func TestValidateSettings(t *testing.T) {
 			Providers: []entities.ProviderConfig{
 				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
-			ExcludeRepos: []string{
-				"rios0rios0/autoupdate",
-				"*/oui",
-				"zestsecurity/frontend/*",
-				"opensearch-dashboards",
-			},
+			Assignees: []string{"alice", " "},
 		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.NoError(t, err)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "assignees[1]")
 	})
 
-	t.Run("should ignore blank exclude_repos entries", func(t *testing.T) {
+	t.Run("should return error for a blank updater reviewer", func(t *testing.T) {
 		t.Parallel()
 
 		// given
@@ -338,17 +489,20 @@ func TestValidateSettings(t *testing.T) {
 			Providers: []entities.ProviderConfig{
 				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
-			ExcludeRepos: []string{"", "   ", "rios0rios0/autoupdate"},
+			Updaters: map[string]entities.UpdaterConfig{
+				"golang": {Reviewers: []string{""}},
+			},
 		}
 
 		// when
 		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.NoError(t, err)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.golang.reviewers[0]")
 	})
 
-	t.Run("should return error for invalid glob patterns in exclude_repos", func(t *testing.T) {
+	t.Run("should return error for a commit author email without an @", func(t *testing.T) {
 		t.Parallel()
 
 		// given
@@ -356,7 +510,7 @@ func TestValidateSettings(t *testing.T) {
 			Providers: []entities.ProviderConfig{
 				{Type: "github", Token: "tok", Organizations: []string{"org"}},
 			},
-			ExcludeRepos: []string{"valid/repo", "bad/[unclosed"},
+			CommitAuthor: entities.CommitAuthor{Name: "Release Bot", Email: "release-bot"},
 		}
 
 		// when
@@ -364,132 +518,902 @@ func TestValidateSettings(t *testing.T) {
 
 		// then
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "exclude_repos[1]")
-		assert.Contains(t, err.Error(), "bad/[unclosed")
+		assert.Contains(t, err.Error(), "commit_author.email")
 	})
-}
-
-func TestInsertChangelogEntry(t *testing.T) {
-	t.Parallel()
 
-	t.Run("should insert entries under Unreleased section", func(t *testing.T) {
+	t.Run("should return error for an unknown signing format", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		content := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
-		entries := []string{"- added new feature X"}
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Signing: entities.Signing{Key: "ABC123", Format: "x509"},
+		}
 
 		// when
-		result := entities.InsertChangelogEntry(content, entries)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Contains(t, result, "- added new feature X")
-		assert.Contains(t, result, "[Unreleased]")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "signing.format")
 	})
 
-	t.Run("should return content unchanged when no Unreleased section exists", func(t *testing.T) {
+	t.Run("should return error for a signing format without a key", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		content := "# Changelog\n\n## [1.0.0] - 2026-01-01\n"
-		entries := []string{"- added something"}
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Signing: entities.Signing{Format: entities.SigningFormatSSH},
+		}
 
 		// when
-		result := entities.InsertChangelogEntry(content, entries)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Equal(t, content, result)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "signing.key")
 	})
-}
 
-func TestMergeUpdatersConfig(t *testing.T) {
-	t.Parallel()
+	t.Run("should return error for a statsd address without a port", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("should keep all defaults when overrides is empty", func(t *testing.T) {
 		// given
-		defaults := map[string]entities.UpdaterConfig{
-			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
-			"golang":    {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			StatsD: "statsd.internal",
 		}
-		overrides := map[string]entities.UpdaterConfig{}
 
 		// when
-		result := entities.MergeUpdatersConfig(defaults, overrides)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Len(t, result, 2)
-		assert.True(t, result["terraform"].IsEnabled())
-		assert.False(t, result["terraform"].IsAutoComplete())
-		assert.True(t, result["golang"].IsEnabled())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "statsd")
 	})
 
-	t.Run("should override enabled when user provides non-nil value", func(t *testing.T) {
+	t.Run("should return error for a Slack webhook that is not an http(s) URL", func(t *testing.T) {
+		t.Parallel()
+
 		// given
-		defaults := map[string]entities.UpdaterConfig{
-			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
-		}
-		overrides := map[string]entities.UpdaterConfig{
-			"terraform": {Enabled: boolPtr(false)},
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Notifications: entities.Notifications{SlackWebhookURL: "hooks.slack.com/services/T000/B000/XXX"},
 		}
 
 		// when
-		result := entities.MergeUpdatersConfig(defaults, overrides)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.False(t, result["terraform"].IsEnabled())
-		assert.False(t, result["terraform"].IsAutoComplete())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "notifications.slack_webhook_url")
+		assert.NotContains(t, err.Error(), "XXX")
 	})
 
-	t.Run("should override auto_complete when user provides non-nil value", func(t *testing.T) {
+	t.Run("should return error for webhook headers without a webhook URL", func(t *testing.T) {
+		t.Parallel()
+
 		// given
-		defaults := map[string]entities.UpdaterConfig{
-			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
-		}
-		overrides := map[string]entities.UpdaterConfig{
-			"terraform": {AutoComplete: boolPtr(true)},
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Notifications: entities.Notifications{
+				Webhook: entities.Webhook{Headers: map[string]string{"Authorization": "Bearer tok"}},
+			},
 		}
 
 		// when
-		result := entities.MergeUpdatersConfig(defaults, overrides)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.True(t, result["terraform"].IsEnabled())
-		assert.True(t, result["terraform"].IsAutoComplete())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "notifications.webhook.url")
 	})
 
-	t.Run("should override target_branch when user provides non-empty value", func(t *testing.T) {
+	t.Run("should return error for a rollout above 100%", func(t *testing.T) {
+		t.Parallel()
+
 		// given
-		defaults := map[string]entities.UpdaterConfig{
-			"terraform": {Enabled: boolPtr(true)},
-		}
-		overrides := map[string]entities.UpdaterConfig{
-			"terraform": {TargetBranch: "develop"},
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{"golang": {Rollout: "120%"}},
 		}
 
 		// when
-		result := entities.MergeUpdatersConfig(defaults, overrides)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.Equal(t, "develop", result["terraform"].TargetBranch)
-		assert.True(t, result["terraform"].IsEnabled())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.golang.rollout")
 	})
 
-	t.Run("should keep default fields when user provides only target_branch", func(t *testing.T) {
+	t.Run("should return error for an unknown node_channel", func(t *testing.T) {
+		t.Parallel()
+
 		// given
-		defaults := map[string]entities.UpdaterConfig{
-			"golang": {Enabled: boolPtr(true), AutoComplete: boolPtr(false), TargetBranch: "main"},
-		}
-		overrides := map[string]entities.UpdaterConfig{
-			"golang": {TargetBranch: "develop"},
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{"javascript": {NodeChannel: "nightly"}},
 		}
 
 		// when
-		result := entities.MergeUpdatersConfig(defaults, overrides)
+		err := entities.ValidateSettings(settings)
 
 		// then
-		assert.True(t, result["golang"].IsEnabled())
-		assert.False(t, result["golang"].IsAutoComplete())
-		assert.Equal(t, "develop", result["golang"].TargetBranch)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.javascript.node_channel")
+	})
+
+	t.Run("should return error when no providers configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one provider")
+	})
+
+	t.Run("should return error when provider type is missing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Token: "tok", Organizations: []string{"org"}},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "type is required")
+	})
+
+	t.Run("should return error when provider token is missing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Organizations: []string{"org"}},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "token is required")
+	})
+
+	t.Run("should return error when provider organizations are empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok"},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "organizations must have at least one entry")
+	})
+
+	t.Run("should accept valid exclude_repos patterns", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			ExcludeRepos: []string{
+				"rios0rios0/autoupdate",
+				"*/oui",
+				"zestsecurity/frontend/*",
+				"opensearch-dashboards",
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should ignore blank exclude_repos entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			ExcludeRepos: []string{"", "   ", "rios0rios0/autoupdate"},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error for an invalid updater tag_pattern", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{"terraform": {TagPattern: "v[0-9"}},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.terraform.tag_pattern")
+	})
+
+	t.Run("should return error for paths on an updater that cannot scope by path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{
+				"javascript": {Paths: []string{"web"}},
+				"golang":     {Paths: []string{"services"}},
+				"dockerfile": {Paths: []string{"images"}},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.dockerfile.paths")
+	})
+
+	t.Run("should return error for concurrency on an updater that runs sequentially", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{
+				"terraform": {Concurrency: 4},
+				"golang":    {Concurrency: 4},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.golang.concurrency")
+	})
+
+	t.Run("should return error for invalid glob patterns in exclude_repos", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			ExcludeRepos: []string{"valid/repo", "bad/[unclosed"},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exclude_repos[1]")
+		assert.Contains(t, err.Error(), "bad/[unclosed")
+	})
+
+	t.Run("should return error when changelog.section contains a heading marker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Changelog: entities.ChangelogSettings{Section: "### Dependencies"},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "changelog.section")
+	})
+
+	t.Run("should return error when changelog.path escapes the repository", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Changelog: entities.ChangelogSettings{Path: "../CHANGELOG.md"},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "changelog.path")
+	})
+
+	t.Run("should return error for a PR title template referencing an unknown field", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{
+				"terraform": {PRTitleTemplate: "bump {{.Dependency}}"},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.terraform.pr_title_template")
+	})
+
+	t.Run("should return error for a commit type that is not a lowercase word", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		settings := &entities.Settings{
+			Providers: []entities.ProviderConfig{
+				{Type: "github", Token: "tok", Organizations: []string{"org"}},
+			},
+			Updaters: map[string]entities.UpdaterConfig{
+				"golang": {CommitType: "Build!", CommitScope: "deps"},
+			},
+		}
+
+		// when
+		err := entities.ValidateSettings(settings)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "updaters.golang.commit_type")
+	})
+}
+
+func TestInsertChangelogEntry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should insert entries under Unreleased section", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- added new feature X"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Contains(t, result, "- added new feature X")
+		assert.Contains(t, result, "[Unreleased]")
+	})
+
+	t.Run("should insert entries under an Unreleased heading without brackets", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## Unreleased\n\n### Changed\n\n- changed Y\n\n## 1.0.0 - 2026-01-01\n"
+		entries := []string{"- added new feature X"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Equal(
+			t,
+			"# Changelog\n\n## Unreleased\n\n### Changed\n\n- changed Y\n- added new feature X\n\n## 1.0.0 - 2026-01-01\n",
+			result,
+		)
+		assert.NotContains(t, result, "[Unreleased]")
+	})
+
+	t.Run("should insert entries under a bracketed heading in another letter case", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- added new feature X"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Contains(t, result, "## [unreleased]\n")
+		assert.NotContains(t, result, "[Unreleased]")
+		assert.Less(t, strings.Index(result, "## [unreleased]"), strings.Index(result, "- added new feature X"))
+		assert.Less(t, strings.Index(result, "- added new feature X"), strings.Index(result, "## [1.0.0]"))
+	})
+
+	t.Run("should return content unchanged when no Unreleased section exists", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- added something"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("should not duplicate an entry already in the Unreleased section", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n### Changed\n\n" +
+			"- changed the Go version to `1.26.0`\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- changed the Go version to `1.26.0`"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Equal(t, content, result)
+	})
+
+	t.Run("should add only the new entries when some are already present", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n### Changed\n\n" +
+			"- changed the Go version to `1.26.0`\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{
+			"- changed the Go version to `1.26.0`",
+			"- changed the Node.js version to `24.1.0`",
+			"- changed the Node.js version to `24.1.0`",
+		}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Equal(
+			t,
+			"# Changelog\n\n## [Unreleased]\n\n### Changed\n\n- changed the Go version to `1.26.0`\n"+
+				"- changed the Node.js version to `24.1.0`\n\n## [1.0.0] - 2026-01-01\n",
+			result,
+		)
+	})
+
+	t.Run("should add an entry that only appears in a released section", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n\n### Changed\n\n" +
+			"- updated all module dependencies\n"
+		entries := []string{"- updated all module dependencies"}
+
+		// when
+		result := entities.InsertChangelogEntry(content, entries)
+
+		// then
+		assert.Equal(t, 2, strings.Count(result, "- updated all module dependencies"))
+	})
+}
+
+func TestInsertChangelogEntryInSection(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should insert entries under the existing custom section", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n### Dependencies\n\n- bumped Z\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- changed the Go version to `1.26.0`"}
+
+		// when
+		result := entities.InsertChangelogEntryInSection(content, entries, "Dependencies")
+
+		// then
+		assert.Equal(
+			t,
+			"# Changelog\n\n## [Unreleased]\n\n### Dependencies\n\n- bumped Z\n"+
+				"- changed the Go version to `1.26.0`\n\n## [1.0.0] - 2026-01-01\n",
+			result,
+		)
+		assert.NotContains(t, result, "### Changed")
+	})
+
+	t.Run("should create the custom section and leave the Changed section alone", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n### Changed\n\n- changed Y\n\n" +
+			"## [1.0.0] - 2026-01-01\n\n### Dependencies\n\n- bumped W\n"
+		entries := []string{"- changed the Go version to `1.26.0`"}
+
+		// when
+		result := entities.InsertChangelogEntryInSection(content, entries, "Dependencies")
+
+		// then
+		unreleased := result[:strings.Index(result, "## [1.0.0]")]
+		assert.Contains(t, unreleased, "### Changed\n\n- changed Y\n")
+		assert.Contains(t, unreleased, "### Dependencies\n\n- changed the Go version to `1.26.0`\n")
+		assert.True(t, strings.HasSuffix(result, "## [1.0.0] - 2026-01-01\n\n### Dependencies\n\n- bumped W\n"))
+	})
+
+	t.Run("should insert under Changed when the section is empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [Unreleased]\n\n### Changed\n\n- changed Y\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- added new feature X"}
+
+		// when
+		result := entities.InsertChangelogEntryInSection(content, entries, "")
+
+		// then
+		assert.Equal(t, entities.InsertChangelogEntry(content, entries), result)
+	})
+
+	t.Run("should return content unchanged when no Unreleased section exists", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "# Changelog\n\n## [1.0.0] - 2026-01-01\n"
+		entries := []string{"- added something"}
+
+		// when
+		result := entities.InsertChangelogEntryInSection(content, entries, "Dependencies")
+
+		// then
+		assert.Equal(t, content, result)
+	})
+}
+
+func TestMergeUpdatersConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep all defaults when overrides is empty", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
+			"golang":    {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Len(t, result, 2)
+		assert.True(t, result["terraform"].IsEnabled())
+		assert.False(t, result["terraform"].IsAutoComplete())
+		assert.True(t, result["golang"].IsEnabled())
+	})
+
+	t.Run("should override enabled when user provides non-nil value", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(false)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.False(t, result["terraform"].IsEnabled())
+		assert.False(t, result["terraform"].IsAutoComplete())
+	})
+
+	t.Run("should override auto_complete when user provides non-nil value", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true), AutoComplete: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {AutoComplete: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["terraform"].IsEnabled())
+		assert.True(t, result["terraform"].IsAutoComplete())
+	})
+
+	t.Run("should override target_branch when user provides non-empty value", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {TargetBranch: "develop"},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, "develop", result["terraform"].TargetBranch)
+		assert.True(t, result["terraform"].IsEnabled())
+	})
+
+	t.Run("should keep default fields when user provides only target_branch", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"golang": {Enabled: boolPtr(true), AutoComplete: boolPtr(false), TargetBranch: "main"},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"golang": {TargetBranch: "develop"},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["golang"].IsEnabled())
+		assert.False(t, result["golang"].IsAutoComplete())
+		assert.Equal(t, "develop", result["golang"].TargetBranch)
+	})
+
+	t.Run("should override paths when user provides a non-empty list", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"javascript": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"javascript": {Paths: []string{"web"}},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, []string{"web"}, result["javascript"].Paths)
+		assert.True(t, result["javascript"].IsEnabled())
+	})
+
+	t.Run("should override upgrade_local_comments when user sets it", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true), UpgradeLocalComments: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {UpgradeLocalComments: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["terraform"].IsUpgradeLocalComments())
+		assert.True(t, result["terraform"].IsEnabled())
+	})
+
+	t.Run("should override audit fix flags when user sets them", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"javascript": {Enabled: boolPtr(true), AuditFix: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"javascript": {AuditFix: boolPtr(true), AuditFixForce: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["javascript"].IsAuditFix())
+		assert.True(t, result["javascript"].IsAuditFixForce())
+		assert.True(t, result["javascript"].IsEnabled())
+	})
+
+	t.Run("should override bump lower bounds when user sets it", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"python": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"python": {BumpLowerBounds: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["python"].IsBumpLowerBounds())
+		assert.False(t, defaults["python"].IsBumpLowerBounds())
+	})
+
+	t.Run("should override run fmt when user sets it", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"golang": {Enabled: boolPtr(true), RunFmt: boolPtr(false)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"golang": {RunFmt: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["golang"].IsRunFmt())
+		assert.True(t, result["golang"].IsEnabled())
+	})
+
+	t.Run("should override exclude when user provides a non-empty list", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"golang": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"golang": {Exclude: []string{"example.com/held"}, MaxVersion: "1.24"},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, []string{"example.com/held"}, result["golang"].Exclude)
+		assert.Equal(t, "1.24", result["golang"].MaxVersion)
+	})
+
+	t.Run("should override allow_yanked when user sets it", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"javascript": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"javascript": {AllowYanked: boolPtr(true)},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.True(t, result["javascript"].IsAllowYanked())
+		assert.False(t, defaults["javascript"].IsAllowYanked())
+	})
+
+	t.Run("should override only when user provides a non-empty list", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"golang": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"golang": {Only: []string{"example.com/focused"}},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, []string{"example.com/focused"}, result["golang"].Only)
+	})
+
+	t.Run("should override series when user provides a non-empty value", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"python": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"python": {Series: "3.12"},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, "3.12", result["python"].Series)
+		assert.True(t, result["python"].IsEnabled())
+	})
+
+	t.Run("should override concurrency when user provides a positive value", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"javascript": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"javascript": {Concurrency: 4},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, 4, result["javascript"].Concurrency)
+	})
+
+	t.Run("should override track branches when user provides a non-empty map", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {TrackBranches: map[string]string{"network-module": "stable"}},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, map[string]string{"network-module": "stable"}, result["terraform"].TrackBranches)
+		assert.True(t, result["terraform"].IsEnabled())
+	})
+
+	t.Run("should override image sources when user provides a non-empty map", func(t *testing.T) {
+		// given
+		defaults := map[string]entities.UpdaterConfig{
+			"terraform": {Enabled: boolPtr(true)},
+		}
+		overrides := map[string]entities.UpdaterConfig{
+			"terraform": {ImageSources: map[string]string{"relayer-http": "service-relayer"}},
+		}
+
+		// when
+		result := entities.MergeUpdatersConfig(defaults, overrides)
+
+		// then
+		assert.Equal(t, map[string]string{"relayer-http": "service-relayer"}, result["terraform"].ImageSources)
 	})
 
 	t.Run("should add new updater not present in defaults", func(t *testing.T) {
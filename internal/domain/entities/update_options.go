@@ -1,9 +1,256 @@
 package entities
 
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultCommitType and DefaultCommitScope form the conventional-commit
+	// prefix of the upgrade commits when no commit_type or commit_scope is set.
+	DefaultCommitType  = "chore"
+	DefaultCommitScope = "deps"
+)
+
+// DefaultExcludedDirs lists the directory names scanners skip when no
+// excluded_dirs setting is configured: vendored code, installed packages and
+// downloaded Terraform modules belong to other projects and must never be
+// parsed or rewritten.
+//
+//nolint:gochecknoglobals // read-only default list
+var DefaultExcludedDirs = []string{"vendor", "node_modules", "third_party", ".terraform"}
+
 // UpdateOptions holds runtime options passed to updaters.
 type UpdateOptions struct {
 	DryRun       bool
 	Verbose      bool
 	TargetBranch string
 	AutoComplete bool
+	// Paths scopes the updater to repository subdirectories (e.g. "infra",
+	// "web"). An empty list means the whole repository.
+	Paths []string
+	// TrackBranches maps a dependency source name to the branch whose
+	// current tag should be used as the target version.
+	TrackBranches map[string]string
+	// ImageSources maps a container image name to the repository ("name"
+	// or "org/name") whose tags version it, when the two names differ.
+	ImageSources map[string]string
+	// TagPattern keeps only the dependency tags matching this glob when
+	// resolving the latest version. Empty keeps every tag.
+	TagPattern string
+	// UpgradeLocalComments enables bumping `# ref vX.Y.Z` comments on
+	// local-path Terraform module sources.
+	UpgradeLocalComments bool
+	// Concurrency bounds how many JavaScript paths, or Terraform file
+	// fetches and module sources during tag resolution, the updater
+	// processes at once. Values below 1 use the updater's default:
+	// sequential paths, and 8 concurrent file fetches or tag lookups.
+	Concurrency int
+	// MaxVersions caps upgrades per dependency name to the highest version
+	// approved by the configured version policy. Only the Terraform updater
+	// reads it.
+	MaxVersions map[string]string
+	// AuditFix runs `npm audit fix` to patch vulnerable transitive
+	// dependencies instead of the regular JavaScript upgrade.
+	AuditFix bool
+	// AuditFixForce passes `--force` to `npm audit fix`.
+	AuditFixForce bool
+	// MaxVersion caps the language version (e.g. the Go directive) the
+	// updater targets. Unlike MaxVersions it applies to the toolchain, not
+	// to individual dependencies.
+	MaxVersion string
+	// ExcludeModules lists dependencies held at their current version
+	// while everything else is upgraded.
+	ExcludeModules []string
+	// OnlyModules, when set, restricts the upgrade to these dependencies,
+	// each moved to its latest version; nothing else is upgraded.
+	OnlyModules []string
+	// CIFiles lists glob patterns of CI files whose Go version fields are
+	// bumped alongside the go directive. Empty targets the GitHub Actions
+	// workflows.
+	CIFiles []string
+	// Labels are attached to the pull requests the updater contributes to.
+	Labels []string
+	// Reviewers are asked to review the pull requests the updater
+	// contributes to.
+	Reviewers []string
+	// Assignees are assigned to the pull requests the updater contributes to.
+	Assignees []string
+	// DockerfileImages lists the image names whose tags are bumped with the
+	// runtime version. Empty targets the official image only.
+	DockerfileImages []string
+	// DirectOnly upgrades only direct dependencies, leaving indirect ones
+	// to minimal version selection.
+	DirectOnly bool
+	// BumpLowerBounds raises `>=` lower bounds when rewriting Python
+	// constraints; by default only caret and tilde bounds move.
+	BumpLowerBounds bool
+	// ExcludedDirs lists directory names skipped at any depth while
+	// scanning. Nil means DefaultExcludedDirs; an empty, non-nil list
+	// disables the exclusion.
+	ExcludedDirs []string
+	// RunFmt reformats Go sources with gofmt and goimports after upgrading.
+	RunFmt bool
+	// UpgradeRegistryModules advances Terraform registry module version
+	// constraints to the latest version they allow.
+	UpgradeRegistryModules bool
+	// IgnoreMajor restricts upgrades to minor and patch releases: candidate
+	// versions whose major exceeds the current one are skipped.
+	IgnoreMajor bool
+	// RefreshLockfile regenerates the lockfile even when no version moved,
+	// so it picks up registry metadata such as integrity hashes.
+	RefreshLockfile bool
+	// UpdateRanges moves the declared JavaScript dependency ranges to the
+	// latest releases (e.g. with `pnpm up --latest`) instead of updating
+	// within them.
+	UpdateRanges bool
+	// AllowYanked lets the latest-version resolution pick retracted Go
+	// module versions and deprecated npm releases, skipped by default.
+	AllowYanked bool
+	// SupersedeStale closes the updater's older open PR for the same
+	// dependency, and deletes its branch, after opening a newer one.
+	SupersedeStale bool
+	// FreezeMode selects how requirements.txt is rewritten after a Python
+	// upgrade: FreezeModeFull (also when empty) or FreezeModeTopLevel.
+	FreezeMode string
+	// NodeChannel selects the Node.js release line the JavaScript updater
+	// targets: NodeChannelLTS (also when empty) or NodeChannelCurrent.
+	NodeChannel string
+	// VersionConflict decides the current runtime version when version
+	// declarations disagree; see ResolveCurrentVersion.
+	VersionConflict string
+	// Series pins the language version to a release series (e.g. "3.12"
+	// for the Python updater): the latest patch of that series is targeted
+	// instead of the newest release. Empty means the newest release.
+	Series string
+	// PRFooter replaces the attribution footer of PR descriptions; see
+	// ApplyPRFooter. Nil keeps the default footer.
+	PRFooter *string
+	// CommitAuthor overrides the git identity of the upgrade commits.
+	CommitAuthor CommitAuthor
+	// Signing, when it has a key, makes the upgrade scripts sign their commits.
+	Signing Signing
+	// ChangelogConflict selects what happens when CHANGELOG.md changed
+	// between reading it and pushing the update: ChangelogConflictRetry
+	// (also when empty) or ChangelogConflictSkip.
+	ChangelogConflict string
+	// Changelog locates the changelog file and the subsection of its
+	// unreleased section the entries go under.
+	Changelog ChangelogSettings
+	// PRTitleTemplate and PRBodyTemplate replace the generated PR title and
+	// description; see RenderPRText. Empty keeps the generated text.
+	PRTitleTemplate string
+	PRBodyTemplate  string
+	// CommitType and CommitScope form the conventional-commit prefix of the
+	// upgrade commit messages; see CommitPrefix.
+	CommitType  string
+	CommitScope string
+}
+
+// CommitPrefix returns the conventional-commit prefix of the upgrade commit
+// messages, e.g. "chore(deps)" or "build(deps)".
+func (o UpdateOptions) CommitPrefix() string {
+	return FormatCommitPrefix(o.CommitType, o.CommitScope)
+}
+
+// FormatCommitPrefix formats a conventional-commit "type(scope)" prefix,
+// using DefaultCommitType and DefaultCommitScope for empty values.
+func FormatCommitPrefix(commitType, scope string) string {
+	if commitType == "" {
+		commitType = DefaultCommitType
+	}
+	if scope == "" {
+		scope = DefaultCommitScope
+	}
+	return commitType + "(" + scope + ")"
+}
+
+// WithCommitPrefix swaps the default "chore(deps)" prefix of an upgrade
+// commit message for prefix. Other messages are returned unchanged.
+func WithCommitPrefix(message, prefix string) string {
+	rest, found := strings.CutPrefix(message, FormatCommitPrefix("", "")+":")
+	if !found || prefix == "" {
+		return message
+	}
+	return prefix + ":" + rest
+}
+
+// RenderPRText returns the PR title and description rendered from the
+// configured templates, keeping the generated title and description for
+// the templates that are not set.
+func (o UpdateOptions) RenderPRText(data PRTemplateData, title, description string) (string, string) {
+	return RenderPRTemplate(o.PRTitleTemplate, data, title), RenderPRTemplate(o.PRBodyTemplate, data, description)
+}
+
+// IsPathInScope reports whether a repository-relative file path falls under
+// one of the configured Paths. It always returns true when Paths is empty.
+func (o UpdateOptions) IsPathInScope(filePath string) bool {
+	if len(o.Paths) == 0 {
+		return true
+	}
+
+	normalized := normalizeScopePath(filePath)
+	for _, scope := range o.Paths {
+		prefix := normalizeScopePath(scope)
+		if prefix == "." || normalized == prefix || strings.HasPrefix(normalized, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPathExcluded reports whether a repository-relative file path lies inside
+// one of the excluded directories (ExcludedDirs, or DefaultExcludedDirs when
+// unset), matched against every directory segment of the path.
+func (o UpdateOptions) IsPathExcluded(filePath string) bool {
+	excluded := o.ExcludedDirs
+	if excluded == nil {
+		excluded = DefaultExcludedDirs
+	}
+	if len(excluded) == 0 {
+		return false
+	}
+
+	segments := strings.Split(normalizeScopePath(filePath), "/")
+	for _, segment := range segments[:len(segments)-1] {
+		for _, dir := range excluded {
+			if segment == strings.Trim(strings.TrimSpace(dir), "/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsMajorBumpIgnored reports whether candidate must be skipped because
+// IgnoreMajor is set and its major version exceeds the major of current.
+// Versions may carry a "v" prefix ("v1.4.0", "1.25", "20"); when either
+// major cannot be parsed the candidate is allowed.
+func (o UpdateOptions) IsMajorBumpIgnored(current, candidate string) bool {
+	if !o.IgnoreMajor {
+		return false
+	}
+	currentMajor, currentOK := parseMajor(current)
+	candidateMajor, candidateOK := parseMajor(candidate)
+	return currentOK && candidateOK && candidateMajor > currentMajor
+}
+
+// parseMajor returns the leading numeric component of a version string.
+func parseMajor(version string) (int, bool) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(v)
+	}
+	major, err := strconv.Atoi(v[:end])
+	return major, err == nil
+}
+
+// normalizeScopePath converts a path to a clean, slash-separated form
+// without leading "/" or "./" so prefixes compare consistently regardless
+// of how the provider or the user wrote them.
+func normalizeScopePath(p string) string {
+	p = strings.ReplaceAll(strings.TrimSpace(p), "\\", "/")
+	return path.Clean(strings.TrimLeft(p, "/"))
 }
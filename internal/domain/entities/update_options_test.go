@@ -0,0 +1,250 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+func TestUpdateOptionsIsPathInScope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should match every path when no paths are configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{}
+
+		// when
+		inScope := opts.IsPathInScope("package.json")
+
+		// then
+		assert.True(t, inScope)
+	})
+
+	t.Run("should match files nested under a configured path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{Paths: []string{"infra"}}
+
+		// when
+		inScope := opts.IsPathInScope("infra/modules/net/main.tf")
+
+		// then
+		assert.True(t, inScope)
+	})
+
+	t.Run("should not match files outside the configured paths", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{Paths: []string{"web"}}
+
+		// when
+		inScope := opts.IsPathInScope("package.json")
+
+		// then
+		assert.False(t, inScope)
+	})
+
+	t.Run("should not match sibling directories sharing a prefix", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{Paths: []string{"web"}}
+
+		// when
+		inScope := opts.IsPathInScope("webhooks/package.json")
+
+		// then
+		assert.False(t, inScope)
+	})
+
+	t.Run("should normalize leading slashes and trailing separators", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{Paths: []string{"./web/"}}
+
+		// when
+		inScope := opts.IsPathInScope("/web/package.json")
+
+		// then
+		assert.True(t, inScope)
+	})
+}
+
+func TestUpdateOptionsIsPathExcluded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should exclude files under the default directories at any depth", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{}
+
+		// when
+		excluded := []bool{
+			opts.IsPathExcluded("vendor/github.com/org/mod/go.mod"),
+			opts.IsPathExcluded("web/node_modules/pkg/package.json"),
+			opts.IsPathExcluded("third_party/lib/Dockerfile"),
+			opts.IsPathExcluded("infra/.terraform/modules/net/main.tf"),
+		}
+
+		// then
+		assert.Equal(t, []bool{true, true, true, true}, excluded)
+	})
+
+	t.Run("should not exclude files whose own name matches a directory", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{}
+
+		// when
+		excluded := opts.IsPathExcluded("scripts/vendor")
+
+		// then
+		assert.False(t, excluded)
+	})
+
+	t.Run("should use the configured directories instead of the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{ExcludedDirs: []string{"generated/"}}
+
+		// when
+		generated := opts.IsPathExcluded("api/generated/main.tf")
+		vendored := opts.IsPathExcluded("vendor/main.tf")
+
+		// then
+		assert.True(t, generated)
+		assert.False(t, vendored)
+	})
+
+	t.Run("should exclude nothing when the list is empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{ExcludedDirs: []string{}}
+
+		// when
+		excluded := opts.IsPathExcluded("node_modules/pkg/package.json")
+
+		// then
+		assert.False(t, excluded)
+	})
+}
+
+func TestUpdateOptionsIsMajorBumpIgnored(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should skip a v2.0.0 candidate for a v1.x current when IgnoreMajor is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{IgnoreMajor: true}
+
+		// when
+		ignored := opts.IsMajorBumpIgnored("v1.4.2", "v2.0.0")
+
+		// then
+		assert.True(t, ignored)
+	})
+
+	t.Run("should allow minor and patch candidates when IgnoreMajor is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{IgnoreMajor: true}
+
+		// when
+		allowed := []bool{
+			opts.IsMajorBumpIgnored("v1.4.2", "v1.9.0"),
+			opts.IsMajorBumpIgnored("1.24", "1.25.7"),
+			opts.IsMajorBumpIgnored("lts/iron", "22.12.0"),
+		}
+
+		// then
+		assert.Equal(t, []bool{false, false, false}, allowed)
+	})
+
+	t.Run("should allow a v2.0.0 candidate when IgnoreMajor is not set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{}
+
+		// when
+		ignored := opts.IsMajorBumpIgnored("v1.4.2", "v2.0.0")
+
+		// then
+		assert.False(t, ignored)
+	})
+}
+
+func TestUpdateOptionsCommitPrefix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should default to chore(deps) when no type or scope is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{}
+
+		// when
+		prefix := opts.CommitPrefix()
+
+		// then
+		assert.Equal(t, "chore(deps)", prefix)
+	})
+
+	t.Run("should use the configured type and scope", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		opts := entities.UpdateOptions{CommitType: "build", CommitScope: "go"}
+
+		// when
+		prefix := opts.CommitPrefix()
+
+		// then
+		assert.Equal(t, "build(go)", prefix)
+	})
+}
+
+func TestWithCommitPrefix(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should replace the default prefix of an upgrade commit message", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		message := "chore(deps): updated Python dependencies"
+
+		// when
+		result := entities.WithCommitPrefix(message, "build(deps)")
+
+		// then
+		assert.Equal(t, "build(deps): updated Python dependencies", result)
+	})
+
+	t.Run("should keep messages with another prefix unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		message := "fix(deps): patched vulnerable transitive npm dependencies"
+
+		// when
+		result := entities.WithCommitPrefix(message, "build(deps)")
+
+		// then
+		assert.Equal(t, message, result)
+	})
+}
@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Values of the version_conflict updater setting, deciding the current
+// runtime version when the files declaring it disagree.
+const (
+	// VersionConflictVersionFileWins trusts the version file (e.g. .nvmrc)
+	// over every other declaration. It is the default.
+	VersionConflictVersionFileWins = "version-file-wins"
+	// VersionConflictHighest takes the highest declared version.
+	VersionConflictHighest = "highest"
+	// VersionConflictLowest takes the lowest declared version.
+	VersionConflictLowest = "lowest"
+	// VersionConflictWarnAndSkip leaves the runtime version alone when the
+	// declarations disagree, still updating the dependencies.
+	VersionConflictWarnAndSkip = "warn-and-skip"
+)
+
+// VersionSource is a runtime version declared by one file of a repository,
+// e.g. {File: ".nvmrc", Version: "18"}.
+type VersionSource struct {
+	File    string
+	Version string
+}
+
+// ResolveCurrentVersion picks the current runtime version among sources
+// according to policy, and reports whether the sources disagree. Sources
+// are ordered by precedence, the version file first; those without a
+// version are ignored. Two versions agree when they match on the
+// components both declare, so "20" agrees with "20.11.0". With
+// VersionConflictWarnAndSkip, a disagreement yields "".
+func ResolveCurrentVersion(policy string, sources []VersionSource) (string, bool) {
+	var versions []string
+	for _, source := range sources {
+		if source.Version != "" {
+			versions = append(versions, source.Version)
+		}
+	}
+	if len(versions) == 0 {
+		return "", false
+	}
+
+	conflict := false
+	for _, version := range versions[1:] {
+		if !versionsAgree(versions[0], version) {
+			conflict = true
+			break
+		}
+	}
+	if !conflict {
+		return versions[0], false
+	}
+
+	switch policy {
+	case VersionConflictHighest, VersionConflictLowest:
+		selected := versions[0]
+		for _, version := range versions[1:] {
+			cmp := compareDottedVersions(version, selected)
+			if (policy == VersionConflictHighest && cmp > 0) || (policy == VersionConflictLowest && cmp < 0) {
+				selected = version
+			}
+		}
+		return selected, true
+	case VersionConflictWarnAndSkip:
+		return "", true
+	default:
+		return versions[0], true
+	}
+}
+
+// versionsAgree reports whether two dotted versions match on every
+// component they both declare.
+func versionsAgree(a, b string) bool {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] != bParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compareDottedVersions compares two dotted release versions numerically,
+// padding the shorter one with zeros. It returns -1, 0 or +1.
+func compareDottedVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		switch {
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		}
+	}
+	return 0
+}
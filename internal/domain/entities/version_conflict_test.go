@@ -0,0 +1,114 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+func TestResolveCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	disagreeing := []entities.VersionSource{
+		{File: ".nvmrc", Version: "18"},
+		{File: "Dockerfile", Version: "20.11.0"},
+	}
+
+	t.Run("should keep the version file when the policy is unset", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		version, conflict := entities.ResolveCurrentVersion("", disagreeing)
+
+		// then
+		assert.Equal(t, "18", version)
+		assert.True(t, conflict)
+	})
+
+	t.Run("should keep the version file with version-file-wins", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		version, conflict := entities.ResolveCurrentVersion(entities.VersionConflictVersionFileWins, disagreeing)
+
+		// then
+		assert.Equal(t, "18", version)
+		assert.True(t, conflict)
+	})
+
+	t.Run("should take the highest declared version with highest", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		version, _ := entities.ResolveCurrentVersion(entities.VersionConflictHighest, disagreeing)
+
+		// then
+		assert.Equal(t, "20.11.0", version)
+	})
+
+	t.Run("should take the lowest declared version with lowest", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		sources := []entities.VersionSource{
+			{File: ".python-version", Version: "3.12.4"},
+			{File: "requires-python", Version: "3.10"},
+			{File: "Dockerfile", Version: "3.11"},
+		}
+
+		// when
+		version, _ := entities.ResolveCurrentVersion(entities.VersionConflictLowest, sources)
+
+		// then
+		assert.Equal(t, "3.10", version)
+	})
+
+	t.Run("should return no version with warn-and-skip", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		version, conflict := entities.ResolveCurrentVersion(entities.VersionConflictWarnAndSkip, disagreeing)
+
+		// then
+		assert.Empty(t, version)
+		assert.True(t, conflict)
+	})
+
+	t.Run("should treat a shorter version matching the leading components as agreeing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		sources := []entities.VersionSource{
+			{File: ".nvmrc", Version: "20.11.0"},
+			{File: "Dockerfile", Version: "20"},
+		}
+
+		// when
+		version, conflict := entities.ResolveCurrentVersion(entities.VersionConflictWarnAndSkip, sources)
+
+		// then
+		assert.Equal(t, "20.11.0", version)
+		assert.False(t, conflict)
+	})
+
+	t.Run("should ignore sources without a version", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		sources := []entities.VersionSource{
+			{File: ".nvmrc", Version: "18"},
+			{File: "Dockerfile"},
+		}
+
+		// when
+		version, conflict := entities.ResolveCurrentVersion(entities.VersionConflictHighest, sources)
+
+		// then
+		assert.Equal(t, "18", version)
+		assert.False(t, conflict)
+	})
+}
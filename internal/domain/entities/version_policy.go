@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VersionPolicy is the schema for an approved-versions policy file, usually
+// maintained by a security or compliance team. It maps a dependency name
+// (Terraform module repository or container image) to the highest version
+// that is approved for use; Terraform upgrades are capped to that version.
+type VersionPolicy struct {
+	MaxVersions map[string]string `yaml:"max_versions"`
+}
+
+// LoadVersionPolicy reads and parses the policy file at path.
+func LoadVersionPolicy(path string) (*VersionPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version policy %q: %w", path, err)
+	}
+
+	var policy VersionPolicy
+	if unmarshalErr := yaml.Unmarshal(data, &policy); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse version policy %q: %w", path, unmarshalErr)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,52 @@
+//go:build unit
+
+package entities_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+func TestLoadVersionPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should parse the approved maximum versions", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		content := `max_versions:
+  network-module: v2.3.0
+  api: 1.4.0
+`
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		// when
+		policy, err := entities.LoadVersionPolicy(path)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"network-module": "v2.3.0", "api": "1.4.0"}, policy.MaxVersions)
+	})
+
+	t.Run("should return error for invalid YAML", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("max_versions: [}"), 0o600))
+
+		// when
+		_, err := entities.LoadVersionPolicy(path)
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse version policy")
+	})
+}
@@ -40,4 +40,12 @@ type LocalUpdateResult struct {
 	CommitMessage string
 	PRTitle       string
 	PRDescription string
+	// Changes lists the dependency versions the update moved, for the
+	// changes report. Updaters that cannot enumerate them leave it empty.
+	Changes []entities.DependencyChange
+	// ChangelogEntries are the CHANGELOG.md lines (e.g. "- changed ...")
+	// describing the update. Updaters do not write CHANGELOG.md themselves:
+	// the pipeline inserts the entries of every contributing updater in a
+	// single InsertChangelogEntry call, so a combined PR gets one block.
+	ChangelogEntries []string
 }
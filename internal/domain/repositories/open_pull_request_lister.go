@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// OpenPullRequestLister is an optional interface that ProviderRepository
+// implementations can satisfy to list the open pull requests of a
+// repository. The gitforge GitHub and Azure DevOps providers and autoupdate's
+// GitLab provider implement it; autoupdate uses it to find the pull requests it opened on earlier runs.
+//
+// Providers that do NOT implement OpenPullRequestLister make those lookups
+// fail, and the features relying on them are skipped with a warning.
+type OpenPullRequestLister interface {
+	// ListOpenPullRequests returns every open pull request of the
+	// repository, whoever authored it.
+	ListOpenPullRequests(ctx context.Context, repo entities.Repository) ([]entities.PullRequestDetail, error)
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// PullRequestAssigner is an optional interface that ProviderRepository
+// implementations can satisfy to assign users to an existing pull request.
+// autoupdate's GitHub and GitLab providers implement it; the run command
+// uses it to route the review of an update PR.
+//
+// Providers that do NOT implement PullRequestAssigner open the pull request
+// without assignees.
+type PullRequestAssigner interface {
+	// AddPullRequestAssignees assigns the given users to the pull request.
+	AddPullRequestAssignees(
+		ctx context.Context,
+		repo entities.Repository,
+		pr entities.PullRequest,
+		assignees []string,
+	) error
+}
+
+// LastAuthorResolver is an optional interface that ProviderRepository
+// implementations can satisfy to look up who last changed a file, like
+// `git log -1 -- <path>`. The run command uses it when
+// `assign_last_committer` is enabled.
+type LastAuthorResolver interface {
+	// LastAuthor returns the username of the author of the most recent
+	// commit touching path on the default branch, or "" when the path has
+	// no history or the author cannot be mapped to a user.
+	LastAuthor(ctx context.Context, repo entities.Repository, path string) (string, error)
+}
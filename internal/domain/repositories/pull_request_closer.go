@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// PullRequestCloser is an optional interface that ProviderRepository
+// implementations can satisfy to close a pull request without merging it
+// and delete its source branch. Updaters use it to supersede their own
+// stale PRs when a newer version of the same dependency is proposed.
+// autoupdate's GitHub and GitLab providers implement it.
+//
+// Providers that do NOT implement PullRequestCloser leave stale PRs open.
+type PullRequestCloser interface {
+	// ClosePullRequest closes (or abandons) the pull request with the
+	// given ID without merging it.
+	ClosePullRequest(ctx context.Context, repo entities.Repository, prID int) error
+	// DeleteBranch deletes the branch, given without `refs/heads/`.
+	DeleteBranch(ctx context.Context, repo entities.Repository, branch string) error
+}
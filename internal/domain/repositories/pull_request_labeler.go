@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// PullRequestLabeler is an optional interface that ProviderRepository
+// implementations can satisfy to attach labels to an existing pull request.
+// autoupdate's GitHub and GitLab providers implement it; the run command uses
+// it to apply the per-updater labels and the size_labels bucket of the pull
+// request.
+//
+// Providers that do NOT implement PullRequestLabeler open the pull request
+// without labels.
+type PullRequestLabeler interface {
+	// AddPullRequestLabels attaches the given labels to the pull request,
+	// creating them on the repository when the provider requires it.
+	AddPullRequestLabels(
+		ctx context.Context,
+		repo entities.Repository,
+		pr entities.PullRequest,
+		labels []string,
+	) error
+}
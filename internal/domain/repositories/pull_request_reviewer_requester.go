@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// PullRequestReviewerRequester is an optional interface that
+// ProviderRepository implementations can satisfy to request reviews on an
+// existing pull request. Implementations resolve the usernames to the ids
+// their API expects (e.g. GitLab reviewer_ids). autoupdate's GitHub and
+// GitLab providers implement it.
+//
+// Providers that do NOT implement PullRequestReviewerRequester open the
+// pull request without reviewers.
+type PullRequestReviewerRequester interface {
+	// RequestPullRequestReviewers requests a review from the given users
+	// or teams.
+	RequestPullRequestReviewers(
+		ctx context.Context,
+		repo entities.Repository,
+		pr entities.PullRequest,
+		reviewers []string,
+	) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+// SelfHostedProvider is an optional interface that ProviderRepository
+// implementations can satisfy to open pull requests on a self-hosted
+// instance (e.g. a GitLab on a custom domain). Local mode uses it for
+// remotes on hosts gitforge does not recognize. autoupdate's GitHub and
+// GitLab providers implement it.
+//
+// Providers that do NOT implement SelfHostedProvider make local mode fail
+// on such remotes outside dry runs.
+type SelfHostedProvider interface {
+	// ForHost returns a copy of the provider whose pull request operations
+	// call the API of the instance at host instead of the public one.
+	ForHost(host string) (ProviderRepository, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// UpdatePlanner is an optional interface that UpdaterRepository implementations
+// can satisfy when they can work out their upgrades through the provider API
+// alone. In dry-run mode the RunCommand asks them for the planned changes,
+// which feed the dry-run report, instead of only logging that they would run.
+type UpdatePlanner interface {
+	// PlanUpdates returns the dependency versions the updater would move in
+	// the repository, without writing anything. An up-to-date repository
+	// yields an empty list.
+	PlanUpdates(
+		ctx context.Context,
+		provider ProviderRepository,
+		repo entities.Repository,
+		opts entities.UpdateOptions,
+	) ([]entities.DependencyChange, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+// UpdaterDescriber is an optional interface that UpdaterRepository implementations
+// can satisfy to describe themselves in the `autoupdate updaters` listing.
+type UpdaterDescriber interface {
+	// Description returns a one-line summary of what the updater upgrades.
+	Description() string
+
+	// DetectionFiles returns the filenames or globs whose presence makes
+	// Detect report the ecosystem as used by a repository.
+	DetectionFiles() []string
+}
@@ -20,6 +20,12 @@ func RegisterProviders(container *dig.Container) error {
 	if err := container.Provide(NewVersionController); err != nil {
 		return err
 	}
+	if err := container.Provide(NewUpdatersController); err != nil {
+		return err
+	}
+	if err := container.Provide(NewPlanController); err != nil {
+		return err
+	}
 	if err := container.Provide(NewControllers); err != nil {
 		return err
 	}
@@ -33,11 +39,15 @@ func NewControllers(
 	localController *LocalController,
 	selfUpdateController *SelfUpdateController,
 	versionController *VersionController,
+	updatersController *UpdatersController,
+	planController *PlanController,
 ) *[]entities.Controller {
 	return &[]entities.Controller{
 		runController,
 		localController,
 		selfUpdateController,
 		versionController,
+		updatersController,
+		planController,
 	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/rios0rios0/autoupdate/internal/domain/commands"
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
 )
 
 // LocalController handles the root command with a path argument (standalone local mode).
@@ -30,6 +31,26 @@ Detects the project type, upgrades dependencies, and creates a pull request.`,
 	}
 }
 
+// AddFlags registers local-mode specific flags on the given command.
+func (it *LocalController) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all-ecosystems", false,
+		"Upgrade every detected ecosystem (one branch and PR each) instead of only the first one",
+	)
+	cmd.Flags().String("remote", gitlocal.DefaultRemote,
+		"Git remote to detect the provider from and push the upgrade branch to",
+	)
+	cmd.Flags().String("base-branch", "",
+		"Branch the pull request targets and the upgrade starts from, which must exist on the remote "+
+			"(default: the checked-out branch)",
+	)
+	cmd.Flags().Int("depth", 0,
+		"Also upgrade projects in subdirectories down to this depth, on one branch and PR (0 = root only)",
+	)
+	cmd.Flags().String("path-filter", "",
+		"With --depth, only consider subdirectories matching this glob (e.g. 'services/*')",
+	)
+}
+
 // Execute runs the local update mode.
 func (it *LocalController) Execute(cmd *cobra.Command, args []string) {
 	ctx := context.Background()
@@ -38,6 +59,11 @@ func (it *LocalController) Execute(cmd *cobra.Command, args []string) {
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	token, _ := cmd.Flags().GetString("token")
+	allEcosystems, _ := cmd.Flags().GetBool("all-ecosystems")
+	remote, _ := cmd.Flags().GetString("remote")
+	baseBranch, _ := cmd.Flags().GetString("base-branch")
+	depth, _ := cmd.Flags().GetInt("depth")
+	pathFilter, _ := cmd.Flags().GetString("path-filter")
 
 	repoDir := "."
 	if len(args) > 0 {
@@ -51,11 +77,16 @@ func (it *LocalController) Execute(cmd *cobra.Command, args []string) {
 	}
 
 	if err := it.command.Execute(ctx, commands.LocalOptions{
-		RepoDir:  repoDir,
-		DryRun:   dryRun,
-		Verbose:  verbose,
-		Token:    token,
-		Settings: settings,
+		RepoDir:       repoDir,
+		DryRun:        dryRun,
+		Verbose:       verbose,
+		Token:         token,
+		Settings:      settings,
+		AllEcosystems: allEcosystems,
+		Remote:        remote,
+		BaseBranch:    baseBranch,
+		Depth:         depth,
+		PathFilter:    pathFilter,
 	}); err != nil {
 		logger.Errorf("Local update failed: %v", err)
 	}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"context"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/commands"
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// PlanController handles the "plan" subcommand.
+type PlanController struct {
+	command commands.Plan
+}
+
+// NewPlanController creates a new PlanController.
+func NewPlanController(command commands.Plan) *PlanController {
+	return &PlanController{command: command}
+}
+
+// GetBind returns the Cobra command metadata for the plan controller.
+func (it *PlanController) GetBind() entities.ControllerBind {
+	return entities.ControllerBind{
+		Use:   "plan",
+		Short: "List outdated dependencies without changing anything",
+		Long: `Discover repositories and list the dependency upgrades the
+updaters would make, as a table on stdout.
+
+Nothing is cloned, pushed or opened: only the updaters that can work
+out their upgrades through the provider API (terraform, dockerfile and
+pipeline) contribute rows.`,
+	}
+}
+
+// Execute prints the planned upgrades.
+func (it *PlanController) Execute(cmd *cobra.Command, _ []string) {
+	configPath, _ := cmd.Flags().GetString("config")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	providerFilter, _ := cmd.Flags().GetString("provider")
+	orgOverride, _ := cmd.Flags().GetString("org")
+	updaterFilter, _ := cmd.Flags().GetString("updater")
+	includeRepos, _ := cmd.Flags().GetStringArray("include-repo")
+	excludeRepos, _ := cmd.Flags().GetStringArray("exclude-repo")
+	workers, _ := cmd.Flags().GetInt("workers")
+
+	settings, err := findReadAndValidateConfig(configPath)
+	if err != nil {
+		logger.Errorf("failed to load config: %v", err)
+		return
+	}
+
+	if planErr := it.command.Execute(context.Background(), settings, commands.PlanOptions{
+		Verbose:      verbose,
+		ProviderName: providerFilter,
+		OrgOverride:  orgOverride,
+		UpdaterName:  updaterFilter,
+		IncludeRepos: includeRepos,
+		ExcludeRepos: excludeRepos,
+		Workers:      workers,
+	}, cmd.OutOrStdout()); planErr != nil {
+		logger.Errorf("Plan failed: %v", planErr)
+	}
+}
+
+// AddFlags adds the plan-specific flags to the given Cobra command.
+func (it *PlanController) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().String("provider", "", "Only process this provider (github, gitlab, azuredevops)")
+	cmd.Flags().String("org", "", "Only process this organization/group")
+	cmd.Flags().String("updater", "", "Only plan this updater (terraform, pipeline, dockerfile)")
+	cmd.Flags().StringArray("include-repo", nil,
+		"Only process repositories whose name matches this glob (repeatable)",
+	)
+	cmd.Flags().StringArray("exclude-repo", nil,
+		"Skip repositories whose name matches this glob (repeatable, wins over --include-repo)",
+	)
+	cmd.Flags().Int("workers", commands.DefaultWorkers,
+		"Number of repositories of an organization processed concurrently",
+	)
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	logger "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -45,6 +47,19 @@ func (it *RunController) Execute(cmd *cobra.Command, _ []string) {
 	providerFilter, _ := cmd.Flags().GetString("provider")
 	orgOverride, _ := cmd.Flags().GetString("org")
 	updaterFilter, _ := cmd.Flags().GetString("updater")
+	annotations, _ := cmd.Flags().GetBool("annotations")
+	strict, _ := cmd.Flags().GetBool("strict")
+	changesReport, _ := cmd.Flags().GetString("changes-report")
+	canary, _ := cmd.Flags().GetString("canary")
+	canaryProceed, _ := cmd.Flags().GetBool("canary-proceed")
+	workers, _ := cmd.Flags().GetInt("workers")
+	report, _ := cmd.Flags().GetString("report")
+	metrics, _ := cmd.Flags().GetString("metrics")
+	prURLsOut, _ := cmd.Flags().GetString("pr-urls-out")
+	includeRepos, _ := cmd.Flags().GetStringArray("include-repo")
+	excludeRepos, _ := cmd.Flags().GetStringArray("exclude-repo")
+	maxRepos, _ := cmd.Flags().GetInt("max-repos")
+	cursorFile, _ := cmd.Flags().GetString("cursor-file")
 
 	settings, err := findReadAndValidateConfig(configPath)
 	if err != nil {
@@ -52,6 +67,10 @@ func (it *RunController) Execute(cmd *cobra.Command, _ []string) {
 		return
 	}
 
+	if changesReport == "" {
+		changesReport = settings.ChangesReportPath
+	}
+
 	logger.Info("Starting autoupdate run...")
 
 	if runErr := it.command.Execute(ctx, settings, commands.RunOptions{
@@ -60,7 +79,25 @@ func (it *RunController) Execute(cmd *cobra.Command, _ []string) {
 		ProviderName: providerFilter,
 		OrgOverride:  orgOverride,
 		UpdaterName:  updaterFilter,
+		IncludeRepos: includeRepos,
+		ExcludeRepos: excludeRepos,
+		Annotations:  annotations,
+		Strict:       strict,
+		// Enabled automatically inside GitHub Actions.
+		JobSummaryPath:    os.Getenv(commands.JobSummaryEnv),
+		ChangesReportPath: changesReport,
+		Canary:            canary,
+		CanaryProceed:     canaryProceed,
+		Workers:           workers,
+		ReportPath:        report,
+		MetricsPath:       metrics,
+		PRURLsPath:        prURLsOut,
+		MaxRepos:          maxRepos,
+		CursorPath:        cursorFile,
 	}); runErr != nil {
+		if strict || errors.Is(runErr, commands.ErrCanaryFailed) {
+			logger.Fatalf("Run failed: %v", runErr)
+		}
 		logger.Errorf("Run failed: %v", runErr)
 	}
 }
@@ -72,4 +109,43 @@ func (it *RunController) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().String("updater", "",
 		"Only run this updater (terraform, golang, python, javascript, pipeline, dockerfile)",
 	)
+	cmd.Flags().StringArray("include-repo", nil,
+		"Only process repositories whose name matches this glob (repeatable)",
+	)
+	cmd.Flags().StringArray("exclude-repo", nil,
+		"Skip repositories whose name matches this glob (repeatable, wins over --include-repo)",
+	)
+	cmd.Flags().Bool("annotations", false,
+		"Also emit GitHub Actions annotations (::notice/::warning) for created PRs and errors",
+	)
+	cmd.Flags().Bool("strict", false,
+		"Fail the run when a configured organization yields zero repositories",
+	)
+	cmd.Flags().String("changes-report", "",
+		"Write a JSON report of the dependency changes to this path (overrides changes_report)",
+	)
+	cmd.Flags().String("canary", "",
+		"Process only this repository (org/name) first and abort the run if it fails",
+	)
+	cmd.Flags().Bool("canary-proceed", false,
+		"Continue with the full run once the --canary repository succeeds",
+	)
+	cmd.Flags().Int("workers", commands.DefaultWorkers,
+		"Number of repositories of an organization processed concurrently",
+	)
+	cmd.Flags().String("report", "",
+		"With --dry-run, write a JSON array of the planned upgrades to this path",
+	)
+	cmd.Flags().String("metrics", "",
+		"Write the run counts to this path in the Prometheus textfile format",
+	)
+	cmd.Flags().Int("max-repos", 0,
+		"Process at most this many repositories of each organization per run (0 means no limit)",
+	)
+	cmd.Flags().String("cursor-file", ".autoupdate-cursor.json",
+		"File remembering where a --max-repos run stopped, so the next run continues from there",
+	)
+	cmd.Flags().String("pr-urls-out", "",
+		"Write the URL of every created PR to this path, one per line (a JSON array for .json)",
+	)
 }
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"github.com/rios0rios0/autoupdate/internal/domain/commands"
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	logger "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type UpdatersController struct {
+	command commands.Updaters
+}
+
+func NewUpdatersController(command commands.Updaters) *UpdatersController {
+	return &UpdatersController{command: command}
+}
+
+func (it *UpdatersController) GetBind() entities.ControllerBind {
+	return entities.ControllerBind{
+		Use:   "updaters",
+		Short: "List the supported updaters",
+		Long:  "List every updater registered in this build, the files it looks for, and what it upgrades.",
+	}
+}
+
+func (it *UpdatersController) Execute(command *cobra.Command, _ []string) {
+	if err := it.command.Execute(command.OutOrStdout()); err != nil {
+		logger.Errorf("Failed to list updaters: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/rios0rios0/autoupdate/internal/domain/commands"
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	logger "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -18,10 +19,18 @@ func (it *VersionController) GetBind() entities.ControllerBind {
 	return entities.ControllerBind{
 		Use:   "version",
 		Short: "Show autoupdate version",
-		Long:  "Display the version information for autoupdate.",
+		Long:  "Display the version, git commit and build date of this autoupdate build.",
 	}
 }
 
-func (it *VersionController) Execute(_ *cobra.Command, _ []string) {
-	it.command.Execute()
+func (it *VersionController) Execute(command *cobra.Command, _ []string) {
+	asJSON, _ := command.Flags().GetBool("json")
+	if err := it.command.Execute(command.OutOrStdout(), asJSON); err != nil {
+		logger.Errorf("Failed to print the version: %v", err)
+	}
+}
+
+// AddFlags adds the version-specific flags to the given Cobra command.
+func (it *VersionController) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("json", false, "Print the build metadata as a JSON object")
 }
@@ -4,9 +4,11 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	csRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/csharp"
 	dfRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/dockerfile"
+	fgRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/forge"
 	goRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/golang"
 	jvRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/java"
 	jsRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/javascript"
+	nxRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/nix"
 	plRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/pipeline"
 	pyRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/python"
 	rbRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/ruby"
@@ -28,8 +30,8 @@ func RegisterProviders(container *dig.Container) error {
 		reg.RegisterAdapter(gitlab.NewProvider(""))
 		reg.RegisterAdapter(azuredevops.NewProvider(""))
 		// Register factories for creating token-bound provider instances
-		reg.RegisterFactory("github", github.NewProvider)
-		reg.RegisterFactory("gitlab", gitlab.NewProvider)
+		reg.RegisterFactory("github", fgRepo.NewGitHubProviderRepository)
+		reg.RegisterFactory("gitlab", fgRepo.NewGitLabProviderRepository)
 		reg.RegisterFactory("azuredevops", azuredevops.NewProvider)
 		return reg
 	}); err != nil {
@@ -58,6 +60,7 @@ func RegisterProviders(container *dig.Container) error {
 		reg.Register(csRepo.NewUpdaterRepository())
 		reg.Register(plRepo.NewUpdaterRepository())
 		reg.Register(dfRepo.NewUpdaterRepository())
+		reg.Register(nxRepo.NewUpdaterRepository())
 		return reg
 	}); err != nil {
 		return err
@@ -68,6 +68,16 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return ".NET target framework and NuGet package references"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langCSharp.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository has C# marker files (e.g. *.csproj, *.sln).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -100,7 +110,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		logger.Infof("[csharp] Latest stable .NET SDK version: %s", latestDotnetVersion)
 	}
 
-	vCtx := resolveVersionContext(ctx, provider, repo, latestDotnetVersion)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestDotnetVersion, opts)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -120,7 +130,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		return []entities.PullRequest{}, nil
 	}
 
-	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx)
+	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -155,8 +165,9 @@ func cloneAndUpgrade(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, error) {
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -177,7 +188,9 @@ func cloneAndUpgrade(
 		AuthToken:     provider.AuthToken(),
 		ProviderName:  provider.Name(),
 		ChangelogFile: changelogFile,
+		ChangelogPath: opts.Changelog.FilePath(),
 		DotnetBinary:  dotnetBinary,
+		CommitPrefix:  opts.CommitPrefix(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade: %w", err)
@@ -208,13 +221,16 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GeneratePRDescription(vCtx.LatestVersion, result.DotnetVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, result.DotnetVersionUpdated),
+	)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -236,12 +252,12 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	_ repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[csharp] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
 	// resolveLocalVersionContext handles fetching + comparison
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	vCtx := resolveLocalVersionContext(ctx, repoDir, opts)
 
 	dotnetBinary, binErr := findDotnetBinary()
 	if binErr != nil {
@@ -282,7 +298,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	// Update CHANGELOG locally
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if dotnetVersionUpdated {
 		entry = fmt.Sprintf(
@@ -292,23 +308,27 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = dotnetChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := dotnetCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := dotnetCommitMsgDeps
 	if dotnetVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded .NET SDK to `%s` and updated all NuGet dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
+
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, dotnetVersionUpdated),
+	)
 
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GeneratePRDescription(vCtx.LatestVersion, dotnetVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
@@ -342,7 +362,11 @@ type upgradeParams struct {
 	AuthToken     string
 	ProviderName  string
 	ChangelogFile string
+	ChangelogPath string // changelog path relative to the repository root
 	DotnetBinary  string
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
 }
 
 type upgradeResult struct {
@@ -376,6 +400,7 @@ func resolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestDotnetVersion string,
+	opts entities.UpdateOptions,
 ) *versionContext {
 	needsVersionUpgrade := false
 
@@ -383,7 +408,8 @@ func resolveVersionContext(
 		content, err := provider.GetFileContent(ctx, repo, "global.json")
 		if err == nil {
 			currentVersion := parseGlobalJSON(content)
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestDotnetVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestDotnetVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestDotnetVersion)
 			logger.Infof(
 				"[csharp] Current global.json SDK version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -405,7 +431,7 @@ func resolveVersionContext(
 
 // resolveLocalVersionContext fetches the latest .NET SDK version and compares
 // it against the local global.json to build a versionContext.
-func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
+func resolveLocalVersionContext(ctx context.Context, repoDir string, opts entities.UpdateOptions) *versionContext {
 	fetcher := NewHTTPDotnetVersionFetcher(&http.Client{Timeout: dotnetVersionTimeout})
 	latestDotnetVersion, err := fetcher.FetchLatestVersion(ctx)
 	if err != nil {
@@ -420,7 +446,8 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 		globalJSONContent, readErr := os.ReadFile(filepath.Join(repoDir, "global.json"))
 		if readErr == nil {
 			currentVersion := parseGlobalJSON(string(globalJSONContent))
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestDotnetVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestDotnetVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestDotnetVersion)
 			logger.Infof(
 				"[csharp] Current global.json SDK version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -440,7 +467,7 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 	}
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the .NET upgrade, and writes the modified
 // content to a temp file.
 func prepareChangelog(
@@ -448,14 +475,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[csharp] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[csharp] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -469,7 +497,7 @@ func prepareChangelog(
 		entry = dotnetChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -719,8 +747,8 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update CHANGELOG.md only if the upgrade produced actual changes.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
@@ -733,10 +761,11 @@ func writeCommitAndPush(sb *strings.Builder) {
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$DOTNET_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded .NET SDK to `$DOTNET_VERSION` and updated all NuGet dependencies\"\n",
+		"        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded .NET SDK to `$DOTNET_VERSION` " +
+			"and updated all NuGet dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): updated NuGet dependencies\"\n")
+	sb.WriteString("        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: updated NuGet dependencies\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
@@ -759,8 +788,11 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 	if params.DotnetVersion != "" {
 		env = append(env, "DOTNET_VERSION="+params.DotnetVersion)
 	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
@@ -819,6 +851,6 @@ func GeneratePRDescription(dotnetVersion string, dotnetVersionUpdated bool) stri
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes in `*.csproj` files\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
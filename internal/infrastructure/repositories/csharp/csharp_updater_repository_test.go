@@ -343,3 +343,22 @@ func TestWriteGitAuth(t *testing.T) {
 		assert.Contains(t, result, "gitlab.com")
 	})
 }
+
+func TestBuildEnv(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should pass the configured commit prefix to the script", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		params := csUpdater.UpgradeParamsExported{CommitPrefix: "build(deps)"}
+
+		// when
+		env := csUpdater.BuildEnv(params, "/tmp/repo")
+		script := csUpdater.BuildUpgradeScript(params, "/tmp/repo")
+
+		// then
+		assert.Contains(t, env, "COMMIT_PREFIX=build(deps)")
+		assert.Contains(t, script, `-m "${COMMIT_PREFIX:-chore(deps)}: updated NuGet dependencies"`)
+	})
+}
@@ -30,8 +30,13 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	opts ...entities.UpdateOptions,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return resolveVersionContext(ctx, provider, repo, latestVersion, o)
 }
 
 // VersionContext is exported for testing.
@@ -84,7 +89,7 @@ func PrepareChangelog(
 	repo entities.Repository,
 	vCtx *VersionContext,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	return prepareChangelog(ctx, provider, repo, vCtx, entities.ChangelogSettings{})
 }
 
 // LogDryRun is exported for testing.
@@ -133,5 +138,5 @@ func SetDefaultRunner(r cmdrunner.Runner) func() {
 
 // ResolveLocalVersionContext is exported for testing.
 func ResolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
-	return resolveLocalVersionContext(ctx, repoDir)
+	return resolveLocalVersionContext(ctx, repoDir, entities.UpdateOptions{})
 }
@@ -58,6 +58,16 @@ func NewUpdaterRepository() repositories.UpdaterRepository {
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Base image tags in Dockerfile FROM instructions"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langDockerfile.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository contains Dockerfiles.
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -82,7 +92,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 ) ([]entities.PullRequest, error) {
 	logger.Infof("[dockerfile] Scanning %s/%s for Dockerfile base images", repo.Organization, repo.Name)
 
-	allRefs := scanAllDockerfiles(ctx, provider, repo)
+	allRefs := scanAllDockerfiles(ctx, provider, repo, opts)
 	if len(allRefs) == 0 {
 		return []entities.PullRequest{}, nil
 	}
@@ -108,6 +118,21 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	return createUpgradePR(ctx, provider, repo, opts, upgrades, allRefs)
 }
 
+// PlanUpdates implements repositories.UpdatePlanner for dry runs. It returns
+// the base image upgrades CreateUpdatePRs would make to the Dockerfiles.
+func (u *UpdaterRepository) PlanUpdates(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	opts entities.UpdateOptions,
+) ([]entities.DependencyChange, error) {
+	allRefs := scanAllDockerfiles(ctx, provider, repo, opts)
+	if len(allRefs) == 0 {
+		return []entities.DependencyChange{}, nil
+	}
+	return collectChanges(determineUpgrades(ctx, allRefs)), nil
+}
+
 // ApplyUpdates implements repositories.LocalUpdater for the clone-based pipeline.
 // It scans the local filesystem for Dockerfiles, fetches latest tags from Docker Hub,
 // writes changes to disk, and returns PR metadata.
@@ -116,12 +141,12 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	_ repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[dockerfile] Scanning local clone of %s/%s for Dockerfile base images",
 		repo.Organization, repo.Name)
 
-	allRefs := localScanAllDockerfiles(repoDir)
+	allRefs := localScanAllDockerfiles(repoDir, opts)
 	if len(allRefs) == 0 {
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
@@ -146,19 +171,39 @@ func (u *UpdaterRepository) ApplyUpdates(
 			up.parsed.FullName(), up.dep.CurrentVer, up.newTag,
 		))
 	}
-	support.LocalChangelogUpdate(repoDir, entries)
 
+	changes := collectChanges(upgrades)
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, changes), generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	return &repositories.LocalUpdateResult{
-		BranchName:    generateBranchName(upgrades),
-		CommitMessage: generateCommitMessage(upgrades),
-		PRTitle:       generatePRTitle(upgrades),
-		PRDescription: generatePRDescription(upgrades),
+		BranchName:       generateBranchName(upgrades),
+		CommitMessage:    generateCommitMessage(upgrades, opts.CommitPrefix()),
+		PRTitle:          prTitle,
+		PRDescription:    prDescription,
+		Changes:          changes,
+		ChangelogEntries: entries,
 	}, nil
 }
 
+// collectChanges converts the applied upgrades into changes report entries.
+func collectChanges(tasks []upgradeTask) []entities.DependencyChange {
+	changes := make([]entities.DependencyChange, 0, len(tasks))
+	for _, t := range tasks {
+		changes = append(changes, entities.DependencyChange{
+			Ecosystem: updaterName,
+			Name:      t.parsed.FullName(),
+			From:      t.dep.CurrentVer,
+			To:        t.newTag,
+			File:      t.dep.FilePath,
+		})
+	}
+	return changes
+}
+
 // localScanAllDockerfiles walks the local filesystem for Dockerfiles
-// and parses them for base image references.
-func localScanAllDockerfiles(repoDir string) []imageRef {
+// and parses them for base image references, skipping excluded directories.
+func localScanAllDockerfiles(repoDir string, opts entities.UpdateOptions) []imageRef {
 	var allRefs []imageRef
 
 	files, err := support.WalkFilesByPredicate(repoDir, isDockerfilePath)
@@ -168,6 +213,9 @@ func localScanAllDockerfiles(repoDir string) []imageRef {
 	}
 
 	for _, relPath := range files {
+		if opts.IsPathExcluded(relPath) {
+			continue
+		}
 		data, readErr := os.ReadFile(filepath.Join(repoDir, relPath))
 		if readErr != nil {
 			logger.Warnf("[dockerfile] Failed to read %s: %v", relPath, readErr)
@@ -187,6 +235,7 @@ func scanAllDockerfiles(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
+	opts entities.UpdateOptions,
 ) []imageRef {
 	var allRefs []imageRef
 
@@ -199,7 +248,7 @@ func scanAllDockerfiles(
 	}
 
 	for _, f := range files {
-		if f.IsDir || !isDockerfilePath(f.Path) {
+		if f.IsDir || !isDockerfilePath(f.Path) || opts.IsPathExcluded(f.Path) {
 			continue
 		}
 
@@ -240,6 +289,64 @@ func isDockerHubImage(imageName string) bool {
 	return !strings.ContainsAny(firstSegment, ".:")
 }
 
+// parseImageReference parses a Docker Hub image name and its version tag.
+// Images from other registries (e.g. ghcr.io/org/image, quay.io/image) and
+// non-version tags such as "latest" are rejected.
+func parseImageReference(imageName, tag string) (*parsedImageRef, bool) {
+	if !isDockerHubImage(imageName) {
+		return nil, false
+	}
+	if tag == "latest" || tag == "edge" || tag == "stable" {
+		return nil, false
+	}
+
+	version, suffix, precision, ok := parseTag(tag)
+	if !ok {
+		return nil, false
+	}
+
+	namespace := ""
+	image := imageName
+	if strings.Contains(imageName, "/") {
+		parts := strings.SplitN(imageName, "/", 2) //nolint:mnd // split into namespace/image
+		namespace = parts[0]
+		image = parts[1]
+	}
+
+	return &parsedImageRef{
+		Namespace: namespace,
+		Image:     image,
+		Tag:       tag,
+		Version:   version,
+		Suffix:    suffix,
+		Precision: precision,
+	}, true
+}
+
+// ResolveImageUpgrade returns the tag an image reference such as
+// "postgres:16.1" should move to, following the same rules as Dockerfile
+// base images: same suffix and precision, same major, and same minor for
+// patch pins. It returns "" when the image is not a versioned Docker Hub
+// image or no newer compatible tag exists. Other updaters use it for the
+// images they reference outside Dockerfiles.
+func ResolveImageUpgrade(ctx context.Context, imageName, tag string) (string, error) {
+	parsed, ok := parseImageReference(imageName, tag)
+	if !ok {
+		return "", nil
+	}
+
+	tags, err := fetchTagsFunc(ctx, parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch tags for %s: %w", parsed.FullName(), err)
+	}
+
+	bestTag := findBestUpgrade(parsed, tags)
+	if bestTag == parsed.Tag {
+		return "", nil
+	}
+	return bestTag, nil
+}
+
 // scanDockerfile parses FROM clauses in a Dockerfile and returns image references.
 func scanDockerfile(content, filePath string) []imageRef {
 	var refs []imageRef
@@ -257,30 +364,11 @@ func scanDockerfile(content, filePath string) []imageRef {
 			continue
 		}
 
-		// Skip non-Docker Hub registry images (e.g., ghcr.io/org/image, quay.io/image)
-		if !isDockerHubImage(imageName) {
-			continue
-		}
-
-		// Skip non-version tags
-		if tag == "latest" || tag == "edge" || tag == "stable" {
-			continue
-		}
-
-		version, suffix, precision, ok := parseTag(tag)
+		parsed, ok := parseImageReference(imageName, tag)
 		if !ok {
 			continue
 		}
 
-		// Parse namespace/image
-		namespace := ""
-		image := imageName
-		if strings.Contains(imageName, "/") {
-			parts := strings.SplitN(imageName, "/", 2) //nolint:mnd // split into namespace/image
-			namespace = parts[0]
-			image = parts[1]
-		}
-
 		lineNum := strings.Count(content[:matchIndices[i][0]], "\n") + 1
 
 		refs = append(refs, imageRef{
@@ -291,14 +379,7 @@ func scanDockerfile(content, filePath string) []imageRef {
 				FilePath:   filePath,
 				Line:       lineNum,
 			},
-			parsed: &parsedImageRef{
-				Namespace: namespace,
-				Image:     image,
-				Tag:       tag,
-				Version:   version,
-				Suffix:    suffix,
-				Precision: precision,
-			},
+			parsed:      parsed,
 			fileContent: content,
 		})
 	}
@@ -417,28 +498,33 @@ func createUpgradePR(
 	}
 
 	fileChanges := applyUpgrades(upgrades, allRefs)
-	fileChanges = appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, opts.Changelog)
 
 	targetBranch := repo.DefaultBranch
 	if opts.TargetBranch != "" {
 		targetBranch = "refs/heads/" + opts.TargetBranch
 	}
 
+	fileChanges = support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, opts.ChangelogConflict)
 	err := provider.CreateBranchWithChanges(ctx, repo, entities.BranchInput{
 		BranchName:    branchName,
 		BaseBranch:    targetBranch,
 		Changes:       fileChanges,
-		CommitMessage: generateCommitMessage(upgrades),
+		CommitMessage: generateCommitMessage(upgrades, opts.CommitPrefix()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create branch: %w", err)
 	}
 
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, collectChanges(upgrades)),
+		generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + branchName,
 		TargetBranch: targetBranch,
-		Title:        generatePRTitle(upgrades),
-		Description:  generatePRDescription(upgrades),
+		Title:        prTitle,
+		Description:  entities.ApplyPRFooter(prDescription, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -458,14 +544,15 @@ func generateBranchName(tasks []upgradeTask) string {
 	return fmt.Sprintf(branchBatchFmt, len(tasks))
 }
 
-func generateCommitMessage(tasks []upgradeTask) string {
+func generateCommitMessage(tasks []upgradeTask, prefix string) string {
 	if len(tasks) == 1 {
 		return fmt.Sprintf(
-			"chore(deps): upgraded `%s` from `%s` to `%s`",
+			"%s: upgraded `%s` from `%s` to `%s`",
+			prefix,
 			tasks[0].parsed.FullName(), tasks[0].dep.CurrentVer, tasks[0].newTag,
 		)
 	}
-	return fmt.Sprintf("chore(deps): upgraded %d Docker base images", len(tasks))
+	return fmt.Sprintf("%s: upgraded %d Docker base images", prefix, len(tasks))
 }
 
 func generatePRTitle(tasks []upgradeTask) string {
@@ -495,27 +582,20 @@ func generatePRDescription(tasks []upgradeTask) string {
 	}
 
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
 
-func appendChangelogEntry(
+// buildChangelogChange reads the changelog (if present) and inserts entries
+// describing the base image upgrades. The change is added to the change set
+// by support.ReconcileChangelogChange right before the push.
+func buildChangelogChange(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	upgrades []upgradeTask,
-	fileChanges []entities.FileChange,
-) []entities.FileChange {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
-		return fileChanges
-	}
-
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
-	if err != nil {
-		logger.Warnf("[dockerfile] Failed to read CHANGELOG.md: %v", err)
-		return fileChanges
-	}
-
+	changelog entities.ChangelogSettings,
+) *support.ChangelogChange {
 	entries := make([]string, 0, len(upgrades))
 	for _, up := range upgrades {
 		entries = append(entries, fmt.Sprintf(
@@ -524,14 +604,5 @@ func appendChangelogEntry(
 		))
 	}
 
-	modified := entities.InsertChangelogEntry(content, entries)
-	if modified == content {
-		return fileChanges
-	}
-
-	return append(fileChanges, entities.FileChange{
-		Path:       "CHANGELOG.md",
-		Content:    modified,
-		ChangeType: "edit",
-	})
+	return support.BuildChangelogChange(ctx, provider, repo, entries, changelog)
 }
@@ -477,6 +477,22 @@ func TestGenerateCommitMessage(t *testing.T) {
 		assert.Contains(t, result, "2")
 		assert.Contains(t, result, "Docker base images")
 	})
+
+	t.Run("should use the configured commit type with the default scope", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tasks := []dockerfile.UpgradeTask{
+			dockerfile.NewUpgradeTask("golang", "1.25", "1.26"),
+			dockerfile.NewUpgradeTask("python", "3.12", "3.13"),
+		}
+
+		// when
+		result := dockerfile.GenerateCommitMessage(tasks, entities.UpdateOptions{CommitType: "build"})
+
+		// then
+		assert.Equal(t, "build(deps): upgraded 2 Docker base images", result)
+	})
 }
 
 func TestGeneratePRTitle(t *testing.T) {
@@ -1252,4 +1268,22 @@ func TestLocalScanAllDockerfiles(t *testing.T) {
 		assert.Equal(t, "alpine", refs[1].Name)
 		assert.Equal(t, "3.19", refs[1].CurrentVer)
 	})
+	t.Run("should skip Dockerfiles inside excluded directories", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		content := "FROM golang:1.25-alpine\n"
+		thirdParty := filepath.Join(tmpDir, "third_party", "tool")
+		require.NoError(t, os.MkdirAll(thirdParty, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(thirdParty, "Dockerfile"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "Dockerfile"), []byte(content), 0o600))
+
+		// when
+		refs := dockerfile.LocalScanAllDockerfiles(tmpDir)
+
+		// then
+		require.Len(t, refs, 1)
+		assert.Equal(t, "Dockerfile", refs[0].FilePath)
+	})
 }
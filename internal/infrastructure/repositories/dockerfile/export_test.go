@@ -8,6 +8,7 @@ import (
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // ParseTag is exported for testing.
@@ -76,7 +77,8 @@ func AppendChangelogEntry(
 	upgrades []upgradeTask,
 	fileChanges []entities.FileChange,
 ) []entities.FileChange {
-	return appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, entities.ChangelogSettings{})
+	return support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, "")
 }
 
 // GenerateBranchName is exported for testing.
@@ -84,9 +86,14 @@ func GenerateBranchName(tasks []UpgradeTask) string {
 	return generateBranchName(tasks)
 }
 
-// GenerateCommitMessage is exported for testing.
-func GenerateCommitMessage(tasks []UpgradeTask) string {
-	return generateCommitMessage(tasks)
+// GenerateCommitMessage is exported for testing. The optional options
+// supply the commit type and scope; none means the defaults.
+func GenerateCommitMessage(tasks []UpgradeTask, opts ...entities.UpdateOptions) string {
+	var options entities.UpdateOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return generateCommitMessage(tasks, options.CommitPrefix())
 }
 
 // GeneratePRTitle is exported for testing.
@@ -100,8 +107,12 @@ func GeneratePRDescription(tasks []UpgradeTask) string {
 }
 
 // LocalScanAllDockerfiles is exported for testing.
-func LocalScanAllDockerfiles(repoDir string) []ImageRefResult {
-	refs := localScanAllDockerfiles(repoDir)
+func LocalScanAllDockerfiles(repoDir string, opts ...entities.UpdateOptions) []ImageRefResult {
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	refs := localScanAllDockerfiles(repoDir, o)
 	results := make([]ImageRefResult, len(refs))
 	for i, ref := range refs {
 		results[i] = ImageRefResult{
@@ -0,0 +1,40 @@
+//go:build unit
+
+package forge
+
+import (
+	"net/url"
+
+	gh "github.com/google/go-github/v66/github"
+	gl "gitlab.com/gitlab-org/api/client-go"
+)
+
+// NewGitHubProviderRepositoryWithBaseURL is exported for testing. It points
+// the API client at baseURL.
+func NewGitHubProviderRepositoryWithBaseURL(token, baseURL string) *GitHubProviderRepository {
+	client := gh.NewClient(nil).WithAuthToken(token)
+	client.BaseURL, _ = url.Parse(baseURL + "/")
+	return newGitHubProviderRepository(token, client)
+}
+
+// NewGitLabProviderRepositoryWithBaseURL is exported for testing. It points
+// the API client at baseURL.
+func NewGitLabProviderRepositoryWithBaseURL(token, baseURL string) *GitLabProviderRepository {
+	client, err := gl.NewClient(token, gl.WithBaseURL(baseURL))
+	if err != nil {
+		panic(err)
+	}
+	return newGitLabProviderRepository(token, client)
+}
+
+// APIBaseURL is exported for testing. It returns the base URL of the API
+// client.
+func (p *GitHubProviderRepository) APIBaseURL() string {
+	return p.client.BaseURL.String()
+}
+
+// APIBaseURL is exported for testing. It returns the base URL of the API
+// client.
+func (p *GitLabProviderRepository) APIBaseURL() string {
+	return p.client.BaseURL().String()
+}
@@ -0,0 +1,199 @@
+// Package forge extends gitforge's hosting providers with the pull request
+// operations autoupdate needs and gitforge does not offer yet. Each provider
+// embeds the gitforge one, so everything else behaves exactly the same, and
+// calls the hosting API directly with the same token for the additions.
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gh "github.com/google/go-github/v66/github"
+	ghForge "github.com/rios0rios0/gitforge/pkg/providers/infrastructure/github"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	globalEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
+)
+
+// GitHubProviderRepository is gitforge's GitHub provider plus pull request
+// labels, reviewers, assignees, file authors, closing pull requests and
+// GitHub Enterprise Server instances.
+type GitHubProviderRepository struct {
+	*ghForge.Provider
+
+	client *gh.Client
+}
+
+var (
+	_ repositories.PullRequestLabeler           = (*GitHubProviderRepository)(nil)
+	_ repositories.PullRequestReviewerRequester = (*GitHubProviderRepository)(nil)
+	_ repositories.PullRequestAssigner          = (*GitHubProviderRepository)(nil)
+	_ repositories.LastAuthorResolver           = (*GitHubProviderRepository)(nil)
+	_ repositories.PullRequestCloser            = (*GitHubProviderRepository)(nil)
+	_ repositories.SelfHostedProvider           = (*GitHubProviderRepository)(nil)
+)
+
+// NewGitHubProviderRepository creates a GitHub provider authenticated with
+// token.
+func NewGitHubProviderRepository(token string) globalEntities.ForgeProvider {
+	return newGitHubProviderRepository(token, gh.NewClient(nil).WithAuthToken(token))
+}
+
+func newGitHubProviderRepository(token string, client *gh.Client) *GitHubProviderRepository {
+	provider, _ := ghForge.NewProvider(token).(*ghForge.Provider)
+	return &GitHubProviderRepository{Provider: provider, client: client}
+}
+
+// ForHost implements repositories.SelfHostedProvider for GitHub Enterprise
+// Server. The pull request methods of the returned provider, creation
+// included, call https://<host>/api/v3; discovery and file access still use
+// github.com.
+func (p *GitHubProviderRepository) ForHost(host string) (repositories.ProviderRepository, error) {
+	token := p.AuthToken()
+	client, err := gh.NewClient(nil).WithAuthToken(token).WithEnterpriseURLs("https://"+host, "https://"+host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GitHub client for %s: %w", host, err)
+	}
+	return newGitHubProviderRepository(token, client), nil
+}
+
+// CreatePullRequest opens a pull request like gitforge's provider does,
+// through the client of this provider so it also reaches GitHub Enterprise
+// Server.
+func (p *GitHubProviderRepository) CreatePullRequest(
+	ctx context.Context,
+	repo entities.Repository,
+	input entities.PullRequestInput,
+) (*entities.PullRequest, error) {
+	sourceBranch := strings.TrimPrefix(input.SourceBranch, "refs/heads/")
+	targetBranch := strings.TrimPrefix(input.TargetBranch, "refs/heads/")
+	maintainerCanModify := true
+	pr, _, err := p.client.PullRequests.Create(ctx, repo.Organization, repo.Name, &gh.NewPullRequest{
+		Title:               &input.Title,
+		Head:                &sourceBranch,
+		Base:                &targetBranch,
+		Body:                &input.Description,
+		MaintainerCanModify: &maintainerCanModify,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	return &entities.PullRequest{
+		ID:     pr.GetNumber(),
+		Title:  pr.GetTitle(),
+		URL:    pr.GetHTMLURL(),
+		Status: pr.GetState(),
+	}, nil
+}
+
+// PullRequestExists reports whether sourceBranch has an open pull request,
+// through the client of this provider like CreatePullRequest.
+func (p *GitHubProviderRepository) PullRequestExists(
+	ctx context.Context,
+	repo entities.Repository,
+	sourceBranch string,
+) (bool, error) {
+	prs, _, err := p.client.PullRequests.List(ctx, repo.Organization, repo.Name, &gh.PullRequestListOptions{
+		Head:  repo.Organization + ":" + sourceBranch,
+		State: "open",
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	return len(prs) > 0, nil
+}
+
+// AddPullRequestLabels implements repositories.PullRequestLabeler. GitHub
+// creates the labels the repository does not have yet.
+func (p *GitHubProviderRepository) AddPullRequestLabels(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	labels []string,
+) error {
+	if _, _, err := p.client.Issues.AddLabelsToIssue(ctx, repo.Organization, repo.Name, pr.ID, labels); err != nil {
+		return fmt.Errorf("failed to add labels to pull request #%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// RequestPullRequestReviewers implements
+// repositories.PullRequestReviewerRequester. A reviewer written as
+// "org/team" requests the review of that team.
+func (p *GitHubProviderRepository) RequestPullRequestReviewers(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	reviewers []string,
+) error {
+	var request gh.ReviewersRequest
+	for _, reviewer := range reviewers {
+		if _, team, isTeam := strings.Cut(reviewer, "/"); isTeam {
+			request.TeamReviewers = append(request.TeamReviewers, team)
+			continue
+		}
+		request.Reviewers = append(request.Reviewers, reviewer)
+	}
+	_, _, err := p.client.PullRequests.RequestReviewers(ctx, repo.Organization, repo.Name, pr.ID, request)
+	if err != nil {
+		return fmt.Errorf("failed to request reviews on pull request #%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// AddPullRequestAssignees implements repositories.PullRequestAssigner.
+// GitHub silently skips users who cannot be assigned to the repository.
+func (p *GitHubProviderRepository) AddPullRequestAssignees(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	assignees []string,
+) error {
+	if _, _, err := p.client.Issues.AddAssignees(ctx, repo.Organization, repo.Name, pr.ID, assignees); err != nil {
+		return fmt.Errorf("failed to assign pull request #%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// LastAuthor implements repositories.LastAuthorResolver. It returns the
+// login of the author of the latest default branch commit touching path,
+// or "" when the commit author has no GitHub account.
+func (p *GitHubProviderRepository) LastAuthor(
+	ctx context.Context,
+	repo entities.Repository,
+	path string,
+) (string, error) {
+	commits, _, err := p.client.Repositories.ListCommits(ctx, repo.Organization, repo.Name, &gh.CommitsListOptions{
+		SHA:         strings.TrimPrefix(repo.DefaultBranch, "refs/heads/"),
+		Path:        path,
+		ListOptions: gh.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list the commits of %s: %w", path, err)
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].GetAuthor().GetLogin(), nil
+}
+
+// ClosePullRequest implements repositories.PullRequestCloser.
+func (p *GitHubProviderRepository) ClosePullRequest(ctx context.Context, repo entities.Repository, prID int) error {
+	state := "closed"
+	if _, _, err := p.client.PullRequests.Edit(ctx, repo.Organization, repo.Name, prID, &gh.PullRequest{
+		State: &state,
+	}); err != nil {
+		return fmt.Errorf("failed to close pull request #%d: %w", prID, err)
+	}
+	return nil
+}
+
+// DeleteBranch implements repositories.PullRequestCloser.
+func (p *GitHubProviderRepository) DeleteBranch(ctx context.Context, repo entities.Repository, branch string) error {
+	if _, err := p.client.Git.DeleteRef(ctx, repo.Organization, repo.Name, "heads/"+branch); err != nil {
+		return fmt.Errorf("failed to delete branch %q: %w", branch, err)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+//go:build unit
+
+package forge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/forge"
+)
+
+func TestGitHubProviderRepository_AddPullRequestLabels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the labels to the issue of the pull request", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath, gotMethod string
+		var gotLabels []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath, gotMethod = r.URL.Path, r.Method
+			_ = json.NewDecoder(r.Body).Decode(&gotLabels)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestLabels(
+			t.Context(), repo, entities.PullRequest{ID: 7}, []string{"size/S", "dependencies"},
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPost, gotMethod)
+		assert.Equal(t, "/repos/acme/api/issues/7/labels", gotPath)
+		assert.Equal(t, []string{"size/S", "dependencies"}, gotLabels)
+	})
+
+	t.Run("should return an error when the API rejects the labels", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestLabels(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"size/S"})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pull request #7")
+	})
+}
+
+func TestGitHubProviderRepository_AddPullRequestAssignees(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the assignees to the issue of the pull request", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath string
+		var gotBody struct {
+			Assignees []string `json:"assignees"`
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.Method + " " + r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"number": 7}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestAssignees(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"alice"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "POST /repos/acme/api/issues/7/assignees", gotPath)
+		assert.Equal(t, []string{"alice"}, gotBody.Assignees)
+	})
+}
+
+func TestGitHubProviderRepository_LastAuthor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the login of the latest commit touching the path on the default branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotQuery url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotQuery = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"sha": "abc", "author": {"login": "alice"}}]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api", DefaultBranch: "refs/heads/main"}
+
+		// when
+		author, err := provider.LastAuthor(t.Context(), repo, "go.mod")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "alice", author)
+		assert.Equal(t, "go.mod", gotQuery.Get("path"))
+		assert.Equal(t, "main", gotQuery.Get("sha"))
+	})
+
+	t.Run("should return an empty author when the path has no history", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api", DefaultBranch: "refs/heads/main"}
+
+		// when
+		author, err := provider.LastAuthor(t.Context(), repo, "go.mod")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, author)
+	})
+}
+
+func TestGitHubProviderRepository_RequestPullRequestReviewers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should request reviews from users and from org/team reviewers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath string
+		var gotBody struct {
+			Reviewers     []string `json:"reviewers"`
+			TeamReviewers []string `json:"team_reviewers"`
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.Method + " " + r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"number": 7}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.RequestPullRequestReviewers(
+			t.Context(), repo, entities.PullRequest{ID: 7}, []string{"alice", "acme/platform"},
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "POST /repos/acme/api/pulls/7/requested_reviewers", gotPath)
+		assert.Equal(t, []string{"alice"}, gotBody.Reviewers)
+		assert.Equal(t, []string{"platform"}, gotBody.TeamReviewers)
+	})
+}
+
+func TestGitHubProviderRepository_ClosePullRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should close the pull request and delete its branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotRequests []string
+		var gotState string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequests = append(gotRequests, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodPatch {
+				var body struct {
+					State string `json:"state"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				gotState = body.State
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		closeErr := provider.ClosePullRequest(t.Context(), repo, 4)
+		deleteErr := provider.DeleteBranch(t.Context(), repo, "chore/upgrade-vpc-v1.5.0")
+
+		// then
+		require.NoError(t, closeErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, []string{
+			"PATCH /repos/acme/api/pulls/4",
+			"DELETE /repos/acme/api/git/refs/heads/chore/upgrade-vpc-v1.5.0",
+		}, gotRequests)
+		assert.Equal(t, "closed", gotState)
+	})
+}
+
+func TestGitHubProviderRepository_ForHost(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should point the API client at the GitHub Enterprise Server instance", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", "https://api.github.com")
+
+		// when
+		hosted, err := provider.ForHost("github.mycorp.com")
+
+		// then
+		require.NoError(t, err)
+		require.IsType(t, &forge.GitHubProviderRepository{}, hosted)
+		hostedProvider, _ := hosted.(*forge.GitHubProviderRepository)
+		assert.Equal(t, "https://github.mycorp.com/api/v3/", hostedProvider.APIBaseURL())
+		assert.Equal(t, "token", hostedProvider.AuthToken())
+	})
+}
+
+func TestGitHubProviderRepository_CreatePullRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should open the pull request through the provider's client", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath string
+		var gotBody map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"number": 12, "html_url": "https://pr/12", "state": "open"}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		pr, err := provider.CreatePullRequest(t.Context(), repo, entities.PullRequestInput{
+			SourceBranch: "refs/heads/chore/bump",
+			TargetBranch: "refs/heads/main",
+			Title:        "chore(deps): bump",
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/repos/acme/api/pulls", gotPath)
+		assert.Equal(t, "chore/bump", gotBody["head"])
+		assert.Equal(t, "main", gotBody["base"])
+		assert.Equal(t, 12, pr.ID)
+		assert.Equal(t, "https://pr/12", pr.URL)
+	})
+}
+
+func TestGitHubProviderRepository_PullRequestExists(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report an open pull request from the branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotQuery url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotQuery = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"number": 12}]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitHubProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		exists, err := provider.PullRequestExists(t.Context(), repo, "chore/bump")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "acme:chore/bump", gotQuery.Get("head"))
+		assert.Equal(t, "open", gotQuery.Get("state"))
+	})
+}
@@ -0,0 +1,333 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	glForge "github.com/rios0rios0/gitforge/pkg/providers/infrastructure/gitlab"
+	gl "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	globalEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
+)
+
+// mergeRequestsPerPage is the page size of the merge request listing.
+const mergeRequestsPerPage = 100
+
+// GitLabProviderRepository is gitforge's GitLab provider plus merge request
+// labels, reviewers, assignees, file authors, the open merge request list,
+// closing merge requests and self-hosted instances.
+type GitLabProviderRepository struct {
+	*glForge.Provider
+
+	client *gl.Client
+}
+
+var (
+	_ repositories.PullRequestLabeler           = (*GitLabProviderRepository)(nil)
+	_ repositories.PullRequestReviewerRequester = (*GitLabProviderRepository)(nil)
+	_ repositories.PullRequestAssigner          = (*GitLabProviderRepository)(nil)
+	_ repositories.LastAuthorResolver           = (*GitLabProviderRepository)(nil)
+	_ repositories.OpenPullRequestLister        = (*GitLabProviderRepository)(nil)
+	_ repositories.PullRequestCloser            = (*GitLabProviderRepository)(nil)
+	_ repositories.SelfHostedProvider           = (*GitLabProviderRepository)(nil)
+)
+
+// NewGitLabProviderRepository creates a GitLab provider authenticated with
+// token.
+func NewGitLabProviderRepository(token string) globalEntities.ForgeProvider {
+	client, err := gl.NewClient(token)
+	if err != nil {
+		client = nil
+	}
+	return newGitLabProviderRepository(token, client)
+}
+
+func newGitLabProviderRepository(token string, client *gl.Client) *GitLabProviderRepository {
+	provider, _ := glForge.NewProvider(token).(*glForge.Provider)
+	return &GitLabProviderRepository{Provider: provider, client: client}
+}
+
+// ForHost implements repositories.SelfHostedProvider. The merge request
+// methods of the returned provider, creation included, call
+// https://<host>/api/v4; discovery and file access still use gitlab.com.
+func (p *GitLabProviderRepository) ForHost(host string) (repositories.ProviderRepository, error) {
+	token := p.AuthToken()
+	client, err := gl.NewClient(token, gl.WithBaseURL("https://"+host))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the GitLab client for %s: %w", host, err)
+	}
+	return newGitLabProviderRepository(token, client), nil
+}
+
+// CreatePullRequest opens a merge request like gitforge's provider does,
+// through the client of this provider so it also reaches self-hosted
+// instances.
+func (p *GitLabProviderRepository) CreatePullRequest(
+	ctx context.Context,
+	repo entities.Repository,
+	input entities.PullRequestInput,
+) (*entities.PullRequest, error) {
+	if p.client == nil {
+		return nil, errors.New("GitLab client not initialized")
+	}
+	sourceBranch := strings.TrimPrefix(input.SourceBranch, "refs/heads/")
+	targetBranch := strings.TrimPrefix(input.TargetBranch, "refs/heads/")
+	removeSourceBranch := true
+	mr, _, err := p.client.MergeRequests.CreateMergeRequest(projectPath(repo), &gl.CreateMergeRequestOptions{
+		Title:              &input.Title,
+		Description:        &input.Description,
+		SourceBranch:       &sourceBranch,
+		TargetBranch:       &targetBranch,
+		RemoveSourceBranch: &removeSourceBranch,
+	}, gl.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return &entities.PullRequest{ID: int(mr.IID), Title: mr.Title, URL: mr.WebURL, Status: mr.State}, nil
+}
+
+// PullRequestExists reports whether sourceBranch has an open merge request,
+// through the client of this provider like CreatePullRequest.
+func (p *GitLabProviderRepository) PullRequestExists(
+	ctx context.Context,
+	repo entities.Repository,
+	sourceBranch string,
+) (bool, error) {
+	if p.client == nil {
+		return false, errors.New("GitLab client not initialized")
+	}
+	state := "opened"
+	opts := &gl.ListProjectMergeRequestsOptions{SourceBranch: &sourceBranch, State: &state}
+	mrs, _, err := p.client.MergeRequests.ListProjectMergeRequests(projectPath(repo), opts, gl.WithContext(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+	return len(mrs) > 0, nil
+}
+
+// AddPullRequestLabels implements repositories.PullRequestLabeler. GitLab
+// creates the labels the project does not have yet.
+func (p *GitLabProviderRepository) AddPullRequestLabels(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	labels []string,
+) error {
+	addLabels := gl.LabelOptions(labels)
+	if err := p.updateMergeRequest(ctx, repo, pr, &gl.UpdateMergeRequestOptions{AddLabels: &addLabels}); err != nil {
+		return fmt.Errorf("failed to add labels to merge request !%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// AddPullRequestAssignees implements repositories.PullRequestAssigner. The
+// usernames are resolved to user ids and added to the current assignees of
+// the merge request.
+func (p *GitLabProviderRepository) AddPullRequestAssignees(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	assignees []string,
+) error {
+	err := p.addMergeRequestUsers(ctx, repo, pr, assignees,
+		func(mr *gl.MergeRequest) []*gl.BasicUser { return mr.Assignees },
+		func(ids *[]int64) *gl.UpdateMergeRequestOptions {
+			return &gl.UpdateMergeRequestOptions{AssigneeIDs: ids}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to assign merge request !%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// RequestPullRequestReviewers implements
+// repositories.PullRequestReviewerRequester. GitLab has no team reviewers,
+// so every reviewer must be a username.
+func (p *GitLabProviderRepository) RequestPullRequestReviewers(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	reviewers []string,
+) error {
+	err := p.addMergeRequestUsers(ctx, repo, pr, reviewers,
+		func(mr *gl.MergeRequest) []*gl.BasicUser { return mr.Reviewers },
+		func(ids *[]int64) *gl.UpdateMergeRequestOptions {
+			return &gl.UpdateMergeRequestOptions{ReviewerIDs: ids}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to request reviews on merge request !%d: %w", pr.ID, err)
+	}
+	return nil
+}
+
+// addMergeRequestUsers resolves usernames to user ids and appends them to
+// the users current reads from the merge request, saving the result with
+// the options update builds.
+func (p *GitLabProviderRepository) addMergeRequestUsers(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	usernames []string,
+	current func(*gl.MergeRequest) []*gl.BasicUser,
+	update func(*[]int64) *gl.UpdateMergeRequestOptions,
+) error {
+	if p.client == nil {
+		return errors.New("GitLab client not initialized")
+	}
+	mr, _, err := p.client.MergeRequests.GetMergeRequest(projectPath(repo), int64(pr.ID), nil, gl.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	ids := make([]int64, 0, len(usernames))
+	for _, user := range current(mr) {
+		ids = append(ids, user.ID)
+	}
+	added, err := p.userIDs(ctx, usernames)
+	if err != nil {
+		return err
+	}
+	ids = append(ids, added...)
+	return p.updateMergeRequest(ctx, repo, pr, update(&ids))
+}
+
+// LastAuthor implements repositories.LastAuthorResolver. GitLab commits
+// only carry the author email, so it returns the username of the user whose
+// public email matches, or "" when no user makes that email public.
+func (p *GitLabProviderRepository) LastAuthor(
+	ctx context.Context,
+	repo entities.Repository,
+	path string,
+) (string, error) {
+	if p.client == nil {
+		return "", errors.New("GitLab client not initialized")
+	}
+	ref := strings.TrimPrefix(repo.DefaultBranch, "refs/heads/")
+	commits, _, err := p.client.Commits.ListCommits(projectPath(repo), &gl.ListCommitsOptions{
+		ListOptions: gl.ListOptions{PerPage: 1},
+		RefName:     &ref,
+		Path:        &path,
+	}, gl.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to list the commits of %s: %w", path, err)
+	}
+	if len(commits) == 0 || commits[0].AuthorEmail == "" {
+		return "", nil
+	}
+	users, _, err := p.client.Users.ListUsers(
+		&gl.ListUsersOptions{PublicEmail: &commits[0].AuthorEmail}, gl.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the author of %s: %w", path, err)
+	}
+	if len(users) == 0 {
+		return "", nil
+	}
+	return users[0].Username, nil
+}
+
+// ListOpenPullRequests implements repositories.OpenPullRequestLister.
+func (p *GitLabProviderRepository) ListOpenPullRequests(
+	ctx context.Context,
+	repo entities.Repository,
+) ([]entities.PullRequestDetail, error) {
+	if p.client == nil {
+		return nil, errors.New("GitLab client not initialized")
+	}
+	state := "opened"
+	opts := &gl.ListProjectMergeRequestsOptions{
+		ListOptions: gl.ListOptions{PerPage: mergeRequestsPerPage},
+		State:       &state,
+	}
+	var prs []entities.PullRequestDetail
+	for {
+		mrs, resp, err := p.client.MergeRequests.ListProjectMergeRequests(projectPath(repo), opts, gl.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open merge requests: %w", err)
+		}
+		for _, mr := range mrs {
+			detail := entities.PullRequestDetail{
+				PullRequest: entities.PullRequest{
+					ID:     int(mr.IID),
+					Title:  mr.Title,
+					URL:    mr.WebURL,
+					Status: mr.State,
+				},
+				SourceBranch: mr.SourceBranch,
+				TargetBranch: mr.TargetBranch,
+				IsDraft:      mr.Draft,
+			}
+			if mr.Author != nil {
+				detail.Author = mr.Author.Username
+			}
+			prs = append(prs, detail)
+		}
+		if resp.NextPage == 0 {
+			return prs, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// ClosePullRequest implements repositories.PullRequestCloser.
+func (p *GitLabProviderRepository) ClosePullRequest(ctx context.Context, repo entities.Repository, prID int) error {
+	closeEvent := "close"
+	pr := entities.PullRequest{ID: prID}
+	if err := p.updateMergeRequest(ctx, repo, pr, &gl.UpdateMergeRequestOptions{StateEvent: &closeEvent}); err != nil {
+		return fmt.Errorf("failed to close merge request !%d: %w", prID, err)
+	}
+	return nil
+}
+
+// DeleteBranch implements repositories.PullRequestCloser.
+func (p *GitLabProviderRepository) DeleteBranch(ctx context.Context, repo entities.Repository, branch string) error {
+	if p.client == nil {
+		return errors.New("GitLab client not initialized")
+	}
+	if _, err := p.client.Branches.DeleteBranch(projectPath(repo), branch, gl.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to delete branch %q: %w", branch, err)
+	}
+	return nil
+}
+
+// userIDs resolves usernames to the numeric ids the GitLab API expects.
+func (p *GitLabProviderRepository) userIDs(ctx context.Context, usernames []string) ([]int64, error) {
+	ids := make([]int64, 0, len(usernames))
+	for _, username := range usernames {
+		users, _, err := p.client.Users.ListUsers(&gl.ListUsersOptions{Username: &username}, gl.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up user %q: %w", username, err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("user %q not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// updateMergeRequest applies opts to the merge request pr of repo.
+func (p *GitLabProviderRepository) updateMergeRequest(
+	ctx context.Context,
+	repo entities.Repository,
+	pr entities.PullRequest,
+	opts *gl.UpdateMergeRequestOptions,
+) error {
+	if p.client == nil {
+		return errors.New("GitLab client not initialized")
+	}
+	_, _, err := p.client.MergeRequests.UpdateMergeRequest(
+		projectPath(repo), int64(pr.ID), opts, gl.WithContext(ctx),
+	)
+	return err
+}
+
+// projectPath returns the "group/project" path GitLab identifies repo by.
+func projectPath(repo entities.Repository) string {
+	return repo.Organization + "/" + repo.Name
+}
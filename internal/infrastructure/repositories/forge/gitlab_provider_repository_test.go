@@ -0,0 +1,404 @@
+//go:build unit
+
+package forge_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/forge"
+)
+
+func TestGitLabProviderRepository_AddPullRequestLabels(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the labels to the merge request", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath, gotMethod string
+		var gotBody map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath, gotMethod = r.URL.EscapedPath(), r.Method
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"iid": 7}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme/platform", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestLabels(
+			t.Context(), repo, entities.PullRequest{ID: 7}, []string{"size/S", "dependencies"},
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.MethodPut, gotMethod)
+		assert.Equal(t, "/api/v4/projects/acme%2Fplatform%2Fapi/merge_requests/7", gotPath)
+		assert.Equal(t, "size/S,dependencies", gotBody["add_labels"])
+	})
+
+	t.Run("should return an error when the API rejects the labels", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestLabels(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"size/S"})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "merge request !7")
+	})
+}
+
+func TestGitLabProviderRepository_AddPullRequestAssignees(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the resolved user ids to the current assignees", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotBody struct {
+			AssigneeIDs []int64 `json:"assignee_ids"`
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/acme/api/merge_requests/7":
+				_, _ = w.Write([]byte(`{"iid": 7, "assignees": [{"id": 1, "username": "ops"}]}`))
+			case "GET /api/v4/users":
+				_, _ = w.Write([]byte(`[{"id": 2, "username": "` + r.URL.Query().Get("username") + `"}]`))
+			case "PUT /api/v4/projects/acme/api/merge_requests/7":
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+				_, _ = w.Write([]byte(`{"iid": 7}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestAssignees(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"alice"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, gotBody.AssigneeIDs)
+	})
+
+	t.Run("should return an error naming an unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updated := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodPut:
+				updated = true
+				_, _ = w.Write([]byte(`{"iid": 7}`))
+			case http.MethodGet:
+				if r.URL.Path == "/api/v4/users" {
+					_, _ = w.Write([]byte(`[]`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"iid": 7}`))
+			}
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.AddPullRequestAssignees(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"ghost"})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"ghost"`)
+		assert.False(t, updated)
+	})
+}
+
+func TestGitLabProviderRepository_LastAuthor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the user whose public email matches the latest commit author", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotCommitsQuery, gotUsersQuery url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/api/v4/projects/acme/api/repository/commits":
+				gotCommitsQuery = r.URL.Query()
+				_, _ = w.Write([]byte(`[{"id": "abc", "author_email": "alice@acme.dev"}]`))
+			case "/api/v4/users":
+				gotUsersQuery = r.URL.Query()
+				_, _ = w.Write([]byte(`[{"id": 2, "username": "alice"}]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api", DefaultBranch: "refs/heads/main"}
+
+		// when
+		author, err := provider.LastAuthor(t.Context(), repo, "go.mod")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "alice", author)
+		assert.Equal(t, "go.mod", gotCommitsQuery.Get("path"))
+		assert.Equal(t, "main", gotCommitsQuery.Get("ref_name"))
+		assert.Equal(t, "alice@acme.dev", gotUsersQuery.Get("public_email"))
+	})
+
+	t.Run("should return an empty author when no user makes the email public", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/api/v4/users" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id": "abc", "author_email": "alice@acme.dev"}]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api", DefaultBranch: "refs/heads/main"}
+
+		// when
+		author, err := provider.LastAuthor(t.Context(), repo, "go.mod")
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, author)
+	})
+}
+
+func TestGitLabProviderRepository_RequestPullRequestReviewers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add the resolved user ids to the current reviewers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotBody map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "GET /api/v4/projects/acme/api/merge_requests/7":
+				_, _ = w.Write([]byte(`{"iid": 7, "reviewers": [{"id": 1, "username": "ops"}]}`))
+			case "GET /api/v4/users":
+				_, _ = w.Write([]byte(`[{"id": 2, "username": "` + r.URL.Query().Get("username") + `"}]`))
+			case "PUT /api/v4/projects/acme/api/merge_requests/7":
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+				_, _ = w.Write([]byte(`{"iid": 7}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		err := provider.RequestPullRequestReviewers(t.Context(), repo, entities.PullRequest{ID: 7}, []string{"alice"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []any{float64(1), float64(2)}, gotBody["reviewer_ids"])
+		assert.NotContains(t, gotBody, "assignee_ids")
+	})
+}
+
+func TestGitLabProviderRepository_ListOpenPullRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the open merge requests across pages", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotState string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotState = r.URL.Query().Get("state")
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"iid": 5, "source_branch": "feat/x", "target_branch": "main"}]`))
+				return
+			}
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"iid": 4, "title": "chore(deps): bump vpc", "state": "opened",
+				"web_url": "https://gitlab.com/acme/api/-/merge_requests/4", "draft": true,
+				"source_branch": "chore/upgrade-vpc-v1.5.0", "target_branch": "main",
+				"author": {"username": "autoupdate-bot"}}]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		prs, err := provider.ListOpenPullRequests(t.Context(), repo)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "opened", gotState)
+		require.Len(t, prs, 2)
+		assert.Equal(t, entities.PullRequestDetail{
+			PullRequest: entities.PullRequest{
+				ID:     4,
+				Title:  "chore(deps): bump vpc",
+				URL:    "https://gitlab.com/acme/api/-/merge_requests/4",
+				Status: "opened",
+			},
+			SourceBranch: "chore/upgrade-vpc-v1.5.0",
+			TargetBranch: "main",
+			Author:       "autoupdate-bot",
+			IsDraft:      true,
+		}, prs[0])
+		assert.Equal(t, 5, prs[1].ID)
+		assert.Empty(t, prs[1].Author)
+	})
+}
+
+func TestGitLabProviderRepository_ClosePullRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should close the merge request and delete its branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotRequests []string
+		var gotBody map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequests = append(gotRequests, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodPut {
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"iid": 4}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		closeErr := provider.ClosePullRequest(t.Context(), repo, 4)
+		deleteErr := provider.DeleteBranch(t.Context(), repo, "chore/upgrade-vpc-v1.5.0")
+
+		// then
+		require.NoError(t, closeErr)
+		require.NoError(t, deleteErr)
+		assert.Equal(t, []string{
+			"PUT /api/v4/projects/acme/api/merge_requests/4",
+			"DELETE /api/v4/projects/acme/api/repository/branches/chore/upgrade-vpc-v1.5.0",
+		}, gotRequests)
+		assert.Equal(t, "close", gotBody["state_event"])
+	})
+}
+
+func TestGitLabProviderRepository_ForHost(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should point the API client at the self-hosted instance", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", "https://gitlab.com")
+
+		// when
+		hosted, err := provider.ForHost("gitlab.mycorp.com")
+
+		// then
+		require.NoError(t, err)
+		require.IsType(t, &forge.GitLabProviderRepository{}, hosted)
+		hostedProvider, _ := hosted.(*forge.GitLabProviderRepository)
+		assert.Equal(t, "https://gitlab.mycorp.com/api/v4/", hostedProvider.APIBaseURL())
+		assert.Equal(t, "token", hostedProvider.AuthToken())
+	})
+}
+
+func TestGitLabProviderRepository_CreatePullRequest(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should open the merge request through the provider's client", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotPath string
+		var gotBody map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&gotBody)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"iid": 12, "title": "chore(deps): bump", "web_url": "https://mr/12"}`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		pr, err := provider.CreatePullRequest(t.Context(), repo, entities.PullRequestInput{
+			SourceBranch: "refs/heads/chore/bump",
+			TargetBranch: "refs/heads/main",
+			Title:        "chore(deps): bump",
+		})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v4/projects/acme/api/merge_requests", gotPath)
+		assert.Equal(t, "chore/bump", gotBody["source_branch"])
+		assert.Equal(t, "main", gotBody["target_branch"])
+		assert.Equal(t, 12, pr.ID)
+		assert.Equal(t, "https://mr/12", pr.URL)
+	})
+}
+
+func TestGitLabProviderRepository_PullRequestExists(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report an open merge request from the branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var gotQuery url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotQuery = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"iid": 12}]`))
+		}))
+		defer server.Close()
+		provider := forge.NewGitLabProviderRepositoryWithBaseURL("token", server.URL)
+		repo := entities.Repository{Organization: "acme", Name: "api"}
+
+		// when
+		exists, err := provider.PullRequestExists(t.Context(), repo, "chore/bump")
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "chore/bump", gotQuery.Get("source_branch"))
+		assert.Equal(t, "opened", gotQuery.Get("state"))
+	})
+}
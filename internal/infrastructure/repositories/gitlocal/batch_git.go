@@ -139,12 +139,44 @@ func (c *BatchGitContext) HasChanges() (bool, error) {
 	return !clean, nil
 }
 
+// WorktreeChanges returns the uncommitted changes of the working tree as
+// FileChanges, read from disk: "delete" for removed files, "add" for new
+// ones and "edit" for the rest. The aggregate pipeline hashes them after
+// FlattenToWorktree to recognise an open PR with the same changes.
+func (c *BatchGitContext) WorktreeChanges() ([]entities.FileChange, error) {
+	status, err := c.workTree.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	changes := make([]entities.FileChange, 0, len(status))
+	for path, fileStatus := range status {
+		if fileStatus.Worktree == git.Unmodified && fileStatus.Staging == git.Unmodified {
+			continue
+		}
+		if fileStatus.Worktree == git.Deleted || fileStatus.Staging == git.Deleted {
+			changes = append(changes, entities.FileChange{Path: path, ChangeType: "delete"})
+			continue
+		}
+		content, readErr := os.ReadFile(filepath.Join(c.tmpDir, path))
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, readErr)
+		}
+		changeType := "edit"
+		if fileStatus.Worktree == git.Untracked || fileStatus.Staging == git.Added {
+			changeType = "add"
+		}
+		changes = append(changes, entities.FileChange{Path: path, Content: string(content), ChangeType: changeType})
+	}
+	return changes, nil
+}
+
 // CommitSignedAndPush stages all changes, commits with GPG/SSH signing
-// (when configured), and pushes the branch to the remote with transport
-// auto-detection.
+// (when configured), and pushes the checked-out branch to branchName on the
+// remote with transport auto-detection.
 //
 // The signing configuration comes from the cloned repo's git config and the
-// global Settings (GpgKeyPath, GpgKeyPassphrase). Multi-token auth retry
+// global Settings (GpgKeyPath, GpgKeyPassphrase); a Settings.Signing key wins
+// over commit.gpgsign, gpg.format and user.signingkey. Multi-token auth retry
 // is handled by CollectBatchAuthMethods.
 //
 // Returns true when changes were committed and pushed, false when the
@@ -178,6 +210,12 @@ func (c *BatchGitContext) CommitSignedAndPush(
 
 	name := userConfig.Name
 	email := userConfig.Email
+	if settings.CommitAuthor.Name != "" {
+		name = settings.CommitAuthor.Name
+	}
+	if settings.CommitAuthor.Email != "" {
+		email = settings.CommitAuthor.Email
+	}
 	if name == "" {
 		name = "autoupdate[bot]"
 	}
@@ -196,11 +234,16 @@ func (c *BatchGitContext) CommitSignedAndPush(
 		globalCfg = gitconfig.NewConfig()
 	}
 
-	gpgSign := gitHelpers.GetOptionFromConfig(localCfg, globalCfg, "commit", "gpgsign")
-	signer, err := signingInfra.ResolveSignerFromGitConfig(
-		gpgSign,
+	gpgSign, signingFormat, signingKey := overlaySigning(
+		settings.Signing,
+		gitHelpers.GetOptionFromConfig(localCfg, globalCfg, "commit", "gpgsign"),
 		userConfig.SigningFormat,
 		userConfig.SigningKey,
+	)
+	signer, err := signingInfra.ResolveSignerFromGitConfig(
+		gpgSign,
+		signingFormat,
+		signingKey,
 		settings.GpgKeyPath,
 		settings.GpgKeyPassphrase,
 		"autoupdate",
@@ -215,8 +258,12 @@ func (c *BatchGitContext) CommitSignedAndPush(
 		return false, fmt.Errorf("failed to commit changes: %w", err)
 	}
 
+	head, err := c.repo.Head()
+	if err != nil {
+		return false, fmt.Errorf("failed to get HEAD: %w", err)
+	}
 	refSpec := gitconfig.RefSpec(
-		fmt.Sprintf("refs/heads/%s:refs/heads/%s", branchName, branchName),
+		fmt.Sprintf("%s:refs/heads/%s", head.Name(), branchName),
 	)
 
 	if err = gitops.PushWithTransportDetection(c.repo, refSpec, authMethods); err != nil {
@@ -226,6 +273,20 @@ func (c *BatchGitContext) CommitSignedAndPush(
 	return true, nil
 }
 
+// overlaySigning lets the signing block of the autoupdate config win over the
+// git config: a configured key turns signing on with that key and format
+// (gpg when empty), no key keeps the git config values untouched.
+func overlaySigning(signing entities.Signing, gpgSign, format, key string) (string, string, string) {
+	if signing.Key == "" {
+		return gpgSign, format, key
+	}
+	format = signing.Format
+	if format == "" {
+		format = entities.SigningFormatGPG
+	}
+	return "true", format, signing.Key
+}
+
 // StashChanges stashes all uncommitted changes (including untracked files)
 // so that a force-checkout can switch branches without losing them.
 // Returns true when a stash entry was actually created, false when the
@@ -317,6 +378,55 @@ func (c *BatchGitContext) HeadHash() (plumbing.Hash, error) {
 	return head.Hash(), nil
 }
 
+// HeadChangedLines returns the number of lines the HEAD commit adds and
+// deletes compared with its parent, like the sum of `git diff --numstat`.
+// The aggregate pipeline calls it after CommitSignedAndPush to pick the
+// size label of the pull request.
+func (c *BatchGitContext) HeadChangedLines() (int, error) {
+	stats, err := c.headStats()
+	if err != nil {
+		return 0, err
+	}
+	lines := 0
+	for _, stat := range stats {
+		lines += stat.Addition + stat.Deletion
+	}
+	return lines, nil
+}
+
+// HeadChangedFiles returns the paths the HEAD commit touches compared with
+// its parent, like `git diff --name-only HEAD~1`. The aggregate pipeline
+// calls it after CommitSignedAndPush to find the last committer of each
+// changed file.
+func (c *BatchGitContext) HeadChangedFiles() ([]string, error) {
+	stats, err := c.headStats()
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		paths = append(paths, stat.Name)
+	}
+	return paths, nil
+}
+
+// headStats returns the per-file stats of the HEAD commit.
+func (c *BatchGitContext) headStats() (object.FileStats, error) {
+	head, err := c.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	commit, err := c.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	stats, err := commit.Stats()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute HEAD commit stats: %w", err)
+	}
+	return stats, nil
+}
+
 // RestoreSnapshot hard-resets the worktree to the given commit without
 // switching branches. This is the rollback primitive for per-updater
 // failure isolation in the aggregate pipeline: after a failing updater
@@ -10,6 +10,8 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 )
 
 func TestHeadHash(t *testing.T) {
@@ -32,6 +34,82 @@ func TestHeadHash(t *testing.T) {
 	})
 }
 
+func TestHeadChangedLines(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should sum the added and deleted lines of the HEAD commit", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		ctx := newBatchGitContext(t, repoDir)
+		require.NoError(t, ctx.CreateBranchFromDefault("chore/autoupdate-2026-04-15"))
+		baseHash, err := ctx.HeadHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("a\nb\nc\n"), 0o600))
+		_, err = ctx.AdvanceSnapshot(baseHash)
+		require.NoError(t, err)
+
+		// when
+		lines, err := ctx.HeadChangedLines()
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, 5, lines)
+	})
+}
+
+func TestHeadChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the paths touched by the HEAD commit", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		ctx := newBatchGitContext(t, repoDir)
+		require.NoError(t, ctx.CreateBranchFromDefault("chore/autoupdate-2026-04-15"))
+		baseHash, err := ctx.HeadHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("a\n"), 0o600))
+		_, err = ctx.AdvanceSnapshot(baseHash)
+		require.NoError(t, err)
+
+		// when
+		paths, err := ctx.HeadChangedFiles()
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"README.md", "new.txt"}, paths)
+	})
+}
+
+func TestWorktreeChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the edited, added and deleted files of the worktree", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		ctx := newBatchGitContext(t, repoDir)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Changed\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("a\n"), 0o600))
+
+		// when
+		changes, err := ctx.WorktreeChanges()
+
+		// then
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []entities.FileChange{
+			{Path: "README.md", Content: "# Changed\n", ChangeType: "edit"},
+			{Path: "new.txt", Content: "a\n", ChangeType: "add"},
+		}, changes)
+	})
+}
+
 func TestRestoreSnapshot(t *testing.T) {
 	t.Parallel()
 
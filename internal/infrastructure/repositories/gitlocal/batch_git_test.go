@@ -9,10 +9,14 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
+	nixUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/nix"
 )
 
 func TestCreateBranchFromDefault_PreservesChangesWithStash(t *testing.T) {
@@ -446,6 +450,62 @@ func TestDropStash(t *testing.T) {
 	})
 }
 
+func TestCommitSignedAndPush(t *testing.T) {
+	// Not parallel: t.Setenv puts a fake nix on PATH for the updater script.
+
+	t.Run("should sign the commit of the applied updates with the configured signing key", func(t *testing.T) {
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		commitFlake(t, repoDir)
+		binDir := t.TempDir()
+		lockAfter := `{"nodes":{"nixpkgs":{"locked":{"rev":"b2e9a1f3c3c7"}},` +
+			`"root":{"inputs":{"nixpkgs":"nixpkgs"}}},"root":"root","version":7}`
+		fakeNix := "#!/bin/sh\ncat > flake.lock <<'EOF'\n" + lockAfter + "\nEOF\n"
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, "nix"), []byte(fakeNix), 0o700))
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		updater, ok := nixUpdater.NewUpdaterRepository().(repositories.LocalUpdater)
+		require.True(t, ok)
+		settings := &entities.Settings{
+			Signing: entities.Signing{Key: newSSHSigningKey(t), Format: entities.SigningFormatSSH},
+		}
+		result, err := updater.ApplyUpdates(
+			t.Context(), repoDir, nil, entities.Repository{}, entities.UpdateOptions{},
+		)
+		require.NoError(t, err)
+		batchCtx := newBatchGitContext(t, repoDir)
+
+		// when
+		_, err = batchCtx.CommitSignedAndPush(result.BranchName, result.CommitMessage, settings, nil)
+
+		// then
+		require.Error(t, err, "there is no remote to push to")
+		commit := headCommit(t, repoDir)
+		assert.Contains(t, commit.Message, result.CommitMessage)
+		assert.Contains(t, commit.PGPSignature, "BEGIN SSH SIGNATURE")
+	})
+}
+
+// commitFlake commits a flake.nix and a flake.lock pinning nixpkgs to an
+// old revision.
+func commitFlake(t *testing.T, repoDir string) {
+	t.Helper()
+
+	lockBefore := `{"nodes":{"nixpkgs":{"locked":{"rev":"a3a3dda3bacf"}},` +
+		`"root":{"inputs":{"nixpkgs":"nixpkgs"}}},"root":"root","version":7}`
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "flake.nix"), []byte("{ }\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "flake.lock"), []byte(lockBefore), 0o600))
+	repo, err := git.PlainOpen(repoDir)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = wt.Add(".")
+	require.NoError(t, err)
+	_, err = wt.Commit("add flake", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+}
+
 // newBatchGitContext creates a BatchGitContext from a local repo for testing.
 func newBatchGitContext(t *testing.T, repoDir string) *gitlocal.BatchGitContext {
 	t.Helper()
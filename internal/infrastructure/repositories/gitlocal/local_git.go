@@ -9,15 +9,21 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	logger "github.com/sirupsen/logrus"
 
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	gitops "github.com/rios0rios0/gitforge/pkg/git/infrastructure"
 	gitHelpers "github.com/rios0rios0/gitforge/pkg/git/infrastructure/helpers"
 	globalEntities "github.com/rios0rios0/gitforge/pkg/global/domain/entities"
 	signingInfra "github.com/rios0rios0/gitforge/pkg/signing/infrastructure"
 )
 
+// DefaultRemote is the remote StageCommitAndPush pushes to unless
+// UseRemote names another one.
+const DefaultRemote = "origin"
+
 // PushAuthResolver resolves authentication for git push operations.
 // It abstracts the ProviderRegistry to avoid import cycles between the
 // gitlocal package and the parent repositories package.
@@ -41,6 +47,9 @@ type LocalGitContext struct {
 	workTree *git.Worktree
 	repoDir  string
 	resolver PushAuthResolver
+	remote   string // remote StageCommitAndPush pushes to
+	base     string // branch of remote CreateBranch starts from, HEAD when empty
+	signing  entities.Signing
 	stashRef string // commit hash of the stash entry created by StashIfDirty
 }
 
@@ -69,9 +78,31 @@ func NewLocalGitContext(repoDir string, resolver PushAuthResolver) (*LocalGitCon
 		workTree: wt,
 		repoDir:  repoDir,
 		resolver: resolver,
+		remote:   DefaultRemote,
 	}, nil
 }
 
+// UseRemote makes StageCommitAndPush push to the named remote instead of
+// origin. An empty name keeps the current remote.
+func (c *LocalGitContext) UseRemote(name string) {
+	if name != "" {
+		c.remote = name
+	}
+}
+
+// UseBaseBranch makes CreateBranch start from the remote-tracking ref of the
+// named branch on the context's remote instead of HEAD. An empty name keeps
+// HEAD.
+func (c *LocalGitContext) UseBaseBranch(name string) {
+	c.base = name
+}
+
+// UseSigning makes StageCommitAndPush sign with the given key instead of the
+// one in the git config. A zero Signing keeps the git config.
+func (c *LocalGitContext) UseSigning(signing entities.Signing) {
+	c.signing = signing
+}
+
 // StashIfDirty checks if the worktree has uncommitted changes and
 // stashes them if so.  Returns true if a stash was created.  The
 // caller must call RestoreStash after the operation completes.
@@ -162,15 +193,33 @@ func (c *LocalGitContext) CheckoutBranch(branchName string) error {
 	return gitops.CheckoutBranch(c.workTree, branchName)
 }
 
-// CreateBranch creates a new branch from HEAD and switches to it.
+// CreateBranch creates a new branch and switches to it. The branch starts
+// from the remote-tracking ref set by UseBaseBranch, or from HEAD.
 func (c *LocalGitContext) CreateBranch(branchName string) error {
-	head, err := c.repo.Head()
+	start, err := c.startPoint()
 	if err != nil {
-		return fmt.Errorf("failed to get HEAD: %w", err)
+		return err
 	}
 
 	logger.Infof("Creating branch %s...", branchName)
-	return gitops.CreateAndSwitchBranch(c.repo, c.workTree, branchName, head.Hash())
+	return gitops.CreateAndSwitchBranch(c.repo, c.workTree, branchName, start)
+}
+
+// startPoint returns the commit CreateBranch starts from.
+func (c *LocalGitContext) startPoint() (plumbing.Hash, error) {
+	if c.base == "" {
+		head, err := c.repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
+		}
+		return head.Hash(), nil
+	}
+
+	ref, err := c.repo.Reference(plumbing.NewRemoteReferenceName(c.remote, c.base), true)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s/%s: %w", c.remote, c.base, err)
+	}
+	return ref.Hash(), nil
 }
 
 // HasChanges returns true when the working tree has unstaged or
@@ -192,7 +241,8 @@ func (c *LocalGitContext) HasChanges() (bool, error) {
 // token-enabled provider via the registry and collect auth methods.
 //
 // If the repository's git config has commit.gpgsign=true, the commit
-// will be signed using GPG or SSH depending on gpg.format.
+// will be signed using GPG or SSH depending on gpg.format; a key set with
+// UseSigning wins over the git config.
 //
 // Returns true when changes were committed and pushed, false when
 // the worktree was clean (nothing to push).
@@ -241,11 +291,16 @@ func (c *LocalGitContext) StageCommitAndPush(
 		globalCfg = config.NewConfig()
 	}
 
-	gpgSign := gitHelpers.GetOptionFromConfig(localCfg, globalCfg, "commit", "gpgsign")
-	signer, err := signingInfra.ResolveSignerFromGitConfig(
-		gpgSign,
+	gpgSign, signingFormat, signingKey := overlaySigning(
+		c.signing,
+		gitHelpers.GetOptionFromConfig(localCfg, globalCfg, "commit", "gpgsign"),
 		userConfig.SigningFormat,
 		userConfig.SigningKey,
+	)
+	signer, err := signingInfra.ResolveSignerFromGitConfig(
+		gpgSign,
+		signingFormat,
+		signingKey,
 		"",
 		os.Getenv("GPG_PASSPHRASE"),
 		"autoupdate",
@@ -269,13 +324,52 @@ func (c *LocalGitContext) StageCommitAndPush(
 		return false, fmt.Errorf("failed to collect auth methods: %w", err)
 	}
 
-	if err = gitops.PushWithTransportDetection(c.repo, refSpec, authMethods); err != nil {
+	if err = c.push(refSpec, authMethods); err != nil {
 		return false, fmt.Errorf("failed to push branch %s: %w", branchName, err)
 	}
 
 	return true, nil
 }
 
+// push sends refSpec to the context's remote. Origin goes through
+// gitforge's transport detection, which only targets origin; any other
+// remote tries each auth method in turn and, over SSH, falls back to the
+// SSH agent like gitforge does.
+func (c *LocalGitContext) push(refSpec config.RefSpec, authMethods []transport.AuthMethod) error {
+	if c.remote == DefaultRemote {
+		return gitops.PushWithTransportDetection(c.repo, refSpec, authMethods)
+	}
+
+	remoteCfg, err := c.repo.Remote(c.remote)
+	if err != nil {
+		return fmt.Errorf("failed to get %s remote: %w", c.remote, err)
+	}
+	urls := remoteCfg.Config().URLs
+	if len(urls) == 0 {
+		return fmt.Errorf("%s remote has no URLs configured", c.remote)
+	}
+	if strings.HasPrefix(urls[0], "git@") || strings.HasPrefix(urls[0], "ssh://") {
+		authMethods = append(authMethods, nil) // nil auth uses the SSH agent
+	}
+	if len(authMethods) == 0 {
+		return fmt.Errorf("no authentication methods provided to push to %s", c.remote)
+	}
+
+	var lastErr error
+	for _, method := range authMethods {
+		lastErr = c.repo.Push(&git.PushOptions{
+			RemoteName: c.remote,
+			RefSpecs:   []config.RefSpec{refSpec},
+			Auth:       method,
+		})
+		if lastErr == nil {
+			return nil
+		}
+		logger.Debugf("Push to %s failed with auth method %T: %v", c.remote, method, lastErr)
+	}
+	return fmt.Errorf("could not push to %s: %w", c.remote, lastErr)
+}
+
 // collectAuthMethods resolves the remote URL, finds the matching provider,
 // and collects all available auth methods for push.  Returns nil (no auth
 // methods) when the resolver is nil, which is fine for SSH push where auth
@@ -285,9 +379,9 @@ func (c *LocalGitContext) collectAuthMethods(authToken string) ([]transport.Auth
 		return nil, nil
 	}
 
-	remoteCfg, err := c.repo.Remote("origin")
+	remoteCfg, err := c.repo.Remote(c.remote)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get origin remote: %w", err)
+		return nil, fmt.Errorf("failed to get %s remote: %w", c.remote, err)
 	}
 
 	urls := remoteCfg.Config().URLs
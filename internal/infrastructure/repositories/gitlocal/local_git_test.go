@@ -4,6 +4,7 @@ package gitlocal_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
 )
 
@@ -173,6 +175,61 @@ func TestCreateBranch(t *testing.T) {
 		require.NoError(t, headErr)
 		assert.Equal(t, "refs/heads/chore/test-branch", head.Name().String())
 	})
+
+	t.Run("should start from the remote branch set by UseBaseBranch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		repo, err := git.PlainOpen(repoDir)
+		require.NoError(t, err)
+		main, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		require.NoError(t, err)
+		wt, err := repo.Worktree()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("x"), 0o600))
+		_, err = wt.Add("feature.txt")
+		require.NoError(t, err)
+		_, err = wt.Commit("feature commit", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.Storer.SetReference(
+			plumbing.NewHashReference(plumbing.NewRemoteReferenceName("upstream", "develop"), main.Hash()),
+		))
+		ctx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+		require.NoError(t, err)
+		ctx.UseRemote("upstream")
+		ctx.UseBaseBranch("develop")
+
+		// when
+		err = ctx.CreateBranch("chore/test-branch")
+
+		// then
+		require.NoError(t, err)
+		head, headErr := repo.Head()
+		require.NoError(t, headErr)
+		assert.Equal(t, "refs/heads/chore/test-branch", head.Name().String())
+		assert.Equal(t, main.Hash(), head.Hash())
+		assert.NoFileExists(t, filepath.Join(repoDir, "feature.txt"))
+	})
+
+	t.Run("should return error when the base branch has no remote-tracking ref", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		ctx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+		require.NoError(t, err)
+		ctx.UseBaseBranch("develop")
+
+		// when
+		err = ctx.CreateBranch("chore/test-branch")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to resolve origin/develop")
+	})
 }
 
 func TestHasChanges(t *testing.T) {
@@ -372,10 +429,102 @@ func TestStageCommitAndPush(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "no authentication methods provided for HTTPS push")
 	})
+
+	t.Run("should push to the remote set by UseRemote", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithHTTPSRemote(t)
+		repo, err := git.PlainOpen(repoDir)
+		require.NoError(t, err)
+		_, err = repo.CreateRemote(&gitconfig.RemoteConfig{
+			Name: "upstream",
+			URLs: []string{"https://github.com/upstream/test-repo.git"},
+		})
+		require.NoError(t, err)
+		ctx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+		require.NoError(t, err)
+		ctx.UseRemote("upstream")
+		require.NoError(t, ctx.CreateBranch("chore/test-branch"))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "file.txt"), []byte("x"), 0o600))
+
+		// when
+		_, err = ctx.StageCommitAndPush("chore/test-branch", "msg", "token")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no authentication methods provided to push to upstream")
+	})
+
+	t.Run("should return error when the remote set by UseRemote does not exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithHTTPSRemote(t)
+		ctx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+		require.NoError(t, err)
+		ctx.UseRemote("upstream")
+		require.NoError(t, ctx.CreateBranch("chore/test-branch"))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "file.txt"), []byte("x"), 0o600))
+
+		// when
+		_, err = ctx.StageCommitAndPush("chore/test-branch", "msg", "token")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get upstream remote")
+	})
+
+	t.Run("should sign the commit with the key set by UseSigning", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := createTestRepoWithCommit(t)
+		ctx, err := gitlocal.NewLocalGitContext(repoDir, nil)
+		require.NoError(t, err)
+		ctx.UseSigning(entities.Signing{Key: newSSHSigningKey(t), Format: entities.SigningFormatSSH})
+		require.NoError(t, ctx.CreateBranch("chore/test-branch"))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "update.txt"), []byte("data"), 0o600))
+
+		// when
+		_, err = ctx.StageCommitAndPush("chore/test-branch", "chore(deps): test commit", "fake-token")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to push")
+		assert.Contains(t, headCommit(t, repoDir).PGPSignature, "BEGIN SSH SIGNATURE")
+	})
 }
 
 // --- test helpers ---
 
+// newSSHSigningKey generates a passphrase-less ed25519 key and returns the
+// path of its private half, which ssh-keygen signs with.
+func newSSHSigningKey(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath).CombinedOutput()
+	require.NoError(t, err, string(out))
+	return keyPath
+}
+
+// headCommit returns the commit HEAD points to in repoDir.
+func headCommit(t *testing.T, repoDir string) *object.Commit {
+	t.Helper()
+
+	repo, err := git.PlainOpen(repoDir)
+	require.NoError(t, err)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	commit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	return commit
+}
+
 func createTestRepoWithHTTPSRemote(t *testing.T) string {
 	t.Helper()
 
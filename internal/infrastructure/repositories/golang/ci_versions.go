@@ -0,0 +1,171 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+const ciFileMode = 0o600
+
+// defaultCIFiles are the CI files bumped when the ci_files option is unset.
+var defaultCIFiles = []string{".github/workflows/*.yml", ".github/workflows/*.yaml"}
+
+var (
+	// ciScalarPattern matches `go-version: 1.24` (optionally quoted, `.x` suffix).
+	ciScalarPattern = regexp.MustCompile(
+		`^(\s*(?:-\s+)?go-version:\s*)(['"]?)(\d+\.\d+(?:\.\d+)?(?:\.x)?)(['"]?)(\s*(?:#.*)?)$`,
+	)
+	// ciFlowListPattern matches `go-version: ['1.23', '1.24']`.
+	ciFlowListPattern = regexp.MustCompile(`^(\s*(?:-\s+)?go-version:\s*)\[([^\]]*)\](\s*(?:#.*)?)$`)
+	// ciBlockKeyPattern matches a `go-version:` key whose value is a block list.
+	ciBlockKeyPattern = regexp.MustCompile(`^(\s*)(?:-\s+)?go-version:\s*(?:#.*)?$`)
+	// ciBlockItemPattern matches one `- '1.24'` entry of a block list.
+	ciBlockItemPattern = regexp.MustCompile(`^(\s*-\s*)(['"]?)(\d+\.\d+(?:\.\d+)?(?:\.x)?)(['"]?)(\s*(?:#.*)?)$`)
+	// ciVersionPattern matches a bare version inside a flow list item.
+	ciVersionPattern = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?(?:\.x)?`)
+)
+
+// ciFilePatterns returns the configured CI file patterns, falling back to
+// the GitHub Actions workflows.
+func ciFilePatterns(configured []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	return defaultCIFiles
+}
+
+// updateCIGoVersions bumps the `go-version:` fields of the CI files matching
+// patterns (globs relative to repoDir) to goVersion. In a version matrix only
+// the highest entry moves, so older versions kept for compatibility testing
+// stay untouched. It returns the repository-relative paths it rewrote.
+func updateCIGoVersions(repoDir string, patterns []string, goVersion string) []string {
+	var changed []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(repoDir, pattern))
+		if err != nil {
+			logger.Warnf("[golang] Invalid CI file pattern %q: %v", pattern, err)
+			continue
+		}
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			data, readErr := os.ReadFile(filepath.Clean(path))
+			if readErr != nil {
+				logger.Warnf("[golang] Failed to read CI file %s: %v", path, readErr)
+				continue
+			}
+			updated := bumpCIGoVersions(string(data), goVersion)
+			if updated == string(data) {
+				continue
+			}
+			if writeErr := os.WriteFile(path, []byte(updated), ciFileMode); writeErr != nil {
+				logger.Warnf("[golang] Failed to write CI file %s: %v", path, writeErr)
+				continue
+			}
+			rel, _ := filepath.Rel(repoDir, path)
+			changed = append(changed, filepath.ToSlash(rel))
+		}
+	}
+	return changed
+}
+
+// bumpCIGoVersions rewrites every `go-version:` field in a YAML document.
+// Scalars are replaced, while flow and block lists only have their highest
+// entry replaced. Versions are never downgraded and keep their original
+// precision and quoting (`1.24` becomes `1.25`, `1.24.x` becomes `1.25.x`).
+func bumpCIGoVersions(content, goVersion string) string {
+	lines := strings.Split(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if m := ciScalarPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + m[2] + bumpCIVersion(m[3], goVersion) + m[4] + m[5]
+			continue
+		}
+		if m := ciFlowListPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + "[" + bumpHighestFlowItem(m[2], goVersion) + "]" + m[3]
+			continue
+		}
+		if m := ciBlockKeyPattern.FindStringSubmatch(line); m != nil {
+			i = bumpBlockList(lines, i+1, goVersion) - 1
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// bumpHighestFlowItem replaces the highest version of a comma-separated
+// flow list body such as `'1.23', '1.24'`.
+func bumpHighestFlowItem(body, goVersion string) string {
+	items := strings.Split(body, ",")
+	highest := -1
+	for idx, item := range items {
+		version := ciVersionPattern.FindString(item)
+		if version == "" {
+			continue
+		}
+		if highest < 0 || compareGoVersions(trimPatchWildcard(version),
+			trimPatchWildcard(ciVersionPattern.FindString(items[highest]))) > 0 {
+			highest = idx
+		}
+	}
+	if highest < 0 {
+		return body
+	}
+	version := ciVersionPattern.FindString(items[highest])
+	items[highest] = strings.Replace(items[highest], version, bumpCIVersion(version, goVersion), 1)
+	return strings.Join(items, ",")
+}
+
+// bumpBlockList bumps the highest entry of the block list starting at
+// lines[start] and returns the index of the first line after the list.
+func bumpBlockList(lines []string, start int, goVersion string) int {
+	highest := -1
+	end := start
+	for ; end < len(lines); end++ {
+		m := ciBlockItemPattern.FindStringSubmatch(lines[end])
+		if m == nil {
+			break
+		}
+		if highest < 0 || compareGoVersions(trimPatchWildcard(m[3]),
+			trimPatchWildcard(ciBlockItemPattern.FindStringSubmatch(lines[highest])[3])) > 0 {
+			highest = end
+		}
+	}
+	if highest >= 0 {
+		m := ciBlockItemPattern.FindStringSubmatch(lines[highest])
+		lines[highest] = m[1] + m[2] + bumpCIVersion(m[3], goVersion) + m[4] + m[5]
+	}
+	return end
+}
+
+// bumpCIVersion returns goVersion formatted with the precision of current,
+// or current itself when it is already at or above goVersion.
+func bumpCIVersion(current, goVersion string) string {
+	wildcard := strings.HasSuffix(current, ".x")
+	base := trimPatchWildcard(current)
+
+	parts := strings.Split(goVersion, ".")
+	if precision := len(strings.Split(base, ".")); precision < len(parts) {
+		parts = parts[:precision]
+	}
+	target := strings.Join(parts, ".")
+	if compareGoVersions(base, target) >= 0 {
+		return current
+	}
+	if wildcard {
+		return target + ".x"
+	}
+	return target
+}
+
+// trimPatchWildcard strips the `.x` patch wildcard used by setup-go.
+func trimPatchWildcard(version string) string {
+	return strings.TrimSuffix(version, ".x")
+}
@@ -0,0 +1,128 @@
+//go:build unit
+
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/golang"
+)
+
+func TestBumpCIGoVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "should bump a quoted scalar keeping its precision",
+			content:  "      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.24'\n",
+			expected: "      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.25'\n",
+		},
+		{
+			name:     "should bump only the highest entry of a flow list",
+			content:  "      matrix:\n        go-version: ['1.23', '1.24']\n",
+			expected: "      matrix:\n        go-version: ['1.23', '1.25']\n",
+		},
+		{
+			name:     "should bump only the highest entry of a block list",
+			content:  "      matrix:\n        go-version:\n          - \"1.24.x\"\n          - \"1.23.x\"\n    steps:\n",
+			expected: "      matrix:\n        go-version:\n          - \"1.25.x\"\n          - \"1.23.x\"\n    steps:\n",
+		},
+		{
+			name:     "should keep a full patch version",
+			content:  "go-version: 1.24.5 # pinned\n",
+			expected: "go-version: 1.25.7 # pinned\n",
+		},
+		{
+			name:     "should not downgrade a newer version",
+			content:  "go-version: '1.26'\n",
+			expected: "go-version: '1.26'\n",
+		},
+		{
+			name:     "should leave expressions untouched",
+			content:  "go-version: ${{ matrix.go }}\n",
+			expected: "go-version: ${{ matrix.go }}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			result := goUpdater.BumpCIGoVersions(tt.content, "1.25.7")
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUpdateCIGoVersions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should rewrite only the files matching the configured patterns", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		workflows := filepath.Join(repoDir, ".github", "workflows")
+		require.NoError(t, os.MkdirAll(workflows, 0o750))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(workflows, "ci.yml"), []byte("go-version: ['1.23', '1.24']\n"), 0o600,
+		))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(repoDir, "other.yml"), []byte("go-version: '1.24'\n"), 0o600,
+		))
+
+		// when
+		changed := goUpdater.UpdateCIGoVersions(repoDir, []string{".github/workflows/*.yml"}, "1.25.7")
+
+		// then
+		assert.Equal(t, []string{".github/workflows/ci.yml"}, changed)
+		ci, err := os.ReadFile(filepath.Join(workflows, "ci.yml"))
+		require.NoError(t, err)
+		assert.Equal(t, "go-version: ['1.23', '1.25']\n", string(ci))
+		other, err := os.ReadFile(filepath.Join(repoDir, "other.yml"))
+		require.NoError(t, err)
+		assert.Equal(t, "go-version: '1.24'\n", string(other))
+	})
+}
+
+func TestCIFilePatterns(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should default to the GitHub Actions workflows", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var configured []string
+
+		// when
+		patterns := goUpdater.CIFilePatterns(configured)
+
+		// then
+		assert.Equal(t, []string{".github/workflows/*.yml", ".github/workflows/*.yaml"}, patterns)
+	})
+
+	t.Run("should keep the configured patterns", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		configured := []string{".gitlab-ci.yml"}
+
+		// when
+		patterns := goUpdater.CIFilePatterns(configured)
+
+		// then
+		assert.Equal(t, configured, patterns)
+	})
+}
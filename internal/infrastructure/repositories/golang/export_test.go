@@ -22,13 +22,27 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	maxVersion ...string,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	return resolveVersionContext(ctx, provider, repo, latestVersion, firstOrEmpty(maxVersion))
 }
 
 // LocalResolveVersionContext is exported for testing.
-func LocalResolveVersionContext(repoDir, latestVersion string) *versionContext {
-	return localResolveVersionContext(repoDir, latestVersion)
+func LocalResolveVersionContext(repoDir, latestVersion string, maxVersion ...string) *versionContext {
+	return localResolveVersionContext(repoDir, latestVersion, firstOrEmpty(maxVersion))
+}
+
+// CompareGoVersions is exported for testing.
+func CompareGoVersions(a, b string) int {
+	return compareGoVersions(a, b)
+}
+
+// firstOrEmpty returns the first element of values, or "" when it is empty.
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
 }
 
 // VersionContext is exported for testing.
@@ -45,8 +59,8 @@ func BuildEnv(params upgradeParams, repoDir, goBinary string) []string {
 }
 
 // BuildLocalGoScript is exported for testing.
-func BuildLocalGoScript(providerName string, hasConfigSH bool) string {
-	return buildLocalGoScript(providerName, hasConfigSH)
+func BuildLocalGoScript(providerName string, hasConfigSH bool, runFmt ...bool) string {
+	return buildLocalGoScript(providerName, hasConfigSH, len(runFmt) > 0 && runFmt[0])
 }
 
 
@@ -62,8 +76,13 @@ func PrepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog ...entities.ChangelogSettings,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	settings := entities.ChangelogSettings{}
+	if len(changelog) > 0 {
+		settings = changelog[0]
+	}
+	return prepareChangelog(ctx, provider, repo, vCtx, settings)
 }
 
 // NewUpdaterRepositoryForTest creates an updater with injected dependencies.
@@ -75,6 +94,15 @@ func NewUpdaterRepositoryForTest(vf VersionFetcher, runner ...cmdrunner.Runner)
 	return &UpdaterRepository{versionFetcher: vf, cmdRunner: r}
 }
 
+// UpgradeGoRepo is exported for testing; it returns the captured script output.
+func UpgradeGoRepo(ctx context.Context, params upgradeParams) (string, error) {
+	result, err := upgradeGoRepo(ctx, params)
+	if result == nil {
+		return "", err
+	}
+	return result.Output, err
+}
+
 // SetDefaultRunner overrides the package-level command runner for testing.
 func SetDefaultRunner(r cmdrunner.Runner) func() {
 	old := defaultRunner
@@ -135,7 +163,7 @@ func BuildLocalEnvFull(params localUpgradeParams, goBinary string) []string {
 
 // PrepareLocalChangelog is exported for testing.
 func PrepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	return prepareLocalChangelog(repoDir, vCtx)
+	return prepareLocalChangelog(repoDir, vCtx, entities.ChangelogSettings{})
 }
 
 // SetLocalCmdRunner overrides the package-level local command runner for testing.
@@ -150,7 +178,52 @@ func RunLanguageUpgradeScript(
 	ctx context.Context,
 	repoDir string,
 	vCtx *versionContext,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	return runLanguageUpgradeScript(ctx, repoDir, vCtx, opts)
+	return runLanguageUpgradeScript(ctx, repoDir, vCtx, changelogFile, opts)
+}
+
+// BumpCIGoVersions is exported for testing.
+func BumpCIGoVersions(content, goVersion string) string {
+	return bumpCIGoVersions(content, goVersion)
+}
+
+// CIFilePatterns is exported for testing.
+func CIFilePatterns(configured []string) []string {
+	return ciFilePatterns(configured)
+}
+
+// UpdateCIGoVersions is exported for testing.
+func UpdateCIGoVersions(repoDir string, patterns []string, goVersion string) []string {
+	return updateCIGoVersions(repoDir, patterns, goVersion)
+}
+
+// ToolchainTarget is exported for testing.
+func ToolchainTarget(goMod, goVersion string) string {
+	return toolchainTarget(goMod, goVersion)
+}
+
+// ParseToolchainDirective is exported for testing.
+func ParseToolchainDirective(content string) string {
+	return parseToolchainDirective(content)
+}
+
+// ParseModuleChanges is exported for testing.
+func ParseModuleChanges(output string) []ModuleChange {
+	return parseModuleChanges(output)
+}
+
+// WriteChangelogUpdate is exported for testing.
+func WriteChangelogUpdate() string {
+	var sb strings.Builder
+	writeChangelogUpdate(&sb)
+	return sb.String()
+}
+
+// WriteDockerfileUpdate is exported for testing.
+func WriteDockerfileUpdate() string {
+	var sb strings.Builder
+	writeDockerfileUpdate(&sb)
+	return sb.String()
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	logger "github.com/sirupsen/logrus"
+	"golang.org/x/mod/semver"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
@@ -69,6 +70,16 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Go module dependencies and the go directive in go.mod"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langGolang.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository has Go marker files (e.g. go.mod).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -99,7 +110,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	}
 	logger.Infof("[golang] Latest stable Go version: %s", latestGoVersion)
 
-	vCtx := resolveVersionContext(ctx, provider, repo, latestGoVersion)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestGoVersion, opts.MaxVersion)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -129,7 +140,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		return []entities.PullRequest{}, nil
 	}
 
-	result, hasConfigSH, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx)
+	result, hasConfigSH, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -150,7 +161,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[golang] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
@@ -160,45 +171,52 @@ func (u *UpdaterRepository) ApplyUpdates(
 	}
 	logger.Infof("[golang] Latest stable Go version: %s", latestGoVersion)
 
-	vCtx := localResolveVersionContext(repoDir, latestGoVersion)
-
-	hasConfigSH := fileExistsLocally(filepath.Join(repoDir, "config.sh"))
+	workDirs := support.ScopedDirs(repoDir, opts.Paths, "go.mod")
+	if len(workDirs) == 0 {
+		logger.Infof("[golang] No go.mod found under the configured paths %v", opts.Paths)
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
 
 	goBinary, goErr := findGoBinary()
 	if goErr != nil {
 		return nil, fmt.Errorf("go binary not found: %w", goErr)
 	}
 
-	script := buildLocalGoScript(provider.Name(), hasConfigSH)
-	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
-	if writeErr := os.WriteFile(scriptPath, []byte(script), scriptFileMode); writeErr != nil {
-		return nil, fmt.Errorf("failed to write script: %w", writeErr)
+	// Each module keeps its own go directive, so whether the version moves
+	// is decided per directory. The PR follows the first one that needs the
+	// upgrade.
+	dirContexts := make([]*versionContext, len(workDirs))
+	for i, workDir := range workDirs {
+		dirContexts[i] = localResolveVersionContext(workDir, latestGoVersion, opts.MaxVersion)
 	}
-	defer func() { _ = os.Remove(scriptPath) }()
-
-	runResult, cmdErr := u.cmdRunner.Run(ctx, "bash", []string{scriptPath}, cmdrunner.RunOptions{
-		Dir: repoDir,
-		Env: append(os.Environ(),
-			"AUTH_TOKEN="+provider.AuthToken(),
-			"GIT_HTTPS_TOKEN="+provider.AuthToken(),
-			"GO_VERSION="+vCtx.LatestVersion,
-			"GO_BINARY="+goBinary,
-		),
-	})
-	outputStr := ""
-	if runResult != nil {
-		outputStr = support.RedactTokens(runResult.Output, provider.AuthToken())
+	vCtx := dirContexts[0]
+	for _, dirCtx := range dirContexts {
+		if dirCtx.NeedsVersionUpgrade {
+			vCtx = dirCtx
+			break
+		}
 	}
-	logger.Debugf("[golang] Upgrade script output:\n%s", outputStr)
 
-	// Remove the script before checking worktree state so it does not
-	// appear as an untracked file in the git status check below.
-	_ = os.Remove(scriptPath)
-	if cmdErr != nil {
-		return nil, fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, outputStr)
+	hasConfigSH := false
+	outputs := make([]string, 0, len(workDirs))
+	for i, workDir := range workDirs {
+		dirHasConfigSH := fileExistsLocally(filepath.Join(workDir, "config.sh"))
+		hasConfigSH = hasConfigSH || dirHasConfigSH
+		output, err := u.runBatchScript(ctx, repoDir, workDir, goBinary, dirHasConfigSH, provider, dirContexts[i], opts)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
 	}
+	outputStr := strings.Join(outputs, "\n")
 
 	goVersionUpdated := strings.Contains(outputStr, "GO_VERSION_UPDATED=true")
+	if goVersionUpdated {
+		changedCI := updateCIGoVersions(repoDir, ciFilePatterns(opts.CIFiles), vCtx.LatestVersion)
+		if len(changedCI) > 0 {
+			logger.Infof("[golang] Updated CI Go versions in %v", changedCI)
+		}
+	}
 
 	// Return early if the upgrade script made no filesystem changes
 	if !support.HasUncommittedChanges(ctx, repoDir) {
@@ -207,7 +225,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 	}
 	logger.Infof("[golang] Filesystem changes detected, proceeding with commit")
 
-	// Update CHANGELOG locally
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if goVersionUpdated {
 		entry = fmt.Sprintf(
@@ -217,38 +235,95 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = goChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := goCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := goCommitMsgDeps
 	if goVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded Go version to `%s` and updated all dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
 
+	moduleChanges := parseModuleChanges(outputStr)
+	changes := toDependencyChanges(moduleChanges)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, changes),
+		prTitle, GenerateGoPRDescription(vCtx.LatestVersion, hasConfigSH, goVersionUpdated, moduleChanges),
+	)
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GenerateGoPRDescription(vCtx.LatestVersion, hasConfigSH, goVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		Changes:          changes,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
+// runBatchScript runs the Go upgrade script in workDir, one of the module
+// directories of the clone at repoDir, and returns its redacted output.
+func (u *UpdaterRepository) runBatchScript(
+	ctx context.Context,
+	repoDir, workDir, goBinary string,
+	hasConfigSH bool,
+	provider repositories.ProviderRepository,
+	vCtx *versionContext,
+	opts entities.UpdateOptions,
+) (string, error) {
+	script := buildLocalGoScript(provider.Name(), hasConfigSH, opts.RunFmt)
+	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
+	if writeErr := os.WriteFile(scriptPath, []byte(script), scriptFileMode); writeErr != nil {
+		return "", fmt.Errorf("failed to write script: %w", writeErr)
+	}
+	// Remove the script before the caller checks the worktree state so it
+	// does not appear as an untracked file in the git status check.
+	defer func() { _ = os.Remove(scriptPath) }()
+
+	runResult, cmdErr := u.cmdRunner.Run(ctx, "bash", []string{scriptPath}, cmdrunner.RunOptions{
+		Dir: workDir,
+		Env: append(os.Environ(),
+			"AUTH_TOKEN="+provider.AuthToken(),
+			"GIT_HTTPS_TOKEN="+provider.AuthToken(),
+			"GO_VERSION="+vCtx.LatestVersion,
+			"TOOLCHAIN_VERSION="+vCtx.ToolchainVersion,
+			"GO_BINARY="+goBinary,
+			excludeModulesEnv(opts.ExcludeModules),
+			onlyModulesEnv(opts.OnlyModules),
+			fmt.Sprintf("GO_DIRECT_ONLY=%t", opts.DirectOnly),
+			fmt.Sprintf("GO_ALLOW_RETRACTED=%t", opts.AllowYanked),
+			dockerfileImagesEnv(opts.DockerfileImages),
+		),
+	})
+	outputStr := ""
+	if runResult != nil {
+		outputStr = support.RedactTokens(runResult.Output, provider.AuthToken())
+	}
+	logger.Debugf("[golang] Upgrade script output:\n%s", outputStr)
+	if cmdErr != nil {
+		return "", fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, outputStr)
+	}
+	return outputStr, nil
+}
+
 // localResolveVersionContext reads the local go.mod to determine the version
 // context instead of using the provider API.
-func localResolveVersionContext(repoDir, latestGoVersion string) *versionContext {
+func localResolveVersionContext(repoDir, latestGoVersion, maxVersion string) *versionContext {
 	needsVersionUpgrade := true
+	var toolchainVersion string
 	goModPath := filepath.Join(repoDir, "go.mod")
 	data, err := os.ReadFile(goModPath)
 	if err != nil {
 		logger.Warnf("[golang] Could not read local go.mod, assuming version upgrade: %v", err)
+		latestGoVersion = capGoVersion("", latestGoVersion, maxVersion)
 	} else {
 		currentGoVersion := parseGoDirective(string(data))
+		latestGoVersion = capGoVersion(currentGoVersion, latestGoVersion, maxVersion)
 		needsVersionUpgrade = currentGoVersion != latestGoVersion
 		logger.Infof("[golang] Current go directive: %s (upgrade needed: %v)", currentGoVersion, needsVersionUpgrade)
+		if needsVersionUpgrade {
+			toolchainVersion = toolchainTarget(string(data), latestGoVersion)
+		}
 	}
 
 	branchName := branchGoDepsFmt
@@ -260,12 +335,14 @@ func localResolveVersionContext(repoDir, latestGoVersion string) *versionContext
 		LatestVersion:       latestGoVersion,
 		NeedsVersionUpgrade: needsVersionUpgrade,
 		BranchName:          branchName,
+		ToolchainVersion:    toolchainVersion,
 	}
 }
 
 // buildLocalGoScript generates a bash script with only language-specific
-// operations (no git clone, branch, commit, or push).
-func buildLocalGoScript(providerName string, hasConfigSH bool) string {
+// operations (no git clone, branch, commit, or push). When runFmt is set the
+// sources are reformatted after the upgrade.
+func buildLocalGoScript(providerName string, hasConfigSH, runFmt bool) string {
 	var sb strings.Builder
 
 	sb.WriteString("#!/bin/bash\n")
@@ -296,6 +373,9 @@ func buildLocalGoScript(providerName string, hasConfigSH bool) string {
 	}
 
 	writeGoUpgradeCommands(&sb)
+	if runFmt {
+		writeGoFmt(&sb)
+	}
 	writeDockerfileUpdate(&sb)
 
 	return sb.String()
@@ -314,9 +394,10 @@ func cloneAndUpgrade(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, bool, error) {
 	hasConfigSH := provider.HasFile(ctx, repo, "config.sh")
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -325,14 +406,25 @@ func cloneAndUpgrade(
 	defaultBranch := strings.TrimPrefix(repo.DefaultBranch, "refs/heads/")
 
 	result, err := upgradeGoRepo(ctx, upgradeParams{
-		CloneURL:      cloneURL,
-		DefaultBranch: defaultBranch,
-		BranchName:    vCtx.BranchName,
-		GoVersion:     vCtx.LatestVersion,
-		AuthToken:     provider.AuthToken(),
-		HasConfigSH:   hasConfigSH,
-		ProviderName:  provider.Name(),
-		ChangelogFile: changelogFile,
+		CloneURL:         cloneURL,
+		DefaultBranch:    defaultBranch,
+		BranchName:       vCtx.BranchName,
+		GoVersion:        vCtx.LatestVersion,
+		ToolchainVersion: vCtx.ToolchainVersion,
+		AuthToken:        provider.AuthToken(),
+		HasConfigSH:      hasConfigSH,
+		ProviderName:     provider.Name(),
+		ChangelogFile:    changelogFile,
+		ChangelogPath:    opts.Changelog.FilePath(),
+		ExcludeModules:   opts.ExcludeModules,
+		OnlyModules:      opts.OnlyModules,
+		DirectOnly:       opts.DirectOnly,
+		AllowRetracted:   opts.AllowYanked,
+		RunFmt:           opts.RunFmt,
+		DockerfileImages: opts.DockerfileImages,
+		CommitAuthor:     opts.CommitAuthor,
+		CommitPrefix:     opts.CommitPrefix(),
+		Signing:          opts.Signing,
 	})
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to upgrade: %w", err)
@@ -364,13 +456,18 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GenerateGoPRDescription(vCtx.LatestVersion, hasConfigSH, result.GoVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, toDependencyChanges(result.ModuleChanges)),
+		prTitle,
+		GenerateGoPRDescription(vCtx.LatestVersion, hasConfigSH, result.GoVersionUpdated, result.ModuleChanges),
+	)
+	prDesc = support.AppendDiffStat(prDesc, result.DiffStat)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -391,29 +488,36 @@ type versionContext struct {
 	LatestVersion       string
 	NeedsVersionUpgrade bool
 	BranchName          string
+	ToolchainVersion    string // toolchain directive target; empty leaves the toolchain line alone
 }
 
 // resolveVersionContext reads the remote go.mod to find the current go
 // directive and picks the right branch-name pattern (version-upgrade vs
 // deps-only).  The latest Go version must be provided by the caller so
 // that this function stays free of HTTP calls and is fully testable with
-// provider test doubles.
+// provider test doubles. A non-empty maxVersion caps the target version.
 func resolveVersionContext(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	latestGoVersion string,
+	latestGoVersion, maxVersion string,
 ) *versionContext {
 	// Read the current go.mod from the remote to decide whether this is a
 	// version upgrade or a deps-only refresh — before cloning.
 	needsVersionUpgrade := true // safe default when go.mod cannot be read
+	var toolchainVersion string
 	goModContent, goModErr := provider.GetFileContent(ctx, repo, "go.mod")
 	if goModErr != nil {
 		logger.Warnf("[golang] Could not read remote go.mod, assuming version upgrade: %v", goModErr)
+		latestGoVersion = capGoVersion("", latestGoVersion, maxVersion)
 	} else {
 		currentGoVersion := parseGoDirective(goModContent)
+		latestGoVersion = capGoVersion(currentGoVersion, latestGoVersion, maxVersion)
 		needsVersionUpgrade = currentGoVersion != latestGoVersion
 		logger.Infof("[golang] Current go directive: %s (upgrade needed: %v)", currentGoVersion, needsVersionUpgrade)
+		if needsVersionUpgrade {
+			toolchainVersion = toolchainTarget(goModContent, latestGoVersion)
+		}
 	}
 
 	// Choose the branch name pattern based on the kind of change, following
@@ -427,10 +531,59 @@ func resolveVersionContext(
 		LatestVersion:       latestGoVersion,
 		NeedsVersionUpgrade: needsVersionUpgrade,
 		BranchName:          branchName,
+		ToolchainVersion:    toolchainVersion,
 	}
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// capGoVersion returns the Go version to target given the configured
+// ceiling. Without a ceiling, or when the latest release is within it, the
+// latest version is returned unchanged. When the current go directive
+// already reaches the ceiling, the current version is kept so the run
+// becomes a deps-only refresh instead of a downgrade.
+func capGoVersion(currentGoVersion, latestGoVersion, maxVersion string) string {
+	if maxVersion == "" || compareGoVersions(latestGoVersion, maxVersion) <= 0 {
+		return latestGoVersion
+	}
+	if currentGoVersion != "" && compareGoVersions(currentGoVersion, maxVersion) >= 0 {
+		logger.Infof(
+			"[golang] Go %s already reaches the configured max version %s, updating dependencies only",
+			currentGoVersion, maxVersion,
+		)
+		return currentGoVersion
+	}
+	logger.Infof("[golang] Capping target Go version %s at the configured max version %s", latestGoVersion, maxVersion)
+	return maxVersion
+}
+
+// toolchainTarget returns the version the `toolchain` directive of goMod
+// moves to when the go directive is bumped to goVersion. Toolchain names
+// always carry a patch version, so a two-part target such as 1.24 becomes
+// 1.24.0. It returns "" when goMod has no toolchain line or already names a
+// toolchain at or above the target, so a capped upgrade never downgrades it.
+func toolchainTarget(goMod, goVersion string) string {
+	current := parseToolchainDirective(goMod)
+	if current == "" {
+		return ""
+	}
+	target := goVersion
+	if strings.Count(target, ".") == 1 {
+		target += ".0"
+	}
+	if compareGoVersions(current, target) >= 0 {
+		logger.Infof("[golang] Toolchain go%s already reaches go%s, leaving it unchanged", current, target)
+		return ""
+	}
+	logger.Infof("[golang] Toolchain directive go%s will follow the go directive to go%s", current, target)
+	return target
+}
+
+// compareGoVersions compares two Go versions such as "1.9", "1.24" or
+// "1.25.7" numerically, so "1.9" sorts before "1.10". It returns -1, 0 or +1.
+func compareGoVersions(a, b string) int {
+	return semver.Compare("v"+strings.TrimPrefix(a, "go"), "v"+strings.TrimPrefix(b, "go"))
+}
+
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the Go upgrade, and writes the modified
 // content to a temp file.  Returns the temp file path, or "" if no
 // changelog is present or reading/writing fails.
@@ -439,14 +592,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[golang] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[golang] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -460,7 +614,7 @@ func prepareChangelog(
 		entry = goChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -493,12 +647,38 @@ type upgradeParams struct {
 	HasConfigSH   bool
 	ProviderName  string
 	ChangelogFile string // path to a temp file with updated CHANGELOG.md content (empty = no changelog)
+	ChangelogPath string // changelog path relative to the repository root
+	// ToolchainVersion is the toolchain directive target; empty leaves it alone.
+	ToolchainVersion string
+	// ExcludeModules lists module paths held at their current version.
+	ExcludeModules []string
+	// OnlyModules lists the only module paths bumped, each to its latest version.
+	OnlyModules []string
+	// DirectOnly upgrades only the modules required directly by go.mod.
+	DirectOnly bool
+	// AllowRetracted lets the listed-modules upgrade pick retracted versions.
+	AllowRetracted bool
+	// RunFmt reformats the sources with gofmt (and goimports) after tidy.
+	RunFmt bool
+	// DockerfileImages lists the image names bumped in Dockerfiles.
+	DockerfileImages []string
+	// CommitAuthor overrides the git identity of the upgrade commit.
+	CommitAuthor entities.CommitAuthor
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
+	// Signing, when it has a key, makes the script sign the upgrade commit.
+	Signing entities.Signing
 }
 
 type upgradeResult struct {
 	HasChanges       bool
 	GoVersionUpdated bool
 	Output           string
+	// ModuleChanges lists the go.mod requirements the upgrade moved.
+	ModuleChanges []ModuleChange
+	// DiffStat is the `git diff --shortstat` of the upgrade commit.
+	DiffStat string
 }
 
 // --- Go version fetching ---
@@ -523,6 +703,19 @@ func parseGoDirective(goModContent string) string {
 	return ""
 }
 
+// parseToolchainDirective extracts the version from a go.mod's "toolchain"
+// directive. For example, given "toolchain go1.25.7", it returns "1.25.7".
+// It returns "" when the go.mod has no toolchain line.
+func parseToolchainDirective(goModContent string) string {
+	for line := range strings.SplitSeq(goModContent, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= goDirectiveFields && fields[0] == "toolchain" {
+			return strings.TrimPrefix(fields[1], "go")
+		}
+	}
+	return ""
+}
+
 // --- clone + upgrade ---
 
 func upgradeGoRepo(
@@ -559,7 +752,7 @@ func upgradeGoRepo(
 		Env: buildEnv(params, repoDir, goBinary),
 	})
 	if runResult != nil {
-		result.Output = runResult.Output
+		result.Output = support.RedactTokens(runResult.Output, params.AuthToken)
 	}
 
 	if runErr != nil {
@@ -570,6 +763,8 @@ func upgradeGoRepo(
 
 	result.HasChanges = strings.Contains(result.Output, "CHANGES_PUSHED=true")
 	result.GoVersionUpdated = strings.Contains(result.Output, "GO_VERSION_UPDATED=true")
+	result.ModuleChanges = parseModuleChanges(result.Output)
+	result.DiffStat = support.ParseDiffStat(result.Output)
 	return result, nil
 }
 
@@ -606,12 +801,17 @@ func buildUpgradeScript(
 	// defaults when the values are missing so that any user-provided
 	// configuration (e.g. from ~/.gitconfig) is preserved.
 	sb.WriteString("# Ensure git user identity is configured\n")
-	sb.WriteString("if ! git config --global user.name > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_NAME:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.name \"$COMMIT_AUTHOR_NAME\"\n")
+	sb.WriteString("elif ! git config --global user.name > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.name \"autoupdate[bot]\"\n")
 	sb.WriteString("fi\n")
-	sb.WriteString("if ! git config --global user.email > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_EMAIL:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.email \"$COMMIT_AUTHOR_EMAIL\"\n")
+	sb.WriteString("elif ! git config --global user.email > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.email \"autoupdate[bot]@users.noreply.github.com\"\n")
 	sb.WriteString("fi\n\n")
+	sb.WriteString(support.CommitSigningScript(params.Signing))
 
 	// Clone
 	sb.WriteString("echo \"Cloning repository...\"\n")
@@ -632,6 +832,11 @@ func buildUpgradeScript(
 	// Go upgrade commands
 	writeGoUpgradeCommands(&sb)
 
+	// Reformat sources touched by API changes in the upgraded modules
+	if params.RunFmt {
+		writeGoFmt(&sb)
+	}
+
 	// Update Dockerfile golang image tags (only when version was bumped)
 	writeDockerfileUpdate(&sb)
 
@@ -639,7 +844,7 @@ func buildUpgradeScript(
 	writeChangelogUpdate(&sb)
 
 	// Check for changes and commit/push
-	writeCommitAndPush(&sb)
+	writeCommitAndPush(&sb, params.Signing.Key != "")
 
 	return sb.String()
 }
@@ -667,6 +872,7 @@ func writeGoUpgradeCommands(sb *strings.Builder) {
 	sb.WriteString("CURRENT_GO_VERSION=$(grep -m1 '^go ' go.mod | awk '{print $2}')\n")
 	sb.WriteString("echo \"Current Go version in go.mod: ${CURRENT_GO_VERSION:-<not found>}\"\n")
 	sb.WriteString("GO_VERSION_CHANGED=false\n\n")
+	writeModuleSnapshot(sb)
 
 	// Only update the go directive if the versions differ.
 	// Use sed + redirect-and-move instead of "go mod edit -go=" to preserve
@@ -692,6 +898,7 @@ func writeGoUpgradeCommands(sb *strings.Builder) {
 	sb.WriteString("    if [ \"$UPDATED_VERSION\" = \"$GO_VERSION\" ]; then\n")
 	sb.WriteString("        GO_VERSION_CHANGED=true\n")
 	sb.WriteString("        echo \"GO_VERSION_UPDATED=true\"\n")
+	writeToolchainUpdate(sb, "        ")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"WARNING: failed to update go directive (sed pattern did not match)\"\n")
 	sb.WriteString("        echo \"GO_VERSION_UPDATED=false\"\n")
@@ -701,10 +908,67 @@ func writeGoUpgradeCommands(sb *strings.Builder) {
 	sb.WriteString("    echo \"GO_VERSION_UPDATED=false\"\n")
 	sb.WriteString("fi\n\n")
 
-	sb.WriteString("echo \"Running go get -u -t ./...\"\n")
+	// Record the current version of every excluded module so it can be
+	// pinned back with "go mod edit -require" once "go get -u" has run.
+	sb.WriteString("# Hold excluded modules (GO_EXCLUDE_MODULES) at their current version\n")
+	sb.WriteString("HELD_MODULES=\"\"\n")
+	sb.WriteString("for mod in ${GO_EXCLUDE_MODULES:-}; do\n")
+	sb.WriteString(
+		"    held=$(\"$GO_BINARY\" list -m -f '{{.Path}}@{{.Version}}' \"$mod\" 2>/dev/null || true)\n",
+	)
+	sb.WriteString("    if [ -n \"$held\" ]; then\n")
+	sb.WriteString("        echo \"Holding $held (excluded from upgrade)\"\n")
+	sb.WriteString("        HELD_MODULES=\"$HELD_MODULES $held\"\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("done\n\n")
+
+	// GO_ONLY_MODULES limits the upgrade to the listed modules: each one is
+	// resolved to its newest release (prereleases skipped) with
+	// "go list -m -versions" and fetched with "go get", so nothing else moves
+	// beyond what the listed modules require. "go list" leaves out the
+	// versions retracted in the module's go.mod unless GO_ALLOW_RETRACTED
+	// adds -retracted; "go get -u" and "@latest" always skip them.
+	// GO_DIRECT_ONLY limits the upgrade to the modules go.mod requires
+	// directly (those without an "// indirect" comment). Indirect modules
+	// then only move as far as minimal version selection requires.
+	sb.WriteString("if [ -n \"${GO_ONLY_MODULES:-}\" ]; then\n")
+	sb.WriteString("    echo \"Running go get on the listed modules only...\"\n")
+	sb.WriteString("    RETRACTED_FLAG=\"\"\n")
+	sb.WriteString("    [ \"${GO_ALLOW_RETRACTED:-false}\" = \"true\" ] && RETRACTED_FLAG=\"-retracted\"\n")
+	sb.WriteString("    for mod in $GO_ONLY_MODULES; do\n")
+	sb.WriteString("        latest=$(\"$GO_BINARY\" list -m $RETRACTED_FLAG -versions \"$mod\" 2>/dev/null | " +
+		"awk '{for (i = NF; i > 1; i--) if ($i !~ /-/) {print $i; exit}}')\n")
+	sb.WriteString("        if [ -z \"$latest\" ]; then\n")
+	sb.WriteString("            echo \"WARNING: no released versions found for $mod, skipping\"\n")
+	sb.WriteString("            continue\n")
+	sb.WriteString("        fi\n")
+	sb.WriteString(
+		"        \"$GO_BINARY\" get \"$mod@$latest\" 2>&1 || " +
+			"echo \"WARNING: go get $mod@$latest had some errors (continuing anyway)\"\n",
+	)
+	sb.WriteString("    done\n")
+	sb.WriteString("elif [ \"${GO_DIRECT_ONLY:-false}\" = \"true\" ]; then\n")
+	sb.WriteString(
+		"    DIRECT_MODULES=$(\"$GO_BINARY\" list -m -f '{{if not (or .Indirect .Main)}}{{.Path}}@latest{{end}}' all)\n",
+	)
+	sb.WriteString("    echo \"Running go get on direct dependencies only...\"\n")
+	sb.WriteString("    if [ -n \"$DIRECT_MODULES\" ]; then\n")
 	sb.WriteString(
-		"\"$GO_BINARY\" get -u -t ./... 2>&1 || echo \"WARNING: go get -u -t had some errors (continuing anyway)\"\n\n",
+		"        \"$GO_BINARY\" get $DIRECT_MODULES 2>&1 || " +
+			"echo \"WARNING: go get on direct dependencies had some errors (continuing anyway)\"\n",
 	)
+	sb.WriteString("    fi\n")
+	sb.WriteString("else\n")
+	sb.WriteString("    echo \"Running go get -u -t ./...\"\n")
+	sb.WriteString(
+		"    \"$GO_BINARY\" get -u -t ./... 2>&1 || echo \"WARNING: go get -u -t had some errors (continuing anyway)\"\n",
+	)
+	sb.WriteString("fi\n\n")
+
+	sb.WriteString("for held in $HELD_MODULES; do\n")
+	sb.WriteString("    echo \"Pinning $held\"\n")
+	sb.WriteString("    \"$GO_BINARY\" mod edit -require=\"$held\"\n")
+	sb.WriteString("done\n\n")
 
 	sb.WriteString("echo \"Running go mod tidy...\"\n")
 	sb.WriteString(
@@ -720,14 +984,51 @@ func writeGoUpgradeCommands(sb *strings.Builder) {
 	sb.WriteString(
 		"    sed \"s/^go [0-9][0-9.]*$/go ${GO_VERSION}/\" go.mod > go.mod.tmp && mv go.mod.tmp go.mod\n",
 	)
+	sb.WriteString("fi\n")
+	sb.WriteString("if [ \"$GO_VERSION_CHANGED\" = \"true\" ]; then\n")
+	writeToolchainUpdate(sb, "    ")
 	sb.WriteString("fi\n\n")
 
+	writeModuleChanges(sb)
+
 	sb.WriteString("if [ -d \"vendor\" ]; then\n")
 	sb.WriteString("    echo \"Running go mod vendor...\"\n")
 	sb.WriteString("    \"$GO_BINARY\" mod vendor 2>&1 || echo \"WARNING: go mod vendor had some errors\"\n")
 	sb.WriteString("fi\n\n")
 }
 
+// writeToolchainUpdate emits the commands that move an existing
+// `toolchain goX.Y.Z` line to TOOLCHAIN_VERSION, so it does not go stale
+// after the go directive is bumped. toolchainTarget leaves TOOLCHAIN_VERSION
+// empty when that would downgrade the toolchain, and go.mod files without a
+// toolchain line are left alone. It uses the same portable sed + move
+// pattern as the go directive update.
+func writeToolchainUpdate(sb *strings.Builder, indent string) {
+	sb.WriteString(indent + "if [ -n \"$TOOLCHAIN_VERSION\" ] && grep -q '^toolchain go' go.mod; then\n")
+	sb.WriteString(indent + "    if ! grep -q \"^toolchain go${TOOLCHAIN_VERSION}$\" go.mod; then\n")
+	sb.WriteString(indent + "        echo \"Updating toolchain directive to go${TOOLCHAIN_VERSION}...\"\n")
+	sb.WriteString(indent + "        sed \"s/^toolchain go[0-9][0-9.]*$/toolchain go${TOOLCHAIN_VERSION}/\" go.mod " +
+		"> go.mod.tmp && mv go.mod.tmp go.mod\n")
+	sb.WriteString(indent + "    fi\n")
+	sb.WriteString(indent + "fi\n")
+}
+
+// writeGoFmt emits the commands that reformat the module after the upgrade:
+// `gofmt -w .` with the gofmt shipped alongside GO_BINARY (falling back to
+// the one on PATH), then `goimports -w .` when goimports is installed.
+// Formatting failures are reported as warnings so they never abort the run.
+func writeGoFmt(sb *strings.Builder) {
+	sb.WriteString("echo \"Formatting Go sources...\"\n")
+	sb.WriteString("GOFMT_BINARY=\"$(\"$GO_BINARY\" env GOROOT 2>/dev/null)/bin/gofmt\"\n")
+	sb.WriteString("if [ ! -x \"$GOFMT_BINARY\" ]; then\n")
+	sb.WriteString("    GOFMT_BINARY=\"gofmt\"\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("\"$GOFMT_BINARY\" -w . 2>&1 || echo \"WARNING: gofmt had some errors (continuing anyway)\"\n")
+	sb.WriteString("if command -v goimports > /dev/null 2>&1; then\n")
+	sb.WriteString("    goimports -w . 2>&1 || echo \"WARNING: goimports had some errors (continuing anyway)\"\n")
+	sb.WriteString("fi\n\n")
+}
+
 func writeDockerfileUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update Dockerfile golang image tags when the Go version was bumped.\n")
 	sb.WriteString("# Uses -print0 / read -d '' to handle paths with spaces or special characters.\n")
@@ -738,12 +1039,16 @@ func writeDockerfileUpdate(sb *strings.Builder) {
 			"\\( -name 'Dockerfile' -o -name 'Dockerfile.*' -o -name '*.Dockerfile' \\) " +
 			"-print0 | while IFS= read -r -d '' df; do\n",
 	)
-	sb.WriteString("        if grep -q 'golang:[0-9]' \"$df\"; then\n")
+	sb.WriteString("        for IMAGE in ${GO_DOCKERFILE_IMAGES:-golang}; do\n")
+	sb.WriteString("            IMAGE_RE=$(printf '%s' \"$IMAGE\" | sed 's/[].[*^$\\\\]/\\\\&/g')\n")
+	sb.WriteString("            if grep -q \"${IMAGE_RE}:[0-9]\" \"$df\"; then\n")
 	sb.WriteString(
-		"            sed \"s|golang:[0-9][0-9.]*|golang:${GO_VERSION}|g\" \"$df\" > \"$df.tmp\" && mv \"$df.tmp\" \"$df\"\n",
+		"                sed \"s|${IMAGE_RE}:[0-9][0-9.]*|${IMAGE}:${GO_VERSION}|g\" \"$df\" > \"$df.tmp\" " +
+			"&& mv \"$df.tmp\" \"$df\"\n",
 	)
-	sb.WriteString("            echo \"  Updated $df\"\n")
-	sb.WriteString("        fi\n")
+	sb.WriteString("                echo \"  Updated $df ($IMAGE)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("        done\n")
 	sb.WriteString("    done\n")
 	sb.WriteString("fi\n\n")
 }
@@ -753,25 +1058,28 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# This prevents creating empty PRs that only touch the changelog.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("fi\n\n")
 }
 
-func writeCommitAndPush(sb *strings.Builder) {
+func writeCommitAndPush(sb *strings.Builder, signed bool) {
+	gitCommit := support.GitCommitCommand(signed)
 	sb.WriteString("if [ -n \"$(git status --porcelain)\" ]; then\n")
 	sb.WriteString("    echo \"Changes detected, committing and pushing...\"\n")
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$GO_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded Go version to `$GO_VERSION` and updated all dependencies\"\n",
+		"        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded Go version to `$GO_VERSION` " +
+			"and updated all dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): update Go module dependencies\"\n")
+	sb.WriteString("        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: update Go module dependencies\"\n")
 	sb.WriteString("    fi\n")
+	sb.WriteString("    " + support.DiffStatScript)
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
 	sb.WriteString("else\n")
@@ -789,16 +1097,64 @@ func buildEnv(params upgradeParams, repoDir, goBinary string) []string {
 		"CLONE_URL="+params.CloneURL,
 		"BRANCH_NAME="+params.BranchName,
 		"GO_VERSION="+params.GoVersion,
+		"TOOLCHAIN_VERSION="+params.ToolchainVersion,
 		"REPO_DIR="+repoDir,
 		"GO_BINARY="+goBinary,
 		"DEFAULT_BRANCH="+params.DefaultBranch,
 	)
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
+	}
+	if len(params.ExcludeModules) > 0 {
+		env = append(env, excludeModulesEnv(params.ExcludeModules))
+	}
+	if len(params.OnlyModules) > 0 {
+		env = append(env, onlyModulesEnv(params.OnlyModules))
+	}
+	if params.DirectOnly {
+		env = append(env, "GO_DIRECT_ONLY=true")
+	}
+	if params.AllowRetracted {
+		env = append(env, "GO_ALLOW_RETRACTED=true")
+	}
+	if len(params.DockerfileImages) > 0 {
+		env = append(env, dockerfileImagesEnv(params.DockerfileImages))
+	}
+	if params.CommitAuthor.Name != "" {
+		env = append(env, "COMMIT_AUTHOR_NAME="+params.CommitAuthor.Name)
+	}
+	if params.CommitAuthor.Email != "" {
+		env = append(env, "COMMIT_AUTHOR_EMAIL="+params.CommitAuthor.Email)
+	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
+	if params.Signing.Key != "" {
+		env = append(env, support.SigningKeyEnv+"="+params.Signing.Key)
 	}
 	return env
 }
 
+// excludeModulesEnv renders the GO_EXCLUDE_MODULES variable read by the
+// upgrade script. Module paths never contain spaces, so they are joined
+// with one.
+func excludeModulesEnv(modules []string) string {
+	return "GO_EXCLUDE_MODULES=" + strings.Join(modules, " ")
+}
+
+// onlyModulesEnv renders the GO_ONLY_MODULES variable read by the upgrade
+// script, joined like GO_EXCLUDE_MODULES.
+func onlyModulesEnv(modules []string) string {
+	return "GO_ONLY_MODULES=" + strings.Join(modules, " ")
+}
+
+// dockerfileImagesEnv renders the GO_DOCKERFILE_IMAGES variable read by the
+// Dockerfile update. The script falls back to the official `golang` image
+// when it is empty.
+func dockerfileImagesEnv(images []string) string {
+	return "GO_DOCKERFILE_IMAGES=" + strings.Join(images, " ")
+}
+
 func findGoBinary() (string, error) {
 	if path, err := exec.LookPath("go"); err == nil {
 		return path, nil
@@ -851,9 +1207,14 @@ func findGoBinaryInGVM(home string) (string, bool) {
 }
 
 // GenerateGoPRDescription builds a markdown PR description for a Go
-// dependency upgrade.  Exported so that the local-mode CLI handler can
-// reuse the same description format.
-func GenerateGoPRDescription(goVersion string, hasConfigSH, goVersionUpdated bool) string {
+// dependency upgrade, including a table of the changed modules when there
+// are any.  Exported so that the local-mode CLI handler can reuse the same
+// description format.
+func GenerateGoPRDescription(
+	goVersion string,
+	hasConfigSH, goVersionUpdated bool,
+	changes []ModuleChange,
+) string {
 	var sb strings.Builder
 	sb.WriteString("## Summary\n\n")
 	if goVersionUpdated {
@@ -874,11 +1235,12 @@ func GenerateGoPRDescription(goVersion string, hasConfigSH, goVersionUpdated boo
 	if hasConfigSH {
 		sb.WriteString("- `config.sh` was sourced before running Go commands (private package settings)\n")
 	}
+	writeModuleChangesTable(&sb, changes)
 	sb.WriteString("\n### Review Checklist\n\n")
 	sb.WriteString("- [ ] Verify build passes\n")
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes in `go.sum`\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/cmdrunner"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // localCmdRunner is the package-level command runner for local-mode upgrade scripts.
@@ -25,6 +26,23 @@ type LocalUpgradeOptions struct {
 	AuthToken    string
 	ProviderName string                    // git provider name (e.g. "azuredevops", "github", "gitlab")
 	PushAuth     gitlocal.PushAuthResolver // resolves auth methods for git push
+	Remote       string                    // git remote to push to; empty means origin
+	BaseBranch   string                    // branch of Remote the upgrade starts from; empty means HEAD
+	// Changelog locates the changelog file and the "### " heading under
+	// [Unreleased] that receives the entry; zero means CHANGELOG.md/Changed.
+	Changelog entities.ChangelogSettings
+	// CommitPrefix replaces the "chore(deps)" prefix of the upgrade commit
+	// message when set.
+	CommitPrefix string
+	// Signing signs the upgrade commit with this key instead of the one in
+	// the git config when its Key is set.
+	Signing entities.Signing
+	// ExcludeModules, OnlyModules, DirectOnly and AllowRetracted narrow the
+	// upgrade like the matching UpdateOptions of batch mode.
+	ExcludeModules []string
+	OnlyModules    []string
+	DirectOnly     bool
+	AllowRetracted bool
 }
 
 // LocalResult holds the outcome of a local upgrade operation.
@@ -34,6 +52,9 @@ type LocalResult struct {
 	LatestVersion    string
 	BranchName       string
 	Output           string
+	ModuleChanges    []ModuleChange
+	// ChangelogEntry is the entry ApplyLocalUpgrade leaves to the caller.
+	ChangelogEntry string
 }
 
 // RunLocalUpgrade runs the Go dependency upgrade directly in a local
@@ -61,6 +82,40 @@ func RunLocalUpgrade(
 	return executeLocalUpgrade(ctx, repoDir, vCtx, opts)
 }
 
+// ApplyLocalUpgrade runs the Go upgrade script in moduleDir without any
+// git operation, for callers that branch, commit and push themselves, such
+// as local mode upgrading several modules of one repository on a single
+// branch. HasChanges is left unset: the caller's commit tells. The
+// changelog is left to the caller too, which gets the entry in ChangelogEntry.
+func ApplyLocalUpgrade(
+	ctx context.Context,
+	moduleDir string,
+	opts LocalUpgradeOptions,
+) (*LocalResult, error) {
+	vCtx, err := resolveLocalVersionContext(ctx, moduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return handleDryRun(vCtx, moduleDir), nil
+	}
+
+	outputStr, runErr := runLanguageUpgradeScript(ctx, moduleDir, vCtx, "", opts)
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	return &LocalResult{
+		GoVersionUpdated: strings.Contains(outputStr, "GO_VERSION_UPDATED=true"),
+		LatestVersion:    vCtx.LatestVersion,
+		BranchName:       vCtx.BranchName,
+		Output:           outputStr,
+		ModuleChanges:    parseModuleChanges(outputStr),
+		ChangelogEntry:   localChangelogEntry(vCtx),
+	}, nil
+}
+
 // resolveLocalVersionContext fetches the latest Go version and compares
 // it against the local go.mod to build a versionContext.
 func resolveLocalVersionContext(ctx context.Context, repoDir string) (*versionContext, error) {
@@ -84,14 +139,17 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) (*versionCo
 	)
 
 	branchName := branchGoDepsFmt
+	var toolchainVersion string
 	if needsVersionUpgrade {
 		branchName = fmt.Sprintf(branchGoVersionFmt, latestGoVersion)
+		toolchainVersion = toolchainTarget(string(goModContent), latestGoVersion)
 	}
 
 	return &versionContext{
 		LatestVersion:       latestGoVersion,
 		NeedsVersionUpgrade: needsVersionUpgrade,
 		BranchName:          branchName,
+		ToolchainVersion:    toolchainVersion,
 	}, nil
 }
 
@@ -130,6 +188,9 @@ func executeLocalUpgrade(
 	if err != nil {
 		return nil, err
 	}
+	gitCtx.UseRemote(opts.Remote)
+	gitCtx.UseBaseBranch(opts.BaseBranch)
+	gitCtx.UseSigning(opts.Signing)
 	originalBranch, err := gitCtx.CurrentBranch()
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine current branch: %w", err)
@@ -153,7 +214,11 @@ func executeLocalUpgrade(
 	}
 
 	// --- Language Operations (bash) ---
-	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, opts)
+	changelogFile := prepareLocalChangelog(repoDir, vCtx, opts.Changelog)
+	if changelogFile != "" {
+		defer os.Remove(changelogFile)
+	}
+	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, changelogFile, opts)
 	if runErr != nil {
 		return nil, runErr
 	}
@@ -168,6 +233,7 @@ func executeLocalUpgrade(
 			vCtx.LatestVersion,
 		)
 	}
+	commitMsg = entities.WithCommitPrefix(commitMsg, opts.CommitPrefix)
 
 	pushed, pushErr := gitCtx.StageCommitAndPush(
 		vCtx.BranchName, commitMsg, opts.AuthToken,
@@ -182,6 +248,7 @@ func executeLocalUpgrade(
 		LatestVersion:    vCtx.LatestVersion,
 		BranchName:       vCtx.BranchName,
 		Output:           outputStr,
+		ModuleChanges:    parseModuleChanges(outputStr),
 	}, nil
 }
 
@@ -192,13 +259,9 @@ func runLanguageUpgradeScript(
 	ctx context.Context,
 	repoDir string,
 	vCtx *versionContext,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	changelogFile := prepareLocalChangelog(repoDir, vCtx)
-	if changelogFile != "" {
-		defer os.Remove(changelogFile)
-	}
-
 	goBinary, err := findGoBinary()
 	if err != nil {
 		return "", fmt.Errorf("go binary not found: %w", err)
@@ -210,12 +273,18 @@ func runLanguageUpgradeScript(
 	}
 
 	params := localUpgradeParams{
-		BranchName:    vCtx.BranchName,
-		GoVersion:     vCtx.LatestVersion,
-		ChangelogFile: changelogFile,
-		AuthToken:     opts.AuthToken,
-		ProviderName:  opts.ProviderName,
-		HasConfigSH:   hasConfigSH,
+		BranchName:       vCtx.BranchName,
+		GoVersion:        vCtx.LatestVersion,
+		ToolchainVersion: vCtx.ToolchainVersion,
+		ChangelogFile:    changelogFile,
+		ChangelogPath:    opts.Changelog.FilePath(),
+		AuthToken:        opts.AuthToken,
+		ProviderName:     opts.ProviderName,
+		HasConfigSH:      hasConfigSH,
+		ExcludeModules:   opts.ExcludeModules,
+		OnlyModules:      opts.OnlyModules,
+		DirectOnly:       opts.DirectOnly,
+		AllowRetracted:   opts.AllowRetracted,
 	}
 
 	script := buildLocalUpgradeScript(params)
@@ -238,7 +307,7 @@ func runLanguageUpgradeScript(
 
 	var outputStr string
 	if runResult != nil {
-		outputStr = runResult.Output
+		outputStr = support.RedactTokens(runResult.Output, params.AuthToken)
 	}
 
 	if opts.Verbose {
@@ -257,12 +326,18 @@ func runLanguageUpgradeScript(
 // --- local-mode internal types & helpers ---
 
 type localUpgradeParams struct {
-	BranchName    string
-	GoVersion     string
-	ChangelogFile string
-	AuthToken     string
-	ProviderName  string // git provider name (for credential setup)
-	HasConfigSH   bool   // whether the repo contains config.sh
+	BranchName       string
+	GoVersion        string
+	ToolchainVersion string // toolchain directive target; empty leaves it alone
+	ChangelogFile    string
+	ChangelogPath    string
+	AuthToken        string
+	ProviderName     string // git provider name (for credential setup)
+	HasConfigSH      bool   // whether the repo contains config.sh
+	ExcludeModules   []string
+	OnlyModules      []string
+	DirectOnly       bool
+	AllowRetracted   bool
 }
 
 // buildLocalUpgradeScript builds a bash script that performs only the
@@ -331,6 +406,7 @@ func buildLocalEnv(params localUpgradeParams, goBinary string) []string {
 	env := append(os.Environ(),
 		"BRANCH_NAME="+params.BranchName,
 		"GO_VERSION="+params.GoVersion,
+		"TOOLCHAIN_VERSION="+params.ToolchainVersion,
 		"GO_BINARY="+goBinary,
 	)
 	if params.AuthToken != "" {
@@ -340,31 +416,34 @@ func buildLocalEnv(params localUpgradeParams, goBinary string) []string {
 		)
 	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
+	}
+	if len(params.ExcludeModules) > 0 {
+		env = append(env, excludeModulesEnv(params.ExcludeModules))
+	}
+	if len(params.OnlyModules) > 0 {
+		env = append(env, onlyModulesEnv(params.OnlyModules))
+	}
+	if params.DirectOnly {
+		env = append(env, "GO_DIRECT_ONLY=true")
+	}
+	if params.AllowRetracted {
+		env = append(env, "GO_ALLOW_RETRACTED=true")
 	}
 	return env
 }
 
-// prepareLocalChangelog reads CHANGELOG.md from disk (if it exists),
+// prepareLocalChangelog reads the changelog from disk (if it exists),
 // inserts an upgrade entry, and writes the result to a temp file.
 // Returns the temp file path, or "" if no changelog update is needed.
-func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+func prepareLocalChangelog(repoDir string, vCtx *versionContext, changelog entities.ChangelogSettings) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath())))
 	if err != nil {
 		return "" // no changelog present
 	}
 
-	var entry string
-	if vCtx.NeedsVersionUpgrade {
-		entry = fmt.Sprintf(
-			"- changed the Go version to `%s` and updated all module dependencies",
-			vCtx.LatestVersion,
-		)
-	} else {
-		entry = goChangelogEntryDeps
-	}
-
-	modified := entities.InsertChangelogEntry(string(content), []string{entry})
+	entry := localChangelogEntry(vCtx)
+	modified := entities.InsertChangelogEntryInSection(string(content), []string{entry}, changelog.Section)
 	if modified == string(content) {
 		return ""
 	}
@@ -385,3 +464,14 @@ func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
 
 	return tmpFile.Name()
 }
+
+// localChangelogEntry returns the changelog entry describing the upgrade.
+func localChangelogEntry(vCtx *versionContext) string {
+	if vCtx.NeedsVersionUpgrade {
+		return fmt.Sprintf(
+			"- changed the Go version to `%s` and updated all module dependencies",
+			vCtx.LatestVersion,
+		)
+	}
+	return goChangelogEntryDeps
+}
@@ -0,0 +1,111 @@
+package golang
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// moduleChangePrefix marks the lines the upgrade script prints for every
+// module requirement whose version moved, e.g.
+// `MODULE_CHANGE=golang.org/x/mod v0.20.0=>v0.22.0`.
+const moduleChangePrefix = "MODULE_CHANGE="
+
+// ModuleChange is a go.mod requirement whose version moved during the
+// upgrade. From is empty for a newly required module and To is empty for a
+// requirement that was dropped.
+type ModuleChange struct {
+	Path string
+	From string
+	To   string
+}
+
+// writeModuleSnapshot emits a `go_mod_requires` helper that lists the
+// "path version" pairs required by go.mod, and records them before the
+// upgrade. It only reads go.mod, so it needs neither network access nor the
+// module proxy.
+func writeModuleSnapshot(sb *strings.Builder) {
+	sb.WriteString("# Snapshot the go.mod requirements to report changed modules afterwards\n")
+	sb.WriteString("go_mod_requires() {\n")
+	sb.WriteString(
+		"    awk '/^require \\(/ {block = 1; next} block && /^\\)/ {block = 0; next} " +
+			"block && NF >= 2 && $1 !~ /^\\/\\// {print $1, $2} " +
+			"/^require [^(]/ {print $2, $3}' go.mod\n",
+	)
+	sb.WriteString("}\n")
+	sb.WriteString("MODULES_BEFORE=$(go_mod_requires || true)\n\n")
+}
+
+// writeModuleChanges emits the commands that compare the go.mod requirements
+// with the snapshot taken by writeModuleSnapshot and print one
+// MODULE_CHANGE line per added, removed or re-versioned module.
+func writeModuleChanges(sb *strings.Builder) {
+	sb.WriteString("# Report every module whose required version changed\n")
+	sb.WriteString(
+		"awk 'NF < 2 {next} NR == FNR {old[$1] = $2; next} " +
+			"{if (old[$1] != $2) print \"" + moduleChangePrefix + "\" $1 \" \" old[$1] \"=>\" $2; delete old[$1]} " +
+			"END {for (m in old) print \"" + moduleChangePrefix + "\" m \" \" old[m] \"=>\"}' " +
+			"<(printf '%s\\n' \"$MODULES_BEFORE\") <(go_mod_requires) || true\n\n",
+	)
+}
+
+// parseModuleChanges collects the MODULE_CHANGE lines of the script output,
+// sorted by module path. Malformed lines are ignored.
+func parseModuleChanges(output string) []ModuleChange {
+	var changes []ModuleChange
+	for line := range strings.SplitSeq(output, "\n") {
+		rest, found := strings.CutPrefix(strings.TrimSpace(line), moduleChangePrefix)
+		if !found {
+			continue
+		}
+		path, versions, hasVersions := strings.Cut(rest, " ")
+		from, to, hasArrow := strings.Cut(versions, "=>")
+		if !hasVersions || !hasArrow || path == "" || from == to {
+			continue
+		}
+		changes = append(changes, ModuleChange{Path: path, From: from, To: to})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// writeModuleChangesTable renders the changed modules as a Markdown table.
+// Nothing is written when there are no changes, e.g. when the upgrade ran
+// without network access and go.mod stayed the same.
+func writeModuleChangesTable(sb *strings.Builder, changes []ModuleChange) {
+	if len(changes) == 0 {
+		return
+	}
+	sb.WriteString("\n### Module Changes\n\n")
+	sb.WriteString("| Module | From | To |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+	for _, change := range changes {
+		fmt.Fprintf(sb, "| `%s` | %s | %s |\n", change.Path, versionCell(change.From), versionCell(change.To))
+	}
+}
+
+// versionCell formats one version column of the module changes table.
+func versionCell(version string) string {
+	if version == "" {
+		return "-"
+	}
+	return "`" + version + "`"
+}
+
+// toDependencyChanges converts the changed modules into changes report
+// entries. Every module requirement lives in go.mod.
+func toDependencyChanges(changes []ModuleChange) []entities.DependencyChange {
+	result := make([]entities.DependencyChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, entities.DependencyChange{
+			Ecosystem: updaterName,
+			Name:      change.Path,
+			From:      change.From,
+			To:        change.To,
+			File:      "go.mod",
+		})
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+package golang_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	goUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/golang"
+)
+
+func TestParseModuleChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should collect the changed modules sorted by path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		output := "Running go mod tidy...\n" +
+			"MODULE_CHANGE=golang.org/x/mod v0.20.0=>v0.22.0\n" +
+			"MODULE_CHANGE=github.com/old/dep v1.2.0=>\n" +
+			"MODULE_CHANGE=malformed\n" +
+			"CHANGES_PUSHED=true\n"
+
+		// when
+		changes := goUpdater.ParseModuleChanges(output)
+
+		// then
+		assert.Equal(t, []goUpdater.ModuleChange{
+			{Path: "github.com/old/dep", From: "v1.2.0"},
+			{Path: "golang.org/x/mod", From: "v0.20.0", To: "v0.22.0"},
+		}, changes)
+	})
+
+	t.Run("should return nothing when the script reported no changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		output := "WARNING: go get -u -t had some errors (continuing anyway)\nCHANGES_PUSHED=false\n"
+
+		// when
+		changes := goUpdater.ParseModuleChanges(output)
+
+		// then
+		assert.Empty(t, changes)
+	})
+}
+
+func TestWriteModuleChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should print a MODULE_CHANGE line for every requirement the upgrade moved", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		goMod := "module example.com/foo\n\ngo 1.25.7\n\n" +
+			"require github.com/single/dep v1.0.0\n\n" +
+			"require (\n\tgolang.org/x/mod v0.20.0\n\tgolang.org/x/text v0.1.0 // indirect\n)\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "go.mod"), []byte(goMod), 0o600))
+		fakeGo := filepath.Join(t.TempDir(), "fake-go.sh")
+		require.NoError(t, os.WriteFile(fakeGo, []byte(
+			"#!/bin/bash\n"+
+				"if [ \"$1\" = \"get\" ]; then "+
+				"sed 's/x\\/mod v0.20.0/x\\/mod v0.22.0/; /x\\/text/d' go.mod > go.mod.tmp && mv go.mod.tmp go.mod; fi\n",
+		), 0o700)) //nolint:gosec // the fake binary must be executable
+
+		// when
+		cmd := exec.CommandContext(t.Context(), "bash", "-c", goUpdater.BuildLocalGoScript("", false))
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "GO_BINARY="+fakeGo, "GO_VERSION=1.25.7")
+		out, err := cmd.CombinedOutput()
+
+		// then
+		require.NoError(t, err, string(out))
+		assert.Equal(t, []goUpdater.ModuleChange{
+			{Path: "golang.org/x/mod", From: "v0.20.0", To: "v0.22.0"},
+			{Path: "golang.org/x/text", From: "v0.1.0"},
+		}, goUpdater.ParseModuleChanges(string(out)))
+	})
+}
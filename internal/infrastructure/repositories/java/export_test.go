@@ -40,8 +40,13 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	opts ...entities.UpdateOptions,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return resolveVersionContext(ctx, provider, repo, latestVersion, o)
 }
 
 // VersionContext is exported for testing.
@@ -94,7 +99,7 @@ func PrepareChangelog(
 	repo entities.Repository,
 	vCtx *VersionContext,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	return prepareChangelog(ctx, provider, repo, vCtx, entities.ChangelogSettings{})
 }
 
 // LogDryRun is exported for testing.
@@ -64,6 +64,17 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Gradle wrapper and Gradle/Maven dependencies"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	files := (&langJavaGradle.Detector{}).DetectionFiles()
+	return append(files, (&langJavaMaven.Detector{}).DetectionFiles()...)
+}
+
 // Detect returns true if the repository has Java marker files (Gradle or Maven).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -103,7 +114,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		logger.Infof("[java] Latest LTS Java version: %s", latestJavaVersion)
 	}
 
-	vCtx := resolveVersionContext(ctx, provider, repo, latestJavaVersion)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestJavaVersion, opts)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -124,7 +135,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	}
 
 	buildSys := detectRemoteBuildSystem(ctx, provider, repo)
-	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, buildSys)
+	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, buildSys, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -160,8 +171,9 @@ func cloneAndUpgrade(
 	repo entities.Repository,
 	vCtx *versionContext,
 	buildSys string,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, error) {
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -177,7 +189,9 @@ func cloneAndUpgrade(
 		AuthToken:     provider.AuthToken(),
 		ProviderName:  provider.Name(),
 		ChangelogFile: changelogFile,
+		ChangelogPath: opts.Changelog.FilePath(),
 		BuildSystem:   buildSys,
+		CommitPrefix:  opts.CommitPrefix(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade: %w", err)
@@ -209,13 +223,16 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GeneratePRDescription(vCtx.LatestVersion, buildSys, result.JavaVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, buildSys, result.JavaVersionUpdated),
+	)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -237,12 +254,12 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	_ repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[java] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
 	// resolveLocalVersionContext handles fetching + comparison
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	vCtx := resolveLocalVersionContext(ctx, repoDir, opts)
 
 	buildSys := detectLocalBuildSystem(repoDir)
 
@@ -280,7 +297,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	// Update CHANGELOG locally
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if javaVersionUpdated {
 		entry = fmt.Sprintf(
@@ -290,23 +307,27 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = javaChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := javaCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := javaCommitMsgDeps
 	if javaVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded Java to `%s` and updated all dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
+
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, buildSys, javaVersionUpdated),
+	)
 
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GeneratePRDescription(vCtx.LatestVersion, buildSys, javaVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
@@ -341,7 +362,11 @@ type upgradeParams struct {
 	AuthToken     string
 	ProviderName  string
 	ChangelogFile string
+	ChangelogPath string // changelog path relative to the repository root
 	BuildSystem   string // "gradle" or "maven"
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
 }
 
 type upgradeResult struct {
@@ -413,6 +438,7 @@ func resolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestJavaVersion string,
+	opts entities.UpdateOptions,
 ) *versionContext {
 	needsVersionUpgrade := false
 
@@ -420,7 +446,8 @@ func resolveVersionContext(
 		content, err := provider.GetFileContent(ctx, repo, ".java-version")
 		if err == nil {
 			currentVersion := parseJavaVersionFile(content)
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestJavaVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestJavaVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestJavaVersion)
 			logger.Infof(
 				"[java] Current .java-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -443,7 +470,7 @@ func resolveVersionContext(
 
 // resolveLocalVersionContext fetches the latest Java version and compares
 // it against the local .java-version to build a versionContext.
-func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
+func resolveLocalVersionContext(ctx context.Context, repoDir string, opts entities.UpdateOptions) *versionContext {
 	fetcher := NewHTTPJavaVersionFetcher(&http.Client{Timeout: javaVersionTimeout})
 	latestJavaVersion, err := fetcher.FetchLatestVersion(ctx)
 	if err != nil {
@@ -458,7 +485,8 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 		javaVersionContent, readErr := os.ReadFile(filepath.Join(repoDir, ".java-version"))
 		if readErr == nil {
 			currentVersion := parseJavaVersionFile(string(javaVersionContent))
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestJavaVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestJavaVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestJavaVersion)
 			logger.Infof(
 				"[java] Current .java-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -479,7 +507,7 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 	}
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the Java upgrade, and writes the modified
 // content to a temp file.
 func prepareChangelog(
@@ -487,14 +515,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[java] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[java] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -508,7 +537,7 @@ func prepareChangelog(
 		entry = javaChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -750,8 +779,8 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update CHANGELOG.md only if the upgrade produced actual changes.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
@@ -764,10 +793,11 @@ func writeCommitAndPush(sb *strings.Builder) {
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$JAVA_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded Java to `$JAVA_VERSION` and updated all dependencies\"\n",
+		"        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded Java to `$JAVA_VERSION` " +
+			"and updated all dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): updated Java dependencies\"\n")
+	sb.WriteString("        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: updated Java dependencies\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
@@ -790,8 +820,11 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 	if params.JavaVersion != "" {
 		env = append(env, "JAVA_VERSION="+params.JavaVersion)
 	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
@@ -831,6 +864,6 @@ func GeneratePRDescription(javaVersion, buildSys string, javaVersionUpdated bool
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
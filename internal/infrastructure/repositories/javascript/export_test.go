@@ -13,7 +13,7 @@ import (
 
 // HasOnlyLockfileVersionChanges is exported for testing.
 func HasOnlyLockfileVersionChanges(ctx context.Context, repoDir string) bool {
-	return hasOnlyLockfileVersionChanges(ctx, repoDir)
+	return hasOnlyLockfileVersionChanges(ctx, repoDir, entities.DefaultChangelogPath)
 }
 
 // IsPackageLockOnlyVersionSync is exported for testing.
@@ -31,6 +31,16 @@ func ParseNodeVersionFile(content string) string {
 	return parseNodeVersionFile(content)
 }
 
+// ParseToolVersionsNode is exported for testing.
+func ParseToolVersionsNode(content string) string {
+	return parseToolVersionsNode(content)
+}
+
+// GeneratePRDescriptionWithRanges is exported for testing.
+func GeneratePRDescriptionWithRanges(nodeVersion, pkgMgr string, nodeVersionUpdated, updateRanges bool) string {
+	return generatePRDescription(nodeVersion, pkgMgr, nodeVersionUpdated, updateRanges)
+}
+
 // IsLTSRelease is exported for testing.
 func IsLTSRelease(release nodeRelease) bool {
 	return isLTSRelease(release)
@@ -65,8 +75,13 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	opts ...entities.UpdateOptions,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return resolveVersionContext(ctx, provider, repo, latestVersion, o)
 }
 
 // ReadCurrentNodeVersion is exported for testing.
@@ -85,7 +100,7 @@ func PrepareChangelog(
 	repo entities.Repository,
 	vCtx *versionContext,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	return prepareChangelog(ctx, provider, repo, vCtx, entities.ChangelogSettings{})
 }
 
 // BuildUpgradeScript is exported for testing.
@@ -107,6 +122,16 @@ func WriteJSUpgradeCommands(params upgradeParams) string {
 	return sb.String()
 }
 
+// DetectWorkspaces is exported for testing.
+func DetectWorkspaces(packageJSON string, hasPnpmWorkspace bool) bool {
+	return detectWorkspaces(packageJSON, hasPnpmWorkspace)
+}
+
+// DetectLocalWorkspaces is exported for testing.
+func DetectLocalWorkspaces(dir string) bool {
+	return detectLocalWorkspaces(dir)
+}
+
 // WriteDockerfileUpdate is exported for testing.
 func WriteDockerfileUpdate() string {
 	var sb strings.Builder
@@ -124,7 +149,7 @@ func WriteChangelogUpdate() string {
 // WriteCommitAndPush is exported for testing.
 func WriteCommitAndPush() string {
 	var sb strings.Builder
-	writeCommitAndPush(&sb)
+	writeCommitAndPush(&sb, false)
 	return sb.String()
 }
 
@@ -184,7 +209,7 @@ func HandleDryRunLocal(vCtx *versionContext, repoDir, pkgMgr string) *LocalResul
 
 // PrepareLocalChangelog is exported for testing.
 func PrepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	return prepareLocalChangelog(repoDir, vCtx)
+	return prepareLocalChangelog(repoDir, vCtx, entities.ChangelogSettings{})
 }
 
 // SetLocalCmdRunner overrides the package-level local command runner for testing.
@@ -200,7 +225,18 @@ func RunLanguageUpgradeScript(
 	repoDir string,
 	vCtx *versionContext,
 	pkgMgr string,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	return runLanguageUpgradeScript(ctx, repoDir, vCtx, pkgMgr, opts)
+	return runLanguageUpgradeScript(ctx, repoDir, vCtx, pkgMgr, changelogFile, opts)
+}
+
+// BuildAuditFixScript is exported for testing.
+func BuildAuditFixScript(force bool) string {
+	return buildAuditFixScript(force)
+}
+
+// ResolveLocalCurrentVersion is exported for testing.
+func ResolveLocalCurrentVersion(repoDir string, opts entities.UpdateOptions) string {
+	return resolveLocalCurrentVersion(repoDir, opts)
 }
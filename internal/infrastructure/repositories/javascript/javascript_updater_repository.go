@@ -27,15 +27,21 @@ const (
 	// Package manager identifiers.
 	pkgMgrPnpm = "pnpm"
 	pkgMgrYarn = "yarn"
+	pkgMgrBun  = "bun"
 	pkgMgrNpm  = "npm"
 
 	// Branch name patterns for JavaScript/Node.js updates.
 	branchNodeVersionFmt = "chore/upgrade-node-%s"
 	branchJSDepsFmt      = "chore/upgrade-js-deps"
+	branchJSAuditFix     = "fix/npm-audit-fix"
 
 	// Commit/PR messages and changelog entries used across remote and local modes.
 	jsCommitMsgDeps      = "chore(deps): updated JavaScript dependencies"
 	jsChangelogEntryDeps = "- changed the JavaScript dependencies to their latest versions"
+
+	// Commit/PR message and changelog entry for the audit-fix mode.
+	jsCommitMsgAuditFix      = "fix(deps): patched vulnerable transitive npm dependencies"
+	jsChangelogEntryAuditFix = "- fixed vulnerable transitive npm dependencies reported by `npm audit`"
 )
 
 // UpdaterRepository implements repositories.UpdaterRepository for JavaScript/Node.js dependencies.
@@ -62,6 +68,16 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Node.js version and npm/yarn/pnpm package dependencies"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langNode.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository has Node/JS marker files (e.g. package.json, tsconfig.json).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -86,18 +102,9 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 ) ([]entities.PullRequest, error) {
 	logger.Infof("[javascript] Processing %s/%s", repo.Organization, repo.Name)
 
-	latestNodeVersion, err := u.versionFetcher.FetchLatestVersion(ctx)
-	if err != nil {
-		logger.Warnf(
-			"[javascript] Failed to fetch latest Node.js version: %v (continuing without version upgrade)",
-			err,
-		)
-		latestNodeVersion = ""
-	} else {
-		logger.Infof("[javascript] Latest Node.js LTS version: %s", latestNodeVersion)
-	}
+	latestNodeVersion := fetchTargetVersion(ctx, u.versionFetcher, opts.NodeChannel)
 
-	vCtx := resolveVersionContext(ctx, provider, repo, latestNodeVersion)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestNodeVersion, opts)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -118,7 +125,8 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	}
 
 	pkgMgr := detectPackageManager(ctx, provider, repo)
-	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, pkgMgr)
+	workspaces := detectRemoteWorkspaces(ctx, provider, repo)
+	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, pkgMgr, workspaces, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -154,8 +162,10 @@ func cloneAndUpgrade(
 	repo entities.Repository,
 	vCtx *versionContext,
 	pkgMgr string,
+	workspaces bool,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, error) {
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -164,14 +174,22 @@ func cloneAndUpgrade(
 	defaultBranch := strings.TrimPrefix(repo.DefaultBranch, "refs/heads/")
 
 	result, err := upgradeRepo(ctx, upgradeParams{
-		CloneURL:       cloneURL,
-		DefaultBranch:  defaultBranch,
-		BranchName:     vCtx.BranchName,
-		NodeVersion:    vCtx.LatestVersion,
-		AuthToken:      provider.AuthToken(),
-		ProviderName:   provider.Name(),
-		ChangelogFile:  changelogFile,
-		PackageManager: pkgMgr,
+		CloneURL:        cloneURL,
+		DefaultBranch:   defaultBranch,
+		BranchName:      vCtx.BranchName,
+		NodeVersion:     vCtx.LatestVersion,
+		AuthToken:       provider.AuthToken(),
+		ProviderName:    provider.Name(),
+		ChangelogFile:   changelogFile,
+		ChangelogPath:   opts.Changelog.FilePath(),
+		PackageManager:  pkgMgr,
+		Workspaces:      workspaces,
+		UpdateRanges:    opts.UpdateRanges,
+		AllowYanked:     opts.AllowYanked,
+		RefreshLockfile: opts.RefreshLockfile,
+		CommitAuthor:    opts.CommitAuthor,
+		CommitPrefix:    opts.CommitPrefix(),
+		Signing:         opts.Signing,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade: %w", err)
@@ -203,13 +221,17 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GeneratePRDescription(vCtx.LatestVersion, pkgMgr, result.NodeVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, generatePRDescription(vCtx.LatestVersion, pkgMgr, result.NodeVersionUpdated, opts.UpdateRanges),
+	)
+	prDesc = support.AppendDiffStat(prDesc, result.DiffStat)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -225,19 +247,44 @@ func openPullRequest(
 
 // ApplyUpdates implements repositories.LocalUpdater. It runs language-specific
 // JavaScript upgrade operations on a locally cloned repository, without
-// performing any git clone, branch, commit, or push operations.
+// performing any git clone, branch, commit, or push operations. When
+// opts.Paths is set, the upgrade only runs inside those subdirectories.
 func (u *UpdaterRepository) ApplyUpdates(
 	ctx context.Context,
 	repoDir string,
-	_ repositories.ProviderRepository,
+	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[javascript] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
-	// resolveLocalVersionContext (from local.go) handles fetching + comparison
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
-	pkgMgr := detectLocalPackageManager(repoDir)
+	workDirs := support.ScopedDirs(repoDir, opts.Paths, "package.json")
+	if len(workDirs) == 0 {
+		logger.Infof("[javascript] No package.json found under the configured paths %v", opts.Paths)
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
+
+	authToken := support.ProviderAuthToken(provider)
+	if opts.AuditFix {
+		return u.applyAuditFix(ctx, repoDir, workDirs, authToken, opts)
+	}
+
+	// Each sub-project keeps its own Node.js version file, so whether the
+	// version moves is decided per directory. The PR follows the first one
+	// that needs the upgrade.
+	latestNodeVersion := fetchTargetVersion(ctx, u.versionFetcher, opts.NodeChannel)
+	dirContexts := make([]*versionContext, len(workDirs))
+	for i, workDir := range workDirs {
+		dirContexts[i] = localVersionContext(latestNodeVersion, workDir, opts)
+	}
+	vCtx := dirContexts[0]
+	for _, dirCtx := range dirContexts {
+		if dirCtx.NeedsVersionUpgrade {
+			vCtx = dirCtx
+			break
+		}
+	}
+	pkgMgr := detectLocalPackageManager(workDirs[0])
 
 	script := buildBatchJSScript()
 	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
@@ -246,24 +293,26 @@ func (u *UpdaterRepository) ApplyUpdates(
 	}
 	defer func() { _ = os.Remove(scriptPath) }()
 
-	cmd := exec.CommandContext(ctx, "bash", scriptPath)
-	cmd.Dir = repoDir
-	env := append(os.Environ(), "PACKAGE_MANAGER="+pkgMgr)
-	if vCtx.LatestVersion != "" {
-		env = append(env, "NODE_VERSION="+vCtx.LatestVersion)
+	// Sub-projects are independent, so they are upgraded in parallel up to
+	// opts.Concurrency (sequentially by default).
+	outputs := make([]string, len(workDirs))
+	runErr := support.ForEachBounded(ctx, workDirs, opts.Concurrency,
+		func(ctx context.Context, i int, workDir string) error {
+			output, err := u.runBatchScript(
+				ctx, scriptPath, workDir, detectLocalPackageManager(workDir), authToken, dirContexts[i], opts,
+			)
+			outputs[i] = output
+			return err
+		},
+	)
+	if runErr != nil {
+		return nil, runErr
 	}
-	cmd.Env = env
-
-	output, cmdErr := cmd.CombinedOutput()
-	outputStr := string(output)
-	logger.Debugf("[javascript] Upgrade script output:\n%s", outputStr)
+	outputStr := strings.Join(outputs, "\n")
 
 	// Remove the script before checking worktree state so it does not
 	// appear as an untracked file in the git status check below.
 	_ = os.Remove(scriptPath)
-	if cmdErr != nil {
-		return nil, fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, outputStr)
-	}
 
 	nodeVersionUpdated := strings.Contains(outputStr, "NODE_VERSION_UPDATED=true")
 
@@ -275,7 +324,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 
 	// Skip when the only lockfile change is a cosmetic project-version sync
 	// (e.g., npm update syncing package-lock.json "version" to match package.json).
-	if hasOnlyLockfileVersionChanges(ctx, repoDir) {
+	if hasOnlyLockfileVersionChanges(ctx, repoDir, opts.Changelog.FilePath()) {
 		logger.Infof(
 			"[javascript] Only cosmetic lockfile version changes detected (project version sync), skipping",
 		)
@@ -284,7 +333,24 @@ func (u *UpdaterRepository) ApplyUpdates(
 	}
 	logger.Infof("[javascript] Filesystem changes detected, proceeding with commit")
 
-	// Update CHANGELOG locally
+	if opts.RefreshLockfile && !nodeVersionUpdated {
+		if changedFiles := gitChangedFiles(ctx, repoDir); isLockfileRefreshOnly(changedFiles) {
+			logger.Infof("[javascript] Versions are current, only the lockfile was refreshed")
+			prTitle, prDesc := opts.RenderPRText(
+				entities.NewPRTemplateData(updaterName, nil),
+				jsCommitMsgLockfile, generateLockfileRefreshPRDescription(changedFiles),
+			)
+			return &repositories.LocalUpdateResult{
+				BranchName:       vCtx.BranchName,
+				CommitMessage:    entities.WithCommitPrefix(jsCommitMsgLockfile, opts.CommitPrefix()),
+				PRTitle:          prTitle,
+				PRDescription:    prDesc,
+				ChangelogEntries: []string{jsChangelogEntryLockfile},
+			}, nil
+		}
+	}
+
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if nodeVersionUpdated {
 		entry = fmt.Sprintf(
@@ -294,26 +360,202 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = jsChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := jsCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := jsCommitMsgDeps
 	if nodeVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded Node.js to `%s` and updated all dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, generatePRDescription(vCtx.LatestVersion, pkgMgr, nodeVersionUpdated, opts.UpdateRanges),
+	)
 
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GeneratePRDescription(vCtx.LatestVersion, pkgMgr, nodeVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
+// applyAuditFix runs `npm audit fix` in every npm sub-project to patch
+// vulnerable transitive dependencies without bumping direct ones, so the
+// resulting PR normally touches only package-lock.json. yarn and pnpm
+// sub-projects are skipped because their audit commands cannot fix in place.
+func (u *UpdaterRepository) applyAuditFix(
+	ctx context.Context,
+	repoDir string,
+	workDirs []string,
+	authToken string,
+	opts entities.UpdateOptions,
+) (*repositories.LocalUpdateResult, error) {
+	var npmDirs []string
+	for _, dir := range workDirs {
+		if pkgMgr := detectLocalPackageManager(dir); pkgMgr != pkgMgrNpm {
+			logger.Infof("[javascript] Skipping audit fix in %s: not supported for %s", dir, pkgMgr)
+			continue
+		}
+		npmDirs = append(npmDirs, dir)
+	}
+	if len(npmDirs) == 0 {
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
+
+	scriptPath := filepath.Join(repoDir, ".autoupdate-audit-fix.sh")
+	script := buildAuditFixScript(opts.AuditFixForce)
+	if writeErr := os.WriteFile(scriptPath, []byte(script), scriptFileMode); writeErr != nil {
+		return nil, fmt.Errorf("failed to write script: %w", writeErr)
+	}
+	defer func() { _ = os.Remove(scriptPath) }()
+
+	runErr := support.ForEachBounded(ctx, npmDirs, opts.Concurrency,
+		func(ctx context.Context, _ int, workDir string) error {
+			runResult, err := u.cmdRunner.Run(ctx, "bash", []string{scriptPath}, cmdrunner.RunOptions{
+				Dir: workDir,
+				Env: os.Environ(),
+			})
+			if runResult != nil && runResult.Output != "" {
+				logger.Debugf("[javascript] Audit fix output for %s:\n%s",
+					workDir, support.RedactTokens(runResult.Output, authToken))
+			}
+			if err != nil {
+				return fmt.Errorf("npm audit fix failed in %s: %w", workDir, err)
+			}
+			return nil
+		},
+	)
+	if runErr != nil {
+		return nil, runErr
+	}
+	_ = os.Remove(scriptPath)
+
+	changedFiles := gitChangedFiles(ctx, repoDir)
+	if len(changedFiles) == 0 {
+		logger.Infof("[javascript] npm audit fix found nothing to patch")
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
+	if !isLockfileOnlyChange(changedFiles) {
+		logger.Warnf("[javascript] npm audit fix changed files beyond package-lock.json: %v", changedFiles)
+	}
+
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		jsCommitMsgAuditFix, generateAuditFixPRDescription(changedFiles, opts.AuditFixForce),
+	)
+	return &repositories.LocalUpdateResult{
+		BranchName:       branchJSAuditFix,
+		CommitMessage:    jsCommitMsgAuditFix,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{jsChangelogEntryAuditFix},
+	}, nil
+}
+
+// buildAuditFixScript returns the bash script that patches vulnerable
+// dependencies in the current directory. `npm audit fix` exits non-zero
+// when vulnerabilities remain unfixable, which is reported but not fatal.
+func buildAuditFixScript(force bool) string {
+	command := "npm audit fix"
+	if force {
+		command += " --force"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("#!/bin/bash\n")
+	sb.WriteString("set -euo pipefail\n\n")
+	sb.WriteString("echo \"Running " + command + "...\"\n")
+	sb.WriteString(command + " 2>&1 || echo \"WARNING: npm audit fix could not patch every vulnerability\"\n")
+	return sb.String()
+}
+
+// isLockfileOnlyChange reports whether every changed path is a
+// package-lock.json, i.e. only transitive dependencies moved.
+func isLockfileOnlyChange(changedFiles []string) bool {
+	for _, f := range changedFiles {
+		if filepath.Base(f) != "package-lock.json" {
+			return false
+		}
+	}
+	return true
+}
+
+// generateAuditFixPRDescription builds the markdown PR description for the
+// audit-fix mode, listing the files `npm audit fix` modified.
+func generateAuditFixPRDescription(changedFiles []string, force bool) string {
+	command := "npm audit fix"
+	if force {
+		command += " --force"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("This PR patches vulnerable transitive npm dependencies reported by `npm audit`.\n\n")
+	sb.WriteString("### Changes\n\n")
+	sb.WriteString("- Ran `" + command + "`\n")
+	for _, f := range changedFiles {
+		sb.WriteString("- Updated `" + f + "`\n")
+	}
+	if !isLockfileOnlyChange(changedFiles) {
+		sb.WriteString("\n> **Note:** files besides `package-lock.json` changed; review direct dependency bumps carefully.\n")
+	}
+	sb.WriteString("\n### Review Checklist\n\n")
+	sb.WriteString("- [ ] Verify build passes\n")
+	sb.WriteString("- [ ] Verify tests pass\n")
+	sb.WriteString("- [ ] Review dependency changes in lockfile\n")
+	sb.WriteString("\n---\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
+	return sb.String()
+}
+
+// runBatchScript executes the batch upgrade script inside workDir using the
+// package manager detected for that directory.
+func (u *UpdaterRepository) runBatchScript(
+	ctx context.Context,
+	scriptPath, workDir, pkgMgr, authToken string,
+	vCtx *versionContext,
+	opts entities.UpdateOptions,
+) (string, error) {
+	env := append(os.Environ(), "PACKAGE_MANAGER="+pkgMgr)
+	if vCtx.NeedsVersionUpgrade {
+		env = append(env, "NODE_VERSION="+vCtx.LatestVersion)
+	}
+	if detectLocalWorkspaces(workDir) {
+		env = append(env, "WORKSPACES=true")
+	}
+	if opts.UpdateRanges {
+		env = append(env, "UPDATE_RANGES=true")
+	}
+	if opts.AllowYanked {
+		env = append(env, "ALLOW_YANKED=true")
+	}
+	if opts.RefreshLockfile {
+		env = append(env, "REFRESH_LOCKFILE=true")
+	}
+	if len(opts.DockerfileImages) > 0 {
+		env = append(env, "NODE_DOCKERFILE_IMAGES="+strings.Join(opts.DockerfileImages, " "))
+	}
+
+	runResult, runErr := u.cmdRunner.Run(ctx, "bash", []string{scriptPath}, cmdrunner.RunOptions{
+		Dir: workDir,
+		Env: env,
+	})
+	var outputStr string
+	if runResult != nil {
+		outputStr = support.RedactTokens(runResult.Output, authToken)
+	}
+	logger.Debugf("[javascript] Upgrade script output (%s):\n%s", workDir, outputStr)
+
+	if runErr != nil {
+		return "", fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", runErr, outputStr)
+	}
+	return outputStr, nil
+}
+
 // buildBatchJSScript generates a bash script with only language-specific
 // operations (no git clone, branch, commit, or push) for the batch pipeline.
 func buildBatchJSScript() string {
@@ -344,13 +586,31 @@ type upgradeParams struct {
 	AuthToken      string
 	ProviderName   string
 	ChangelogFile  string
-	PackageManager string // "npm", "yarn", or "pnpm"
+	ChangelogPath  string // changelog path relative to the repository root
+	PackageManager string // "npm", "yarn", "pnpm", or "bun"
+	// Workspaces runs the recursive update of a workspace root.
+	Workspaces bool
+	// UpdateRanges moves the package.json ranges to the latest releases.
+	UpdateRanges bool
+	// AllowYanked lets the range update move to deprecated releases.
+	AllowYanked bool
+	// RefreshLockfile regenerates the lockfile after the update.
+	RefreshLockfile bool
+	// CommitAuthor overrides the git identity of the upgrade commit.
+	CommitAuthor entities.CommitAuthor
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
+	// Signing, when it has a key, makes the script sign the upgrade commit.
+	Signing entities.Signing
 }
 
 type upgradeResult struct {
 	HasChanges         bool
 	NodeVersionUpdated bool
 	Output             string
+	// DiffStat is the `git diff --shortstat` of the upgrade commit.
+	DiffStat string
 }
 
 // --- Node.js version types and helpers ---
@@ -387,10 +647,26 @@ func parseNodeVersionFile(content string) string {
 	return ""
 }
 
+// parseToolVersionsNode extracts the Node.js version from an asdf
+// .tool-versions file: the first version of its `nodejs` (or mise's `node`)
+// line. Other tools and comments are ignored.
+func parseToolVersionsNode(content string) string {
+	for line := range strings.SplitSeq(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && (fields[0] == "nodejs" || fields[0] == "node") { //nolint:mnd // tool name and version
+			return strings.TrimPrefix(fields[1], "v")
+		}
+	}
+	return ""
+}
+
 // --- package manager detection ---
 
 // detectPackageManager determines which package manager the repository uses
-// by checking for lockfiles.
+// by checking for lockfiles. When several are committed, pnpm-lock.yaml
+// wins, then yarn.lock, then Bun's bun.lockb (or its text successor
+// bun.lock); npm is the default.
 func detectPackageManager(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
@@ -402,6 +678,9 @@ func detectPackageManager(
 	if provider.HasFile(ctx, repo, "yarn.lock") {
 		return pkgMgrYarn
 	}
+	if provider.HasFile(ctx, repo, "bun.lockb") || provider.HasFile(ctx, repo, "bun.lock") {
+		return pkgMgrBun
+	}
 	return pkgMgrNpm // default
 }
 
@@ -414,13 +693,15 @@ func resolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestNodeVersion string,
+	opts entities.UpdateOptions,
 ) *versionContext {
 	needsVersionUpgrade := false
 
 	if latestNodeVersion != "" {
 		currentVersion := readCurrentNodeVersion(ctx, provider, repo)
 		if currentVersion != "" {
-			needsVersionUpgrade = currentVersion != latestNodeVersion
+			needsVersionUpgrade = currentVersion != latestNodeVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestNodeVersion)
 			logger.Infof(
 				"[javascript] Current Node.js version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -441,7 +722,7 @@ func resolveVersionContext(
 }
 
 // readCurrentNodeVersion tries to read the Node.js version from .nvmrc
-// or .node-version files.
+// or .node-version files, then from the nodejs entry of .tool-versions.
 func readCurrentNodeVersion(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
@@ -458,10 +739,16 @@ func readCurrentNodeVersion(
 			}
 		}
 	}
+	if provider.HasFile(ctx, repo, ".tool-versions") {
+		content, err := provider.GetFileContent(ctx, repo, ".tool-versions")
+		if err == nil {
+			return parseToolVersionsNode(content)
+		}
+	}
 	return ""
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the JavaScript upgrade, and writes the modified
 // content to a temp file.
 func prepareChangelog(
@@ -469,14 +756,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[javascript] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[javascript] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -490,7 +778,7 @@ func prepareChangelog(
 		entry = jsChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -540,7 +828,7 @@ func upgradeRepo(
 	cmd.Env = buildEnv(params, repoDir)
 
 	output, err := cmd.CombinedOutput()
-	result.Output = string(output)
+	result.Output = support.RedactTokens(string(output), params.AuthToken)
 
 	if err != nil {
 		return result, fmt.Errorf(
@@ -550,6 +838,7 @@ func upgradeRepo(
 
 	result.HasChanges = strings.Contains(result.Output, "CHANGES_PUSHED=true")
 	result.NodeVersionUpdated = strings.Contains(result.Output, "NODE_VERSION_UPDATED=true")
+	result.DiffStat = support.ParseDiffStat(result.Output)
 	return result, nil
 }
 
@@ -569,12 +858,17 @@ func buildUpgradeScript(
 
 	// Ensure git user identity is configured
 	sb.WriteString("# Ensure git user identity is configured\n")
-	sb.WriteString("if ! git config --global user.name > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_NAME:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.name \"$COMMIT_AUTHOR_NAME\"\n")
+	sb.WriteString("elif ! git config --global user.name > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.name \"autoupdate[bot]\"\n")
 	sb.WriteString("fi\n")
-	sb.WriteString("if ! git config --global user.email > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_EMAIL:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.email \"$COMMIT_AUTHOR_EMAIL\"\n")
+	sb.WriteString("elif ! git config --global user.email > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.email \"autoupdate[bot]@users.noreply.github.com\"\n")
 	sb.WriteString("fi\n\n")
+	sb.WriteString(support.CommitSigningScript(params.Signing))
 
 	// Clone
 	sb.WriteString("echo \"Cloning repository...\"\n")
@@ -594,7 +888,7 @@ func buildUpgradeScript(
 	writeChangelogUpdate(&sb)
 
 	// Check for changes and commit/push
-	writeCommitAndPush(&sb)
+	writeCommitAndPush(&sb, params.Signing.Key != "")
 
 	return sb.String()
 }
@@ -625,7 +919,8 @@ func writeGitAuth(sb *strings.Builder, params upgradeParams) {
 }
 
 func writeJSUpgradeCommands(sb *strings.Builder, _ upgradeParams) {
-	// Update .nvmrc / .node-version if it exists and a new version is available
+	// Update .nvmrc / .node-version / .tool-versions if it exists and a new
+	// version is available
 	sb.WriteString("# Check and update Node.js version\n")
 	sb.WriteString("NODE_VERSION_CHANGED=false\n")
 	sb.WriteString("if [ -n \"${NODE_VERSION:-}\" ]; then\n")
@@ -642,6 +937,7 @@ func writeJSUpgradeCommands(sb *strings.Builder, _ upgradeParams) {
 	sb.WriteString("            fi\n")
 	sb.WriteString("        fi\n")
 	sb.WriteString("    done\n")
+	writeToolVersionsUpdate(sb)
 	sb.WriteString("fi\n")
 	sb.WriteString("if [ \"$NODE_VERSION_CHANGED\" = \"false\" ]; then\n")
 	sb.WriteString("    echo \"NODE_VERSION_UPDATED=false\"\n")
@@ -650,20 +946,82 @@ func writeJSUpgradeCommands(sb *strings.Builder, _ upgradeParams) {
 	// Run package manager update
 	sb.WriteString("# Update dependencies using detected package manager\n")
 	sb.WriteString("echo \"Using package manager: $PACKAGE_MANAGER\"\n")
-	sb.WriteString("case \"$PACKAGE_MANAGER\" in\n")
-	sb.WriteString("    pnpm)\n")
-	sb.WriteString("        echo \"Running pnpm update...\"\n")
-	sb.WriteString("        pnpm update 2>&1 || echo \"WARNING: pnpm update had some errors (continuing anyway)\"\n")
-	sb.WriteString("        ;;\n")
-	sb.WriteString("    yarn)\n")
-	sb.WriteString("        echo \"Running yarn upgrade...\"\n")
-	sb.WriteString("        yarn upgrade 2>&1 || echo \"WARNING: yarn upgrade had some errors (continuing anyway)\"\n")
-	sb.WriteString("        ;;\n")
-	sb.WriteString("    *)\n")
-	sb.WriteString("        echo \"Running npm update...\"\n")
-	sb.WriteString("        npm update 2>&1 || echo \"WARNING: npm update had some errors (continuing anyway)\"\n")
-	sb.WriteString("        ;;\n")
-	sb.WriteString("esac\n\n")
+	// UPDATE_RANGES=true moves the declared ranges to the latest releases
+	// (see writeLatestRangeUpdate); otherwise dependencies are updated
+	// within their ranges. Workspace roots (WORKSPACES=true) use the
+	// recursive variants so every member package is updated, not only the
+	// root manifest. Bun's update already covers the workspace members.
+	// Yarn Classic has no `workspaces foreach`, so it falls back to
+	// `yarn upgrade`.
+	sb.WriteString("WORKSPACES=${WORKSPACES:-false}\n")
+	sb.WriteString("if [ \"${UPDATE_RANGES:-false}\" = \"true\" ]; then\n")
+	writeLatestRangeUpdate(sb)
+	sb.WriteString("else\n")
+	sb.WriteString("    case \"$PACKAGE_MANAGER\" in\n")
+	sb.WriteString("        pnpm)\n")
+	sb.WriteString("            if [ \"$WORKSPACES\" = \"true\" ]; then\n")
+	sb.WriteString("                echo \"Running pnpm -r update...\"\n")
+	sb.WriteString("                pnpm -r update 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: pnpm -r update had some errors (continuing anyway)\"\n")
+	sb.WriteString("            else\n")
+	sb.WriteString("                echo \"Running pnpm update...\"\n")
+	sb.WriteString("                pnpm update 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: pnpm update had some errors (continuing anyway)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        yarn)\n")
+	sb.WriteString("            if [ \"$WORKSPACES\" = \"true\" ]; then\n")
+	sb.WriteString("                echo \"Running yarn workspaces foreach upgrade...\"\n")
+	sb.WriteString("                yarn workspaces foreach upgrade 2>&1 || yarn upgrade 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: yarn upgrade had some errors (continuing anyway)\"\n")
+	sb.WriteString("            else\n")
+	sb.WriteString("                echo \"Running yarn upgrade...\"\n")
+	sb.WriteString("                yarn upgrade 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: yarn upgrade had some errors (continuing anyway)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        bun)\n")
+	sb.WriteString("            echo \"Running bun update...\"\n")
+	sb.WriteString("            bun update 2>&1 || echo \"WARNING: bun update had some errors (continuing anyway)\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        *)\n")
+	sb.WriteString("            if [ \"$WORKSPACES\" = \"true\" ]; then\n")
+	sb.WriteString("                echo \"Running npm update --workspaces...\"\n")
+	sb.WriteString("                npm update --workspaces --include-workspace-root 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: npm update had some errors (continuing anyway)\"\n")
+	sb.WriteString("            else\n")
+	sb.WriteString("                echo \"Running npm update...\"\n")
+	sb.WriteString("                npm update 2>&1 ||\n")
+	sb.WriteString("                    echo \"WARNING: npm update had some errors (continuing anyway)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("    esac\n")
+	sb.WriteString("fi\n\n")
+
+	writeLockfileRefresh(sb)
+}
+
+// writeToolVersionsUpdate emits the commands that move the nodejs line of
+// an asdf .tool-versions file to NODE_VERSION, leaving the other tools
+// untouched. It runs inside the NODE_VERSION check of writeJSUpgradeCommands.
+func writeToolVersionsUpdate(sb *strings.Builder) {
+	sb.WriteString("    if [ -f .tool-versions ]; then\n")
+	sb.WriteString(
+		"        CURRENT_NODE_VERSION=$(awk '$1 == \"nodejs\" || $1 == \"node\" {print $2; exit}' .tool-versions " +
+			"| sed 's/^v//')\n",
+	)
+	sb.WriteString(
+		"        if [ -n \"$CURRENT_NODE_VERSION\" ] && [ \"$CURRENT_NODE_VERSION\" != \"$NODE_VERSION\" ]; then\n",
+	)
+	sb.WriteString("            echo \"Updating .tool-versions from $CURRENT_NODE_VERSION to $NODE_VERSION...\"\n")
+	sb.WriteString(
+		"            sed -E \"s/^((nodejs|node)[[:space:]]+)v?[0-9][0-9.]*/\\1${NODE_VERSION}/\" .tool-versions " +
+			"> .tool-versions.tmp && mv .tool-versions.tmp .tool-versions\n",
+	)
+	sb.WriteString("            NODE_VERSION_CHANGED=true\n")
+	sb.WriteString("            echo \"NODE_VERSION_UPDATED=true\"\n")
+	sb.WriteString("        fi\n")
+	sb.WriteString("    fi\n")
 }
 
 func writeDockerfileUpdate(sb *strings.Builder) {
@@ -675,12 +1033,16 @@ func writeDockerfileUpdate(sb *strings.Builder) {
 			"\\( -name 'Dockerfile' -o -name 'Dockerfile.*' -o -name '*.Dockerfile' \\) " +
 			"-print0 | while IFS= read -r -d '' df; do\n",
 	)
-	sb.WriteString("        if grep -q 'node:[0-9]' \"$df\"; then\n")
+	sb.WriteString("        for IMAGE in ${NODE_DOCKERFILE_IMAGES:-node}; do\n")
+	sb.WriteString("            IMAGE_RE=$(printf '%s' \"$IMAGE\" | sed 's/[].[*^$\\\\]/\\\\&/g')\n")
+	sb.WriteString("            if grep -q \"${IMAGE_RE}:[0-9]\" \"$df\"; then\n")
 	sb.WriteString(
-		"            sed \"s|node:[0-9][0-9.]*|node:${NODE_VERSION}|g\" \"$df\" > \"$df.tmp\" && mv \"$df.tmp\" \"$df\"\n",
+		"                sed \"s|${IMAGE_RE}:[0-9][0-9.]*|${IMAGE}:${NODE_VERSION}|g\" \"$df\" > \"$df.tmp\" " +
+			"&& mv \"$df.tmp\" \"$df\"\n",
 	)
-	sb.WriteString("            echo \"  Updated $df\"\n")
-	sb.WriteString("        fi\n")
+	sb.WriteString("                echo \"  Updated $df ($IMAGE)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("        done\n")
 	sb.WriteString("    done\n")
 	sb.WriteString("fi\n\n")
 }
@@ -689,15 +1051,16 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update CHANGELOG.md only if the upgrade produced actual changes.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("fi\n\n")
 }
 
-func writeCommitAndPush(sb *strings.Builder) {
+func writeCommitAndPush(sb *strings.Builder, signed bool) {
+	gitCommit := support.GitCommitCommand(signed)
 	sb.WriteString("if [ -n \"$(git status --porcelain)\" ]; then\n")
 
 	// Detect cosmetic-only lockfile changes (project version sync with zero
@@ -711,11 +1074,13 @@ func writeCommitAndPush(sb *strings.Builder) {
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$NODE_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded Node.js to `$NODE_VERSION` and updated all dependencies\"\n",
+		"        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded Node.js to `$NODE_VERSION` " +
+			"and updated all dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): updated JavaScript dependencies\"\n")
+	sb.WriteString("        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: updated JavaScript dependencies\"\n")
 	sb.WriteString("    fi\n")
+	sb.WriteString("    " + support.DiffStatScript)
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
 	sb.WriteString("else\n")
@@ -729,9 +1094,11 @@ func writeCommitAndPush(sb *strings.Builder) {
 func writeLockfileOnlyCheck(sb *strings.Builder) {
 	sb.WriteString("    # Skip cosmetic lockfile-only version sync\n")
 	sb.WriteString("    CHANGED_FILES=$(git diff --name-only)\n")
-	// Filter out CHANGELOG.md because writeChangelogUpdate may have copied it
+	// Filter out the changelog because writeChangelogUpdate may have copied it
 	// even when the only real change is a cosmetic lockfile version sync.
-	sb.WriteString("    SIGNIFICANT_FILES=$(echo \"$CHANGED_FILES\" | grep -v '^CHANGELOG.md$')\n")
+	sb.WriteString(
+		"    SIGNIFICANT_FILES=$(echo \"$CHANGED_FILES\" | grep -vxF \"${CHANGELOG_PATH:-CHANGELOG.md}\")\n",
+	)
 	sb.WriteString("    if [ \"$SIGNIFICANT_FILES\" = \"package-lock.json\" ]; then\n")
 	// Use Node.js for a precise JSON comparison matching the Go-based
 	// isPackageLockOnlyVersionSync: strip only root "version" and
@@ -771,7 +1138,31 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 		env = append(env, "NODE_VERSION="+params.NodeVersion)
 	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
+	}
+	if params.Workspaces {
+		env = append(env, "WORKSPACES=true")
+	}
+	if params.UpdateRanges {
+		env = append(env, "UPDATE_RANGES=true")
+	}
+	if params.AllowYanked {
+		env = append(env, "ALLOW_YANKED=true")
+	}
+	if params.RefreshLockfile {
+		env = append(env, "REFRESH_LOCKFILE=true")
+	}
+	if params.CommitAuthor.Name != "" {
+		env = append(env, "COMMIT_AUTHOR_NAME="+params.CommitAuthor.Name)
+	}
+	if params.CommitAuthor.Email != "" {
+		env = append(env, "COMMIT_AUTHOR_EMAIL="+params.CommitAuthor.Email)
+	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
+	if params.Signing.Key != "" {
+		env = append(env, support.SigningKeyEnv+"="+params.Signing.Key)
 	}
 	return env
 }
@@ -780,12 +1171,23 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 // dependency upgrade. Exported so that the local-mode CLI handler can
 // reuse the same description format.
 func GeneratePRDescription(nodeVersion, pkgMgr string, nodeVersionUpdated bool) string {
+	return generatePRDescription(nodeVersion, pkgMgr, nodeVersionUpdated, false)
+}
+
+// generatePRDescription is GeneratePRDescription for the updater itself,
+// which also describes the update_ranges mode: the package.json ranges
+// moved to the latest releases, so new majors may be part of the diff.
+func generatePRDescription(nodeVersion, pkgMgr string, nodeVersionUpdated, updateRanges bool) string {
 	var sb strings.Builder
 	sb.WriteString("## Summary\n\n")
 	if nodeVersionUpdated {
 		sb.WriteString(
 			"This PR upgrades the Node.js version to **" + nodeVersion + "** and updates all JavaScript dependencies.\n\n",
 		)
+	} else if updateRanges {
+		sb.WriteString(
+			"This PR moves the JavaScript dependency ranges in `package.json` to their latest releases.\n\n",
+		)
 	} else {
 		sb.WriteString(
 			"This PR updates all JavaScript dependencies to their latest versions.\n\n",
@@ -793,7 +1195,12 @@ func GeneratePRDescription(nodeVersion, pkgMgr string, nodeVersionUpdated bool)
 	}
 	sb.WriteString("### Changes\n\n")
 	if nodeVersionUpdated {
-		sb.WriteString("- Updated `.nvmrc` / `.node-version` to `" + nodeVersion + "`\n")
+		sb.WriteString("- Updated `.nvmrc` / `.node-version` / `.tool-versions` to `" + nodeVersion + "`\n")
+	}
+
+	if updateRanges {
+		writeLatestRangeChanges(&sb, pkgMgr)
+		return sb.String()
 	}
 
 	switch pkgMgr {
@@ -801,6 +1208,8 @@ func GeneratePRDescription(nodeVersion, pkgMgr string, nodeVersionUpdated bool)
 		sb.WriteString("- Ran `pnpm update` to update all dependencies\n")
 	case "yarn":
 		sb.WriteString("- Ran `yarn upgrade` to update all dependencies\n")
+	case "bun":
+		sb.WriteString("- Ran `bun update` to update all dependencies\n")
 	default:
 		sb.WriteString("- Ran `npm update` to update all dependencies\n")
 	}
@@ -810,6 +1219,6 @@ func GeneratePRDescription(nodeVersion, pkgMgr string, nodeVersionUpdated bool)
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes in lockfile\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
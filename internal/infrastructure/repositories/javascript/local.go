@@ -13,6 +13,7 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/cmdrunner"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // localCmdRunner is the package-level command runner for local-mode upgrade scripts.
@@ -25,6 +26,17 @@ type LocalUpgradeOptions struct {
 	AuthToken    string
 	ProviderName string                    // git provider name (e.g. "azuredevops", "github", "gitlab")
 	PushAuth     gitlocal.PushAuthResolver // resolves auth methods for git push
+	Remote       string                    // git remote to push to; empty means origin
+	BaseBranch   string                    // branch of Remote the upgrade starts from; empty means HEAD
+	// Changelog locates the changelog file and the "### " heading under
+	// [Unreleased] that receives the entry; zero means CHANGELOG.md/Changed.
+	Changelog entities.ChangelogSettings
+	// CommitPrefix replaces the "chore(deps)" prefix of the upgrade commit
+	// message when set.
+	CommitPrefix string
+	// Signing signs the upgrade commit with this key instead of the one in
+	// the git config when its Key is set.
+	Signing entities.Signing
 }
 
 // LocalResult holds the outcome of a local upgrade operation.
@@ -35,6 +47,8 @@ type LocalResult struct {
 	BranchName         string
 	PackageManager     string
 	Output             string
+	// ChangelogEntry is the entry ApplyLocalUpgrade leaves to the caller.
+	ChangelogEntry string
 }
 
 // RunLocalUpgrade runs the JavaScript dependency upgrade directly in a local
@@ -50,7 +64,8 @@ func RunLocalUpgrade(
 		logger.SetLevel(logger.DebugLevel)
 	}
 
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	fetcher := NewHTTPNodeVersionFetcher(&http.Client{Timeout: nodeVersionTimeout})
+	vCtx := resolveLocalVersionContext(ctx, fetcher, repoDir, entities.UpdateOptions{})
 
 	pkgMgr := detectLocalPackageManager(repoDir)
 
@@ -61,26 +76,62 @@ func RunLocalUpgrade(
 	return executeLocalUpgrade(ctx, repoDir, vCtx, pkgMgr, opts)
 }
 
-// resolveLocalVersionContext fetches the latest Node.js version and compares
-// it against the local .nvmrc or .node-version to build a versionContext.
-func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
+// ApplyLocalUpgrade runs the JavaScript upgrade script in moduleDir without any
+// git operation, for callers that branch, commit and push themselves, such
+// as local mode upgrading several modules of one repository on a single
+// branch. HasChanges is left unset: the caller's commit tells. The
+// changelog is left to the caller too, which gets the entry in ChangelogEntry.
+// The cosmetic lockfile check of RunLocalUpgrade is skipped, as the
+// worktree may already hold other modules' changes.
+func ApplyLocalUpgrade(
+	ctx context.Context,
+	moduleDir string,
+	opts LocalUpgradeOptions,
+) (*LocalResult, error) {
 	fetcher := NewHTTPNodeVersionFetcher(&http.Client{Timeout: nodeVersionTimeout})
-	latestNodeVersion, err := fetcher.FetchLatestVersion(ctx)
-	if err != nil {
-		logger.Warnf(
-			"[javascript] Failed to fetch latest Node.js version: %v (continuing without version upgrade)",
-			err,
-		)
-		latestNodeVersion = ""
-	} else {
-		logger.Infof("[javascript] Latest Node.js LTS version: %s", latestNodeVersion)
+	vCtx := resolveLocalVersionContext(ctx, fetcher, moduleDir, entities.UpdateOptions{})
+
+	pkgMgr := detectLocalPackageManager(moduleDir)
+
+	if opts.DryRun {
+		return handleDryRunLocal(vCtx, moduleDir, pkgMgr), nil
+	}
+
+	outputStr, runErr := runLanguageUpgradeScript(ctx, moduleDir, vCtx, pkgMgr, "", opts)
+	if runErr != nil {
+		return nil, runErr
 	}
 
+	return &LocalResult{
+		NodeVersionUpdated: strings.Contains(outputStr, "NODE_VERSION_UPDATED=true"),
+		LatestVersion:      vCtx.LatestVersion,
+		BranchName:         vCtx.BranchName,
+		PackageManager:     pkgMgr,
+		Output:             outputStr,
+		ChangelogEntry:     localChangelogEntry(vCtx),
+	}, nil
+}
+
+// resolveLocalVersionContext fetches the latest Node.js version and compares
+// it against the local .nvmrc or .node-version to build a versionContext.
+func resolveLocalVersionContext(
+	ctx context.Context,
+	fetcher VersionFetcher,
+	repoDir string,
+	opts entities.UpdateOptions,
+) *versionContext {
+	return localVersionContext(fetchTargetVersion(ctx, fetcher, opts.NodeChannel), repoDir, opts)
+}
+
+// localVersionContext compares latestNodeVersion against the Node.js
+// version of the project in repoDir to build a versionContext.
+func localVersionContext(latestNodeVersion, repoDir string, opts entities.UpdateOptions) *versionContext {
 	needsVersionUpgrade := false
 	if latestNodeVersion != "" {
-		currentVersion := readLocalNodeVersion(repoDir)
+		currentVersion := resolveLocalCurrentVersion(repoDir, opts)
 		if currentVersion != "" {
-			needsVersionUpgrade = currentVersion != latestNodeVersion
+			needsVersionUpgrade = currentVersion != latestNodeVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestNodeVersion)
 			logger.Infof(
 				"[javascript] Current Node.js version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -100,8 +151,26 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 	}
 }
 
+// resolveLocalCurrentVersion returns the current Node.js version of the
+// local repository: the version file, unless the root Dockerfile's node
+// image disagrees and the version_conflict policy picks otherwise.
+func resolveLocalCurrentVersion(repoDir string, opts entities.UpdateOptions) string {
+	fileVersion := readLocalNodeVersion(repoDir)
+	if fileVersion == "" {
+		return ""
+	}
+	images := opts.DockerfileImages
+	if len(images) == 0 {
+		images = []string{"node"}
+	}
+	return support.ResolveCurrentVersion("javascript", opts.VersionConflict, []entities.VersionSource{
+		{File: "version file", Version: fileVersion},
+		{File: "Dockerfile", Version: support.DockerfileImageVersion(repoDir, images...)},
+	})
+}
+
 // readLocalNodeVersion reads the Node.js version from .nvmrc or .node-version
-// files in the local repository.
+// files in the local repository, falling back to .tool-versions.
 func readLocalNodeVersion(repoDir string) string {
 	for _, versionFile := range []string{".nvmrc", ".node-version"} {
 		content, err := os.ReadFile(filepath.Join(repoDir, versionFile))
@@ -112,11 +181,15 @@ func readLocalNodeVersion(repoDir string) string {
 			}
 		}
 	}
+	if content, err := os.ReadFile(filepath.Join(repoDir, ".tool-versions")); err == nil {
+		return parseToolVersionsNode(string(content))
+	}
 	return ""
 }
 
 // detectLocalPackageManager determines which package manager the local
-// repository uses by checking for lockfiles.
+// repository uses by checking for lockfiles, with the same precedence as
+// detectPackageManager.
 func detectLocalPackageManager(repoDir string) string {
 	if _, err := os.Stat(filepath.Join(repoDir, "pnpm-lock.yaml")); err == nil {
 		return pkgMgrPnpm
@@ -124,6 +197,11 @@ func detectLocalPackageManager(repoDir string) string {
 	if _, err := os.Stat(filepath.Join(repoDir, "yarn.lock")); err == nil {
 		return pkgMgrYarn
 	}
+	for _, lockfile := range []string{"bun.lockb", "bun.lock"} {
+		if _, err := os.Stat(filepath.Join(repoDir, lockfile)); err == nil {
+			return pkgMgrBun
+		}
+	}
 	return pkgMgrNpm
 }
 
@@ -164,6 +242,9 @@ func executeLocalUpgrade(
 	if err != nil {
 		return nil, err
 	}
+	gitCtx.UseRemote(opts.Remote)
+	gitCtx.UseBaseBranch(opts.BaseBranch)
+	gitCtx.UseSigning(opts.Signing)
 	originalBranch, err := gitCtx.CurrentBranch()
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine current branch: %w", err)
@@ -187,7 +268,11 @@ func executeLocalUpgrade(
 	}
 
 	// --- Language Operations (bash) ---
-	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, pkgMgr, opts)
+	changelogFile := prepareLocalChangelog(repoDir, vCtx, opts.Changelog)
+	if changelogFile != "" {
+		defer os.Remove(changelogFile)
+	}
+	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, pkgMgr, changelogFile, opts)
 	if runErr != nil {
 		return nil, runErr
 	}
@@ -195,7 +280,7 @@ func executeLocalUpgrade(
 	nodeVersionUpdated := strings.Contains(outputStr, "NODE_VERSION_UPDATED=true")
 
 	// Skip when the only change is a cosmetic lockfile version sync.
-	if hasOnlyLockfileVersionChanges(ctx, repoDir) {
+	if hasOnlyLockfileVersionChanges(ctx, repoDir, opts.Changelog.FilePath()) {
 		logger.Infof(
 			"[javascript] Only cosmetic lockfile version changes detected (project version sync), skipping",
 		)
@@ -216,6 +301,7 @@ func executeLocalUpgrade(
 			vCtx.LatestVersion,
 		)
 	}
+	commitMsg = entities.WithCommitPrefix(commitMsg, opts.CommitPrefix)
 
 	pushed, pushErr := gitCtx.StageCommitAndPush(
 		vCtx.BranchName, commitMsg, opts.AuthToken,
@@ -242,20 +328,18 @@ func runLanguageUpgradeScript(
 	repoDir string,
 	vCtx *versionContext,
 	pkgMgr string,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	changelogFile := prepareLocalChangelog(repoDir, vCtx)
-	if changelogFile != "" {
-		defer os.Remove(changelogFile)
-	}
-
 	params := localUpgradeParams{
 		BranchName:     vCtx.BranchName,
 		NodeVersion:    vCtx.LatestVersion,
 		ChangelogFile:  changelogFile,
+		ChangelogPath:  opts.Changelog.FilePath(),
 		AuthToken:      opts.AuthToken,
 		ProviderName:   opts.ProviderName,
 		PackageManager: pkgMgr,
+		Workspaces:     detectLocalWorkspaces(repoDir),
 	}
 
 	script := buildLocalUpgradeScript(params)
@@ -278,7 +362,7 @@ func runLanguageUpgradeScript(
 
 	var outputStr string
 	if runResult != nil {
-		outputStr = runResult.Output
+		outputStr = support.RedactTokens(runResult.Output, params.AuthToken)
 	}
 
 	if opts.Verbose {
@@ -300,9 +384,11 @@ type localUpgradeParams struct {
 	BranchName     string
 	NodeVersion    string
 	ChangelogFile  string
+	ChangelogPath  string
 	AuthToken      string
 	ProviderName   string
 	PackageManager string
+	Workspaces     bool
 }
 
 // buildLocalUpgradeScript builds a bash script that performs only the
@@ -370,6 +456,9 @@ func buildLocalEnv(params localUpgradeParams) []string {
 	if params.NodeVersion != "" {
 		env = append(env, "NODE_VERSION="+params.NodeVersion)
 	}
+	if params.Workspaces {
+		env = append(env, "WORKSPACES=true")
+	}
 	if params.AuthToken != "" {
 		env = append(env,
 			"AUTH_TOKEN="+params.AuthToken,
@@ -377,30 +466,21 @@ func buildLocalEnv(params localUpgradeParams) []string {
 		)
 	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
 
-// prepareLocalChangelog reads CHANGELOG.md from disk (if it exists),
+// prepareLocalChangelog reads the changelog from disk (if it exists),
 // inserts an upgrade entry, and writes the result to a temp file.
-func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+func prepareLocalChangelog(repoDir string, vCtx *versionContext, changelog entities.ChangelogSettings) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath())))
 	if err != nil {
 		return "" // no changelog present
 	}
 
-	var entry string
-	if vCtx.NeedsVersionUpgrade {
-		entry = fmt.Sprintf(
-			"- changed the Node.js version to `%s` and updated all JavaScript dependencies",
-			vCtx.LatestVersion,
-		)
-	} else {
-		entry = jsChangelogEntryDeps
-	}
-
-	modified := entities.InsertChangelogEntry(string(content), []string{entry})
+	entry := localChangelogEntry(vCtx)
+	modified := entities.InsertChangelogEntryInSection(string(content), []string{entry}, changelog.Section)
 	if modified == string(content) {
 		return ""
 	}
@@ -421,3 +501,14 @@ func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
 
 	return tmpFile.Name()
 }
+
+// localChangelogEntry returns the changelog entry describing the upgrade.
+func localChangelogEntry(vCtx *versionContext) string {
+	if vCtx.NeedsVersionUpgrade {
+		return fmt.Sprintf(
+			"- changed the Node.js version to `%s` and updated all JavaScript dependencies",
+			vCtx.LatestVersion,
+		)
+	}
+	return jsChangelogEntryDeps
+}
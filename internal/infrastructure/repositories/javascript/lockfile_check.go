@@ -14,8 +14,9 @@ import (
 // hasOnlyLockfileVersionChanges returns true when the only uncommitted
 // modifications in repoDir are cosmetic lockfile version-field syncs
 // (e.g., npm update syncing the root "version" in package-lock.json to
-// match package.json) with zero actual dependency changes.
-func hasOnlyLockfileVersionChanges(ctx context.Context, repoDir string) bool {
+// match package.json) with zero actual dependency changes. A change to the
+// changelog at changelogPath is tolerated alongside them.
+func hasOnlyLockfileVersionChanges(ctx context.Context, repoDir, changelogPath string) bool {
 	changedFiles := gitChangedFiles(ctx, repoDir)
 	if len(changedFiles) == 0 {
 		return false
@@ -29,7 +30,7 @@ func hasOnlyLockfileVersionChanges(ctx context.Context, repoDir string) bool {
 			if !isPackageLockOnlyVersionSync(ctx, repoDir) {
 				return false
 			}
-		case "CHANGELOG.md":
+		case changelogPath:
 			// Tolerate auto-generated changelog updates alongside cosmetic
 			// lockfile syncs — writeChangelogUpdate copies the changelog
 			// whenever git status is non-empty, even for cosmetic-only changes.
@@ -0,0 +1,91 @@
+package javascript
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+const (
+	jsCommitMsgLockfile      = "chore(deps): refreshed the JavaScript lockfile"
+	jsChangelogEntryLockfile = "- refreshed the JavaScript lockfile to pick up the latest registry metadata"
+)
+
+// writeLockfileRefresh emits the commands that regenerate the lockfile from
+// scratch when REFRESH_LOCKFILE=true, so integrity hashes and resolved URLs
+// follow the registry even when no version changed. The previous lockfile is
+// restored when the package manager fails, so a broken refresh never deletes
+// it.
+func writeLockfileRefresh(sb *strings.Builder) {
+	sb.WriteString("# Regenerate the lockfile when a refresh was requested\n")
+	sb.WriteString("if [ \"${REFRESH_LOCKFILE:-false}\" = \"true\" ]; then\n")
+	sb.WriteString("    case \"$PACKAGE_MANAGER\" in\n")
+	sb.WriteString("        pnpm)\n")
+	sb.WriteString("            LOCKFILE=pnpm-lock.yaml\n")
+	sb.WriteString("            REFRESH_CMD=\"pnpm install --lockfile-only\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        yarn)\n")
+	sb.WriteString("            LOCKFILE=yarn.lock\n")
+	sb.WriteString("            REFRESH_CMD=\"yarn install --ignore-scripts\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        bun)\n")
+	sb.WriteString("            LOCKFILE=bun.lockb\n")
+	sb.WriteString("            [ -f bun.lock ] && LOCKFILE=bun.lock\n")
+	sb.WriteString("            REFRESH_CMD=\"bun install --ignore-scripts\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        *)\n")
+	sb.WriteString("            LOCKFILE=package-lock.json\n")
+	sb.WriteString("            REFRESH_CMD=\"npm install --package-lock-only --ignore-scripts\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("    esac\n")
+	sb.WriteString("    if [ -f \"$LOCKFILE\" ]; then\n")
+	sb.WriteString("        echo \"Refreshing $LOCKFILE...\"\n")
+	sb.WriteString("        mv \"$LOCKFILE\" \"$LOCKFILE.autoupdate-bak\"\n")
+	sb.WriteString("        if $REFRESH_CMD 2>&1 && [ -f \"$LOCKFILE\" ]; then\n")
+	sb.WriteString("            rm -f \"$LOCKFILE.autoupdate-bak\"\n")
+	sb.WriteString("        else\n")
+	sb.WriteString("            echo \"WARNING: lockfile refresh failed, keeping the previous $LOCKFILE\"\n")
+	sb.WriteString("            mv \"$LOCKFILE.autoupdate-bak\" \"$LOCKFILE\"\n")
+	sb.WriteString("        fi\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("fi\n\n")
+}
+
+// isLockfileRefreshOnly reports whether every changed path is a JavaScript
+// lockfile, i.e. the refresh re-hashed the lockfile without moving any
+// declared version.
+func isLockfileRefreshOnly(changedFiles []string) bool {
+	if len(changedFiles) == 0 {
+		return false
+	}
+	for _, f := range changedFiles {
+		switch filepath.Base(f) {
+		case "package-lock.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb", "bun.lock":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// generateLockfileRefreshPRDescription builds the markdown PR description
+// for a run where only the lockfiles changed.
+func generateLockfileRefreshPRDescription(changedFiles []string) string {
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("All JavaScript dependencies are already at their latest versions. ")
+	sb.WriteString("This PR regenerates the lockfile so it reflects the current registry metadata ")
+	sb.WriteString("(integrity hashes, resolved URLs and deprecations).\n\n")
+	sb.WriteString("### Changes\n\n")
+	for _, f := range changedFiles {
+		sb.WriteString("- Refreshed `" + f + "`\n")
+	}
+	sb.WriteString("\n### Review Checklist\n\n")
+	sb.WriteString("- [ ] Verify build passes\n")
+	sb.WriteString("- [ ] Verify tests pass\n")
+	sb.WriteString("- [ ] Review the lockfile diff\n")
+	sb.WriteString("\n---\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
+	return sb.String()
+}
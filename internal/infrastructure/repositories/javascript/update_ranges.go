@@ -0,0 +1,73 @@
+package javascript
+
+import (
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// writeLatestRangeUpdate emits the commands used when UPDATE_RANGES=true:
+// instead of staying inside the declared semver ranges, every range in
+// package.json moves to the latest release (majors included) and the
+// dependencies are reinstalled. npm has no built-in equivalent, so
+// npm-check-updates rewrites package.json before `npm install`, skipping
+// deprecated releases unless ALLOW_YANKED=true. The other managers follow
+// the `latest` dist-tag, which never points at an unpublished version. The
+// commands run inside the `if` branch opened by writeJSUpgradeCommands.
+func writeLatestRangeUpdate(sb *strings.Builder) {
+	sb.WriteString("    case \"$PACKAGE_MANAGER\" in\n")
+	sb.WriteString("        pnpm)\n")
+	sb.WriteString("            PNPM_RECURSIVE=\"\"\n")
+	sb.WriteString("            [ \"$WORKSPACES\" = \"true\" ] && PNPM_RECURSIVE=\"-r\"\n")
+	sb.WriteString("            echo \"Running pnpm up --latest...\"\n")
+	sb.WriteString("            pnpm $PNPM_RECURSIVE up --latest 2>&1 ||\n")
+	sb.WriteString("                echo \"WARNING: pnpm up --latest had some errors (continuing anyway)\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        yarn)\n")
+	sb.WriteString("            echo \"Running yarn upgrade --latest...\"\n")
+	sb.WriteString("            yarn upgrade --latest 2>&1 ||\n")
+	sb.WriteString("                echo \"WARNING: yarn upgrade --latest had some errors (continuing anyway)\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        bun)\n")
+	sb.WriteString("            echo \"Running bun update --latest...\"\n")
+	sb.WriteString("            bun update --latest 2>&1 ||\n")
+	sb.WriteString("                echo \"WARNING: bun update --latest had some errors (continuing anyway)\"\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("        *)\n")
+	sb.WriteString("            NCU_WORKSPACES=\"\"\n")
+	sb.WriteString("            [ \"$WORKSPACES\" = \"true\" ] && NCU_WORKSPACES=\"--workspaces --root\"\n")
+	sb.WriteString("            NCU_DEPRECATED=\"--no-deprecated\"\n")
+	sb.WriteString("            [ \"${ALLOW_YANKED:-false}\" = \"true\" ] && NCU_DEPRECATED=\"\"\n")
+	sb.WriteString("            echo \"Running npm-check-updates -u...\"\n")
+	sb.WriteString("            if npx --yes npm-check-updates -u $NCU_DEPRECATED $NCU_WORKSPACES 2>&1; then\n")
+	sb.WriteString("                npm install 2>&1 || echo \"WARNING: npm install had some errors (continuing anyway)\"\n")
+	sb.WriteString("            else\n")
+	sb.WriteString("                echo \"WARNING: npm-check-updates failed, package.json ranges were not moved\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("            ;;\n")
+	sb.WriteString("    esac\n")
+}
+
+// writeLatestRangeChanges writes the rest of the PR description for the
+// update_ranges mode: the command that moved the ranges and a review item
+// for the major upgrades it may have brought in.
+func writeLatestRangeChanges(sb *strings.Builder, pkgMgr string) {
+	switch pkgMgr {
+	case "pnpm":
+		sb.WriteString("- Ran `pnpm up --latest` to move the `package.json` ranges to the latest releases\n")
+	case "yarn":
+		sb.WriteString("- Ran `yarn upgrade --latest` to move the `package.json` ranges to the latest releases\n")
+	case "bun":
+		sb.WriteString("- Ran `bun update --latest` to move the `package.json` ranges to the latest releases\n")
+	default:
+		sb.WriteString("- Ran `npm-check-updates -u` to move the `package.json` ranges to the latest releases\n")
+		sb.WriteString("- Ran `npm install` to update the lockfile\n")
+	}
+
+	sb.WriteString("\n### Review Checklist\n\n")
+	sb.WriteString("- [ ] Verify build passes\n")
+	sb.WriteString("- [ ] Verify tests pass\n")
+	sb.WriteString("- [ ] Review the changed ranges in `package.json`, new major versions may have breaking changes\n")
+	sb.WriteString("\n---\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
+}
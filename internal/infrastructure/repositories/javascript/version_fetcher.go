@@ -7,11 +7,18 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 )
 
 // VersionFetcher abstracts latest Node.js version resolution for testability.
 type VersionFetcher interface {
 	FetchLatestVersion(ctx context.Context) (string, error)
+	// FetchLatestVersionInChannel returns the newest release of a release
+	// channel: entities.NodeChannelLTS or entities.NodeChannelCurrent.
+	FetchLatestVersionInChannel(ctx context.Context, channel string) (string, error)
 }
 
 // defaultNodeVersionURL is the default URL for fetching Node.js release metadata.
@@ -35,6 +42,13 @@ func NewHTTPNodeVersionFetcherWithURL(client *http.Client, baseURL string) Versi
 
 // FetchLatestVersion returns the latest LTS Node.js version string (e.g. "20.18.0").
 func (f *HTTPNodeVersionFetcher) FetchLatestVersion(ctx context.Context) (string, error) {
+	return f.FetchLatestVersionInChannel(ctx, entities.NodeChannelLTS)
+}
+
+// FetchLatestVersionInChannel returns the newest Node.js version of the
+// channel: the latest LTS for entities.NodeChannelLTS (also when empty), or
+// the latest release overall for entities.NodeChannelCurrent.
+func (f *HTTPNodeVersionFetcher) FetchLatestVersionInChannel(ctx context.Context, channel string) (string, error) {
 	req, err := http.NewRequestWithContext(
 		ctx, http.MethodGet, f.baseURL, nil,
 	)
@@ -57,11 +71,43 @@ func (f *HTTPNodeVersionFetcher) FetchLatestVersion(ctx context.Context) (string
 		return "", fmt.Errorf("failed to parse Node.js versions: %w", decodeErr)
 	}
 
+	return latestInChannel(releases, channel)
+}
+
+// latestInChannel picks the first release of the channel from the Node.js
+// release index, which lists the newest release first.
+func latestInChannel(releases []nodeRelease, channel string) (string, error) {
 	for _, release := range releases {
-		if isLTSRelease(release) {
+		if channel == entities.NodeChannelCurrent || isLTSRelease(release) {
 			return strings.TrimPrefix(release.Version, "v"), nil
 		}
 	}
 
+	if channel == entities.NodeChannelCurrent {
+		return "", errors.New("no Node.js version found")
+	}
 	return "", errors.New("no LTS Node.js version found")
 }
+
+// fetchTargetVersion returns the Node.js version the updater should move to
+// for the configured channel. Failures are logged and reported as "" so the
+// run continues with a dependencies-only update.
+func fetchTargetVersion(ctx context.Context, fetcher VersionFetcher, channel string) string {
+	if channel == "" {
+		channel = entities.NodeChannelLTS
+	}
+	latest, err := fetcher.FetchLatestVersionInChannel(ctx, channel)
+	if err != nil {
+		logger.Warnf(
+			"[javascript] Failed to fetch latest Node.js version: %v (continuing without version upgrade)",
+			err,
+		)
+		return ""
+	}
+	if channel == entities.NodeChannelCurrent {
+		logger.Infof("[javascript] Latest Node.js Current version: %s", latest)
+	} else {
+		logger.Infof("[javascript] Latest Node.js LTS version: %s", latest)
+	}
+	return latest
+}
@@ -162,3 +162,44 @@ func TestHTTPNodeVersionFetcher(t *testing.T) {
 		assert.Empty(t, version)
 	})
 }
+
+func TestHTTPNodeVersionFetcherInChannel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		channel  string
+		expected string
+	}{
+		{name: "should pick the newest LTS release for the lts channel", channel: "lts", expected: "22.12.0"},
+		{name: "should pick the newest LTS release when no channel is set", channel: "", expected: "22.12.0"},
+		{name: "should pick the newest release for the current channel", channel: "current", expected: "23.5.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				releases := []map[string]any{
+					{"version": "v23.5.0", "lts": false},
+					{"version": "v23.4.0", "lts": false},
+					{"version": "v22.12.0", "lts": "Jod"},
+					{"version": "v20.18.1", "lts": "Iron"},
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(releases)
+			}))
+			defer server.Close()
+			fetcher := jsUpdater.NewHTTPNodeVersionFetcherWithURL(server.Client(), server.URL)
+
+			// when
+			version, err := fetcher.FetchLatestVersionInChannel(t.Context(), tt.channel)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package javascript
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+// detectWorkspaces reports whether a project is a workspace (monorepo)
+// root: its package.json declares a non-empty `workspaces` field, either
+// as a list of globs or as Yarn's `{"packages": [...]}` object, or a
+// pnpm-workspace.yaml sits next to it.
+func detectWorkspaces(packageJSON string, hasPnpmWorkspace bool) bool {
+	if hasPnpmWorkspace {
+		return true
+	}
+
+	var manifest struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal([]byte(packageJSON), &manifest); err != nil || len(manifest.Workspaces) == 0 {
+		return false
+	}
+
+	var globs []string
+	if json.Unmarshal(manifest.Workspaces, &globs) == nil {
+		return len(globs) > 0
+	}
+	var object struct {
+		Packages []string `json:"packages"`
+	}
+	if json.Unmarshal(manifest.Workspaces, &object) == nil {
+		return len(object.Packages) > 0
+	}
+	return false
+}
+
+// detectRemoteWorkspaces detects a workspace root through the provider API.
+func detectRemoteWorkspaces(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+) bool {
+	content, err := provider.GetFileContent(ctx, repo, "package.json")
+	if err != nil {
+		content = ""
+	}
+	return detectWorkspaces(content, provider.HasFile(ctx, repo, "pnpm-workspace.yaml"))
+}
+
+// detectLocalWorkspaces detects a workspace root in a checked-out directory.
+func detectLocalWorkspaces(dir string) bool {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		content = nil
+	}
+	_, pnpmErr := os.Stat(filepath.Join(dir, "pnpm-workspace.yaml"))
+	return detectWorkspaces(string(content), pnpmErr == nil)
+}
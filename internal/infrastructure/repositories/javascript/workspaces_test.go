@@ -0,0 +1,257 @@
+//go:build unit
+
+package javascript_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jsUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/javascript"
+)
+
+// runUpgradeCommands runs the generated upgrade commands with fake package
+// manager binaries that print how they were invoked. The fake yarn rejects
+// `workspaces foreach` like Yarn Classic when classicYarn is set. Extra
+// environment variables (e.g. "UPDATE_RANGES=true") are passed through.
+func runUpgradeCommands(t *testing.T, pkgMgr string, workspaces, classicYarn bool, env ...string) string {
+	t.Helper()
+	return runUpgradeCommandsIn(t, t.TempDir(), pkgMgr, workspaces, classicYarn, env...)
+}
+
+// runUpgradeCommandsIn is runUpgradeCommands inside an existing project
+// directory, so tests can seed the files the script rewrites.
+func runUpgradeCommandsIn(
+	t *testing.T,
+	dir, pkgMgr string,
+	workspaces, classicYarn bool,
+	env ...string,
+) string {
+	t.Helper()
+
+	binDir := filepath.Join(dir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0o755))
+	for _, name := range []string{"npm", "npx", "pnpm", "yarn", "bun"} {
+		fake := "#!/bin/sh\n"
+		if name == "yarn" && classicYarn {
+			fake += "[ \"$1\" = \"workspaces\" ] && exit 1\n"
+		}
+		fake += "echo \"CALLED " + name + " $*\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, name), []byte(fake), 0o755))
+	}
+
+	script := "#!/bin/bash\nset -euo pipefail\n" +
+		jsUpdater.WriteJSUpgradeCommands(jsUpdater.UpgradeParams{PackageManager: pkgMgr})
+	scriptPath := filepath.Join(dir, "upgrade.sh")
+	require.NoError(t, os.WriteFile(scriptPath, []byte(script), 0o755))
+
+	cmd := exec.Command("bash", scriptPath)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+		"PACKAGE_MANAGER="+pkgMgr,
+	)
+	if workspaces {
+		cmd.Env = append(cmd.Env, "WORKSPACES=true")
+	}
+	cmd.Env = append(cmd.Env, env...)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+func TestDetectWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		packageJSON      string
+		hasPnpmWorkspace bool
+		expected         bool
+	}{
+		{"should detect a workspaces list", `{"workspaces": ["packages/*"]}`, false, true},
+		{"should detect a Yarn workspaces object", `{"workspaces": {"packages": ["apps/*"]}}`, false, true},
+		{"should detect a pnpm-workspace.yaml", `{"name": "root"}`, true, true},
+		{"should ignore an empty workspaces list", `{"workspaces": []}`, false, false},
+		{"should ignore a package without workspaces", `{"name": "app"}`, false, false},
+		{"should ignore an unparsable package.json", `{`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given / when
+			result := jsUpdater.DetectWorkspaces(tt.packageJSON, tt.hasPnpmWorkspace)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("should detect a pnpm workspace root on disk", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "root"}`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pnpm-workspace.yaml"), []byte("packages: []\n"), 0o644))
+
+		// when
+		result := jsUpdater.DetectLocalWorkspaces(dir)
+
+		// then
+		assert.True(t, result)
+	})
+}
+
+func TestWriteJSUpgradeCommandsWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should run pnpm -r update in a workspace root", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "pnpm", true, false)
+
+		// then
+		assert.Contains(t, output, "CALLED pnpm -r update\n")
+	})
+
+	t.Run("should run yarn workspaces foreach upgrade in a workspace root", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "yarn", true, false)
+
+		// then
+		assert.Contains(t, output, "CALLED yarn workspaces foreach upgrade\n")
+		assert.NotContains(t, output, "CALLED yarn upgrade")
+	})
+
+	t.Run("should fall back to yarn upgrade when workspaces foreach is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "yarn", true, true)
+
+		// then
+		assert.Contains(t, output, "CALLED yarn upgrade\n")
+	})
+
+	t.Run("should run npm update --workspaces in a workspace root", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "npm", true, false)
+
+		// then
+		assert.Contains(t, output, "CALLED npm update --workspaces --include-workspace-root\n")
+	})
+
+	t.Run("should keep the plain update outside a workspace root", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "pnpm", false, false)
+
+		// then
+		assert.Contains(t, output, "CALLED pnpm update\n")
+		assert.NotContains(t, output, "-r update")
+	})
+}
+
+func TestWriteJSUpgradeCommandsUpdateRanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should run npm-check-updates and reinstall when update ranges is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "npm", false, false, "UPDATE_RANGES=true")
+
+		// then
+		assert.Contains(t, output, "CALLED npx --yes npm-check-updates -u --no-deprecated\n")
+		assert.Contains(t, output, "CALLED npm install\n")
+		assert.NotContains(t, output, "CALLED npm update")
+	})
+
+	t.Run("should install only after npm-check-updates rewrote the ranges", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "npm", false, false, "UPDATE_RANGES=true")
+
+		// then
+		rewrite := strings.Index(output, "CALLED npx --yes npm-check-updates -u")
+		install := strings.Index(output, "CALLED npm install")
+		require.NotEqual(t, -1, rewrite)
+		require.NotEqual(t, -1, install)
+		assert.Less(t, rewrite, install)
+	})
+
+	t.Run("should pass the workspace flags to npm-check-updates in a workspace root", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "npm", true, false, "UPDATE_RANGES=true")
+
+		// then
+		assert.Contains(t, output, "CALLED npx --yes npm-check-updates -u --no-deprecated --workspaces --root\n")
+	})
+
+	t.Run("should let npm-check-updates pick deprecated releases when yanked versions are allowed", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "npm", false, false, "UPDATE_RANGES=true", "ALLOW_YANKED=true")
+
+		// then
+		assert.Contains(t, output, "CALLED npx --yes npm-check-updates -u\n")
+		assert.NotContains(t, output, "--no-deprecated")
+	})
+
+	t.Run("should run pnpm up --latest when update ranges is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "pnpm", false, false, "UPDATE_RANGES=true")
+
+		// then
+		assert.Contains(t, output, "CALLED pnpm up --latest\n")
+		assert.NotContains(t, output, "CALLED pnpm update")
+	})
+
+	t.Run("should run yarn upgrade --latest when update ranges is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		output := runUpgradeCommands(t, "yarn", false, false, "UPDATE_RANGES=true")
+
+		// then
+		assert.Contains(t, output, "CALLED yarn upgrade --latest\n")
+	})
+
+	t.Run("should stay within the declared ranges when update ranges is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		outputs := []string{
+			runUpgradeCommands(t, "npm", false, false),
+			runUpgradeCommands(t, "pnpm", false, false),
+			runUpgradeCommands(t, "yarn", false, false),
+		}
+
+		// then
+		for _, output := range outputs {
+			assert.NotContains(t, output, "--latest")
+			assert.NotContains(t, output, "npm-check-updates")
+		}
+		assert.Contains(t, outputs[0], "CALLED npm update\n")
+	})
+}
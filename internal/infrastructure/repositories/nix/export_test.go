@@ -0,0 +1,26 @@
+//go:build unit
+
+package nix
+
+// InputUpdate is exported for testing.
+type InputUpdate = inputUpdate
+
+// BuildBatchNixScript is exported for testing.
+func BuildBatchNixScript() string {
+	return buildBatchNixScript()
+}
+
+// DiffFlakeLocks is exported for testing.
+func DiffFlakeLocks(before, after []byte) ([]InputUpdate, error) {
+	return diffFlakeLocks(before, after)
+}
+
+// GeneratePRDescription is exported for testing.
+func GeneratePRDescription(updates []InputUpdate) string {
+	return generatePRDescription(updates)
+}
+
+// ChangelogEntry is exported for testing.
+func ChangelogEntry(updates []InputUpdate) string {
+	return changelogEntry(updates)
+}
@@ -0,0 +1,96 @@
+package nix
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	fullRevLen  = 40 // length of a full git SHA-1 revision
+	shortRevLen = 7
+)
+
+// inputUpdate describes a flake input whose locked revision changed.
+type inputUpdate struct {
+	Name string
+	From string // empty when the input was not locked before
+	To   string
+}
+
+// flakeLock mirrors the parts of flake.lock the updater reads: every node
+// except the root pins an input through its "locked" attributes.
+type flakeLock struct {
+	Nodes map[string]struct {
+		Locked *struct {
+			Rev     string `json:"rev"`
+			NarHash string `json:"narHash"`
+		} `json:"locked"`
+	} `json:"nodes"`
+}
+
+// parseFlakeLock returns the locked revision of every input in a flake.lock,
+// keyed by node name. Inputs without a git revision (e.g. tarballs) are
+// identified by their narHash instead.
+func parseFlakeLock(content []byte) (map[string]string, error) {
+	var lock flakeLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse flake.lock: %w", err)
+	}
+
+	revisions := make(map[string]string, len(lock.Nodes))
+	for name, node := range lock.Nodes {
+		if node.Locked == nil {
+			continue
+		}
+		switch {
+		case node.Locked.Rev != "":
+			revisions[name] = node.Locked.Rev
+		case node.Locked.NarHash != "":
+			revisions[name] = node.Locked.NarHash
+		}
+	}
+	return revisions, nil
+}
+
+// diffFlakeLocks lists the inputs whose locked revision differs between two
+// flake.lock contents, sorted by name. An empty before (no flake.lock yet)
+// reports every locked input as newly pinned.
+func diffFlakeLocks(before, after []byte) ([]inputUpdate, error) {
+	oldRevs := map[string]string{}
+	if len(before) > 0 {
+		parsed, err := parseFlakeLock(before)
+		if err != nil {
+			return nil, err
+		}
+		oldRevs = parsed
+	}
+
+	newRevs, err := parseFlakeLock(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []inputUpdate
+	for name, rev := range newRevs {
+		if oldRevs[name] == rev {
+			continue
+		}
+		updates = append(updates, inputUpdate{
+			Name: name,
+			From: shortRev(oldRevs[name]),
+			To:   shortRev(rev),
+		})
+	}
+	sort.Slice(updates, func(i, j int) bool { return updates[i].Name < updates[j].Name })
+	return updates, nil
+}
+
+// shortRev abbreviates a git revision the way `git log --oneline` does.
+// Hashes such as "sha256-..." are kept whole.
+func shortRev(rev string) string {
+	if len(rev) == fullRevLen {
+		return rev[:shortRevLen]
+	}
+	return rev
+}
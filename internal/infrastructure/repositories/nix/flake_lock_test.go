@@ -0,0 +1,113 @@
+//go:build unit
+
+package nix_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	nixUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/nix"
+)
+
+const lockBefore = `{
+  "nodes": {
+    "flake-utils": {
+      "locked": {"owner": "numtide", "repo": "flake-utils", "type": "github",
+        "rev": "11707dc2f618dd54ca8739b309ec4fc024de578b"}
+    },
+    "nixpkgs": {
+      "locked": {"owner": "NixOS", "repo": "nixpkgs", "type": "github",
+        "rev": "a3a3dda3bacf61e8a39258a0ed9c924eeca8e293"}
+    },
+    "root": {"inputs": {"flake-utils": "flake-utils", "nixpkgs": "nixpkgs"}}
+  },
+  "root": "root",
+  "version": 7
+}`
+
+const lockAfter = `{
+  "nodes": {
+    "flake-utils": {
+      "locked": {"owner": "numtide", "repo": "flake-utils", "type": "github",
+        "rev": "11707dc2f618dd54ca8739b309ec4fc024de578b"}
+    },
+    "nixpkgs": {
+      "locked": {"owner": "NixOS", "repo": "nixpkgs", "type": "github",
+        "rev": "b2e9a1f3c3c7a8f1e0d1d6d5c4b3a29180706050"}
+    },
+    "src": {
+      "locked": {"type": "tarball", "narHash": "sha256-Zm9vYmFy"}
+    },
+    "root": {"inputs": {"flake-utils": "flake-utils", "nixpkgs": "nixpkgs", "src": "src"}}
+  },
+  "root": "root",
+  "version": 7
+}`
+
+func TestDiffFlakeLocks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list the inputs whose locked revision changed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		before, after := []byte(lockBefore), []byte(lockAfter)
+
+		// when
+		updates, err := nixUpdater.DiffFlakeLocks(before, after)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []nixUpdater.InputUpdate{
+			{Name: "nixpkgs", From: "a3a3dda", To: "b2e9a1f"},
+			{Name: "src", From: "", To: "sha256-Zm9vYmFy"},
+		}, updates)
+	})
+
+	t.Run("should report every input as new when there was no flake.lock", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		after := []byte(lockBefore)
+
+		// when
+		updates, err := nixUpdater.DiffFlakeLocks(nil, after)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []nixUpdater.InputUpdate{
+			{Name: "flake-utils", To: "11707dc"},
+			{Name: "nixpkgs", To: "a3a3dda"},
+		}, updates)
+	})
+
+	t.Run("should return nothing when the lock did not change", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		lock := []byte(lockBefore)
+
+		// when
+		updates, err := nixUpdater.DiffFlakeLocks(lock, lock)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("should return an error for malformed JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		before := []byte("{not json")
+
+		// when
+		_, err := nixUpdater.DiffFlakeLocks(before, []byte(lockAfter))
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "flake.lock")
+	})
+}
@@ -0,0 +1,232 @@
+package nix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+const (
+	updaterName    = "nix"
+	scriptFileMode = 0o700
+
+	flakeFile     = "flake.nix"
+	flakeLockFile = "flake.lock"
+
+	branchNixInputs       = "chore/upgrade-nix-flake-inputs"
+	nixCommitMsg          = "chore(deps): updated Nix flake inputs"
+	nixChangelogEntryFmt  = "- changed the Nix flake inputs %s to their latest revisions"
+	nixExperimentalFlakes = "--extra-experimental-features 'nix-command flakes'"
+)
+
+// ErrLocalPipelineOnly is returned by CreateUpdatePRs: refreshing flake.lock
+// runs `nix` against a local clone, so the updater only works through the
+// clone-based pipeline (repositories.LocalUpdater).
+var ErrLocalPipelineOnly = errors.New("the nix updater only runs through the clone-based pipeline")
+
+// UpdaterRepository implements repositories.UpdaterRepository for Nix flakes.
+// It runs `nix flake update` in the cloned repository to refresh the inputs
+// pinned in flake.lock and lists the updated inputs in the PR.
+type UpdaterRepository struct {
+	lookPath func(file string) (string, error)
+}
+
+// NewUpdaterRepository creates a new Nix updater.
+func NewUpdaterRepository() repositories.UpdaterRepository {
+	return &UpdaterRepository{lookPath: exec.LookPath}
+}
+
+// NewUpdaterRepositoryWithDeps creates a Nix updater with an injected PATH
+// lookup (for testing).
+func NewUpdaterRepositoryWithDeps(lookPath func(file string) (string, error)) repositories.UpdaterRepository {
+	return &UpdaterRepository{lookPath: lookPath}
+}
+
+func (u *UpdaterRepository) Name() string { return updaterName }
+
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Nix flake inputs pinned in flake.lock (needs nix on the PATH)"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return []string{flakeFile, flakeLockFile}
+}
+
+// Detect returns true if the repository has a flake.nix or flake.lock and
+// the `nix` binary is available to refresh it.
+func (u *UpdaterRepository) Detect(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+) bool {
+	found := false
+	for _, file := range u.DetectionFiles() {
+		if provider.HasFile(ctx, repo, file) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	if _, err := u.lookPath("nix"); err != nil {
+		logger.Warnf("[nix] %s/%s uses Nix flakes but `nix` is not on the PATH, skipping",
+			repo.Organization, repo.Name)
+		return false
+	}
+	return true
+}
+
+// CreateUpdatePRs is not supported; see ErrLocalPipelineOnly.
+func (u *UpdaterRepository) CreateUpdatePRs(
+	_ context.Context,
+	_ repositories.ProviderRepository,
+	_ entities.Repository,
+	_ entities.UpdateOptions,
+) ([]entities.PullRequest, error) {
+	return nil, ErrLocalPipelineOnly
+}
+
+// ApplyUpdates implements repositories.LocalUpdater. It runs `nix flake
+// update` on a locally cloned repository and describes the inputs whose
+// locked revision changed, without performing any git operations.
+func (u *UpdaterRepository) ApplyUpdates(
+	ctx context.Context,
+	repoDir string,
+	_ repositories.ProviderRepository,
+	repo entities.Repository,
+	opts entities.UpdateOptions,
+) (*repositories.LocalUpdateResult, error) {
+	logger.Infof("[nix] Processing local clone of %s/%s", repo.Organization, repo.Name)
+
+	if _, err := os.Stat(filepath.Join(repoDir, flakeFile)); err != nil {
+		logger.Infof("[nix] No %s in %s/%s, nothing to update", flakeFile, repo.Organization, repo.Name)
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
+
+	lockPath := filepath.Join(repoDir, flakeLockFile)
+	before, readErr := os.ReadFile(lockPath)
+	if readErr != nil && !os.IsNotExist(readErr) {
+		return nil, fmt.Errorf("failed to read %s: %w", flakeLockFile, readErr)
+	}
+
+	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
+	if writeErr := os.WriteFile(scriptPath, []byte(buildBatchNixScript()), scriptFileMode); writeErr != nil {
+		return nil, fmt.Errorf("failed to write script: %w", writeErr)
+	}
+	defer func() { _ = os.Remove(scriptPath) }()
+
+	cmd := exec.CommandContext(ctx, "bash", scriptPath)
+	cmd.Dir = repoDir
+	output, cmdErr := cmd.CombinedOutput()
+	logger.Debugf("[nix] Upgrade script output:\n%s", output)
+	if cmdErr != nil {
+		return nil, fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, output)
+	}
+	_ = os.Remove(scriptPath)
+
+	after, err := os.ReadFile(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s after update: %w", flakeLockFile, err)
+	}
+
+	updates, diffErr := diffFlakeLocks(before, after)
+	if diffErr != nil {
+		return nil, diffErr
+	}
+	if len(updates) == 0 || !support.HasUncommittedChanges(ctx, repoDir) {
+		logger.Infof("[nix] No flake inputs changed after update")
+		return nil, repositories.ErrNoUpdatesNeeded
+	}
+
+	changes := dependencyChanges(updates)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, changes), nixCommitMsg, generatePRDescription(updates),
+	)
+	return &repositories.LocalUpdateResult{
+		BranchName:       branchNixInputs,
+		CommitMessage:    entities.WithCommitPrefix(nixCommitMsg, opts.CommitPrefix()),
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		Changes:          changes,
+		ChangelogEntries: []string{changelogEntry(updates)},
+	}, nil
+}
+
+// buildBatchNixScript generates a bash script with only the flake update
+// (no git clone, branch, commit, or push) for the batch pipeline. The
+// experimental features flag keeps it working where flakes are not enabled
+// in nix.conf.
+func buildBatchNixScript() string {
+	var sb strings.Builder
+
+	sb.WriteString("#!/bin/bash\n")
+	sb.WriteString("set -euo pipefail\n\n")
+
+	sb.WriteString("# Refresh every input pinned in flake.lock\n")
+	sb.WriteString("echo \"Running nix flake update...\"\n")
+	sb.WriteString("nix " + nixExperimentalFlakes + " flake update 2>&1\n")
+
+	return sb.String()
+}
+
+// changelogEntry builds the CHANGELOG.md line naming the updated inputs.
+func changelogEntry(updates []inputUpdate) string {
+	names := make([]string, 0, len(updates))
+	for _, up := range updates {
+		names = append(names, "`"+up.Name+"`")
+	}
+	return fmt.Sprintf(nixChangelogEntryFmt, strings.Join(names, ", "))
+}
+
+// dependencyChanges converts the updated inputs for the changes report.
+func dependencyChanges(updates []inputUpdate) []entities.DependencyChange {
+	changes := make([]entities.DependencyChange, 0, len(updates))
+	for _, up := range updates {
+		changes = append(changes, entities.DependencyChange{
+			Ecosystem: updaterName,
+			Name:      up.Name,
+			From:      up.From,
+			To:        up.To,
+			File:      flakeLockFile,
+		})
+	}
+	return changes
+}
+
+// generatePRDescription builds a markdown PR description listing the flake
+// inputs whose locked revision changed.
+func generatePRDescription(updates []inputUpdate) string {
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("This PR updates the Nix flake inputs pinned in `flake.lock` to their latest revisions.\n\n")
+	sb.WriteString("### Updated Inputs\n\n")
+	sb.WriteString("| Input | From | To |\n")
+	sb.WriteString("|-------|------|----|\n")
+	for _, up := range updates {
+		from := "-"
+		if up.From != "" {
+			from = "`" + up.From + "`"
+		}
+		fmt.Fprintf(&sb, "| `%s` | %s | `%s` |\n", up.Name, from, up.To)
+	}
+	sb.WriteString("\n### Review Checklist\n\n")
+	sb.WriteString("- [ ] Verify `nix flake check` passes\n")
+	sb.WriteString("- [ ] Review the input changes in `flake.lock`\n")
+	sb.WriteString("\n---\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
+	return sb.String()
+}
@@ -0,0 +1,203 @@
+//go:build unit
+
+package nix_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	nixUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/nix"
+	"github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
+)
+
+func nixOnPath(string) (string, error) { return "/usr/bin/nix", nil }
+
+func nixMissing(string) (string, error) { return "", errors.New("executable file not found in $PATH") }
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nix as updater name", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updater := nixUpdater.NewUpdaterRepository()
+
+		// when
+		name := updater.Name()
+
+		// then
+		assert.Equal(t, "nix", name)
+	})
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return true when flake.nix exists and nix is available", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"flake.nix": true}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+
+		// when
+		detected := nixUpdater.NewUpdaterRepositoryWithDeps(nixOnPath).Detect(t.Context(), provider, repo)
+
+		// then
+		assert.True(t, detected)
+	})
+
+	t.Run("should return true when only flake.lock exists", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"flake.lock": true}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+
+		// when
+		detected := nixUpdater.NewUpdaterRepositoryWithDeps(nixOnPath).Detect(t.Context(), provider, repo)
+
+		// then
+		assert.True(t, detected)
+	})
+
+	t.Run("should return false when no flake files exist", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"default.nix": true}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+
+		// when
+		detected := nixUpdater.NewUpdaterRepositoryWithDeps(nixOnPath).Detect(t.Context(), provider, repo)
+
+		// then
+		assert.False(t, detected)
+	})
+
+	t.Run("should return false when nix is not on the PATH", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"flake.nix": true, "flake.lock": true}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+
+		// when
+		detected := nixUpdater.NewUpdaterRepositoryWithDeps(nixMissing).Detect(t.Context(), provider, repo)
+
+		// then
+		assert.False(t, detected)
+	})
+}
+
+func TestCreateUpdatePRs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should refuse to run outside the clone-based pipeline", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		updater := nixUpdater.NewUpdaterRepositoryWithDeps(nixOnPath)
+
+		// when
+		prs, err := updater.CreateUpdatePRs(t.Context(), provider, entities.Repository{}, entities.UpdateOptions{})
+
+		// then
+		require.ErrorIs(t, err, nixUpdater.ErrLocalPipelineOnly)
+		assert.Empty(t, prs)
+	})
+}
+
+func TestApplyUpdates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report no updates when the repository has no flake.nix", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "flake.lock"), []byte(`{"nodes":{}}`), 0o600))
+		updater := nixUpdater.NewUpdaterRepositoryWithDeps(nixOnPath)
+		lu, ok := updater.(repositories.LocalUpdater)
+		require.True(t, ok)
+
+		// when
+		result, err := lu.ApplyUpdates(t.Context(), repoDir, nil, entities.Repository{}, entities.UpdateOptions{})
+
+		// then
+		require.ErrorIs(t, err, repositories.ErrNoUpdatesNeeded)
+		assert.Nil(t, result)
+	})
+}
+
+func TestBuildBatchNixScript(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should run nix flake update with flakes enabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		script := nixUpdater.BuildBatchNixScript()
+
+		// then
+		assert.Contains(t, script, "set -euo pipefail")
+		assert.Contains(t, script, "nix --extra-experimental-features 'nix-command flakes' flake update")
+		assert.NotContains(t, script, "git push")
+	})
+}
+
+func TestGeneratePRDescription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list every updated input with its old and new revision", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updates := []nixUpdater.InputUpdate{
+			{Name: "flake-utils", To: "11707dc"},
+			{Name: "nixpkgs", From: "a3a3dda", To: "b2e9a1f"},
+		}
+
+		// when
+		desc := nixUpdater.GeneratePRDescription(updates)
+
+		// then
+		assert.Contains(t, desc, "| `flake-utils` | - | `11707dc` |")
+		assert.Contains(t, desc, "| `nixpkgs` | `a3a3dda` | `b2e9a1f` |")
+		assert.Contains(t, desc, entities.DefaultPRFooter)
+	})
+}
+
+func TestChangelogEntry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should name the updated inputs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		updates := []nixUpdater.InputUpdate{{Name: "flake-utils"}, {Name: "nixpkgs"}}
+
+		// when
+		entry := nixUpdater.ChangelogEntry(updates)
+
+		// then
+		assert.Equal(t, "- changed the Nix flake inputs `flake-utils`, `nixpkgs` to their latest revisions", entry)
+	})
+}
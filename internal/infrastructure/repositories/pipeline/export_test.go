@@ -7,6 +7,7 @@ import (
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // TruncateToGranularity is exported for testing.
@@ -61,7 +62,8 @@ func AppendChangelogEntry(
 	upgrades []upgradeTask,
 	fileChanges []entities.FileChange,
 ) []entities.FileChange {
-	return appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, entities.ChangelogSettings{})
+	return support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, "")
 }
 
 // --- GitHub Actions exports ---
@@ -115,8 +117,31 @@ func FindActionUpgradesInFile(
 	provider repositories.ProviderRepository,
 	content, filePath string,
 	cache ActionTagCache,
+	opts ...entities.UpdateOptions,
 ) []UpgradeTask {
-	return findActionUpgradesInFile(ctx, provider, content, filePath, cache)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return findActionUpgradesInFile(ctx, provider, content, filePath, cache, o)
+}
+
+// ImageTagCache is exported for testing.
+type ImageTagCache = imageTagCache
+
+// FindImageUpgradesInFile is exported for testing.
+func FindImageUpgradesInFile(
+	ctx context.Context,
+	content, filePath string,
+	resolve func(ctx context.Context, image, tag string) (string, error),
+	cache ImageTagCache,
+	opts ...entities.UpdateOptions,
+) []UpgradeTask {
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return findImageUpgradesInFile(ctx, content, filePath, resolve, cache, o)
 }
 
 // SanitizeBranchSegment is exported for testing.
@@ -155,9 +180,14 @@ func GenerateBranchName(tasks []UpgradeTask) string {
 	return generateBranchName(tasks)
 }
 
-// GenerateCommitMessage is exported for testing.
-func GenerateCommitMessage(tasks []UpgradeTask) string {
-	return generateCommitMessage(tasks)
+// GenerateCommitMessage is exported for testing. The optional options
+// supply the commit type and scope; none means the defaults.
+func GenerateCommitMessage(tasks []UpgradeTask, opts ...entities.UpdateOptions) string {
+	var options entities.UpdateOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return generateCommitMessage(tasks, options.CommitPrefix())
 }
 
 // GeneratePRTitle is exported for testing.
@@ -176,8 +206,13 @@ func LocalScanAndDetermineUpgrades(
 	repoDir string,
 	provider repositories.ProviderRepository,
 	latestVersions map[string]string,
+	opts ...entities.UpdateOptions,
 ) ([]UpgradeTask, map[string]string) {
-	return localScanAndDetermineUpgrades(ctx, repoDir, provider, latestVersions)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return localScanAndDetermineUpgrades(ctx, repoDir, provider, latestVersions, o)
 }
 
 // CreateUpgradePR is exported for testing.
@@ -191,3 +226,8 @@ func CreateUpgradePR(
 ) ([]entities.PullRequest, error) {
 	return createUpgradePR(ctx, provider, repo, opts, upgrades, fileContents)
 }
+
+// CollectChanges exports collectChanges for testing.
+func CollectChanges(tasks []UpgradeTask) []entities.DependencyChange {
+	return collectChanges(tasks)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	dfRepo "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/dockerfile"
 	"github.com/rios0rios0/autoupdate/internal/support"
 	langPipeline "github.com/rios0rios0/langforge/pkg/infrastructure/languages/pipeline"
 	langVersions "github.com/rios0rios0/langforge/pkg/infrastructure/versions"
@@ -91,6 +92,27 @@ var actionUsesPattern = regexp.MustCompile(
 	`(?m)(uses:\s+['"]?([a-zA-Z0-9_.-]+)/([a-zA-Z0-9_.-]+)@(v\d+(?:\.\d+(?:\.\d+)?)?)['"]?)(?:\s+#.*)?$`,
 )
 
+// imageRefPattern matches the container images of GitHub Actions jobs: the
+// `container:` shorthand and the `image:` key of a job container or service.
+// Captures: (1) the core clause (without trailing comment), (2) image, (3) tag.
+// Digest pins, expressions and registry hosts with ports are not matched.
+var imageRefPattern = regexp.MustCompile(
+	`(?m)^\s*(?:-\s+)?((?:container|image):\s+['"]?([a-z0-9][a-z0-9._/-]*):([A-Za-z0-9][A-Za-z0-9._-]*)['"]?)` +
+		`\s*(?:#.*)?$`,
+)
+
+// imageResolver returns the tag an image should be upgraded to, or "" when
+// it is already up to date.
+type imageResolver func(ctx context.Context, image, tag string) (string, error)
+
+// imageTagCache caches resolved upgrades per "image:tag" key so an image
+// shared by several jobs or files is looked up once.
+type imageTagCache map[string]string
+
+// resolveImageUpgradeFunc resolves container image upgrades with the same
+// Docker Hub rules the dockerfile updater applies to base images.
+var resolveImageUpgradeFunc imageResolver = dfRepo.ResolveImageUpgrade //nolint:gochecknoglobals // test override for DI
+
 // UpdaterRepository implements repositories.UpdaterRepository for CI/CD pipeline files.
 type UpdaterRepository struct{}
 
@@ -101,6 +123,16 @@ func NewUpdaterRepository() repositories.UpdaterRepository {
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Language versions pinned in CI/CD pipeline configuration files"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langPipeline.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository contains CI/CD pipeline configuration files.
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -131,7 +163,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		return []entities.PullRequest{}, nil
 	}
 
-	upgrades, fileContents := scanAndDetermineUpgrades(ctx, provider, repo, latestVersions)
+	upgrades, fileContents := scanAndDetermineUpgrades(ctx, provider, repo, latestVersions, opts)
 	if len(upgrades) == 0 {
 		logger.Infof("[pipeline] %s/%s: all pipeline versions up to date", repo.Organization, repo.Name)
 		return []entities.PullRequest{}, nil
@@ -152,6 +184,22 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	return createUpgradePR(ctx, provider, repo, opts, upgrades, fileContents)
 }
 
+// PlanUpdates implements repositories.UpdatePlanner for dry runs. It returns
+// the language version bumps CreateUpdatePRs would make to the pipeline files.
+func (u *UpdaterRepository) PlanUpdates(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	opts entities.UpdateOptions,
+) ([]entities.DependencyChange, error) {
+	latestVersions := fetchAllLatestVersions(ctx)
+	if len(latestVersions) == 0 {
+		return []entities.DependencyChange{}, nil
+	}
+	upgrades, _ := scanAndDetermineUpgrades(ctx, provider, repo, latestVersions, opts)
+	return collectChanges(upgrades), nil
+}
+
 // ApplyUpdates implements repositories.LocalUpdater for the clone-based pipeline.
 // It scans the local filesystem for pipeline version references, fetches latest
 // versions via HTTP, writes changes to disk, and returns PR metadata.
@@ -160,7 +208,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[pipeline] Scanning local clone of %s/%s for pipeline version references",
 		repo.Organization, repo.Name)
@@ -171,7 +219,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	upgrades, fileContents := localScanAndDetermineUpgrades(ctx, repoDir, provider, latestVersions)
+	upgrades, fileContents := localScanAndDetermineUpgrades(ctx, repoDir, provider, latestVersions, opts)
 	if len(upgrades) == 0 {
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
@@ -194,23 +242,45 @@ func (u *UpdaterRepository) ApplyUpdates(
 			up.match.Language, up.match.CurrentVer, up.newVersion,
 		))
 	}
-	support.LocalChangelogUpdate(repoDir, entries)
 
+	changes := collectChanges(upgrades)
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, changes), generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	return &repositories.LocalUpdateResult{
-		BranchName:    generateBranchName(upgrades),
-		CommitMessage: generateCommitMessage(upgrades),
-		PRTitle:       generatePRTitle(upgrades),
-		PRDescription: generatePRDescription(upgrades),
+		BranchName:       generateBranchName(upgrades),
+		CommitMessage:    generateCommitMessage(upgrades, opts.CommitPrefix()),
+		PRTitle:          prTitle,
+		PRDescription:    prDescription,
+		Changes:          changes,
+		ChangelogEntries: entries,
 	}, nil
 }
 
+// collectChanges converts the applied upgrades into changes report entries.
+func collectChanges(tasks []upgradeTask) []entities.DependencyChange {
+	changes := make([]entities.DependencyChange, 0, len(tasks))
+	for _, t := range tasks {
+		changes = append(changes, entities.DependencyChange{
+			Ecosystem: updaterName,
+			Name:      t.match.Language,
+			From:      t.match.CurrentVer,
+			To:        t.newVersion,
+			File:      t.match.FilePath,
+		})
+	}
+	return changes
+}
+
 // localScanAndDetermineUpgrades walks the local filesystem for YAML files,
 // scans them for version references, and returns upgrade tasks plus file contents.
+// Files inside excluded directories are skipped.
 func localScanAndDetermineUpgrades(
 	ctx context.Context,
 	repoDir string,
 	provider repositories.ProviderRepository,
 	latestVersions map[string]string,
+	opts entities.UpdateOptions,
 ) ([]upgradeTask, map[string]string) {
 	fileContents := make(map[string]string)
 	var upgrades []upgradeTask
@@ -227,8 +297,12 @@ func localScanAndDetermineUpgrades(
 	allFiles = append(allFiles, ymlFiles...)
 
 	tagCache := make(actionTagCache)
+	imageCache := make(imageTagCache)
 
 	for _, relPath := range allFiles {
+		if opts.IsPathExcluded(relPath) {
+			continue
+		}
 		ci := classifyFile(relPath)
 		if ci == "" {
 			continue
@@ -241,12 +315,16 @@ func localScanAndDetermineUpgrades(
 		}
 		content := string(data)
 
-		fileUpgrades := findUpgradesInFile(content, relPath, ci, latestVersions)
+		fileUpgrades := findUpgradesInFile(content, relPath, ci, latestVersions, opts)
 
 		if ci == ciGitHubActions && provider != nil {
-			actionUpgrades := findActionUpgradesInFile(ctx, provider, content, relPath, tagCache)
+			actionUpgrades := findActionUpgradesInFile(ctx, provider, content, relPath, tagCache, opts)
 			fileUpgrades = append(fileUpgrades, actionUpgrades...)
 		}
+		if ci == ciGitHubActions {
+			imageUpgrades := findImageUpgradesInFile(ctx, content, relPath, resolveImageUpgradeFunc, imageCache, opts)
+			fileUpgrades = append(fileUpgrades, imageUpgrades...)
+		}
 
 		upgrades = append(upgrades, fileUpgrades...)
 
@@ -298,12 +376,14 @@ func scanAndDetermineUpgrades(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersions map[string]string,
+	opts entities.UpdateOptions,
 ) ([]upgradeTask, map[string]string) {
 	fileContents := make(map[string]string)
 	var upgrades []upgradeTask
 
-	allFiles := listPipelineFiles(ctx, provider, repo)
+	allFiles := listPipelineFiles(ctx, provider, repo, opts)
 	tagCache := make(actionTagCache)
+	imageCache := make(imageTagCache)
 
 	for _, f := range allFiles {
 		if f.IsDir {
@@ -321,11 +401,13 @@ func scanAndDetermineUpgrades(
 			continue
 		}
 
-		fileUpgrades := findUpgradesInFile(content, f.Path, ci, latestVersions)
+		fileUpgrades := findUpgradesInFile(content, f.Path, ci, latestVersions, opts)
 
 		if ci == ciGitHubActions {
-			actionUpgrades := findActionUpgradesInFile(ctx, provider, content, f.Path, tagCache)
+			actionUpgrades := findActionUpgradesInFile(ctx, provider, content, f.Path, tagCache, opts)
 			fileUpgrades = append(fileUpgrades, actionUpgrades...)
+			imageUpgrades := findImageUpgradesInFile(ctx, content, f.Path, resolveImageUpgradeFunc, imageCache, opts)
+			fileUpgrades = append(fileUpgrades, imageUpgrades...)
 		}
 
 		upgrades = append(upgrades, fileUpgrades...)
@@ -338,11 +420,13 @@ func scanAndDetermineUpgrades(
 	return upgrades, fileContents
 }
 
-// listPipelineFiles collects all YAML files from the repository.
+// listPipelineFiles collects all YAML files from the repository outside the
+// excluded directories.
 func listPipelineFiles(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
+	opts entities.UpdateOptions,
 ) []entities.File {
 	yamlFiles, err := provider.ListFiles(ctx, repo, ".yaml")
 	if err != nil {
@@ -354,14 +438,22 @@ func listPipelineFiles(
 		logger.Warnf("[pipeline] Failed to list .yml files: %v", ymlErr)
 	}
 
-	return append(yamlFiles, ymlFiles...)
+	var files []entities.File
+	for _, f := range append(yamlFiles, ymlFiles...) {
+		if !opts.IsPathExcluded(f.Path) {
+			files = append(files, f)
+		}
+	}
+	return files
 }
 
 // findUpgradesInFile scans a single file for version references and returns upgrade tasks.
+// Major version bumps are skipped when opts.IgnoreMajor is set.
 func findUpgradesInFile(
 	content, filePath string,
 	ci ciSystem,
 	latestVersions map[string]string,
+	opts entities.UpdateOptions,
 ) []upgradeTask {
 	rules := rulesForCI(ci)
 	matches := scanFileForVersions(content, filePath, rules)
@@ -378,7 +470,7 @@ func findUpgradesInFile(
 		}
 
 		truncated := truncateToGranularity(latestVer, match.CurrentVer)
-		if truncated == match.CurrentVer {
+		if truncated == match.CurrentVer || opts.IsMajorBumpIgnored(match.CurrentVer, truncated) {
 			continue
 		}
 
@@ -676,12 +768,14 @@ func normalizeActionVersion(ref string) string {
 }
 
 // findActionUpgradesInFile scans a workflow file for GitHub Action references
-// and returns upgrade tasks using the existing upgradeTask type.
+// and returns upgrade tasks using the existing upgradeTask type. Major ref
+// bumps (e.g. "v4" to "v5") are skipped when opts.IgnoreMajor is set.
 func findActionUpgradesInFile(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	content, filePath string,
 	cache actionTagCache,
+	opts entities.UpdateOptions,
 ) []upgradeTask {
 	refs := scanFileForActions(content, filePath)
 	var tasks []upgradeTask
@@ -689,7 +783,7 @@ func findActionUpgradesInFile(
 	for _, ref := range refs {
 		tags := resolveActionTags(ctx, provider, ref.Owner, ref.Repo, cache)
 		up := determineActionUpgrade(ref, tags)
-		if up == nil {
+		if up == nil || opts.IsMajorBumpIgnored(ref.CurrentRef, up.newRef) {
 			continue
 		}
 
@@ -707,6 +801,49 @@ func findActionUpgradesInFile(
 	return tasks
 }
 
+// findImageUpgradesInFile scans a workflow file for job container and
+// service images (e.g. `container: node:20.11` or `image: postgres:16.1`)
+// and returns one upgrade task per reference with a newer compatible tag.
+// Lookup failures are logged and the image is left unchanged.
+func findImageUpgradesInFile(
+	ctx context.Context,
+	content, filePath string,
+	resolve imageResolver,
+	cache imageTagCache,
+	opts entities.UpdateOptions,
+) []upgradeTask {
+	var tasks []upgradeTask
+
+	for _, m := range imageRefPattern.FindAllStringSubmatch(content, -1) {
+		image, tag := m[2], m[3]
+		key := image + ":" + tag
+		newTag, cached := cache[key]
+		if !cached {
+			resolved, err := resolve(ctx, image, tag)
+			if err != nil {
+				logger.Warnf("[pipeline] Failed to resolve image %s: %v", key, err)
+			}
+			newTag = resolved
+			cache[key] = newTag
+		}
+		if newTag == "" || newTag == tag || opts.IsMajorBumpIgnored(tag, newTag) {
+			continue
+		}
+
+		tasks = append(tasks, upgradeTask{
+			match: versionMatch{
+				FilePath:   filePath,
+				Language:   "image:" + image,
+				CurrentVer: tag,
+				FullMatch:  m[1],
+			},
+			newVersion: newTag,
+		})
+	}
+
+	return tasks
+}
+
 // --- version validation ---
 
 // versionPattern matches simple dotted numeric versions like "1.25", "1.25.7", "21".
@@ -820,28 +957,33 @@ func createUpgradePR(
 	}
 
 	fileChanges := applyUpgrades(upgrades, fileContents)
-	fileChanges = appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, opts.Changelog)
 
 	targetBranch := repo.DefaultBranch
 	if opts.TargetBranch != "" {
 		targetBranch = "refs/heads/" + opts.TargetBranch
 	}
 
+	fileChanges = support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, opts.ChangelogConflict)
 	err := provider.CreateBranchWithChanges(ctx, repo, entities.BranchInput{
 		BranchName:    branchName,
 		BaseBranch:    targetBranch,
 		Changes:       fileChanges,
-		CommitMessage: generateCommitMessage(upgrades),
+		CommitMessage: generateCommitMessage(upgrades, opts.CommitPrefix()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create branch: %w", err)
 	}
 
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, collectChanges(upgrades)),
+		generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + branchName,
 		TargetBranch: targetBranch,
-		Title:        generatePRTitle(upgrades),
-		Description:  generatePRDescription(upgrades),
+		Title:        prTitle,
+		Description:  entities.ApplyPRFooter(prDescription, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -865,14 +1007,15 @@ func generateBranchName(tasks []upgradeTask) string {
 	return fmt.Sprintf(branchBatchFmt, len(tasks))
 }
 
-func generateCommitMessage(tasks []upgradeTask) string {
+func generateCommitMessage(tasks []upgradeTask, prefix string) string {
 	if len(tasks) == 1 {
 		return fmt.Sprintf(
-			"chore(deps): upgraded %s pipeline version from `%s` to `%s`",
+			"%s: upgraded %s pipeline version from `%s` to `%s`",
+			prefix,
 			tasks[0].match.Language, tasks[0].match.CurrentVer, tasks[0].newVersion,
 		)
 	}
-	return fmt.Sprintf("chore(deps): upgraded %d pipeline version references", len(tasks))
+	return fmt.Sprintf("%s: upgraded %d pipeline version references", prefix, len(tasks))
 }
 
 func generatePRTitle(tasks []upgradeTask) string {
@@ -902,30 +1045,20 @@ func generatePRDescription(tasks []upgradeTask) string {
 	}
 
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
 
-// appendChangelogEntry reads CHANGELOG.md (if present), inserts entries
-// describing the pipeline version upgrades, and appends the modified file
-// to the change set.
-func appendChangelogEntry(
+// buildChangelogChange reads the changelog (if present) and inserts entries
+// describing the pipeline version upgrades. The change is added to the
+// change set by support.ReconcileChangelogChange right before the push.
+func buildChangelogChange(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	upgrades []upgradeTask,
-	fileChanges []entities.FileChange,
-) []entities.FileChange {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
-		return fileChanges
-	}
-
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
-	if err != nil {
-		logger.Warnf("[pipeline] Failed to read CHANGELOG.md: %v", err)
-		return fileChanges
-	}
-
+	changelog entities.ChangelogSettings,
+) *support.ChangelogChange {
 	entries := make([]string, 0, len(upgrades))
 	for _, up := range upgrades {
 		entries = append(entries, fmt.Sprintf(
@@ -934,14 +1067,5 @@ func appendChangelogEntry(
 		))
 	}
 
-	modified := entities.InsertChangelogEntry(content, entries)
-	if modified == content {
-		return fileChanges
-	}
-
-	return append(fileChanges, entities.FileChange{
-		Path:       "CHANGELOG.md",
-		Content:    modified,
-		ChangeType: "edit",
-	})
+	return support.BuildChangelogChange(ctx, provider, repo, entries, changelog)
 }
@@ -3,6 +3,7 @@
 package pipeline_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -52,6 +53,41 @@ func TestLocalScanAndDetermineUpgrades(t *testing.T) {
 		assert.Contains(t, fileContents, "azure-devops/build.yaml")
 	})
 
+	t.Run("should skip a new major language version when ignore major is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		root := t.TempDir()
+		adoDir := root + "/azure-devops"
+		require.NoError(t, os.MkdirAll(adoDir, 0o755))
+
+		content := `steps:
+  - task: NodeTool@0
+    inputs:
+      version: '20.11'
+  - task: UsePythonVersion@0
+    inputs:
+      versionSpec: '3.12'
+`
+		require.NoError(t, os.WriteFile(adoDir+"/build.yaml", []byte(content), 0o644))
+
+		latestVersions := map[string]string{
+			"nodejs": "22.12.0",
+			"python": "3.13.1",
+		}
+		opts := entities.UpdateOptions{IgnoreMajor: true}
+
+		// when
+		upgrades, _ := pipeline.LocalScanAndDetermineUpgrades(
+			t.Context(), root, nil, latestVersions, opts,
+		)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "python", pipeline.UpgradeTaskLanguage(upgrades[0]))
+		assert.Equal(t, "3.13", pipeline.UpgradeTaskNewVersion(upgrades[0]))
+	})
+
 	t.Run("should skip hidden directories like .github in local filesystem walk", func(t *testing.T) {
 		t.Parallel()
 
@@ -265,6 +301,27 @@ func TestApplyUpdatesLocal(t *testing.T) {
 	})
 }
 
+func TestCollectChanges(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should report every upgrade as a pipeline dependency change", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		upgrades := []pipeline.UpgradeTask{
+			pipeline.NewUpgradeTaskWithFullMatch("python", "3.12", "3.13", "azure-devops/build.yml", "versionSpec: '3.12'"),
+		}
+
+		// when
+		changes := pipeline.CollectChanges(upgrades)
+
+		// then
+		assert.Equal(t, []entities.DependencyChange{{
+			Ecosystem: "pipeline", Name: "python", From: "3.12", To: "3.13", File: "azure-devops/build.yml",
+		}}, changes)
+	})
+}
+
 func TestCreateUpgradePR(t *testing.T) {
 	t.Parallel()
 
@@ -1168,6 +1225,31 @@ func TestFindActionUpgradesInFile(t *testing.T) {
 		assert.Equal(t, "v5", pipeline.UpgradeTaskNewVersion(tasks[1]))
 	})
 
+	t.Run("should skip major ref bumps when ignore major is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `steps:
+  - uses: actions/checkout@v1
+  - uses: actions/setup-go@v1.0.0
+`
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithTags([]string{"v2.0.0", "v1.4.0", "v1.0.0"}).
+			BuildSpy()
+		cache := make(pipeline.ActionTagCache)
+		opts := entities.UpdateOptions{IgnoreMajor: true}
+
+		// when
+		tasks := pipeline.FindActionUpgradesInFile(
+			t.Context(), provider, content, ".github/workflows/ci.yml", cache, opts,
+		)
+
+		// then
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "action:actions/setup-go", pipeline.UpgradeTaskLanguage(tasks[0]))
+		assert.Equal(t, "v1.4.0", pipeline.UpgradeTaskNewVersion(tasks[0]))
+	})
+
 	t.Run("should cache tags between calls for same owner/repo", func(t *testing.T) {
 		t.Parallel()
 
@@ -1220,6 +1302,102 @@ func TestFindActionUpgradesInFile(t *testing.T) {
 	})
 }
 
+func TestFindImageUpgradesInFile(t *testing.T) {
+	t.Parallel()
+
+	workflow := `jobs:
+  test:
+    runs-on: ubuntu-latest
+    container: node:20.11
+    services:
+      postgres:
+        image: "postgres:16.1" # database
+      cache:
+        image: ghcr.io/acme/cache:1.0.0
+    steps:
+      - uses: actions/checkout@v3
+`
+	resolve := func(_ context.Context, image, tag string) (string, error) {
+		switch image + ":" + tag {
+		case "node:20.11":
+			return "20.18", nil
+		case "postgres:16.1":
+			return "16.4", nil
+		}
+		return "", nil
+	}
+
+	t.Run("should bump the job container and service images", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		cache := make(pipeline.ImageTagCache)
+
+		// when
+		tasks := pipeline.FindImageUpgradesInFile(
+			t.Context(), workflow, ".github/workflows/ci.yml", resolve, cache,
+		)
+
+		// then
+		require.Len(t, tasks, 2)
+		assert.Equal(t, "image:node", pipeline.UpgradeTaskLanguage(tasks[0]))
+		assert.Equal(t, "20.11", pipeline.UpgradeTaskCurrentVer(tasks[0]))
+		assert.Equal(t, "20.18", pipeline.UpgradeTaskNewVersion(tasks[0]))
+		assert.Equal(t, "image:postgres", pipeline.UpgradeTaskLanguage(tasks[1]))
+		assert.Equal(t, "16.4", pipeline.UpgradeTaskNewVersion(tasks[1]))
+	})
+
+	t.Run("should rewrite image tags alongside action upgrades", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithTags([]string{"v4.0.0", "v3.0.0"}).
+			BuildSpy()
+		path := ".github/workflows/ci.yml"
+		tasks := pipeline.FindActionUpgradesInFile(t.Context(), provider, workflow, path, make(pipeline.ActionTagCache))
+		tasks = append(tasks, pipeline.FindImageUpgradesInFile(
+			t.Context(), workflow, path, resolve, make(pipeline.ImageTagCache),
+		)...)
+
+		// when
+		changes := pipeline.ApplyUpgrades(tasks, map[string]string{path: workflow})
+
+		// then
+		require.Len(t, changes, 1)
+		assert.Contains(t, changes[0].Content, "container: node:20.18\n")
+		assert.Contains(t, changes[0].Content, `image: "postgres:16.4" # database`)
+		assert.Contains(t, changes[0].Content, "image: ghcr.io/acme/cache:1.0.0\n")
+		assert.Contains(t, changes[0].Content, "uses: actions/checkout@v4\n")
+	})
+
+	t.Run("should resolve each image once and skip failed lookups", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `jobs:
+  a:
+    container: redis:7.2
+  b:
+    container: redis:7.2
+`
+		calls := 0
+		failing := func(context.Context, string, string) (string, error) {
+			calls++
+			return "", assert.AnError
+		}
+
+		// when
+		tasks := pipeline.FindImageUpgradesInFile(
+			t.Context(), content, ".github/workflows/ci.yml", failing, make(pipeline.ImageTagCache),
+		)
+
+		// then
+		assert.Empty(t, tasks)
+		assert.Equal(t, 1, calls)
+	})
+}
+
 func TestSanitizeBranchSegment(t *testing.T) {
 	t.Parallel()
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/dig"
 
 	domainRepos "github.com/rios0rios0/autoupdate/internal/domain/repositories"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories"
@@ -91,3 +92,24 @@ func TestProviderRegistry_GetAuthProvider_UnsupportedType(t *testing.T) {
 		assert.Contains(t, err.Error(), "unsupported service type")
 	})
 }
+
+func TestProviderRegistry_OpenPullRequestLister(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should list open pull requests on GitHub and Azure DevOps", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		container := dig.New()
+		require.NoError(t, repositories.RegisterProviders(container))
+
+		// when / then
+		require.NoError(t, container.Invoke(func(registry *repositories.ProviderRegistry) {
+			for _, name := range []string{"github", "azuredevops"} {
+				provider, err := registry.Get(name, "token")
+				require.NoError(t, err)
+				assert.Implements(t, (*domainRepos.OpenPullRequestLister)(nil), provider, name)
+			}
+		}))
+	})
+}
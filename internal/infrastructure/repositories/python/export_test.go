@@ -30,8 +30,13 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	opts ...entities.UpdateOptions,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return resolveVersionContext(ctx, provider, repo, latestVersion, o)
 }
 
 // VersionContext is exported for testing.
@@ -66,8 +71,12 @@ func BuildUpgradeScript(params UpgradeParamsExported, repoDir string) string {
 }
 
 // BuildBatchPythonScript is exported for testing.
-func BuildBatchPythonScript(hasRequirements, hasPyproject bool) string {
-	return buildBatchPythonScript(hasRequirements, hasPyproject)
+func BuildBatchPythonScript(hasRequirements, hasPyproject bool, toolchain ...string) string {
+	var tc string
+	if len(toolchain) > 0 {
+		tc = toolchain[0]
+	}
+	return buildBatchPythonScript(hasRequirements, hasPyproject, tc, "")
 }
 
 // WriteGitAuth is exported for testing.
@@ -80,6 +89,21 @@ func WritePythonUpgradeCommands(sb *strings.Builder, params UpgradeParamsExporte
 	writePythonUpgradeCommands(sb, params)
 }
 
+// WritePythonVersionUpdate is exported for testing.
+func WritePythonVersionUpdate(sb *strings.Builder) {
+	writePythonVersionUpdate(sb)
+}
+
+// ParseRequiresPython is exported for testing.
+func ParseRequiresPython(pyprojectContent string) string {
+	return parseRequiresPython(pyprojectContent)
+}
+
+// RequiresPythonTarget is exported for testing.
+func RequiresPythonTarget(floor, latest string, opts entities.UpdateOptions) string {
+	return requiresPythonTarget(floor, latest, opts)
+}
+
 // BuildEnv is exported for testing.
 func BuildEnv(params UpgradeParamsExported, repoDir string) []string {
 	return buildEnv(params, repoDir)
@@ -92,7 +116,7 @@ func PrepareChangelog(
 	repo entities.Repository,
 	vCtx *VersionContext,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	return prepareChangelog(ctx, provider, repo, vCtx, entities.ChangelogSettings{})
 }
 
 // LogDryRun is exported for testing.
@@ -124,7 +148,7 @@ func WriteChangelogUpdate(sb *strings.Builder) {
 
 // WriteCommitAndPush is exported for testing.
 func WriteCommitAndPush(sb *strings.Builder) {
-	writeCommitAndPush(sb)
+	writeCommitAndPush(sb, false)
 }
 
 // BuildLocalUpgradeScript is exported for testing.
@@ -144,7 +168,7 @@ func HandleDryRun(vCtx *VersionContext, repoDir string) *LocalResult {
 
 // PrepareLocalChangelog is exported for testing.
 func PrepareLocalChangelog(repoDir string, vCtx *VersionContext) string {
-	return prepareLocalChangelog(repoDir, vCtx)
+	return prepareLocalChangelog(repoDir, vCtx, entities.ChangelogSettings{})
 }
 
 // FindPythonBinary is exported for testing.
@@ -164,7 +188,51 @@ func RunLanguageUpgradeScript(
 	ctx context.Context,
 	repoDir string,
 	vCtx *versionContext,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	return runLanguageUpgradeScript(ctx, repoDir, vCtx, opts)
+	return runLanguageUpgradeScript(ctx, repoDir, vCtx, changelogFile, opts)
+}
+
+// RewriteConstraint is exported for testing.
+func RewriteConstraint(constraint, latest string, bumpLowerBounds bool) string {
+	return rewriteConstraint(constraint, latest, bumpLowerBounds)
+}
+
+// UpdatePyprojectConstraints is exported for testing.
+func UpdatePyprojectConstraints(content string, installed map[string]string, bumpLowerBounds bool) string {
+	return updatePyprojectConstraints(content, installed, bumpLowerBounds)
+}
+
+// ParsePipFreeze is exported for testing.
+func ParsePipFreeze(output string) map[string]string {
+	return parsePipFreeze(output)
+}
+
+// DetectPythonToolchain is exported for testing.
+func DetectPythonToolchain(pyprojectContent string, hasUvLock bool) string {
+	return detectPythonToolchain(pyprojectContent, hasUvLock)
+}
+
+// DetectLocalToolchain is exported for testing.
+func DetectLocalToolchain(repoDir string) string {
+	return detectLocalToolchain(repoDir)
+}
+
+// FindUvBinary is exported for testing.
+func FindUvBinary() (string, error) {
+	return findUvBinary()
+}
+
+// WriteFreezeRequirements is exported for testing.
+func WriteFreezeRequirements(sb *strings.Builder, freezeMode string) {
+	writeFreezeRequirements(sb, freezeMode)
+}
+
+// FetchTargetVersion exports fetchTargetVersion for testing.
+var FetchTargetVersion = fetchTargetVersion //nolint:gochecknoglobals // test export
+
+// ResolveLocalCurrentVersion is exported for testing.
+func ResolveLocalCurrentVersion(repoDir, fileVersion string, opts entities.UpdateOptions) string {
+	return resolveLocalCurrentVersion(repoDir, fileVersion, opts)
 }
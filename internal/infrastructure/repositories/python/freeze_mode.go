@@ -0,0 +1,48 @@
+package python
+
+import (
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// freezeCommand lists the installed packages as pinned requirements,
+// dropping local `@ file://` installs such as the project itself.
+const freezeCommand = "pip freeze | sed '/@\\s*file:\\/\\//d'"
+
+// writeFreezeRequirements defines the `freeze_requirements` function that
+// prints the new content of requirements.txt. In the full mode it is the
+// whole `pip freeze` output, transitive dependencies included. In the
+// top-level mode the packages requirements.txt declares are recorded before
+// anything is installed (names normalized per PEP 503), and only their
+// pins are kept; when none can be read it falls back to the full output
+// so requirements.txt is never emptied.
+func writeFreezeRequirements(sb *strings.Builder, freezeMode string) {
+	if freezeMode != entities.FreezeModeTopLevel {
+		sb.WriteString("freeze_requirements() {\n")
+		sb.WriteString("    " + freezeCommand + "\n")
+		sb.WriteString("}\n\n")
+		return
+	}
+
+	sb.WriteString("# Record the packages requirements.txt declares (freeze_mode: top-level)\n")
+	sb.WriteString("DECLARED_PACKAGES_FILE=$(mktemp)\n")
+	sb.WriteString("if [ -f \"requirements.txt\" ]; then\n")
+	sb.WriteString(
+		"    { sed -E 's/[#;].*//' requirements.txt | grep -oE '^[[:space:]]*[A-Za-z0-9][A-Za-z0-9._-]*' | " +
+			"sed -E 's/^[[:space:]]+//' | tr '[:upper:]' '[:lower:]' | sed -E 's/[-_.]+/-/g' | sort -u; } " +
+			"> \"$DECLARED_PACKAGES_FILE\" || true\n",
+	)
+	sb.WriteString("fi\n")
+	sb.WriteString("freeze_requirements() {\n")
+	sb.WriteString("    if [ -s \"$DECLARED_PACKAGES_FILE\" ]; then\n")
+	sb.WriteString(
+		"        " + freezeCommand + " | awk 'NR == FNR {keep[$0] = 1; next} " +
+			"{name = $0; sub(/[=<>!~ ;@\\[].*/, \"\", name); name = tolower(name); gsub(/[-_.]+/, \"-\", name); " +
+			"if (name in keep) print}' \"$DECLARED_PACKAGES_FILE\" -\n",
+	)
+	sb.WriteString("    else\n")
+	sb.WriteString("        " + freezeCommand + "\n")
+	sb.WriteString("    fi\n")
+	sb.WriteString("}\n\n")
+}
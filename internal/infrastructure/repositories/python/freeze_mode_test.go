@@ -0,0 +1,139 @@
+//go:build unit
+
+package python_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	pyUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/python"
+)
+
+// runFreezeRequirements runs the freeze_requirements function generated for
+// freezeMode in repoDir, with a fake pip whose freeze prints freezeOutput.
+func runFreezeRequirements(t *testing.T, repoDir, freezeMode, freezeOutput string) string {
+	t.Helper()
+
+	binDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "pip"), []byte(
+		"#!/bin/bash\nprintf '%s' '"+freezeOutput+"'\n",
+	), 0o700)) //nolint:gosec // the fake binary must be executable
+
+	var sb strings.Builder
+	pyUpdater.WriteFreezeRequirements(&sb, freezeMode)
+	sb.WriteString("freeze_requirements\n")
+
+	cmd := exec.CommandContext(t.Context(), "bash", "-c", sb.String())
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(), "PATH="+binDir+":"+os.Getenv("PATH"))
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return string(out)
+}
+
+func TestWriteFreezeRequirements(t *testing.T) {
+	t.Parallel()
+
+	freezeOutput := "certifi==2024.8.30\n" +
+		"Flask==3.0.3\n" +
+		"itsdangerous==2.2.0\n" +
+		"python_dateutil==2.9.0\n" +
+		"requests==2.32.3\n" +
+		"myproject @ file:///tmp/src\n"
+
+	t.Run("should keep every installed package in the full mode", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "requirements.txt"), []byte("flask\n"), 0o600))
+
+		// when
+		out := runFreezeRequirements(t, repoDir, entities.FreezeModeFull, freezeOutput)
+
+		// then
+		assert.Equal(t, "certifi==2024.8.30\nFlask==3.0.3\nitsdangerous==2.2.0\n"+
+			"python_dateutil==2.9.0\nrequests==2.32.3\n", out)
+	})
+
+	t.Run("should keep only the declared packages in the top-level mode", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		requirements := "# web stack\nflask>=3.0  # pinned by CI\nrequests[socks]==2.31.0\n" +
+			"Python-DateUtil ; python_version >= '3.9'\n-r extra.txt\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "requirements.txt"), []byte(requirements), 0o600))
+
+		// when
+		out := runFreezeRequirements(t, repoDir, entities.FreezeModeTopLevel, freezeOutput)
+
+		// then
+		assert.Equal(t, "Flask==3.0.3\npython_dateutil==2.9.0\nrequests==2.32.3\n", out)
+	})
+
+	t.Run("should fall back to the full output when no package is declared", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "requirements.txt"), []byte("# empty\n"), 0o600))
+
+		// when
+		out := runFreezeRequirements(t, repoDir, entities.FreezeModeTopLevel, freezeOutput)
+
+		// then
+		assert.Contains(t, out, "certifi==2024.8.30\n")
+		assert.Contains(t, out, "itsdangerous==2.2.0\n")
+	})
+}
+
+func TestWritePythonUpgradeCommandsFreezeMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should write the full freeze to requirements.txt by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var sb strings.Builder
+		params := pyUpdater.UpgradeParamsExported{HasRequirements: true}
+
+		// when
+		pyUpdater.WritePythonUpgradeCommands(&sb, params)
+
+		// then
+		result := sb.String()
+		assert.Contains(t, result, "freeze_requirements > requirements.txt")
+		assert.NotContains(t, result, "DECLARED_PACKAGES_FILE")
+	})
+
+	t.Run("should record the declared packages before installing in the top-level mode", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var sb strings.Builder
+		params := pyUpdater.UpgradeParamsExported{
+			HasRequirements: true,
+			FreezeMode:      entities.FreezeModeTopLevel,
+		}
+
+		// when
+		pyUpdater.WritePythonUpgradeCommands(&sb, params)
+
+		// then
+		result := sb.String()
+		snapshot := strings.Index(result, "DECLARED_PACKAGES_FILE=$(mktemp)")
+		install := strings.Index(result, "pip install --upgrade -r requirements.txt")
+		require.NotEqual(t, -1, snapshot)
+		assert.Less(t, snapshot, install)
+		assert.Contains(t, result, "freeze_requirements > requirements.txt")
+		assert.Contains(t, result, "rm -f \"$DECLARED_PACKAGES_FILE\"")
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/cmdrunner"
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/gitlocal"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // localCmdRunner is the package-level command runner for local-mode upgrade scripts.
@@ -25,6 +26,17 @@ type LocalUpgradeOptions struct {
 	AuthToken    string
 	ProviderName string                    // git provider name (e.g. "azuredevops", "github", "gitlab")
 	PushAuth     gitlocal.PushAuthResolver // resolves auth methods for git push
+	Remote       string                    // git remote to push to; empty means origin
+	BaseBranch   string                    // branch of Remote the upgrade starts from; empty means HEAD
+	// Changelog locates the changelog file and the "### " heading under
+	// [Unreleased] that receives the entry; zero means CHANGELOG.md/Changed.
+	Changelog entities.ChangelogSettings
+	// CommitPrefix replaces the "chore(deps)" prefix of the upgrade commit
+	// message when set.
+	CommitPrefix string
+	// Signing signs the upgrade commit with this key instead of the one in
+	// the git config when its Key is set.
+	Signing entities.Signing
 }
 
 // LocalResult holds the outcome of a local upgrade operation.
@@ -34,6 +46,8 @@ type LocalResult struct {
 	LatestVersion        string
 	BranchName           string
 	Output               string
+	// ChangelogEntry is the entry ApplyLocalUpgrade leaves to the caller.
+	ChangelogEntry string
 }
 
 // RunLocalUpgrade runs the Python dependency upgrade directly in a local
@@ -49,7 +63,7 @@ func RunLocalUpgrade(
 		logger.SetLevel(logger.DebugLevel)
 	}
 
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	vCtx := resolveLocalVersionContext(ctx, repoDir, entities.UpdateOptions{})
 
 	if opts.DryRun {
 		return handleDryRun(vCtx, repoDir), nil
@@ -58,41 +72,96 @@ func RunLocalUpgrade(
 	return executeLocalUpgrade(ctx, repoDir, vCtx, opts)
 }
 
+// ApplyLocalUpgrade runs the Python upgrade script in moduleDir without any
+// git operation, for callers that branch, commit and push themselves, such
+// as local mode upgrading several modules of one repository on a single
+// branch. HasChanges is left unset: the caller's commit tells. The
+// changelog is left to the caller too, which gets the entry in ChangelogEntry.
+func ApplyLocalUpgrade(
+	ctx context.Context,
+	moduleDir string,
+	opts LocalUpgradeOptions,
+) (*LocalResult, error) {
+	vCtx := resolveLocalVersionContext(ctx, moduleDir, entities.UpdateOptions{})
+
+	if opts.DryRun {
+		return handleDryRun(vCtx, moduleDir), nil
+	}
+
+	outputStr, runErr := runLanguageUpgradeScript(ctx, moduleDir, vCtx, "", opts)
+	if runErr != nil {
+		return nil, runErr
+	}
+
+	return &LocalResult{
+		PythonVersionUpdated: strings.Contains(outputStr, "PYTHON_VERSION_UPDATED=true"),
+		LatestVersion:        vCtx.LatestVersion,
+		BranchName:           vCtx.BranchName,
+		Output:               outputStr,
+		ChangelogEntry:       localChangelogEntry(vCtx),
+	}, nil
+}
+
 // resolveLocalVersionContext fetches the latest Python version and compares
-// it against the local .python-version to build a versionContext.
-func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
+// it against the local .python-version (or, without one, the
+// `requires-python` floor of pyproject.toml) to build a versionContext.
+func resolveLocalVersionContext(ctx context.Context, repoDir string, opts entities.UpdateOptions) *versionContext {
+	return localVersionContext(repoDir, fetchLocalTargetVersion(ctx, opts), opts)
+}
+
+// fetchLocalTargetVersion fetches the Python version local upgrades move
+// to, or "" when it cannot be fetched.
+func fetchLocalTargetVersion(ctx context.Context, opts entities.UpdateOptions) string {
 	fetcher := NewHTTPPythonVersionFetcher(&http.Client{Timeout: pyVersionTimeout})
-	latestPyVersion, err := fetcher.FetchLatestVersion(ctx)
-	if err != nil {
-		logger.Warnf("[python] Failed to fetch latest Python version: %v (continuing without version upgrade)", err)
-		latestPyVersion = ""
-	} else {
-		logger.Infof("[python] Latest stable Python version: %s", latestPyVersion)
-	}
+	return fetchTargetVersion(ctx, fetcher, opts.Series)
+}
 
+// localVersionContext compares the Python version of the project at repoDir
+// against latestPyVersion; an empty latestPyVersion never upgrades it.
+func localVersionContext(repoDir, latestPyVersion string, opts entities.UpdateOptions) *versionContext {
 	needsVersionUpgrade := false
+	requiresPython := ""
 	if latestPyVersion != "" {
 		pyVersionContent, readErr := os.ReadFile(filepath.Join(repoDir, ".python-version"))
 		if readErr == nil {
-			currentVersion := parsePythonVersionFile(string(pyVersionContent))
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestPyVersion
+			fileVersion := parsePythonVersionFile(string(pyVersionContent))
+			currentVersion := resolveLocalCurrentVersion(repoDir, fileVersion, opts)
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestPyVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestPyVersion)
 			logger.Infof(
 				"[python] Current .python-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
 			)
+		} else if pyproject, pyErr := os.ReadFile(filepath.Join(repoDir, "pyproject.toml")); pyErr == nil {
+			requiresPython = resolveRequiresPython(string(pyproject), latestPyVersion, opts)
+			needsVersionUpgrade = requiresPython != ""
 		}
 	}
 
-	branchName := branchPyDepsFmt
-	if needsVersionUpgrade {
-		branchName = fmt.Sprintf(branchPyVersionFmt, latestPyVersion)
-	}
+	return newVersionContext(latestPyVersion, needsVersionUpgrade, requiresPython)
+}
 
-	return &versionContext{
-		LatestVersion:       latestPyVersion,
-		NeedsVersionUpgrade: needsVersionUpgrade,
-		BranchName:          branchName,
+// resolveLocalCurrentVersion returns the current Python version of the
+// local repository: the .python-version one, unless the `requires-python`
+// floor or the root Dockerfile's python image disagrees and the
+// version_conflict policy picks otherwise.
+func resolveLocalCurrentVersion(repoDir, fileVersion string, opts entities.UpdateOptions) string {
+	if fileVersion == "" {
+		return ""
+	}
+	requiresPython := ""
+	if pyproject, err := os.ReadFile(filepath.Join(repoDir, "pyproject.toml")); err == nil {
+		requiresPython = parseRequiresPython(string(pyproject))
 	}
+	images := opts.DockerfileImages
+	if len(images) == 0 {
+		images = []string{"python"}
+	}
+	return support.ResolveCurrentVersion("python", opts.VersionConflict, []entities.VersionSource{
+		{File: ".python-version", Version: fileVersion},
+		{File: "requires-python", Version: requiresPython},
+		{File: "Dockerfile", Version: support.DockerfileImageVersion(repoDir, images...)},
+	})
 }
 
 // handleDryRun logs the planned action and returns a result without
@@ -130,6 +199,9 @@ func executeLocalUpgrade(
 	if err != nil {
 		return nil, err
 	}
+	gitCtx.UseRemote(opts.Remote)
+	gitCtx.UseBaseBranch(opts.BaseBranch)
+	gitCtx.UseSigning(opts.Signing)
 	originalBranch, err := gitCtx.CurrentBranch()
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine current branch: %w", err)
@@ -153,7 +225,11 @@ func executeLocalUpgrade(
 	}
 
 	// --- Language Operations (bash) ---
-	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, opts)
+	changelogFile := prepareLocalChangelog(repoDir, vCtx, opts.Changelog)
+	if changelogFile != "" {
+		defer os.Remove(changelogFile)
+	}
+	outputStr, runErr := runLanguageUpgradeScript(ctx, repoDir, vCtx, changelogFile, opts)
 	if runErr != nil {
 		return nil, runErr
 	}
@@ -168,6 +244,7 @@ func executeLocalUpgrade(
 			vCtx.LatestVersion,
 		)
 	}
+	commitMsg = entities.WithCommitPrefix(commitMsg, opts.CommitPrefix)
 
 	pushed, pushErr := gitCtx.StageCommitAndPush(
 		vCtx.BranchName, commitMsg, opts.AuthToken,
@@ -192,17 +269,18 @@ func runLanguageUpgradeScript(
 	ctx context.Context,
 	repoDir string,
 	vCtx *versionContext,
+	changelogFile string,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	changelogFile := prepareLocalChangelog(repoDir, vCtx)
-	if changelogFile != "" {
-		defer os.Remove(changelogFile)
-	}
-
 	pythonBinary, err := findPythonBinary()
 	if err != nil {
 		return "", fmt.Errorf("python binary not found: %w", err)
 	}
+	toolchain := detectLocalToolchain(repoDir)
+	uvBinary, err := resolveUvBinary(toolchain)
+	if err != nil {
+		return "", err
+	}
 
 	hasRequirements := false
 	if _, statErr := os.Stat(filepath.Join(repoDir, "requirements.txt")); statErr == nil {
@@ -215,14 +293,18 @@ func runLanguageUpgradeScript(
 	}
 
 	params := localUpgradeParams{
-		BranchName:      vCtx.BranchName,
-		PythonVersion:   vCtx.LatestVersion,
-		ChangelogFile:   changelogFile,
-		AuthToken:       opts.AuthToken,
-		ProviderName:    opts.ProviderName,
-		HasRequirements: hasRequirements,
-		HasPyproject:    hasPyproject,
-		PythonBinary:    pythonBinary,
+		Toolchain:             toolchain,
+		UvBinary:              uvBinary,
+		BranchName:            vCtx.BranchName,
+		PythonVersion:         vCtx.LatestVersion,
+		RequiresPythonVersion: vCtx.RequiresPythonVersion,
+		ChangelogFile:         changelogFile,
+		ChangelogPath:         opts.Changelog.FilePath(),
+		AuthToken:             opts.AuthToken,
+		ProviderName:          opts.ProviderName,
+		HasRequirements:       hasRequirements,
+		HasPyproject:          hasPyproject,
+		PythonBinary:          pythonBinary,
 	}
 
 	script := buildLocalUpgradeScript(params)
@@ -245,7 +327,7 @@ func runLanguageUpgradeScript(
 
 	var outputStr string
 	if runResult != nil {
-		outputStr = runResult.Output
+		outputStr = support.RedactTokens(runResult.Output, params.AuthToken)
 	}
 
 	if opts.Verbose {
@@ -264,14 +346,19 @@ func runLanguageUpgradeScript(
 // --- local-mode internal types & helpers ---
 
 type localUpgradeParams struct {
-	BranchName      string
-	PythonVersion   string
-	ChangelogFile   string
-	AuthToken       string
-	ProviderName    string
-	HasRequirements bool
-	HasPyproject    bool
-	PythonBinary    string
+	BranchName    string
+	PythonVersion string
+	// RequiresPythonVersion is the new `requires-python` floor, if any.
+	RequiresPythonVersion string
+	ChangelogFile         string
+	ChangelogPath         string
+	AuthToken             string
+	ProviderName          string
+	HasRequirements       bool
+	HasPyproject          bool
+	PythonBinary          string
+	Toolchain             string
+	UvBinary              string
 }
 
 // buildLocalUpgradeScript builds a bash script that performs only the
@@ -292,6 +379,7 @@ func buildLocalUpgradeScript(params localUpgradeParams) string {
 	writePythonUpgradeCommands(&sb, upgradeParams{
 		HasRequirements: params.HasRequirements,
 		HasPyproject:    params.HasPyproject,
+		Toolchain:       params.Toolchain,
 	})
 
 	// Update Dockerfile python image tags
@@ -338,9 +426,15 @@ func buildLocalEnv(params localUpgradeParams) []string {
 		"BRANCH_NAME="+params.BranchName,
 		"PYTHON_BINARY="+params.PythonBinary,
 	)
+	if params.UvBinary != "" {
+		env = append(env, "UV_BINARY="+params.UvBinary)
+	}
 	if params.PythonVersion != "" {
 		env = append(env, "PYTHON_VERSION="+params.PythonVersion)
 	}
+	if params.RequiresPythonVersion != "" {
+		env = append(env, "REQUIRES_PYTHON_VERSION="+params.RequiresPythonVersion)
+	}
 	if params.AuthToken != "" {
 		env = append(env,
 			"AUTH_TOKEN="+params.AuthToken,
@@ -348,30 +442,21 @@ func buildLocalEnv(params localUpgradeParams) []string {
 		)
 	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
 
-// prepareLocalChangelog reads CHANGELOG.md from disk (if it exists),
+// prepareLocalChangelog reads the changelog from disk (if it exists),
 // inserts an upgrade entry, and writes the result to a temp file.
-func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+func prepareLocalChangelog(repoDir string, vCtx *versionContext, changelog entities.ChangelogSettings) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath())))
 	if err != nil {
 		return "" // no changelog present
 	}
 
-	var entry string
-	if vCtx.NeedsVersionUpgrade {
-		entry = fmt.Sprintf(
-			"- changed the Python version to `%s` and updated all pip dependencies",
-			vCtx.LatestVersion,
-		)
-	} else {
-		entry = "- changed the Python dependencies to their latest versions"
-	}
-
-	modified := entities.InsertChangelogEntry(string(content), []string{entry})
+	entry := localChangelogEntry(vCtx)
+	modified := entities.InsertChangelogEntryInSection(string(content), []string{entry}, changelog.Section)
 	if modified == string(content) {
 		return ""
 	}
@@ -392,3 +477,14 @@ func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
 
 	return tmpFile.Name()
 }
+
+// localChangelogEntry returns the changelog entry describing the upgrade.
+func localChangelogEntry(vCtx *versionContext) string {
+	if vCtx.NeedsVersionUpgrade {
+		return fmt.Sprintf(
+			"- changed the Python version to `%s` and updated all pip dependencies",
+			vCtx.LatestVersion,
+		)
+	}
+	return "- changed the Python dependencies to their latest versions"
+}
@@ -0,0 +1,254 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+const pyprojectFileMode = 0o600
+
+var (
+	// constraintClausePattern splits one version clause such as "^1.2",
+	// "~=1.4.0", ">= 2.0" or "==3.1.4" into operator and version.
+	constraintClausePattern = regexp.MustCompile(`^(\s*)(\^|~=|~|===|==|>=|<=|!=|>|<)?(\s*)(\d+(?:\.\d+)*)(\.\*)?(\s*)$`)
+	// releaseVersionPattern accepts final releases only, so bounds never
+	// advance to a pre-release or local version.
+	releaseVersionPattern = regexp.MustCompile(`^\d+(?:\.\d+)*$`)
+	// pep508Pattern matches a quoted PEP 508 requirement inside a TOML array:
+	// name, optional extras, version specifier, and optional markers.
+	pep508Pattern = regexp.MustCompile(`(["'])([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?(\s*)([^"';]*?)(\s*;[^"']*)?(["'])`)
+	// poetryInlinePattern matches `name = "^1.2"` in a Poetry table.
+	poetryInlinePattern = regexp.MustCompile(`^(\s*"?([A-Za-z0-9][A-Za-z0-9._-]*)"?\s*=\s*")([^"]*)(".*)$`)
+	// poetryTablePattern matches `name = { version = "^1.2", ... }`.
+	poetryTablePattern = regexp.MustCompile(`^(\s*"?([A-Za-z0-9][A-Za-z0-9._-]*)"?\s*=\s*\{.*?version\s*=\s*")([^"]*)(".*)$`)
+	// tomlTablePattern matches a TOML table header such as `[project]`.
+	tomlTablePattern = regexp.MustCompile(`^\s*\[\[?\s*([^\[\]]+?)\s*\]\]?\s*(?:#.*)?$`)
+	// tomlArrayKeyPattern matches the key of an array assignment (`key = [`).
+	tomlArrayKeyPattern = regexp.MustCompile(`^\s*"?([A-Za-z0-9_.-]+)"?\s*=\s*\[`)
+	// nameNormalizer implements PEP 503 name normalization.
+	nameNormalizer = regexp.MustCompile(`[-_.]+`)
+)
+
+// rewritePyprojectFile advances the constraints in pyprojectPath to the
+// versions recorded by `pip freeze` in freezePath. Failures are logged and
+// leave the file untouched, since the pip upgrade itself already succeeded.
+func rewritePyprojectFile(pyprojectPath, freezePath string, bumpLowerBounds bool) {
+	freeze, err := os.ReadFile(filepath.Clean(freezePath))
+	if err != nil || len(freeze) == 0 {
+		return
+	}
+	content, err := os.ReadFile(filepath.Clean(pyprojectPath))
+	if err != nil {
+		logger.Warnf("[python] Failed to read pyproject.toml: %v", err)
+		return
+	}
+
+	updated := updatePyprojectConstraints(string(content), parsePipFreeze(string(freeze)), bumpLowerBounds)
+	if updated == string(content) {
+		return
+	}
+	if writeErr := os.WriteFile(pyprojectPath, []byte(updated), pyprojectFileMode); writeErr != nil {
+		logger.Warnf("[python] Failed to write pyproject.toml: %v", writeErr)
+		return
+	}
+	logger.Infof("[python] Advanced pyproject.toml constraints to the installed versions")
+}
+
+// rewriteConstraint advances the bounds of a dependency constraint to the
+// installed version while preserving the operator style of every clause.
+// Caret, tilde and compatible-release (~=) bounds move up to latest,
+// truncated to the precision already written (`^1.2` becomes `^1.3` when
+// 1.3.4 is installed). Lower bounds (>=, >) move only when bumpLowerBounds
+// is set; upper bounds, exclusions, wildcards and exact pins never change,
+// since the upgrade never installs past an exact pin. Bounds are never
+// lowered.
+func rewriteConstraint(constraint, latest string, bumpLowerBounds bool) string {
+	if !releaseVersionPattern.MatchString(latest) {
+		return constraint
+	}
+
+	clauses := strings.Split(constraint, ",")
+	for i, clause := range clauses {
+		m := constraintClausePattern.FindStringSubmatch(clause)
+		if m == nil || m[5] != "" {
+			continue
+		}
+		operator, version := m[2], m[4]
+		switch operator {
+		case "^", "~", "~=":
+		case ">=", ">":
+			if !bumpLowerBounds {
+				continue
+			}
+		default:
+			continue
+		}
+		clauses[i] = m[1] + operator + m[3] + advanceVersion(version, latest) + m[6]
+	}
+	return strings.Join(clauses, ",")
+}
+
+// logExactPin reports the exact pins (==, === or a bare Poetry version) of
+// a constraint, which rewriteConstraint leaves for a person to raise.
+func logExactPin(name, constraint string) {
+	for clause := range strings.SplitSeq(constraint, ",") {
+		m := constraintClausePattern.FindStringSubmatch(clause)
+		if m == nil || m[5] != "" {
+			continue
+		}
+		if operator := m[2]; operator == "==" || operator == "===" || operator == "" {
+			logger.Infof("[python] Leaving the exact pin %s %s unchanged; raise it by hand to upgrade",
+				name, strings.TrimSpace(constraint))
+			return
+		}
+	}
+}
+
+// advanceVersion returns latest truncated to the precision of current, or
+// current when it is already at or above that value.
+func advanceVersion(current, latest string) string {
+	precision := len(strings.Split(current, "."))
+	parts := strings.Split(latest, ".")
+	for len(parts) < precision {
+		parts = append(parts, "0")
+	}
+	target := strings.Join(parts[:precision], ".")
+	if comparePythonVersions(target, current) <= 0 {
+		return current
+	}
+	return target
+}
+
+// comparePythonVersions compares two dotted release versions numerically,
+// padding the shorter one with zeros. It returns -1, 0 or +1.
+func comparePythonVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		switch {
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		}
+	}
+	return 0
+}
+
+// updatePyprojectConstraints rewrites the dependency constraints of a
+// pyproject.toml to the installed versions. It handles PEP 621 arrays
+// (`dependencies = ["requests>=2.0"]`, optional dependencies and dependency
+// groups) and Poetry tables (`requests = "^2.28"` or `{ version = "^2.28" }`).
+// installed maps normalized package names to versions, as returned by
+// parsePipFreeze. Lines outside dependency sections are left untouched.
+func updatePyprojectConstraints(content string, installed map[string]string, bumpLowerBounds bool) string {
+	lines := strings.Split(content, "\n")
+	section := ""
+	depth := 0
+	for i, line := range lines {
+		if depth == 0 {
+			if m := tomlTablePattern.FindStringSubmatch(line); m != nil {
+				section = m[1]
+				continue
+			}
+		}
+
+		switch {
+		case depth > 0:
+			lines[i] = rewritePep508Line(line, installed, bumpLowerBounds)
+			depth += strings.Count(line, "[") - strings.Count(line, "]")
+		case isRequirementArray(section, line):
+			lines[i] = rewritePep508Line(line, installed, bumpLowerBounds)
+			depth = strings.Count(line, "[") - strings.Count(line, "]")
+		case isPoetrySection(section):
+			lines[i] = rewritePoetryLine(line, installed, bumpLowerBounds)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isRequirementArray reports whether line opens an array of PEP 508
+// requirements: `dependencies` under [project], or any key under
+// [project.optional-dependencies] and [dependency-groups].
+func isRequirementArray(section, line string) bool {
+	m := tomlArrayKeyPattern.FindStringSubmatch(line)
+	if m == nil {
+		return false
+	}
+	switch section {
+	case "project":
+		return m[1] == "dependencies"
+	case "project.optional-dependencies", "dependency-groups":
+		return true
+	}
+	return false
+}
+
+// rewritePep508Line rewrites every quoted PEP 508 requirement on a line.
+func rewritePep508Line(line string, installed map[string]string, bumpLowerBounds bool) string {
+	return pep508Pattern.ReplaceAllStringFunc(line, func(match string) string {
+		m := pep508Pattern.FindStringSubmatch(match)
+		latest, ok := installed[normalizePackageName(m[2])]
+		if !ok || strings.TrimSpace(m[5]) == "" {
+			return match
+		}
+		logExactPin(m[2], m[5])
+		return m[1] + m[2] + m[3] + m[4] + rewriteConstraint(m[5], latest, bumpLowerBounds) + m[6] + m[7]
+	})
+}
+
+// rewritePoetryLine rewrites a Poetry dependency entry, skipping the
+// `python` requirement, which describes the interpreter rather than a package.
+func rewritePoetryLine(line string, installed map[string]string, bumpLowerBounds bool) string {
+	for _, pattern := range []*regexp.Regexp{poetryTablePattern, poetryInlinePattern} {
+		m := pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		name := normalizePackageName(m[2])
+		latest, ok := installed[name]
+		if name == "python" || !ok {
+			return line
+		}
+		logExactPin(m[2], m[3])
+		return m[1] + rewriteConstraint(m[3], latest, bumpLowerBounds) + m[4]
+	}
+	return line
+}
+
+// isPoetrySection reports whether a TOML table holds Poetry dependencies.
+func isPoetrySection(section string) bool {
+	return section == "tool.poetry.dependencies" || section == "tool.poetry.dev-dependencies" ||
+		(strings.HasPrefix(section, "tool.poetry.group.") && strings.HasSuffix(section, ".dependencies"))
+}
+
+// parsePipFreeze maps normalized package names to the versions listed in
+// `pip freeze` output, ignoring editable installs and direct URL references.
+func parsePipFreeze(output string) map[string]string {
+	installed := make(map[string]string)
+	for line := range strings.SplitSeq(output, "\n") {
+		name, version, found := strings.Cut(strings.TrimSpace(line), "==")
+		if !found || name == "" {
+			continue
+		}
+		installed[normalizePackageName(name)] = strings.TrimSpace(version)
+	}
+	return installed
+}
+
+// normalizePackageName lowercases a package name and collapses runs of
+// "-", "_" and "." into "-", per PEP 503.
+func normalizePackageName(name string) string {
+	return nameNormalizer.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-")
+}
@@ -0,0 +1,182 @@
+//go:build unit
+
+package python_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pyUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/python"
+)
+
+func TestRewriteConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		constraint      string
+		latest          string
+		bumpLowerBounds bool
+		expected        string
+	}{
+		{
+			name:       "should advance a caret bound keeping its precision",
+			constraint: "^1.2",
+			latest:     "1.3.4",
+			expected:   "^1.3",
+		},
+		{
+			name:       "should advance a tilde bound keeping its precision",
+			constraint: "~1.2.0",
+			latest:     "1.2.5",
+			expected:   "~1.2.5",
+		},
+		{
+			name:       "should advance a compatible release bound",
+			constraint: "~=2.28",
+			latest:     "2.31.0",
+			expected:   "~=2.31",
+		},
+		{
+			name:       "should leave an exact pin untouched",
+			constraint: "==3.1.4",
+			latest:     "3.2.0",
+			expected:   "==3.1.4",
+		},
+		{
+			name:       "should leave a lower bound alone by default",
+			constraint: ">=1.0",
+			latest:     "1.5.2",
+			expected:   ">=1.0",
+		},
+		{
+			name:            "should advance a lower bound when configured",
+			constraint:      ">=1.0",
+			latest:          "1.5.2",
+			bumpLowerBounds: true,
+			expected:        ">=1.5",
+		},
+		{
+			name:            "should advance only the lower bound of a range",
+			constraint:      ">=1.0,<2.0",
+			latest:          "1.5.2",
+			bumpLowerBounds: true,
+			expected:        ">=1.5,<2.0",
+		},
+		{
+			name:       "should leave an upper bound untouched",
+			constraint: "<2.0",
+			latest:     "1.5.2",
+			expected:   "<2.0",
+		},
+		{
+			name:       "should leave a wildcard pin untouched",
+			constraint: "==1.*",
+			latest:     "1.5.2",
+			expected:   "==1.*",
+		},
+		{
+			name:       "should ignore a pre-release latest version",
+			constraint: "^1.2",
+			latest:     "1.3.0rc1",
+			expected:   "^1.2",
+		},
+		{
+			name:       "should never lower a bound",
+			constraint: "^2.0",
+			latest:     "1.9.0",
+			expected:   "^2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			result := pyUpdater.RewriteConstraint(tt.constraint, tt.latest, tt.bumpLowerBounds)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestUpdatePyprojectConstraints(t *testing.T) {
+	t.Parallel()
+
+	installed := map[string]string{
+		"requests": "2.31.0",
+		"pydantic": "2.7.1",
+		"pytest":   "8.2.0",
+		"python":   "3.13.0",
+	}
+
+	t.Run("should rewrite PEP 621 dependency arrays", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "[project]\nname = \"app\"\nversion = \"1.0.0\"\ndependencies = [\n" +
+			"    \"requests~=2.28\",\n    \"Pydantic>=2.0\",\n]\n\n" +
+			"[project.optional-dependencies]\ntest = [\"pytest==8.0.0; python_version >= '3.9'\"]\n"
+
+		// when
+		result := pyUpdater.UpdatePyprojectConstraints(content, installed, false)
+
+		// then
+		expected := "[project]\nname = \"app\"\nversion = \"1.0.0\"\ndependencies = [\n" +
+			"    \"requests~=2.31\",\n    \"Pydantic>=2.0\",\n]\n\n" +
+			"[project.optional-dependencies]\ntest = [\"pytest==8.0.0; python_version >= '3.9'\"]\n"
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should rewrite Poetry tables and skip the python requirement", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "[tool.poetry.dependencies]\npython = \"^3.11\"\nrequests = \"^2.28\"\n" +
+			"pydantic = { version = \"^2.5\", extras = [\"email\"] }\n\n" +
+			"[tool.poetry.group.dev.dependencies]\npytest = \"~8.0\"\n"
+
+		// when
+		result := pyUpdater.UpdatePyprojectConstraints(content, installed, false)
+
+		// then
+		expected := "[tool.poetry.dependencies]\npython = \"^3.11\"\nrequests = \"^2.31\"\n" +
+			"pydantic = { version = \"^2.7\", extras = [\"email\"] }\n\n" +
+			"[tool.poetry.group.dev.dependencies]\npytest = \"~8.2\"\n"
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("should leave arrays outside dependency sections untouched", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "[tool.ruff]\nselect = [\"requests==1.0\"]\n"
+
+		// when
+		result := pyUpdater.UpdatePyprojectConstraints(content, installed, false)
+
+		// then
+		assert.Equal(t, content, result)
+	})
+}
+
+func TestParsePipFreeze(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should map normalized names to versions and skip other entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		output := "Requests==2.31.0\ntyping_extensions==4.12.0\n-e git+https://example.com/app.git#egg=app\n" +
+			"app @ file:///tmp/app\n"
+
+		// when
+		result := pyUpdater.ParsePipFreeze(output)
+
+		// then
+		assert.Equal(t, map[string]string{"requests": "2.31.0", "typing-extensions": "4.12.0"}, result)
+	})
+}
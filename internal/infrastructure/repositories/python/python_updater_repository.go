@@ -59,6 +59,16 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Python version and pip requirements"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langPython.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository has Python marker files (e.g. pyproject.toml, requirements.txt).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -83,15 +93,8 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 ) ([]entities.PullRequest, error) {
 	logger.Infof("[python] Processing %s/%s", repo.Organization, repo.Name)
 
-	latestPyVersion, err := u.versionFetcher.FetchLatestVersion(ctx)
-	if err != nil {
-		logger.Warnf("[python] Failed to fetch latest Python version: %v (continuing without version upgrade)", err)
-		latestPyVersion = ""
-	} else {
-		logger.Infof("[python] Latest stable Python version: %s", latestPyVersion)
-	}
-
-	vCtx := resolveVersionContext(ctx, provider, repo, latestPyVersion)
+	latestPyVersion := fetchTargetVersion(ctx, u.versionFetcher, opts.Series)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestPyVersion, opts)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -111,7 +114,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		return []entities.PullRequest{}, nil
 	}
 
-	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx)
+	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -146,13 +149,15 @@ func cloneAndUpgrade(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, error) {
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
 	hasRequirements := provider.HasFile(ctx, repo, "requirements.txt")
 	hasPyproject := provider.HasFile(ctx, repo, "pyproject.toml")
+	toolchain := detectRemoteToolchain(ctx, provider, repo, hasPyproject)
 
 	cloneURL := provider.CloneURL(repo)
 	defaultBranch := strings.TrimPrefix(repo.DefaultBranch, "refs/heads/")
@@ -161,18 +166,30 @@ func cloneAndUpgrade(
 	if err != nil {
 		return nil, fmt.Errorf("python binary not found: %w", err)
 	}
+	uvBinary, err := resolveUvBinary(toolchain)
+	if err != nil {
+		return nil, err
+	}
 
 	result, err := upgradeRepo(ctx, upgradeParams{
-		CloneURL:        cloneURL,
-		DefaultBranch:   defaultBranch,
-		BranchName:      vCtx.BranchName,
-		PythonVersion:   vCtx.LatestVersion,
-		AuthToken:       provider.AuthToken(),
-		ProviderName:    provider.Name(),
-		ChangelogFile:   changelogFile,
-		HasRequirements: hasRequirements,
-		HasPyproject:    hasPyproject,
-		PythonBinary:    pythonBinary,
+		CloneURL:              cloneURL,
+		DefaultBranch:         defaultBranch,
+		BranchName:            vCtx.BranchName,
+		PythonVersion:         vCtx.LatestVersion,
+		RequiresPythonVersion: vCtx.RequiresPythonVersion,
+		AuthToken:             provider.AuthToken(),
+		ProviderName:          provider.Name(),
+		ChangelogFile:         changelogFile,
+		ChangelogPath:         opts.Changelog.FilePath(),
+		HasRequirements:       hasRequirements,
+		HasPyproject:          hasPyproject,
+		PythonBinary:          pythonBinary,
+		Toolchain:             toolchain,
+		UvBinary:              uvBinary,
+		FreezeMode:            opts.FreezeMode,
+		CommitAuthor:          opts.CommitAuthor,
+		CommitPrefix:          opts.CommitPrefix(),
+		Signing:               opts.Signing,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade: %w", err)
@@ -203,13 +220,17 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GeneratePRDescription(vCtx.LatestVersion, result.PythonVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, result.PythonVersionUpdated),
+	)
+	prDesc = support.AppendDiffStat(prDesc, result.DiffStat)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -229,23 +250,16 @@ func openPullRequest(
 func (u *UpdaterRepository) ApplyUpdates(
 	ctx context.Context,
 	repoDir string,
-	_ repositories.ProviderRepository,
+	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[python] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
-	// resolveLocalVersionContext (from local.go) handles fetching + comparison
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
-
-	hasRequirements := false
-	if _, statErr := os.Stat(filepath.Join(repoDir, "requirements.txt")); statErr == nil {
-		hasRequirements = true
-	}
-
-	hasPyproject := false
-	if _, statErr := os.Stat(filepath.Join(repoDir, "pyproject.toml")); statErr == nil {
-		hasPyproject = true
+	workDirs := support.ScopedDirs(repoDir, opts.Paths, "requirements.txt", "pyproject.toml")
+	if len(workDirs) == 0 {
+		logger.Infof("[python] No requirements.txt or pyproject.toml found under the configured paths %v", opts.Paths)
+		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
 	pythonBinary, binErr := findPythonBinary()
@@ -253,32 +267,31 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, fmt.Errorf("python binary not found: %w", binErr)
 	}
 
-	script := buildBatchPythonScript(hasRequirements, hasPyproject)
-	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
-	if writeErr := os.WriteFile(scriptPath, []byte(script), scriptFileMode); writeErr != nil {
-		return nil, fmt.Errorf("failed to write script: %w", writeErr)
+	// Each project keeps its own .python-version or requires-python, so
+	// whether the version moves is decided per directory. The PR follows the
+	// first one that needs the upgrade.
+	latestPyVersion := fetchLocalTargetVersion(ctx, opts)
+	dirContexts := make([]*versionContext, len(workDirs))
+	for i, workDir := range workDirs {
+		dirContexts[i] = localVersionContext(workDir, latestPyVersion, opts)
 	}
-	defer func() { _ = os.Remove(scriptPath) }()
-
-	cmd := exec.CommandContext(ctx, "bash", scriptPath)
-	cmd.Dir = repoDir
-	env := append(os.Environ(), "PYTHON_BINARY="+pythonBinary)
-	if vCtx.LatestVersion != "" {
-		env = append(env, "PYTHON_VERSION="+vCtx.LatestVersion)
+	vCtx := dirContexts[0]
+	for _, dirCtx := range dirContexts {
+		if dirCtx.NeedsVersionUpgrade {
+			vCtx = dirCtx
+			break
+		}
 	}
-	cmd.Env = env
 
-	output, cmdErr := cmd.CombinedOutput()
-	outputStr := string(output)
-	logger.Debugf("[python] Upgrade script output:\n%s", outputStr)
-
-	if cmdErr != nil {
-		return nil, fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, outputStr)
+	outputs := make([]string, 0, len(workDirs))
+	for i, workDir := range workDirs {
+		output, err := runBatchScript(ctx, repoDir, workDir, pythonBinary, provider, dirContexts[i], opts)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
 	}
-
-	// Remove the script before checking worktree state so it does not
-	// appear as an untracked file in the git status check below.
-	_ = os.Remove(scriptPath)
+	outputStr := strings.Join(outputs, "\n")
 	pyVersionUpdated := strings.Contains(outputStr, "PYTHON_VERSION_UPDATED=true")
 
 	// Return early if the upgrade script made no filesystem changes
@@ -287,7 +300,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	// Update CHANGELOG locally
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if pyVersionUpdated {
 		entry = fmt.Sprintf(
@@ -297,29 +310,108 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = pyChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := pyCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := pyCommitMsgDeps
 	if pyVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded Python to `%s` and updated all dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
+
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, pyVersionUpdated),
+	)
 
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GeneratePRDescription(vCtx.LatestVersion, pyVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
+// runBatchScript runs the Python upgrade script in workDir, one of the
+// project directories of the clone at repoDir, rewrites the pyproject.toml
+// constraints there and returns the redacted script output.
+func runBatchScript(
+	ctx context.Context,
+	repoDir, workDir, pythonBinary string,
+	provider repositories.ProviderRepository,
+	vCtx *versionContext,
+	opts entities.UpdateOptions,
+) (string, error) {
+	hasRequirements := false
+	if _, statErr := os.Stat(filepath.Join(workDir, "requirements.txt")); statErr == nil {
+		hasRequirements = true
+	}
+
+	hasPyproject := false
+	if _, statErr := os.Stat(filepath.Join(workDir, "pyproject.toml")); statErr == nil {
+		hasPyproject = true
+	}
+
+	toolchain := detectLocalToolchain(workDir)
+	uvBinary, uvErr := resolveUvBinary(toolchain)
+	if uvErr != nil {
+		return "", uvErr
+	}
+
+	script := buildBatchPythonScript(hasRequirements, hasPyproject, toolchain, opts.FreezeMode)
+	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
+	if writeErr := os.WriteFile(scriptPath, []byte(script), scriptFileMode); writeErr != nil {
+		return "", fmt.Errorf("failed to write script: %w", writeErr)
+	}
+	// Remove the script before the caller checks the worktree state so it
+	// does not appear as an untracked file in the git status check.
+	defer func() { _ = os.Remove(scriptPath) }()
+
+	cmd := exec.CommandContext(ctx, "bash", scriptPath)
+	cmd.Dir = workDir
+	env := append(os.Environ(), "PYTHON_BINARY="+pythonBinary)
+	if uvBinary != "" {
+		env = append(env, "UV_BINARY="+uvBinary)
+	}
+	if vCtx.LatestVersion != "" {
+		env = append(env, "PYTHON_VERSION="+vCtx.LatestVersion)
+	}
+	if vCtx.RequiresPythonVersion != "" {
+		env = append(env, "REQUIRES_PYTHON_VERSION="+vCtx.RequiresPythonVersion)
+	}
+	if len(opts.DockerfileImages) > 0 {
+		env = append(env, "PYTHON_DOCKERFILE_IMAGES="+strings.Join(opts.DockerfileImages, " "))
+	}
+	freezePath := ""
+	if hasPyproject {
+		if freezeFile, tmpErr := os.CreateTemp("", "autoupdate-pip-freeze-*.txt"); tmpErr == nil {
+			freezePath = freezeFile.Name()
+			_ = freezeFile.Close()
+			defer func() { _ = os.Remove(freezePath) }()
+			env = append(env, "PIP_FREEZE_FILE="+freezePath)
+		}
+	}
+	cmd.Env = env
+
+	output, cmdErr := cmd.CombinedOutput()
+	outputStr := support.RedactTokens(string(output), support.ProviderAuthToken(provider))
+	logger.Debugf("[python] Upgrade script output:\n%s", outputStr)
+
+	if cmdErr != nil {
+		return "", fmt.Errorf("upgrade script failed: %w\nOutput:\n%s", cmdErr, outputStr)
+	}
+
+	if freezePath != "" {
+		rewritePyprojectFile(filepath.Join(workDir, "pyproject.toml"), freezePath, opts.BumpLowerBounds)
+	}
+	return outputStr, nil
+}
+
 // buildBatchPythonScript generates a bash script with only language-specific
 // operations (no git clone, branch, commit, or push) for the batch pipeline.
-func buildBatchPythonScript(hasRequirements, hasPyproject bool) string {
+func buildBatchPythonScript(hasRequirements, hasPyproject bool, toolchain, freezeMode string) string {
 	var sb strings.Builder
 
 	sb.WriteString("#!/bin/bash\n")
@@ -328,6 +420,8 @@ func buildBatchPythonScript(hasRequirements, hasPyproject bool) string {
 	writePythonUpgradeCommands(&sb, upgradeParams{
 		HasRequirements: hasRequirements,
 		HasPyproject:    hasPyproject,
+		Toolchain:       toolchain,
+		FreezeMode:      freezeMode,
 	})
 	writeDockerfileUpdate(&sb)
 
@@ -340,25 +434,49 @@ type versionContext struct {
 	LatestVersion       string
 	NeedsVersionUpgrade bool
 	BranchName          string
+	// RequiresPythonVersion is the new `requires-python` floor, set when
+	// the project declares its runtime there instead of in .python-version.
+	RequiresPythonVersion string
 }
 
 type upgradeParams struct {
-	CloneURL        string
-	DefaultBranch   string
-	BranchName      string
-	PythonVersion   string
-	AuthToken       string
-	ProviderName    string
-	ChangelogFile   string
-	HasRequirements bool
-	HasPyproject    bool
-	PythonBinary    string
+	CloneURL      string
+	DefaultBranch string
+	BranchName    string
+	PythonVersion string
+	// RequiresPythonVersion raises the `requires-python` floor when there
+	// is no .python-version; see writeRequiresPythonUpdate.
+	RequiresPythonVersion string
+	AuthToken             string
+	ProviderName          string
+	ChangelogFile         string
+	ChangelogPath         string // changelog path relative to the repository root
+	HasRequirements       bool
+	HasPyproject          bool
+	PythonBinary          string
+	// Toolchain is the dependency manager the script drives: toolchainPip,
+	// toolchainPoetry or toolchainUv. Empty means toolchainPip.
+	Toolchain string
+	// UvBinary is the uv executable, set only for the uv toolchain.
+	UvBinary string
+	// FreezeMode is entities.FreezeModeFull (also when empty) or
+	// entities.FreezeModeTopLevel; see writeFreezeRequirements.
+	FreezeMode string
+	// CommitAuthor overrides the git identity of the upgrade commit.
+	CommitAuthor entities.CommitAuthor
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
+	// Signing, when it has a key, makes the script sign the upgrade commit.
+	Signing entities.Signing
 }
 
 type upgradeResult struct {
 	HasChanges           bool
 	PythonVersionUpdated bool
 	Output               string
+	// DiffStat is the `git diff --shortstat` of the upgrade commit.
+	DiffStat string
 }
 
 // parsePythonVersionFile extracts the Python version from a .python-version
@@ -376,40 +494,69 @@ func parsePythonVersionFile(content string) string {
 // --- version context ---
 
 // resolveVersionContext reads the remote .python-version to find the current
-// Python version and picks the right branch-name pattern.
+// Python version and picks the right branch-name pattern. Without a
+// .python-version, the `requires-python` floor of pyproject.toml is used.
 func resolveVersionContext(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestPyVersion string,
+	opts entities.UpdateOptions,
 ) *versionContext {
 	needsVersionUpgrade := false
+	requiresPython := ""
 
 	if latestPyVersion != "" && provider.HasFile(ctx, repo, ".python-version") {
 		content, err := provider.GetFileContent(ctx, repo, ".python-version")
 		if err == nil {
 			currentVersion := parsePythonVersionFile(content)
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestPyVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestPyVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestPyVersion)
 			logger.Infof(
 				"[python] Current .python-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
 			)
 		}
+	} else if latestPyVersion != "" && provider.HasFile(ctx, repo, "pyproject.toml") {
+		content, err := provider.GetFileContent(ctx, repo, "pyproject.toml")
+		if err == nil {
+			requiresPython = resolveRequiresPython(content, latestPyVersion, opts)
+			needsVersionUpgrade = requiresPython != ""
+		}
 	}
 
+	return newVersionContext(latestPyVersion, needsVersionUpgrade, requiresPython)
+}
+
+// resolveRequiresPython derives the current runtime floor from the
+// `requires-python` field of pyproject.toml and returns the floor it should
+// move to, or "" when it is current or absent.
+func resolveRequiresPython(pyprojectContent, latestPyVersion string, opts entities.UpdateOptions) string {
+	floor := parseRequiresPython(pyprojectContent)
+	if floor == "" {
+		return ""
+	}
+	target := requiresPythonTarget(floor, latestPyVersion, opts)
+	logger.Infof("[python] Current requires-python floor: %s (upgrade needed: %v)", floor, target != "")
+	return target
+}
+
+// newVersionContext picks the branch-name pattern for the resolved versions.
+func newVersionContext(latestPyVersion string, needsVersionUpgrade bool, requiresPython string) *versionContext {
 	branchName := branchPyDepsFmt
 	if needsVersionUpgrade {
 		branchName = fmt.Sprintf(branchPyVersionFmt, latestPyVersion)
 	}
 
 	return &versionContext{
-		LatestVersion:       latestPyVersion,
-		NeedsVersionUpgrade: needsVersionUpgrade,
-		BranchName:          branchName,
+		LatestVersion:         latestPyVersion,
+		NeedsVersionUpgrade:   needsVersionUpgrade,
+		BranchName:            branchName,
+		RequiresPythonVersion: requiresPython,
 	}
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the Python upgrade, and writes the modified
 // content to a temp file.
 func prepareChangelog(
@@ -417,14 +564,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[python] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[python] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -438,7 +586,7 @@ func prepareChangelog(
 		entry = pyChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -488,7 +636,7 @@ func upgradeRepo(
 	cmd.Env = buildEnv(params, repoDir)
 
 	output, err := cmd.CombinedOutput()
-	result.Output = string(output)
+	result.Output = support.RedactTokens(string(output), params.AuthToken)
 
 	if err != nil {
 		return result, fmt.Errorf(
@@ -498,6 +646,7 @@ func upgradeRepo(
 
 	result.HasChanges = strings.Contains(result.Output, "CHANGES_PUSHED=true")
 	result.PythonVersionUpdated = strings.Contains(result.Output, "PYTHON_VERSION_UPDATED=true")
+	result.DiffStat = support.ParseDiffStat(result.Output)
 	return result, nil
 }
 
@@ -517,12 +666,17 @@ func buildUpgradeScript(
 
 	// Ensure git user identity is configured
 	sb.WriteString("# Ensure git user identity is configured\n")
-	sb.WriteString("if ! git config --global user.name > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_NAME:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.name \"$COMMIT_AUTHOR_NAME\"\n")
+	sb.WriteString("elif ! git config --global user.name > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.name \"autoupdate[bot]\"\n")
 	sb.WriteString("fi\n")
-	sb.WriteString("if ! git config --global user.email > /dev/null 2>&1; then\n")
+	sb.WriteString("if [ -n \"${COMMIT_AUTHOR_EMAIL:-}\" ]; then\n")
+	sb.WriteString("    git config --global user.email \"$COMMIT_AUTHOR_EMAIL\"\n")
+	sb.WriteString("elif ! git config --global user.email > /dev/null 2>&1; then\n")
 	sb.WriteString("    git config --global user.email \"autoupdate[bot]@users.noreply.github.com\"\n")
 	sb.WriteString("fi\n\n")
+	sb.WriteString(support.CommitSigningScript(params.Signing))
 
 	// Clone
 	sb.WriteString("echo \"Cloning repository...\"\n")
@@ -542,7 +696,7 @@ func buildUpgradeScript(
 	writeChangelogUpdate(&sb)
 
 	// Check for changes and commit/push
-	writeCommitAndPush(&sb)
+	writeCommitAndPush(&sb, params.Signing.Key != "")
 
 	return sb.String()
 }
@@ -572,7 +726,26 @@ func writeGitAuth(sb *strings.Builder, params upgradeParams) {
 	sb.WriteString("trap 'rm -f \"$TEMP_GITCONFIG\"' EXIT\n\n")
 }
 
+// writePythonUpgradeCommands bumps .python-version and upgrades the
+// dependencies with the project's toolchain: `uv lock --upgrade` for uv,
+// `poetry update` for Poetry (refreshing poetry.lock), and pip otherwise.
 func writePythonUpgradeCommands(sb *strings.Builder, params upgradeParams) {
+	writePythonVersionUpdate(sb)
+
+	switch params.Toolchain {
+	case toolchainUv:
+		writeUvUpgradeCommands(sb)
+	case toolchainPoetry:
+		writePoetryUpgradeCommands(sb, params)
+	default:
+		writePipUpgradeCommands(sb, params)
+	}
+}
+
+// writePythonVersionUpdate bumps .python-version when a newer Python
+// version is available, or the `requires-python` floor of pyproject.toml
+// when the project has no .python-version.
+func writePythonVersionUpdate(sb *strings.Builder) {
 	// Update .python-version if it exists and a new version is available
 	sb.WriteString("# Check and update Python version\n")
 	sb.WriteString("PYTHON_VERSION_CHANGED=false\n")
@@ -589,17 +762,39 @@ func writePythonUpgradeCommands(sb *strings.Builder, params upgradeParams) {
 	sb.WriteString("        echo \"Python version already at $CURRENT_PY_VERSION, skipping version update\"\n")
 	sb.WriteString("        echo \"PYTHON_VERSION_UPDATED=false\"\n")
 	sb.WriteString("    fi\n")
+	writeRequiresPythonUpdate(sb)
 	sb.WriteString("else\n")
 	sb.WriteString("    echo \"PYTHON_VERSION_UPDATED=false\"\n")
 	sb.WriteString("fi\n\n")
+}
 
-	// Create virtual environment and upgrade dependencies
+// writeVenvSetup creates and activates a throwaway virtual environment for
+// the dependency upgrade.
+func writeVenvSetup(sb *strings.Builder) {
 	sb.WriteString("# Create virtual environment for dependency upgrade\n")
 	sb.WriteString("VENV_DIR=$(mktemp -d)\n")
 	sb.WriteString("\"$PYTHON_BINARY\" -m venv \"$VENV_DIR\"\n")
 	sb.WriteString("# shellcheck disable=SC1091\n")
 	sb.WriteString("source \"$VENV_DIR/bin/activate\"\n")
 	sb.WriteString("pip install --upgrade pip 2>&1 || echo \"WARNING: pip upgrade had some errors\"\n\n")
+}
+
+// writeVenvTeardown deactivates and removes the virtual environment, along
+// with the declared-packages snapshot of the top-level freeze mode.
+func writeVenvTeardown(sb *strings.Builder, freezeMode string) {
+	sb.WriteString("deactivate 2>/dev/null || true\n")
+	sb.WriteString("rm -rf \"$VENV_DIR\"\n")
+	if freezeMode == entities.FreezeModeTopLevel {
+		sb.WriteString("rm -f \"$DECLARED_PACKAGES_FILE\"\n")
+	}
+	sb.WriteString("\n")
+}
+
+// writePipUpgradeCommands upgrades requirements.txt and pyproject.toml
+// dependencies with pip inside a virtual environment.
+func writePipUpgradeCommands(sb *strings.Builder, params upgradeParams) {
+	writeVenvSetup(sb)
+	writeFreezeRequirements(sb, params.FreezeMode)
 
 	if params.HasRequirements {
 		sb.WriteString("# Upgrade dependencies from requirements.txt\n")
@@ -611,7 +806,7 @@ func writePythonUpgradeCommands(sb *strings.Builder, params upgradeParams) {
 			"    pip install --upgrade -r requirements.txt 2>&1 || echo \"WARNING: pip upgrade had some errors\"\n\n",
 		)
 		sb.WriteString("    echo \"Freezing updated requirements...\"\n")
-		sb.WriteString("    pip freeze | sed '/@\\s*file:\\/\\//d' > requirements.txt\n")
+		sb.WriteString("    freeze_requirements > requirements.txt\n")
 		sb.WriteString("fi\n\n")
 	}
 
@@ -622,14 +817,77 @@ func writePythonUpgradeCommands(sb *strings.Builder, params upgradeParams) {
 		sb.WriteString(
 			"    pip install --upgrade . 2>&1 || echo \"WARNING: pip install --upgrade . had some errors\"\n",
 		)
+		// The installed versions let the updater advance pyproject.toml
+		// constraints after the script returns.
+		sb.WriteString("    if [ -n \"${PIP_FREEZE_FILE:-}\" ]; then\n")
+		sb.WriteString("        pip freeze > \"$PIP_FREEZE_FILE\" 2>/dev/null || true\n")
+		sb.WriteString("    fi\n")
 		sb.WriteString("    if [ -f \"requirements.txt\" ]; then\n")
-		sb.WriteString("        pip freeze | sed '/@\\s*file:\\/\\//d' > requirements.txt\n")
+		sb.WriteString("        freeze_requirements > requirements.txt\n")
 		sb.WriteString("    fi\n")
 		sb.WriteString("fi\n\n")
 	}
 
-	sb.WriteString("deactivate 2>/dev/null || true\n")
-	sb.WriteString("rm -rf \"$VENV_DIR\"\n\n")
+	writeVenvTeardown(sb, params.FreezeMode)
+}
+
+// writePoetryUpgradeCommands upgrades a Poetry project with `poetry update`,
+// which resolves the latest versions its constraints allow and rewrites
+// poetry.lock. Poetry comes from PATH or, when missing, is installed into a
+// separate tool environment so it does not mix with the project's packages.
+// The dependencies are installed into the activated virtual environment, so
+// `pip freeze` still feeds the pyproject.toml constraint rewrite.
+func writePoetryUpgradeCommands(sb *strings.Builder, params upgradeParams) {
+	writeVenvSetup(sb)
+	writeFreezeRequirements(sb, params.FreezeMode)
+
+	sb.WriteString("# Upgrade dependencies with Poetry (updates poetry.lock)\n")
+	sb.WriteString("POETRY_BIN=$(command -v poetry || true)\n")
+	sb.WriteString("POETRY_TOOL_DIR=\"\"\n")
+	sb.WriteString("if [ -z \"$POETRY_BIN\" ]; then\n")
+	sb.WriteString("    echo \"Installing Poetry...\"\n")
+	sb.WriteString("    POETRY_TOOL_DIR=$(mktemp -d)\n")
+	sb.WriteString("    \"$PYTHON_BINARY\" -m venv \"$POETRY_TOOL_DIR\"\n")
+	sb.WriteString(
+		"    \"$POETRY_TOOL_DIR/bin/pip\" install poetry 2>&1 || echo \"WARNING: Poetry install had some errors\"\n",
+	)
+	sb.WriteString("    POETRY_BIN=\"$POETRY_TOOL_DIR/bin/poetry\"\n")
+	sb.WriteString("fi\n")
+	sb.WriteString("echo \"Running poetry update...\"\n")
+	sb.WriteString("\"$POETRY_BIN\" update 2>&1 || echo \"WARNING: poetry update had some errors (continuing anyway)\"\n")
+	sb.WriteString("if [ -n \"${PIP_FREEZE_FILE:-}\" ]; then\n")
+	sb.WriteString("    pip freeze > \"$PIP_FREEZE_FILE\" 2>/dev/null || true\n")
+	sb.WriteString("fi\n")
+	if params.HasRequirements {
+		sb.WriteString("if [ -f \"requirements.txt\" ]; then\n")
+		sb.WriteString("    freeze_requirements > requirements.txt\n")
+		sb.WriteString("fi\n")
+	}
+	sb.WriteString("if [ -n \"$POETRY_TOOL_DIR\" ]; then\n")
+	sb.WriteString("    rm -rf \"$POETRY_TOOL_DIR\"\n")
+	sb.WriteString("fi\n\n")
+
+	writeVenvTeardown(sb, params.FreezeMode)
+}
+
+// writeUvUpgradeCommands upgrades a uv project: `uv lock --upgrade`
+// re-resolves uv.lock to the latest versions its constraints allow, and
+// `uv sync` installs them to prove the new lock is installable. The project
+// environment lives in a temporary directory so no .venv is left in the
+// repository. pyproject.toml constraints are not rewritten, since uv.lock
+// records them and would go stale.
+func writeUvUpgradeCommands(sb *strings.Builder) {
+	sb.WriteString("# Upgrade dependencies with uv (updates uv.lock)\n")
+	sb.WriteString("UV_VENV_DIR=$(mktemp -d)\n")
+	sb.WriteString("export UV_PROJECT_ENVIRONMENT=\"$UV_VENV_DIR\"\n")
+	sb.WriteString("echo \"Running uv lock --upgrade...\"\n")
+	sb.WriteString(
+		"\"$UV_BINARY\" lock --upgrade 2>&1 || echo \"WARNING: uv lock --upgrade had some errors (continuing anyway)\"\n",
+	)
+	sb.WriteString("echo \"Running uv sync...\"\n")
+	sb.WriteString("\"$UV_BINARY\" sync 2>&1 || echo \"WARNING: uv sync had some errors (continuing anyway)\"\n")
+	sb.WriteString("unset UV_PROJECT_ENVIRONMENT\n")
+	sb.WriteString("rm -rf \"$UV_VENV_DIR\"\n\n")
 }
 
 func writeDockerfileUpdate(sb *strings.Builder) {
@@ -641,12 +899,16 @@ func writeDockerfileUpdate(sb *strings.Builder) {
 			"\\( -name 'Dockerfile' -o -name 'Dockerfile.*' -o -name '*.Dockerfile' \\) " +
 			"-print0 | while IFS= read -r -d '' df; do\n",
 	)
-	sb.WriteString("        if grep -q 'python:[0-9]' \"$df\"; then\n")
+	sb.WriteString("        for IMAGE in ${PYTHON_DOCKERFILE_IMAGES:-python}; do\n")
+	sb.WriteString("            IMAGE_RE=$(printf '%s' \"$IMAGE\" | sed 's/[].[*^$\\\\]/\\\\&/g')\n")
+	sb.WriteString("            if grep -q \"${IMAGE_RE}:[0-9]\" \"$df\"; then\n")
 	sb.WriteString(
-		"            sed \"s|python:[0-9][0-9.]*|python:${PYTHON_VERSION}|g\" \"$df\" > \"$df.tmp\" && mv \"$df.tmp\" \"$df\"\n",
+		"                sed \"s|${IMAGE_RE}:[0-9][0-9.]*|${IMAGE}:${PYTHON_VERSION}|g\" \"$df\" > \"$df.tmp\" " +
+			"&& mv \"$df.tmp\" \"$df\"\n",
 	)
-	sb.WriteString("            echo \"  Updated $df\"\n")
-	sb.WriteString("        fi\n")
+	sb.WriteString("                echo \"  Updated $df ($IMAGE)\"\n")
+	sb.WriteString("            fi\n")
+	sb.WriteString("        done\n")
 	sb.WriteString("    done\n")
 	sb.WriteString("fi\n\n")
 }
@@ -655,25 +917,28 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update CHANGELOG.md only if the upgrade produced actual changes.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("fi\n\n")
 }
 
-func writeCommitAndPush(sb *strings.Builder) {
+func writeCommitAndPush(sb *strings.Builder, signed bool) {
+	gitCommit := support.GitCommitCommand(signed)
 	sb.WriteString("if [ -n \"$(git status --porcelain)\" ]; then\n")
 	sb.WriteString("    echo \"Changes detected, committing and pushing...\"\n")
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$PYTHON_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded Python to `$PYTHON_VERSION` and updated all dependencies\"\n",
+		"        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded Python to `$PYTHON_VERSION` " +
+			"and updated all dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): updated Python dependencies\"\n")
+	sb.WriteString("        " + gitCommit + " -m \"${COMMIT_PREFIX:-chore(deps)}: updated Python dependencies\"\n")
 	sb.WriteString("    fi\n")
+	sb.WriteString("    " + support.DiffStatScript)
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
 	sb.WriteString("else\n")
@@ -692,11 +957,29 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 		"DEFAULT_BRANCH="+params.DefaultBranch,
 		"PYTHON_BINARY="+params.PythonBinary,
 	)
+	if params.UvBinary != "" {
+		env = append(env, "UV_BINARY="+params.UvBinary)
+	}
 	if params.PythonVersion != "" {
 		env = append(env, "PYTHON_VERSION="+params.PythonVersion)
 	}
+	if params.RequiresPythonVersion != "" {
+		env = append(env, "REQUIRES_PYTHON_VERSION="+params.RequiresPythonVersion)
+	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
+	}
+	if params.CommitAuthor.Name != "" {
+		env = append(env, "COMMIT_AUTHOR_NAME="+params.CommitAuthor.Name)
+	}
+	if params.CommitAuthor.Email != "" {
+		env = append(env, "COMMIT_AUTHOR_EMAIL="+params.CommitAuthor.Email)
+	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
+	if params.Signing.Key != "" {
+		env = append(env, support.SigningKeyEnv+"="+params.Signing.Key)
 	}
 	return env
 }
@@ -733,6 +1016,41 @@ func findPythonBinary() (string, error) {
 	return "", errors.New("python binary not found in PATH or common locations")
 }
 
+// findUvBinary locates the uv executable, checking PATH first and then the
+// directories its standalone installer and cargo install into.
+func findUvBinary() (string, error) {
+	if path, err := exec.LookPath("uv"); err == nil {
+		return path, nil
+	}
+
+	home, _ := os.UserHomeDir()
+	if home != "" {
+		for _, p := range []string{
+			filepath.Join(home, ".local", "bin", "uv"),
+			filepath.Join(home, ".cargo", "bin", "uv"),
+		} {
+			if _, statErr := os.Stat(p); statErr == nil {
+				return p, nil
+			}
+		}
+	}
+
+	return "", errors.New("uv binary not found in PATH or common locations")
+}
+
+// resolveUvBinary returns the uv executable when the project uses the uv
+// toolchain and "" for every other toolchain, which never needs it.
+func resolveUvBinary(toolchain string) (string, error) {
+	if toolchain != toolchainUv {
+		return "", nil
+	}
+	uvBinary, err := findUvBinary()
+	if err != nil {
+		return "", fmt.Errorf("uv.lock found but %w", err)
+	}
+	return uvBinary, nil
+}
+
 // GeneratePRDescription builds a markdown PR description for a Python
 // dependency upgrade. Exported so that the local-mode CLI handler can
 // reuse the same description format.
@@ -759,6 +1077,6 @@ func GeneratePRDescription(pyVersion string, pyVersionUpdated bool) string {
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes in `requirements.txt`\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
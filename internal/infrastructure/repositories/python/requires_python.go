@@ -0,0 +1,73 @@
+package python
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// requiresPythonPattern matches the `requires-python` floor of a
+// pyproject.toml [project] table, e.g. `requires-python = ">=3.11"` or
+// `requires-python = ">= 3.11, <4"`. Captures: (1) the floor version.
+var requiresPythonPattern = regexp.MustCompile(
+	`^\s*requires-python\s*=\s*["']>=\s*(\d+\.\d+(?:\.\d+)?)\s*(?:,[^"']*)?["']`,
+)
+
+// parseRequiresPython returns the `requires-python` floor declared in the
+// [project] table of a pyproject.toml, or "" when there is none. Only
+// `>=` floors are recognized; exact pins and other operators are left to
+// the project.
+func parseRequiresPython(pyprojectContent string) string {
+	inProject := false
+	for line := range strings.SplitSeq(pyprojectContent, "\n") {
+		if m := tomlTablePattern.FindStringSubmatch(line); m != nil {
+			inProject = m[1] == "project"
+			continue
+		}
+		if !inProject {
+			continue
+		}
+		if m := requiresPythonPattern.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// requiresPythonTarget returns the floor `requires-python` should move to
+// for the latest Python release, truncated to the precision of the current
+// floor (">=3.11" becomes ">=3.13" when 3.13.1 is out). It returns "" when
+// the floor is already current or the move is a major bump that opts skip.
+func requiresPythonTarget(floor, latest string, opts entities.UpdateOptions) string {
+	if floor == "" || latest == "" {
+		return ""
+	}
+	parts := strings.Split(latest, ".")
+	precision := len(strings.Split(floor, "."))
+	if len(parts) > precision {
+		parts = parts[:precision]
+	}
+	target := strings.Join(parts, ".")
+	if semver.Compare("v"+target, "v"+floor) <= 0 || opts.IsMajorBumpIgnored(floor, target) {
+		return ""
+	}
+	return target
+}
+
+// writeRequiresPythonUpdate emits the branch of the Python version update
+// that raises the `requires-python` floor to REQUIRES_PYTHON_VERSION when
+// the project has no .python-version. It continues the `if` chain opened by
+// writePythonVersionUpdate.
+func writeRequiresPythonUpdate(sb *strings.Builder) {
+	sb.WriteString("elif [ -n \"${REQUIRES_PYTHON_VERSION:-}\" ] && [ -f \"pyproject.toml\" ]; then\n")
+	sb.WriteString("    echo \"Updating the requires-python floor to $REQUIRES_PYTHON_VERSION...\"\n")
+	sb.WriteString(
+		"    sed -E \"s/^(requires-python[[:space:]]*=[[:space:]]*.>=[[:space:]]*)[0-9][0-9.]*/" +
+			"\\1${REQUIRES_PYTHON_VERSION}/\" pyproject.toml > pyproject.toml.tmp && mv pyproject.toml.tmp pyproject.toml\n",
+	)
+	sb.WriteString("    PYTHON_VERSION_CHANGED=true\n")
+	sb.WriteString("    echo \"PYTHON_VERSION_UPDATED=true\"\n")
+}
@@ -0,0 +1,172 @@
+//go:build unit
+
+package python_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	pyUpdater "github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/python"
+)
+
+func TestParseRequiresPython(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"should read a minor floor", "[project]\nrequires-python = \">=3.11\"\n", "3.11"},
+		{"should read a floor with an upper bound", "[project]\nrequires-python = '>= 3.10.4, <4'\n", "3.10.4"},
+		{"should ignore an exact pin", "[project]\nrequires-python = \"==3.12.*\"\n", ""},
+		{"should ignore the field outside the project table", "[tool.x]\nrequires-python = \">=3.9\"\n", ""},
+		{"should return empty without the field", "[project]\nname = \"app\"\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// given / when
+			result := pyUpdater.ParseRequiresPython(tt.content)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRequiresPythonTarget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep the precision of the current floor", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		minor := pyUpdater.RequiresPythonTarget("3.11", "3.13.1", entities.UpdateOptions{})
+		patch := pyUpdater.RequiresPythonTarget("3.11.4", "3.13.1", entities.UpdateOptions{})
+
+		// then
+		assert.Equal(t, "3.13", minor)
+		assert.Equal(t, "3.13.1", patch)
+	})
+
+	t.Run("should never lower or repeat the floor", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		same := pyUpdater.RequiresPythonTarget("3.13", "3.13.1", entities.UpdateOptions{})
+		newer := pyUpdater.RequiresPythonTarget("3.14", "3.13.1", entities.UpdateOptions{})
+
+		// then
+		assert.Empty(t, same)
+		assert.Empty(t, newer)
+	})
+
+	t.Run("should skip a major bump when ignore major is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given / when
+		result := pyUpdater.RequiresPythonTarget("3.13", "4.0.0", entities.UpdateOptions{IgnoreMajor: true})
+
+		// then
+		assert.Empty(t, result)
+	})
+}
+
+func TestWritePythonVersionUpdateRequiresPython(t *testing.T) {
+	t.Parallel()
+
+	runVersionUpdate := func(t *testing.T, repoDir string) string {
+		t.Helper()
+		var sb strings.Builder
+		pyUpdater.WritePythonVersionUpdate(&sb)
+		cmd := exec.CommandContext(t.Context(), "bash", "-c", sb.String())
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "PYTHON_VERSION=3.13.1", "REQUIRES_PYTHON_VERSION=3.13")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+
+	t.Run("should raise the requires-python floor when there is no .python-version", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		pyproject := "[project]\nname = \"app\"\nrequires-python = \">=3.11, <4\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "pyproject.toml"), []byte(pyproject), 0o600))
+
+		// when
+		out := runVersionUpdate(t, repoDir)
+
+		// then
+		assert.Contains(t, out, "PYTHON_VERSION_UPDATED=true")
+		data, err := os.ReadFile(filepath.Join(repoDir, "pyproject.toml"))
+		require.NoError(t, err)
+		assert.Equal(t, "[project]\nname = \"app\"\nrequires-python = \">=3.13, <4\"\n", string(data))
+	})
+
+	t.Run("should prefer .python-version over requires-python", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		pyproject := "[project]\nrequires-python = \">=3.11\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "pyproject.toml"), []byte(pyproject), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, ".python-version"), []byte("3.12.0\n"), 0o600))
+
+		// when
+		runVersionUpdate(t, repoDir)
+
+		// then
+		data, err := os.ReadFile(filepath.Join(repoDir, "pyproject.toml"))
+		require.NoError(t, err)
+		assert.Equal(t, pyproject, string(data))
+		version, err := os.ReadFile(filepath.Join(repoDir, ".python-version"))
+		require.NoError(t, err)
+		assert.Equal(t, "3.13.1\n", string(version))
+	})
+}
+
+func TestResolveLocalCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should take the requires-python floor with the lowest policy", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		pyproject := "[project]\nname = \"demo\"\nrequires-python = \">=3.10\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "pyproject.toml"), []byte(pyproject), 0o600))
+
+		// when
+		version := pyUpdater.ResolveLocalCurrentVersion(repoDir, "3.12.4",
+			entities.UpdateOptions{VersionConflict: entities.VersionConflictLowest})
+
+		// then
+		assert.Equal(t, "3.10", version)
+	})
+
+	t.Run("should keep the .python-version version by default", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "Dockerfile"), []byte("FROM python:3.11-slim\n"), 0o600))
+
+		// when
+		version := pyUpdater.ResolveLocalCurrentVersion(repoDir, "3.12.4", entities.UpdateOptions{})
+
+		// then
+		assert.Equal(t, "3.12.4", version)
+	})
+}
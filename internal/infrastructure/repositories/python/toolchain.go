@@ -0,0 +1,62 @@
+package python
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+// Dependency managers the upgrade script knows how to drive.
+const (
+	toolchainPip    = "pip"
+	toolchainPoetry = "poetry"
+	toolchainUv     = "uv"
+)
+
+// detectPythonToolchain picks the dependency manager of a project: uv when
+// a uv.lock is committed, Poetry when pyproject.toml declares a
+// [tool.poetry] table, and pip otherwise.
+func detectPythonToolchain(pyprojectContent string, hasUvLock bool) string {
+	if hasUvLock {
+		return toolchainUv
+	}
+	for line := range strings.SplitSeq(pyprojectContent, "\n") {
+		m := tomlTablePattern.FindStringSubmatch(line)
+		if m != nil && (m[1] == "tool.poetry" || strings.HasPrefix(m[1], "tool.poetry.")) {
+			return toolchainPoetry
+		}
+	}
+	return toolchainPip
+}
+
+// detectRemoteToolchain detects the toolchain of a repository through the
+// provider API. Projects without a pyproject.toml always use pip.
+func detectRemoteToolchain(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	hasPyproject bool,
+) string {
+	if !hasPyproject {
+		return toolchainPip
+	}
+	content, err := provider.GetFileContent(ctx, repo, "pyproject.toml")
+	if err != nil {
+		content = ""
+	}
+	return detectPythonToolchain(content, provider.HasFile(ctx, repo, "uv.lock"))
+}
+
+// detectLocalToolchain detects the toolchain of a checked-out repository.
+func detectLocalToolchain(repoDir string) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, "pyproject.toml"))
+	if err != nil {
+		return toolchainPip
+	}
+	_, uvErr := os.Stat(filepath.Join(repoDir, "uv.lock"))
+	return detectPythonToolchain(string(content), uvErr == nil)
+}
@@ -7,13 +7,23 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	logger "github.com/sirupsen/logrus"
 )
 
 // VersionFetcher abstracts latest Python version resolution for testability.
 type VersionFetcher interface {
 	FetchLatestVersion(ctx context.Context) (string, error)
+	// FetchLatestVersionInSeries returns the latest patch of a release
+	// series such as "3.12", or ErrSeriesEndOfLife when it is no longer
+	// supported.
+	FetchLatestVersionInSeries(ctx context.Context, series string) (string, error)
 }
 
+// ErrSeriesEndOfLife is returned when the configured Python series has
+// reached end-of-life.
+var ErrSeriesEndOfLife = errors.New("python series has reached end-of-life")
+
 // pythonRelease represents a single Python release cycle from the endoflife.date API.
 type pythonRelease struct {
 	Cycle  string `json:"cycle"`
@@ -42,35 +52,98 @@ func NewHTTPPythonVersionFetcherWithURL(client *http.Client, baseURL string) Ver
 
 // FetchLatestVersion returns the latest stable Python version string (e.g. "3.13.1").
 func (f *HTTPPythonVersionFetcher) FetchLatestVersion(ctx context.Context) (string, error) {
+	releases, err := f.fetchReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, release := range releases {
+		if isActiveRelease(release) {
+			return release.Latest, nil
+		}
+	}
+
+	return "", errors.New("no active Python release found")
+}
+
+// FetchLatestVersionInSeries returns the latest patch of the given release
+// series (e.g. "3.12.8" for "3.12").
+func (f *HTTPPythonVersionFetcher) FetchLatestVersionInSeries(ctx context.Context, series string) (string, error) {
+	releases, err := f.fetchReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, release := range releases {
+		if release.Cycle != series {
+			continue
+		}
+		if !isActiveRelease(release) {
+			return "", fmt.Errorf("%w: %s", ErrSeriesEndOfLife, series)
+		}
+		return release.Latest, nil
+	}
+
+	return "", fmt.Errorf("python series %q not found", series)
+}
+
+// fetchReleases downloads the Python release cycles, newest first.
+func (f *HTTPPythonVersionFetcher) fetchReleases(ctx context.Context) ([]pythonRelease, error) {
 	req, err := http.NewRequestWithContext(
 		ctx, http.MethodGet, f.baseURL, nil,
 	)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := f.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch Python versions: %w", err)
+		return nil, fmt.Errorf("failed to fetch Python versions: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
 	var releases []pythonRelease
 	if decodeErr := json.NewDecoder(resp.Body).Decode(&releases); decodeErr != nil {
-		return "", fmt.Errorf("failed to parse Python versions: %w", decodeErr)
+		return nil, fmt.Errorf("failed to parse Python versions: %w", decodeErr)
 	}
+	return releases, nil
+}
 
-	for _, release := range releases {
-		if isActiveRelease(release) {
-			return release.Latest, nil
+// fetchTargetVersion returns the Python version the updater should move
+// to: the latest patch of series when one is configured, the latest stable
+// release otherwise. Failures, including an end-of-life series, are logged
+// and reported as "" so the run continues with a dependencies-only update.
+func fetchTargetVersion(ctx context.Context, fetcher VersionFetcher, series string) string {
+	if series == "" {
+		latest, err := fetcher.FetchLatestVersion(ctx)
+		if err != nil {
+			logger.Warnf(
+				"[python] Failed to fetch latest Python version: %v (continuing without version upgrade)", err,
+			)
+			return ""
 		}
+		logger.Infof("[python] Latest stable Python version: %s", latest)
+		return latest
 	}
 
-	return "", errors.New("no active Python release found")
+	latest, err := fetcher.FetchLatestVersionInSeries(ctx, series)
+	if errors.Is(err, ErrSeriesEndOfLife) {
+		logger.Warnf("[python] Python %s has reached end-of-life (continuing without version upgrade)", series)
+		return ""
+	}
+	if err != nil {
+		logger.Warnf(
+			"[python] Failed to fetch the latest Python %s version: %v (continuing without version upgrade)",
+			series, err,
+		)
+		return ""
+	}
+	logger.Infof("[python] Latest Python %s version: %s", series, latest)
+	return latest
 }
 
 // isActiveRelease returns true if the Python release cycle has not reached
@@ -209,3 +209,116 @@ func TestHTTPPythonVersionFetcher(t *testing.T) {
 		assert.Empty(t, version)
 	})
 }
+
+// newReleaseListServer serves a mocked endoflife.date Python release list.
+func newReleaseListServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		releases := []map[string]any{
+			{"cycle": "3.13", "latest": "3.13.1", "eol": false},
+			{"cycle": "3.12", "latest": "3.12.8", "eol": time.Now().AddDate(2, 0, 0).Format("2006-01-02")},
+			{"cycle": "3.8", "latest": "3.8.20", "eol": "2024-10-07"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(releases)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPPythonVersionFetcherInSeries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the latest patch of the configured series", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version, err := fetcher.FetchLatestVersionInSeries(t.Context(), "3.12")
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "3.12.8", version)
+	})
+
+	t.Run("should return ErrSeriesEndOfLife when the series is EOL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version, err := fetcher.FetchLatestVersionInSeries(t.Context(), "3.8")
+
+		// then
+		require.ErrorIs(t, err, pyUpdater.ErrSeriesEndOfLife)
+		assert.Empty(t, version)
+	})
+
+	t.Run("should return error when the series is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version, err := fetcher.FetchLatestVersionInSeries(t.Context(), "2.7")
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `python series "2.7" not found`)
+		assert.Empty(t, version)
+	})
+}
+
+func TestFetchTargetVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should target the newest release when no series is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version := pyUpdater.FetchTargetVersion(t.Context(), fetcher, "")
+
+		// then
+		assert.Equal(t, "3.13.1", version)
+	})
+
+	t.Run("should stay within the configured series", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version := pyUpdater.FetchTargetVersion(t.Context(), fetcher, "3.12")
+
+		// then
+		assert.Equal(t, "3.12.8", version)
+	})
+
+	t.Run("should fall back to a dependencies-only update when the series is EOL", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := newReleaseListServer(t)
+		fetcher := pyUpdater.NewHTTPPythonVersionFetcherWithURL(server.Client(), server.URL)
+
+		// when
+		version := pyUpdater.FetchTargetVersion(t.Context(), fetcher, "3.8")
+
+		// then
+		assert.Empty(t, version)
+	})
+}
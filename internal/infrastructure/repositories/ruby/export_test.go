@@ -30,8 +30,13 @@ func ResolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestVersion string,
+	opts ...entities.UpdateOptions,
 ) *versionContext {
-	return resolveVersionContext(ctx, provider, repo, latestVersion)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return resolveVersionContext(ctx, provider, repo, latestVersion, o)
 }
 
 // VersionContext is exported for testing.
@@ -92,7 +97,7 @@ func PrepareChangelog(
 	repo entities.Repository,
 	vCtx *VersionContext,
 ) string {
-	return prepareChangelog(ctx, provider, repo, vCtx)
+	return prepareChangelog(ctx, provider, repo, vCtx, entities.ChangelogSettings{})
 }
 
 // LogDryRun is exported for testing.
@@ -144,7 +149,7 @@ func HandleDryRun(vCtx *VersionContext, repoDir string) *LocalResult {
 
 // PrepareLocalChangelog is exported for testing.
 func PrepareLocalChangelog(repoDir string, vCtx *VersionContext) string {
-	return prepareLocalChangelog(repoDir, vCtx)
+	return prepareLocalChangelog(repoDir, vCtx, entities.ChangelogSettings{})
 }
 
 // SetLocalCmdRunner overrides the package-level local command runner for testing.
@@ -25,6 +25,12 @@ type LocalUpgradeOptions struct {
 	AuthToken    string
 	ProviderName string                    // git provider name (e.g. "azuredevops", "github", "gitlab")
 	PushAuth     gitlocal.PushAuthResolver // resolves auth methods for git push
+	// Changelog locates the changelog file and the "### " heading under
+	// [Unreleased] that receives the entry; zero means CHANGELOG.md/Changed.
+	Changelog entities.ChangelogSettings
+	// CommitPrefix replaces the "chore(deps)" prefix of the upgrade commit
+	// message when set.
+	CommitPrefix string
 }
 
 // LocalResult holds the outcome of a local upgrade operation.
@@ -49,7 +55,7 @@ func RunLocalUpgrade(
 		logger.SetLevel(logger.DebugLevel)
 	}
 
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	vCtx := resolveLocalVersionContext(ctx, repoDir, entities.UpdateOptions{})
 
 	if opts.DryRun {
 		return handleDryRun(vCtx, repoDir), nil
@@ -60,7 +66,7 @@ func RunLocalUpgrade(
 
 // resolveLocalVersionContext fetches the latest Ruby version and compares
 // it against the local .ruby-version to build a versionContext.
-func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionContext {
+func resolveLocalVersionContext(ctx context.Context, repoDir string, opts entities.UpdateOptions) *versionContext {
 	fetcher := NewHTTPRubyVersionFetcher(&http.Client{Timeout: rbVersionTimeout})
 	latestRbVersion, err := fetcher.FetchLatestVersion(ctx)
 	if err != nil {
@@ -75,7 +81,8 @@ func resolveLocalVersionContext(ctx context.Context, repoDir string) *versionCon
 		rbVersionContent, readErr := os.ReadFile(filepath.Join(repoDir, ".ruby-version"))
 		if readErr == nil {
 			currentVersion := parseRubyVersionFile(string(rbVersionContent))
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestRbVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestRbVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestRbVersion)
 			logger.Infof(
 				"[ruby] Current .ruby-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -168,6 +175,7 @@ func executeLocalUpgrade(
 			vCtx.LatestVersion,
 		)
 	}
+	commitMsg = entities.WithCommitPrefix(commitMsg, opts.CommitPrefix)
 
 	pushed, pushErr := gitCtx.StageCommitAndPush(
 		vCtx.BranchName, commitMsg, opts.AuthToken,
@@ -194,7 +202,7 @@ func runLanguageUpgradeScript(
 	vCtx *versionContext,
 	opts LocalUpgradeOptions,
 ) (string, error) {
-	changelogFile := prepareLocalChangelog(repoDir, vCtx)
+	changelogFile := prepareLocalChangelog(repoDir, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -203,6 +211,7 @@ func runLanguageUpgradeScript(
 		BranchName:    vCtx.BranchName,
 		RubyVersion:   vCtx.LatestVersion,
 		ChangelogFile: changelogFile,
+		ChangelogPath: opts.Changelog.FilePath(),
 		AuthToken:     opts.AuthToken,
 		ProviderName:  opts.ProviderName,
 	}
@@ -249,6 +258,7 @@ type localUpgradeParams struct {
 	BranchName    string
 	RubyVersion   string
 	ChangelogFile string
+	ChangelogPath string
 	AuthToken     string
 	ProviderName  string
 }
@@ -323,15 +333,15 @@ func buildLocalEnv(params localUpgradeParams) []string {
 		)
 	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
 
-// prepareLocalChangelog reads CHANGELOG.md from disk (if it exists),
+// prepareLocalChangelog reads the changelog from disk (if it exists),
 // inserts an upgrade entry, and writes the result to a temp file.
-func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
-	content, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+func prepareLocalChangelog(repoDir string, vCtx *versionContext, changelog entities.ChangelogSettings) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath())))
 	if err != nil {
 		return "" // no changelog present
 	}
@@ -346,7 +356,7 @@ func prepareLocalChangelog(repoDir string, vCtx *versionContext) string {
 		entry = "- changed the Ruby gem dependencies to their latest versions"
 	}
 
-	modified := entities.InsertChangelogEntry(string(content), []string{entry})
+	modified := entities.InsertChangelogEntryInSection(string(content), []string{entry}, changelog.Section)
 	if modified == string(content) {
 		return ""
 	}
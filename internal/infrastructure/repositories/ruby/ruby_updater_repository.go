@@ -58,6 +58,16 @@ func NewUpdaterRepositoryWithDeps(vf VersionFetcher) repositories.UpdaterReposit
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Ruby version and Bundler gem dependencies"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return (&langRuby.Detector{}).DetectionFiles()
+}
+
 // Detect returns true if the repository has Ruby marker files (e.g. Gemfile, .ruby-version).
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
@@ -90,7 +100,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		logger.Infof("[ruby] Latest stable Ruby version: %s", latestRbVersion)
 	}
 
-	vCtx := resolveVersionContext(ctx, provider, repo, latestRbVersion)
+	vCtx := resolveVersionContext(ctx, provider, repo, latestRbVersion, opts)
 
 	// Check if PR already exists
 	exists, prCheckErr := provider.PullRequestExists(ctx, repo, vCtx.BranchName)
@@ -110,7 +120,7 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		return []entities.PullRequest{}, nil
 	}
 
-	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx)
+	result, upgradeErr := cloneAndUpgrade(ctx, provider, repo, vCtx, opts)
 	if upgradeErr != nil {
 		return nil, upgradeErr
 	}
@@ -145,8 +155,9 @@ func cloneAndUpgrade(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	opts entities.UpdateOptions,
 ) (*upgradeResult, error) {
-	changelogFile := prepareChangelog(ctx, provider, repo, vCtx)
+	changelogFile := prepareChangelog(ctx, provider, repo, vCtx, opts.Changelog)
 	if changelogFile != "" {
 		defer os.Remove(changelogFile)
 	}
@@ -162,6 +173,8 @@ func cloneAndUpgrade(
 		AuthToken:     provider.AuthToken(),
 		ProviderName:  provider.Name(),
 		ChangelogFile: changelogFile,
+		ChangelogPath: opts.Changelog.FilePath(),
+		CommitPrefix:  opts.CommitPrefix(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to upgrade: %w", err)
@@ -192,13 +205,16 @@ func openPullRequest(
 			vCtx.LatestVersion,
 		)
 	}
-	prDesc := GeneratePRDescription(vCtx.LatestVersion, result.RubyVersionUpdated)
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, result.RubyVersionUpdated),
+	)
 
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + vCtx.BranchName,
 		TargetBranch: targetBranch,
 		Title:        prTitle,
-		Description:  prDesc,
+		Description:  entities.ApplyPRFooter(prDesc, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -220,12 +236,12 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	_ repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[ruby] Processing local clone of %s/%s", repo.Organization, repo.Name)
 
 	// resolveLocalVersionContext (from local.go) handles fetching + comparison
-	vCtx := resolveLocalVersionContext(ctx, repoDir)
+	vCtx := resolveLocalVersionContext(ctx, repoDir, opts)
 
 	script := buildBatchRubyScript()
 	scriptPath := filepath.Join(repoDir, ".autoupdate-upgrade.sh")
@@ -261,7 +277,7 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	// Update CHANGELOG locally
+	// CHANGELOG entry, inserted by the pipeline along with the other updaters' entries
 	var entry string
 	if rbVersionUpdated {
 		entry = fmt.Sprintf(
@@ -271,23 +287,27 @@ func (u *UpdaterRepository) ApplyUpdates(
 	} else {
 		entry = rbChangelogEntryDeps
 	}
-	support.LocalChangelogUpdate(repoDir, []string{entry})
 
-	commitMsg := rbCommitMsgDeps
-	prTitle := commitMsg
+	prTitle := rbCommitMsgDeps
 	if rbVersionUpdated {
-		commitMsg = fmt.Sprintf(
+		prTitle = fmt.Sprintf(
 			"chore(deps): upgraded Ruby to `%s` and updated all gem dependencies",
 			vCtx.LatestVersion,
 		)
-		prTitle = commitMsg
 	}
+	commitMsg := entities.WithCommitPrefix(prTitle, opts.CommitPrefix())
+
+	prTitle, prDesc := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, nil),
+		prTitle, GeneratePRDescription(vCtx.LatestVersion, rbVersionUpdated),
+	)
 
 	return &repositories.LocalUpdateResult{
-		BranchName:    vCtx.BranchName,
-		CommitMessage: commitMsg,
-		PRTitle:       prTitle,
-		PRDescription: GeneratePRDescription(vCtx.LatestVersion, rbVersionUpdated),
+		BranchName:       vCtx.BranchName,
+		CommitMessage:    commitMsg,
+		PRTitle:          prTitle,
+		PRDescription:    prDesc,
+		ChangelogEntries: []string{entry},
 	}, nil
 }
 
@@ -321,6 +341,10 @@ type upgradeParams struct {
 	AuthToken     string
 	ProviderName  string
 	ChangelogFile string
+	ChangelogPath string // changelog path relative to the repository root
+	// CommitPrefix is the conventional-commit prefix of the upgrade commit
+	// message, e.g. "chore(deps)".
+	CommitPrefix string
 }
 
 type upgradeResult struct {
@@ -350,6 +374,7 @@ func resolveVersionContext(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	latestRbVersion string,
+	opts entities.UpdateOptions,
 ) *versionContext {
 	needsVersionUpgrade := false
 
@@ -357,7 +382,8 @@ func resolveVersionContext(
 		content, err := provider.GetFileContent(ctx, repo, ".ruby-version")
 		if err == nil {
 			currentVersion := parseRubyVersionFile(content)
-			needsVersionUpgrade = currentVersion != "" && currentVersion != latestRbVersion
+			needsVersionUpgrade = currentVersion != "" && currentVersion != latestRbVersion &&
+				!opts.IsMajorBumpIgnored(currentVersion, latestRbVersion)
 			logger.Infof(
 				"[ruby] Current .ruby-version: %s (upgrade needed: %v)",
 				currentVersion, needsVersionUpgrade,
@@ -377,7 +403,7 @@ func resolveVersionContext(
 	}
 }
 
-// prepareChangelog reads the target repo's CHANGELOG.md (if it exists),
+// prepareChangelog reads the target repo's changelog (if it exists),
 // inserts an entry describing the Ruby upgrade, and writes the modified
 // content to a temp file.
 func prepareChangelog(
@@ -385,14 +411,15 @@ func prepareChangelog(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	vCtx *versionContext,
+	changelog entities.ChangelogSettings,
 ) string {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
+	if !provider.HasFile(ctx, repo, changelog.FilePath()) {
 		return ""
 	}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
+	content, err := provider.GetFileContent(ctx, repo, changelog.FilePath())
 	if err != nil {
-		logger.Warnf("[ruby] Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("[ruby] Failed to read %s: %v", changelog.FilePath(), err)
 		return ""
 	}
 
@@ -406,7 +433,7 @@ func prepareChangelog(
 		entry = rbChangelogEntryDeps
 	}
 
-	modified := entities.InsertChangelogEntry(content, []string{entry})
+	modified := entities.InsertChangelogEntryInSection(content, []string{entry}, changelog.Section)
 	if modified == content {
 		return ""
 	}
@@ -595,8 +622,8 @@ func writeChangelogUpdate(sb *strings.Builder) {
 	sb.WriteString("# Update CHANGELOG.md only if the upgrade produced actual changes.\n")
 	sb.WriteString("if [ -n \"${CHANGELOG_FILE:-}\" ] && [ -f \"$CHANGELOG_FILE\" ]; then\n")
 	sb.WriteString("    if [ -n \"$(git status --porcelain)\" ]; then\n")
-	sb.WriteString("        echo \"Updating CHANGELOG.md...\"\n")
-	sb.WriteString("        cp \"$CHANGELOG_FILE\" CHANGELOG.md\n")
+	sb.WriteString("        echo \"Updating ${CHANGELOG_PATH:-CHANGELOG.md}...\"\n")
+	sb.WriteString("        cp \"$CHANGELOG_FILE\" \"${CHANGELOG_PATH:-CHANGELOG.md}\"\n")
 	sb.WriteString("    else\n")
 	sb.WriteString("        echo \"No dependency changes detected, skipping CHANGELOG update.\"\n")
 	sb.WriteString("    fi\n")
@@ -609,10 +636,11 @@ func writeCommitAndPush(sb *strings.Builder) {
 	sb.WriteString("    git add -A\n")
 	sb.WriteString("    if [ \"$RUBY_VERSION_CHANGED\" = \"true\" ]; then\n")
 	sb.WriteString(
-		"        git commit -m \"chore(deps): upgraded Ruby to `$TARGET_RUBY_VERSION` and updated all gem dependencies\"\n",
+		"        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: upgraded Ruby to `$TARGET_RUBY_VERSION` " +
+			"and updated all gem dependencies\"\n",
 	)
 	sb.WriteString("    else\n")
-	sb.WriteString("        git commit -m \"chore(deps): updated Ruby gem dependencies\"\n")
+	sb.WriteString("        git commit -m \"${COMMIT_PREFIX:-chore(deps)}: updated Ruby gem dependencies\"\n")
 	sb.WriteString("    fi\n")
 	sb.WriteString("    git push origin \"$BRANCH_NAME\" 2>&1\n")
 	sb.WriteString("    echo \"CHANGES_PUSHED=true\"\n")
@@ -634,8 +662,11 @@ func buildEnv(params upgradeParams, repoDir string) []string {
 	if params.RubyVersion != "" {
 		env = append(env, "TARGET_RUBY_VERSION="+params.RubyVersion)
 	}
+	if params.CommitPrefix != "" {
+		env = append(env, "COMMIT_PREFIX="+params.CommitPrefix)
+	}
 	if params.ChangelogFile != "" {
-		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile)
+		env = append(env, "CHANGELOG_FILE="+params.ChangelogFile, "CHANGELOG_PATH="+params.ChangelogPath)
 	}
 	return env
 }
@@ -666,6 +697,6 @@ func GeneratePRDescription(rbVersion string, rbVersionUpdated bool) string {
 	sb.WriteString("- [ ] Verify tests pass\n")
 	sb.WriteString("- [ ] Review dependency changes in `Gemfile.lock`\n")
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
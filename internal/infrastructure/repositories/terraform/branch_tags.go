@@ -0,0 +1,105 @@
+package terraform
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+const (
+	lsRemoteTimeout = 30 * time.Second
+	tagRefPrefix    = "refs/tags/"
+	peeledRefSuffix = "^{}"
+)
+
+// resolveTrackedBranchTag returns the tag, among tags, that the alias branch
+// configured for depRepo in trackBranches points to. The branch head and the
+// tag commits come from `git ls-remote` on the provider's clone URL. It
+// returns an empty string when no branch is tracked for the repository, the
+// lookup fails or no listed tag points at the branch head, so the caller can
+// fall back to the regular tag selection.
+func resolveTrackedBranchTag(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	depRepo *entities.Repository,
+	tags []string,
+	trackBranches map[string]string,
+) string {
+	if depRepo == nil {
+		return ""
+	}
+	branch := trackBranches[depRepo.Name]
+	if branch == "" {
+		return ""
+	}
+
+	refs, err := listRemoteRefs(ctx, provider.CloneURL(*depRepo), "refs/heads/"+branch, tagRefPrefix+"*")
+	if err != nil {
+		logger.Warnf("[terraform] Failed to list the refs of %s, using latest tag: %v", depRepo.Name, err)
+		return ""
+	}
+	tag := branchTag(refs, branch, tags)
+	if tag == "" {
+		logger.Warnf("[terraform] No tag of %s points at branch %q, using latest tag", depRepo.Name, branch)
+	}
+	return tag
+}
+
+// listRemoteRefs runs `git ls-remote` and returns the commit of every
+// matching ref, keyed by ref name. Annotated tags resolve to the commit they
+// point to rather than to the tag object.
+func listRemoteRefs(ctx context.Context, remoteURL string, patterns ...string) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, lsRemoteTimeout)
+	defer cancel()
+
+	args := append([]string{"ls-remote", remoteURL}, patterns...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if err != nil {
+		// the error is kept short on purpose: the clone URL carries the token
+		return nil, fmt.Errorf("git ls-remote failed: %w", err)
+	}
+
+	refs := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		hash, ref, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		if peeled, isPeeled := strings.CutSuffix(ref, peeledRefSuffix); isPeeled {
+			refs[peeled] = hash
+			continue
+		}
+		if _, seen := refs[ref]; !seen {
+			refs[ref] = hash
+		}
+	}
+	return refs, nil
+}
+
+// branchTag returns the first of tags whose commit is the head of branch in
+// refs, or an empty string when none is. tags are ordered newest first, so
+// the newest of several tags on the same commit wins.
+func branchTag(refs map[string]string, branch string, tags []string) string {
+	head := refs["refs/heads/"+branch]
+	if head == "" {
+		return ""
+	}
+	for _, tag := range tags {
+		if refs[tagRefPrefix+tag] == head {
+			return tag
+		}
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// constraintClausePattern splits one clause of a Terraform version
+// constraint such as "~> 2.1", ">= 1.0.0" or "3.2.1" into its leading
+// spaces, operator, spaces, version and trailing spaces.
+var constraintClausePattern = regexp.MustCompile(
+	`^(\s*)(~>|>=|<=|!=|>|<|=)?(\s*)(v?\d+(?:\.\d+){0,2}(?:-[0-9A-Za-z.-]+)?)(\s*)$`,
+)
+
+// constraintClause is one comparison of a Terraform version constraint.
+type constraintClause struct {
+	operator string
+	version  string
+}
+
+// parseConstraint parses a comma-separated Terraform version constraint
+// (e.g. "~> 2.1" or ">= 1.2, < 2.0"). A clause without an operator is an
+// exact match, like "=".
+func parseConstraint(constraint string) ([]constraintClause, error) {
+	var clauses []constraintClause
+	for raw := range strings.SplitSeq(constraint, ",") {
+		m := constraintClausePattern.FindStringSubmatch(raw)
+		if m == nil {
+			return nil, fmt.Errorf("invalid version constraint %q", constraint)
+		}
+		operator := m[2]
+		if operator == "" {
+			operator = "="
+		}
+		clauses = append(clauses, constraintClause{operator: operator, version: m[4]})
+	}
+	return clauses, nil
+}
+
+// satisfies reports whether version meets every clause. Prereleases only
+// satisfy exact clauses that name them, as in Terraform itself.
+func satisfies(clauses []constraintClause, version string) bool {
+	v := normalizeVersion(version)
+	if !semver.IsValid(v) {
+		return false
+	}
+	for _, c := range clauses {
+		cv := normalizeVersion(c.version)
+		if isPrerelease(version) && (c.operator != "=" || semver.Compare(v, cv) != 0) {
+			return false
+		}
+		cmp := semver.Compare(v, cv)
+		var ok bool
+		switch c.operator {
+		case "=":
+			ok = cmp == 0
+		case "!=":
+			ok = cmp != 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case "~>":
+			upper := pessimisticUpperBound(c.version)
+			ok = cmp >= 0 && (upper == "" || semver.Compare(v, upper) < 0)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// pessimisticUpperBound returns the exclusive upper bound of a "~>" clause:
+// the rightmost written component may grow, so "~> 2.1" stops before 3 and
+// "~> 2.1.0" before 2.2. A single component ("~> 2") has no upper bound,
+// reported as "".
+func pessimisticUpperBound(version string) string {
+	parts := strings.Split(strings.SplitN(strings.TrimPrefix(version, "v"), "-", 2)[0], ".")
+	if len(parts) < 2 { //nolint:mnd // a bound needs at least major.minor
+		return ""
+	}
+	upper := parts[:len(parts)-1]
+	last, _ := strconv.Atoi(upper[len(upper)-1])
+	upper[len(upper)-1] = strconv.Itoa(last + 1)
+	return normalizeVersion(strings.Join(upper, "."))
+}
+
+// latestSatisfying returns the highest stable version allowed by the
+// constraint, or "" when none is.
+func latestSatisfying(constraint string, versions []string) (string, error) {
+	clauses, err := parseConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	for _, v := range filterStableVersions(versions) {
+		if satisfies(clauses, v) && (latest == "" || isNewerVersion(latest, v)) {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+// advanceConstraint raises the lower bounds ("~>" and ">=") of a constraint
+// to version, truncated to the precision already written, so "~> 2.1"
+// becomes "~> 2.9" when 2.9.3 is the latest match and still excludes 3.0.
+// Operators, spacing, upper bounds and exact pins are preserved, and bounds
+// are never lowered.
+func advanceConstraint(constraint, version string) string {
+	raw := strings.Split(constraint, ",")
+	for i, clause := range raw {
+		m := constraintClausePattern.FindStringSubmatch(clause)
+		if m == nil || (m[2] != "~>" && m[2] != ">=") || isPrerelease(m[4]) {
+			continue
+		}
+		precision := len(strings.Split(strings.TrimPrefix(m[4], "v"), "."))
+		parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+		if len(parts) > precision {
+			parts = parts[:precision]
+		}
+		target := strings.Join(parts, ".")
+		if strings.HasPrefix(m[4], "v") {
+			target = "v" + target
+		}
+		if !isNewerVersion(m[4], target) {
+			continue
+		}
+		raw[i] = m[1] + m[2] + m[3] + target + m[5]
+	}
+	return strings.Join(raw, ",")
+}
@@ -0,0 +1,166 @@
+//go:build unit
+
+package terraform_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/terraform"
+)
+
+func TestLatestSatisfying(t *testing.T) {
+	t.Parallel()
+
+	versions := []string{"2.0.0", "2.1.0", "2.1.4", "2.9.3", "3.0.0", "3.1.0-beta1", "1.8.0"}
+
+	tests := []struct {
+		name       string
+		constraint string
+		expected   string
+	}{
+		{
+			name:       "should select the latest 2.x for ~> 2.1 without crossing to 3.0",
+			constraint: "~> 2.1",
+			expected:   "2.9.3",
+		},
+		{
+			name:       "should stay within the minor version for a three-part ~> constraint",
+			constraint: "~> 2.1.0",
+			expected:   "2.1.4",
+		},
+		{
+			name:       "should select the latest stable version for a lower bound",
+			constraint: ">= 2.0",
+			expected:   "3.0.0",
+		},
+		{
+			name:       "should respect both ends of a range",
+			constraint: ">= 1.5, < 2.5",
+			expected:   "2.1.4",
+		},
+		{
+			name:       "should honour exclusions",
+			constraint: "~> 2.1, != 2.9.3",
+			expected:   "2.1.4",
+		},
+		{
+			name:       "should keep an exact pin",
+			constraint: "2.1.0",
+			expected:   "2.1.0",
+		},
+		{
+			name:       "should return empty when no version matches",
+			constraint: "~> 4.0",
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			result, err := terraform.LatestSatisfying(tt.constraint, versions)
+
+			// then
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("should reject an invalid constraint", func(t *testing.T) {
+		t.Parallel()
+
+		// when
+		_, err := terraform.LatestSatisfying("~> latest", versions)
+
+		// then
+		require.Error(t, err)
+	})
+}
+
+func TestAdvanceConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		expected   string
+	}{
+		{
+			name:       "should advance a pessimistic bound keeping its precision",
+			constraint: "~> 2.1",
+			version:    "2.9.3",
+			expected:   "~> 2.9",
+		},
+		{
+			name:       "should advance a three-part pessimistic bound",
+			constraint: "~> 2.1.0",
+			version:    "2.1.4",
+			expected:   "~> 2.1.4",
+		},
+		{
+			name:       "should advance only the lower bound of a range",
+			constraint: ">= 1.5, < 2.5",
+			version:    "2.1.4",
+			expected:   ">= 2.1, < 2.5",
+		},
+		{
+			name:       "should leave an exact pin untouched",
+			constraint: "= 2.1.0",
+			version:    "2.1.0",
+			expected:   "= 2.1.0",
+		},
+		{
+			name:       "should never lower a bound",
+			constraint: "~> 2.9",
+			version:    "2.9.0",
+			expected:   "~> 2.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			result := terraform.AdvanceConstraint(tt.constraint, tt.version)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestIsRegistryModule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		source   string
+		expected bool
+	}{
+		{name: "should accept a public registry address", source: "terraform-aws-modules/vpc/aws", expected: true},
+		{name: "should accept a private registry address", source: "app.terraform.io/org/net/azurerm", expected: true},
+		{name: "should accept a subdirectory", source: "hashicorp/consul/aws//modules/consul-cluster", expected: true},
+		{name: "should reject a git source", source: "git::https://github.com/org/mod.git", expected: false},
+		{name: "should reject a github shorthand", source: "github.com/org/mod", expected: false},
+		{name: "should reject a local path", source: "../modules/net/aws", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// when
+			result := terraform.IsRegistryModule(tt.source)
+
+			// then
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+	"github.com/rios0rios0/autoupdate/internal/support"
 )
 
 // --- Exported types ---
@@ -23,8 +24,9 @@ type UpgradeTask = upgradeTask
 // --- Exported constants ---
 
 const (
-	DepKindModule = depKindModule
-	DepKindImage  = depKindImage
+	DepKindModule   = depKindModule
+	DepKindImage    = depKindImage
+	DepKindRegistry = depKindRegistry
 )
 
 // --- Exported constructors ---
@@ -75,6 +77,11 @@ func ExtractRepoName(source string) string {
 	return extractRepoName(source)
 }
 
+// IsSameVersion is exported for testing.
+func IsSameVersion(a, b string) bool {
+	return isSameVersion(a, b)
+}
+
 // IsNewerVersion is exported for testing.
 func IsNewerVersion(current, newVersion string) bool {
 	return isNewerVersion(current, newVersion)
@@ -85,6 +92,16 @@ func NormalizeVersion(version string) string {
 	return normalizeVersion(version)
 }
 
+// FilterTagsMatching is exported for testing.
+func FilterTagsMatching(tags []string, pattern string) []string {
+	return filterTagsMatching(tags, pattern)
+}
+
+// FilterStableVersions is exported for testing.
+func FilterStableVersions(tags []string) []string {
+	return filterStableVersions(tags)
+}
+
 // ApplyVersionUpgrade is exported for testing.
 func ApplyVersionUpgrade(content string, dep entities.Dependency, newVersion string) string {
 	return applyVersionUpgrade(content, dep, newVersion)
@@ -110,6 +127,11 @@ func ScanWithRegex(content, filePath string) []entities.Dependency {
 	return scanWithRegex(content, filePath)
 }
 
+// ScanLocalModuleComments is exported for testing.
+func ScanLocalModuleComments(content, filePath string) []entities.Dependency {
+	return scanLocalModuleComments(content, filePath)
+}
+
 // ScanHCLFile is exported for testing.
 func ScanHCLFile(content, filePath string) []entities.Dependency {
 	return scanHCLFile(content, filePath)
@@ -125,9 +147,14 @@ func GenerateBranchName(tasks []upgradeTask) string {
 	return generateBranchName(tasks)
 }
 
-// GenerateCommitMessage is exported for testing.
-func GenerateCommitMessage(tasks []upgradeTask) string {
-	return generateCommitMessage(tasks)
+// GenerateCommitMessage is exported for testing. The optional options
+// supply the commit type and scope; none means the defaults.
+func GenerateCommitMessage(tasks []upgradeTask, opts ...entities.UpdateOptions) string {
+	var options entities.UpdateOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	return generateCommitMessage(tasks, options.CommitPrefix())
 }
 
 // GeneratePRTitle is exported for testing.
@@ -152,8 +179,14 @@ func AppendChangelogEntry(
 	repo entities.Repository,
 	upgrades []upgradeTask,
 	fileChanges []entities.FileChange,
+	settings ...entities.ChangelogSettings,
 ) []entities.FileChange {
-	return appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelogSettings := entities.ChangelogSettings{}
+	if len(settings) > 0 {
+		changelogSettings = settings[0]
+	}
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, changelogSettings)
+	return support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, "")
 }
 
 // StripVersionPrefix is exported for testing.
@@ -182,8 +215,27 @@ func UpgradeTaskNewVersion(t UpgradeTask) string {
 }
 
 // LocalScanAllDependencies is exported for testing.
-func LocalScanAllDependencies(u *UpdaterRepository, repoDir string) []DepWithContent {
-	return u.localScanAllDependencies(repoDir)
+func LocalScanAllDependencies(
+	u *UpdaterRepository,
+	repoDir string,
+	opts ...entities.UpdateOptions,
+) []DepWithContent {
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return u.localScanAllDependencies(repoDir, o)
+}
+
+// ScanAllDependencies is exported for testing.
+func ScanAllDependencies(
+	ctx context.Context,
+	u *UpdaterRepository,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	opts entities.UpdateOptions,
+) []DepWithContent {
+	return u.scanAllDependencies(ctx, provider, repo, opts)
 }
 
 // DetermineUpgrades is exported for testing.
@@ -193,8 +245,13 @@ func DetermineUpgrades(
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	allDeps []DepWithContent,
+	opts ...entities.UpdateOptions,
 ) []UpgradeTask {
-	return u.determineUpgrades(ctx, provider, repo, allDeps)
+	var o entities.UpdateOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	return u.determineUpgrades(ctx, provider, repo, allDeps, o)
 }
 
 // CreateUpgradePR is exported for testing.
@@ -215,6 +272,35 @@ func ResolveTagsForSource(
 	provider repositories.ProviderRepository,
 	currentRepo entities.Repository,
 	source string,
+	imageSources map[string]string,
 ) ([]string, *entities.Repository) {
-	return resolveTagsForSource(ctx, provider, currentRepo, source)
+	return resolveTagsForSource(ctx, provider, currentRepo, source, imageSources)
+}
+
+// LatestSatisfying is exported for testing.
+func LatestSatisfying(constraint string, versions []string) (string, error) {
+	return latestSatisfying(constraint, versions)
+}
+
+// AdvanceConstraint is exported for testing.
+func AdvanceConstraint(constraint, version string) string {
+	return advanceConstraint(constraint, version)
+}
+
+// ScanRegistryModules is exported for testing.
+func ScanRegistryModules(content, filePath string) []entities.Dependency {
+	return scanRegistryModules(content, filePath)
+}
+
+// IsRegistryModule is exported for testing.
+func IsRegistryModule(source string) bool {
+	return isRegistryModule(source)
+}
+
+// SetFetchRegistryVersionsFunc overrides the registry version fetching
+// function for testing. It returns a function that restores the original.
+func SetFetchRegistryVersionsFunc(fn func(ctx context.Context, source string) ([]string, error)) func() {
+	original := fetchRegistryVersionsFunc
+	fetchRegistryVersionsFunc = fn
+	return func() { fetchRegistryVersionsFunc = original }
 }
@@ -0,0 +1,162 @@
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	registryTimeout     = 15 * time.Second
+	defaultRegistryHost = "registry.terraform.io"
+	// defaultModulesPath is the modules.v1 service path of the public
+	// registry; other hosts advertise theirs through service discovery.
+	defaultModulesPath = "/v1/modules/"
+)
+
+// registrySourcePattern matches a module registry address:
+// [<host>/]<namespace>/<name>/<system>, optionally followed by a
+// "//subdirectory".
+var registrySourcePattern = regexp.MustCompile(
+	`^(?:([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+(?::\d+)?)/)?` +
+		`([A-Za-z0-9][A-Za-z0-9_-]*)/([A-Za-z0-9][A-Za-z0-9_-]*)/([A-Za-z0-9][A-Za-z0-9_-]*)(?://.*)?$`,
+)
+
+// registryAddress identifies a module in a Terraform module registry.
+type registryAddress struct {
+	Host      string
+	Namespace string
+	Name      string
+	System    string
+}
+
+// parseRegistrySource parses a registry module source such as
+// "terraform-aws-modules/vpc/aws" or "app.terraform.io/org/net/azurerm".
+// Git and local sources are rejected.
+func parseRegistrySource(source string) (registryAddress, bool) {
+	if isGitModule(source) || isLocalModule(source) {
+		return registryAddress{}, false
+	}
+	m := registrySourcePattern.FindStringSubmatch(source)
+	if m == nil {
+		return registryAddress{}, false
+	}
+	host := m[1]
+	if host == "" {
+		host = defaultRegistryHost
+	}
+	return registryAddress{Host: host, Namespace: m[2], Name: m[3], System: m[4]}, true
+}
+
+// isRegistryModule reports whether a module source is a registry address.
+func isRegistryModule(source string) bool {
+	_, ok := parseRegistrySource(source)
+	return ok
+}
+
+// --- registry API ---
+
+type registryVersionsResponse struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+type serviceDiscoveryResponse struct {
+	ModulesV1 string `json:"modules.v1"`
+}
+
+// fetchRegistryVersionsFunc is the function used to list the published
+// versions of a registry module. It defaults to fetchRegistryVersions and
+// can be overridden in tests.
+var fetchRegistryVersionsFunc = fetchRegistryVersions //nolint:gochecknoglobals // test override for DI
+
+// fetchRegistryVersions lists the versions published for a registry module
+// through the registry's modules.v1 API.
+func fetchRegistryVersions(ctx context.Context, source string) ([]string, error) {
+	addr, ok := parseRegistrySource(source)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a registry module source", source)
+	}
+
+	client := &http.Client{Timeout: registryTimeout}
+	base, err := modulesServiceURL(ctx, client, addr.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s%s/%s/%s/versions", base, addr.Namespace, addr.Name, addr.System)
+	var versionsResp registryVersionsResponse
+	if getErr := getJSON(ctx, client, apiURL, &versionsResp); getErr != nil {
+		return nil, fmt.Errorf("failed to fetch versions of %s: %w", source, getErr)
+	}
+
+	var versions []string
+	for _, module := range versionsResp.Modules {
+		for _, v := range module.Versions {
+			versions = append(versions, v.Version)
+		}
+	}
+	return versions, nil
+}
+
+// modulesServiceURL returns the absolute modules.v1 endpoint of a registry
+// host, ending in "/". The public registry is known; other hosts are asked
+// through `/.well-known/terraform.json`.
+func modulesServiceURL(ctx context.Context, client *http.Client, host string) (string, error) {
+	hostURL := "https://" + host
+	if host == defaultRegistryHost {
+		return hostURL + defaultModulesPath, nil
+	}
+
+	var discovery serviceDiscoveryResponse
+	if err := getJSON(ctx, client, hostURL+"/.well-known/terraform.json", &discovery); err != nil {
+		return "", fmt.Errorf("failed to discover the module registry of %s: %w", host, err)
+	}
+	if discovery.ModulesV1 == "" {
+		return "", fmt.Errorf("%s does not advertise a module registry", host)
+	}
+
+	base, err := url.Parse(hostURL + "/")
+	if err != nil {
+		return "", fmt.Errorf("invalid registry host %q: %w", host, err)
+	}
+	ref, err := url.Parse(discovery.ModulesV1)
+	if err != nil {
+		return "", fmt.Errorf("invalid modules.v1 service %q: %w", discovery.ModulesV1, err)
+	}
+	resolved := base.ResolveReference(ref).String()
+	if !strings.HasSuffix(resolved, "/") {
+		resolved += "/"
+	}
+	return resolved, nil
+}
+
+// getJSON fetches apiURL and decodes its JSON body into target.
+func getJSON(ctx context.Context, client *http.Client, apiURL string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(target); decodeErr != nil {
+		return fmt.Errorf("failed to parse response: %w", decodeErr)
+	}
+	return nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclparse"
@@ -26,15 +29,26 @@ const (
 	maxDetailedUpgrades = 5
 	branchBatchFmt      = "chore/upgrade-%d-dependencies"
 	branchSingleFmt     = "chore/upgrade-%s-%s"
+
+	// defaultTagResolutionWorkers bounds concurrent tag lookups when the
+	// updater config does not set a concurrency.
+	defaultTagResolutionWorkers = 8
+
+	// defaultFileFetchWorkers bounds concurrent file content fetches when
+	// the updater config does not set a concurrency.
+	defaultFileFetchWorkers = 8
 )
 
 // depKind distinguishes Terraform module references (in .tf files) from
-// container image references (in .hcl / Terragrunt files).
+// container image references (in .hcl / Terragrunt and .tfvars files).
 type depKind int
 
 const (
 	depKindModule depKind = iota
 	depKindImage
+	// depKindRegistry is a registry module whose `version` attribute holds
+	// a constraint rather than a single version.
+	depKindRegistry
 )
 
 // UpdaterRepository implements repositories.UpdaterRepository for Terraform module dependencies.
@@ -49,7 +63,18 @@ func NewUpdaterRepository() repositories.UpdaterRepository {
 
 func (u *UpdaterRepository) Name() string { return updaterName }
 
-// Detect returns true if the repository contains Terraform marker files (e.g. *.tf, *.hcl).
+// Description summarizes what this updater upgrades.
+func (u *UpdaterRepository) Description() string {
+	return "Terraform module refs and container image tags in .tfvars"
+}
+
+// DetectionFiles returns the files or globs Detect looks for.
+func (u *UpdaterRepository) DetectionFiles() []string {
+	return append((&langTerraform.Detector{}).DetectionFiles(), "*.tfvars")
+}
+
+// Detect returns true if the repository contains Terraform marker files
+// (e.g. *.tf, *.hcl) or .tfvars files pinning container images.
 func (u *UpdaterRepository) Detect(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
@@ -60,7 +85,17 @@ func (u *UpdaterRepository) Detect(
 		logger.Warnf("[terraform] detection error for %s/%s: %v", repo.Organization, repo.Name, err)
 		return false
 	}
-	return found
+	if found {
+		return true
+	}
+
+	tfvarsFiles, listErr := provider.ListFiles(ctx, repo, ".tfvars")
+	if listErr != nil {
+		logger.Warnf("[terraform] Failed to list .tfvars files for %s/%s: %v",
+			repo.Organization, repo.Name, listErr)
+		return false
+	}
+	return len(tfvarsFiles) > 0
 }
 
 // CreateUpdatePRs scans for outdated Terraform module dependencies,
@@ -76,12 +111,12 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 		repo.Organization, repo.Name,
 	)
 
-	allDeps := u.scanAllDependencies(ctx, provider, repo)
+	allDeps := u.scanAllDependencies(ctx, provider, repo, opts)
 	if len(allDeps) == 0 {
 		return []entities.PullRequest{}, nil
 	}
 
-	upgrades := u.determineUpgrades(ctx, provider, repo, allDeps)
+	upgrades := u.determineUpgrades(ctx, provider, repo, allDeps, opts)
 	if len(upgrades) == 0 {
 		logger.Infof(
 			"[terraform] %s/%s: all Terraform dependencies up to date",
@@ -108,6 +143,22 @@ func (u *UpdaterRepository) CreateUpdatePRs(
 	return u.createUpgradePR(ctx, provider, repo, opts, upgrades)
 }
 
+// PlanUpdates implements repositories.UpdatePlanner for dry runs. It scans
+// the repository through the provider API and returns the module and image
+// upgrades that CreateUpdatePRs or ApplyUpdates would make.
+func (u *UpdaterRepository) PlanUpdates(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	opts entities.UpdateOptions,
+) ([]entities.DependencyChange, error) {
+	allDeps := u.scanAllDependencies(ctx, provider, repo, opts)
+	if len(allDeps) == 0 {
+		return []entities.DependencyChange{}, nil
+	}
+	return collectChanges(u.determineUpgrades(ctx, provider, repo, allDeps, opts)), nil
+}
+
 // ApplyUpdates implements repositories.LocalUpdater for the clone-based pipeline.
 // It scans the local filesystem for Terraform dependencies, determines upgrades
 // using the provider API for tag resolution, writes changes to disk, and returns
@@ -117,17 +168,17 @@ func (u *UpdaterRepository) ApplyUpdates(
 	repoDir string,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
-	_ entities.UpdateOptions,
+	opts entities.UpdateOptions,
 ) (*repositories.LocalUpdateResult, error) {
 	logger.Infof("[terraform] Scanning local clone of %s/%s for Terraform dependencies",
 		repo.Organization, repo.Name)
 
-	allDeps := u.localScanAllDependencies(repoDir)
+	allDeps := u.localScanAllDependencies(repoDir, opts)
 	if len(allDeps) == 0 {
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
 
-	upgrades := u.determineUpgrades(ctx, provider, repo, allDeps)
+	upgrades := u.determineUpgrades(ctx, provider, repo, allDeps, opts)
 	if len(upgrades) == 0 {
 		return nil, repositories.ErrNoUpdatesNeeded
 	}
@@ -140,170 +191,257 @@ func (u *UpdaterRepository) ApplyUpdates(
 		return nil, err
 	}
 
-	entries := make([]string, 0, len(upgrades))
-	for _, up := range upgrades {
-		label := "Terraform module"
-		if up.kind == depKindImage {
-			label = "container image"
-		}
-		entries = append(entries, fmt.Sprintf(
-			"- changed the %s `%s` from `%s` to `%s`",
-			label, extractRepoName(up.dep.Source), up.dep.CurrentVer, up.newVersion,
-		))
-	}
-	support.LocalChangelogUpdate(repoDir, entries)
-
+	changes := collectChanges(upgrades)
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, changes), generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	return &repositories.LocalUpdateResult{
-		BranchName:    generateBranchName(upgrades),
-		CommitMessage: generateCommitMessage(upgrades),
-		PRTitle:       generatePRTitle(upgrades),
-		PRDescription: generatePRDescription(upgrades),
+		BranchName:       generateBranchName(upgrades),
+		CommitMessage:    generateCommitMessage(upgrades, opts.CommitPrefix()),
+		PRTitle:          prTitle,
+		PRDescription:    prDescription,
+		Changes:          changes,
+		ChangelogEntries: changelogEntries(upgrades),
 	}, nil
 }
 
-// localScanAllDependencies walks the local filesystem for .tf and .hcl files
-// and parses them for module and container image dependencies.
-func (u *UpdaterRepository) localScanAllDependencies(repoDir string) []depWithContent {
-	var allDeps []depWithContent
-
-	tfFiles, err := support.WalkFilesByExtension(repoDir, ".tf")
-	if err != nil {
-		logger.Warnf("[terraform] Failed to walk .tf files: %v", err)
+// collectChanges converts the applied upgrades into changes report entries.
+func collectChanges(tasks []upgradeTask) []entities.DependencyChange {
+	changes := make([]entities.DependencyChange, 0, len(tasks))
+	for _, t := range tasks {
+		changes = append(changes, entities.DependencyChange{
+			Ecosystem: updaterName,
+			Name:      t.dep.Name,
+			From:      t.dep.CurrentVer,
+			To:        t.newVersion,
+			File:      t.dep.FilePath,
+		})
 	}
-	for _, relPath := range tfFiles {
-		data, readErr := os.ReadFile(filepath.Join(repoDir, relPath))
-		if readErr != nil {
-			logger.Warnf("[terraform] Failed to read %s: %v", relPath, readErr)
-			continue
-		}
-		content := string(data)
-		deps := scanTerraformFile(content, relPath)
-		for _, dep := range deps {
-			allDeps = append(allDeps, depWithContent{
-				Dependency:  dep,
-				FileContent: content,
-				Kind:        depKindModule,
-			})
+	return changes
+}
+
+// scanTarget pairs a file extension with the scanner used for files of that
+// type and the kind of dependency it produces.
+type scanTarget struct {
+	ext  string
+	scan func(content, filePath string) []entities.Dependency
+	kind depKind
+}
+
+// scanTargets lists the file types the updater inspects: module references
+// in .tf files, and container image pins in Terragrunt .hcl files and
+// .tfvars files (which share the same `key = "name:tag"` syntax). When
+// opts.UpgradeLocalComments is set, .tf files are also scanned for version
+// comments on local-path module sources, and when opts.UpgradeRegistryModules
+// is set, .tf and Terragrunt .hcl files are scanned for registry modules
+// with a version constraint.
+func scanTargets(opts entities.UpdateOptions) []scanTarget {
+	scanModules := scanTerraformFile
+	if opts.UpgradeLocalComments {
+		scanModules = func(content, filePath string) []entities.Dependency {
+			return append(scanTerraformFile(content, filePath), scanLocalModuleComments(content, filePath)...)
 		}
 	}
 
-	hclFiles, hclErr := support.WalkFilesByExtension(repoDir, ".hcl")
-	if hclErr != nil {
-		logger.Warnf("[terraform] Failed to walk .hcl files: %v", hclErr)
+	targets := []scanTarget{
+		{ext: ".tf", scan: scanModules, kind: depKindModule},
+		{ext: ".hcl", scan: scanHCLFile, kind: depKindImage},
+		{ext: ".tfvars", scan: scanHCLFile, kind: depKindImage},
 	}
-	for _, relPath := range hclFiles {
-		data, readErr := os.ReadFile(filepath.Join(repoDir, relPath))
-		if readErr != nil {
-			logger.Warnf("[terraform] Failed to read %s: %v", relPath, readErr)
-			continue
+	if opts.UpgradeRegistryModules {
+		targets = append(targets,
+			scanTarget{ext: ".tf", scan: scanRegistryModules, kind: depKindRegistry},
+			scanTarget{ext: ".hcl", scan: scanRegistryModules, kind: depKindRegistry},
+		)
+	}
+	return targets
+}
+
+// localScanAllDependencies walks the local filesystem for .tf, .hcl and
+// .tfvars files and parses them for module and container image
+// dependencies. Files outside the configured opts.Paths or inside excluded
+// directories are skipped.
+func (u *UpdaterRepository) localScanAllDependencies(
+	repoDir string,
+	opts entities.UpdateOptions,
+) []depWithContent {
+	var allDeps []depWithContent
+
+	for _, target := range scanTargets(opts) {
+		files, err := support.WalkFilesByExtension(repoDir, target.ext)
+		if err != nil {
+			logger.Warnf("[terraform] Failed to walk %s files: %v", target.ext, err)
 		}
-		content := string(data)
-		deps := scanHCLFile(content, relPath)
-		for _, dep := range deps {
-			allDeps = append(allDeps, depWithContent{
-				Dependency:  dep,
-				FileContent: content,
-				Kind:        depKindImage,
-			})
+		for _, relPath := range files {
+			if !opts.IsPathInScope(relPath) || opts.IsPathExcluded(relPath) {
+				continue
+			}
+			data, readErr := os.ReadFile(filepath.Join(repoDir, relPath))
+			if readErr != nil {
+				logger.Warnf("[terraform] Failed to read %s: %v", relPath, readErr)
+				continue
+			}
+			content := string(data)
+			for _, dep := range target.scan(content, relPath) {
+				allDeps = append(allDeps, depWithContent{
+					Dependency:  dep,
+					FileContent: content,
+					Kind:        target.kind,
+				})
+			}
 		}
 	}
 
 	return allDeps
 }
 
-// scanAllDependencies lists .tf and .hcl files and parses them for
-// module dependencies (from .tf) and container image references (from .hcl).
+// scanAllDependencies lists .tf, .hcl and .tfvars files and parses them for
+// module dependencies (from .tf) and container image references (from .hcl
+// and .tfvars). Files outside the configured opts.Paths or inside excluded
+// directories are skipped before being fetched. The contents are fetched
+// concurrently (see fetchFileContents), but every target is scanned in path
+// order, so the dependencies come out in the same order on every run.
 func (u *UpdaterRepository) scanAllDependencies(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
+	opts entities.UpdateOptions,
 ) []depWithContent {
-	var allDeps []depWithContent
+	targets := scanTargets(opts)
+	targetPaths := make([][]string, len(targets))
+	var paths []string
+	seen := make(map[string]bool)
+
+	for i, target := range targets {
+		files, err := provider.ListFiles(ctx, repo, target.ext)
+		if err != nil {
+			logger.Warnf("[terraform] Failed to list %s files: %v", target.ext, err)
+		}
 
-	// Scan .tf files for Terraform module references
-	tfFiles, err := provider.ListFiles(ctx, repo, ".tf")
-	if err != nil {
-		logger.Warnf("[terraform] Failed to list .tf files: %v", err)
+		for _, f := range files {
+			if f.IsDir || !opts.IsPathInScope(f.Path) || opts.IsPathExcluded(f.Path) {
+				continue
+			}
+			targetPaths[i] = append(targetPaths[i], f.Path)
+			if !seen[f.Path] {
+				seen[f.Path] = true
+				paths = append(paths, f.Path)
+			}
+		}
+		sort.Strings(targetPaths[i])
 	}
 
-	for _, f := range tfFiles {
-		if f.IsDir {
-			continue
-		}
-		content, contentErr := provider.GetFileContent(ctx, repo, f.Path)
-		if contentErr != nil {
-			logger.Warnf("[terraform] Failed to read %s: %v", f.Path, contentErr)
-			continue
-		}
+	contents := fetchFileContents(ctx, provider, repo, paths, opts.Concurrency)
 
-		deps := scanTerraformFile(content, f.Path)
-		for _, dep := range deps {
-			allDeps = append(allDeps, depWithContent{
-				Dependency:  dep,
-				FileContent: content,
-				Kind:        depKindModule,
-			})
+	var allDeps []depWithContent
+	for i, target := range targets {
+		for _, filePath := range targetPaths[i] {
+			content, ok := contents[filePath]
+			if !ok {
+				continue
+			}
+			for _, dep := range target.scan(content, filePath) {
+				allDeps = append(allDeps, depWithContent{
+					Dependency:  dep,
+					FileContent: content,
+					Kind:        target.kind,
+				})
+			}
 		}
 	}
 
-	// Scan .hcl files for container image references (Terragrunt)
-	hclFiles, hclErr := provider.ListFiles(ctx, repo, ".hcl")
-	if hclErr != nil {
-		logger.Warnf("[terraform] Failed to list .hcl files: %v", hclErr)
-	}
+	return allDeps
+}
 
-	for _, f := range hclFiles {
-		if f.IsDir {
-			continue
-		}
-		content, contentErr := provider.GetFileContent(ctx, repo, f.Path)
-		if contentErr != nil {
-			logger.Warnf("[terraform] Failed to read %s: %v", f.Path, contentErr)
-			continue
-		}
+// fetchFileContents fetches every path through the provider API with a
+// bounded pool of concurrency workers (defaultFileFetchWorkers when below
+// 1). Files that cannot be read are logged and left out of the result.
+func fetchFileContents(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	paths []string,
+	concurrency int,
+) map[string]string {
+	workers := concurrency
+	if workers < 1 {
+		workers = defaultFileFetchWorkers
+	}
 
-		deps := scanHCLFile(content, f.Path)
-		for _, dep := range deps {
-			allDeps = append(allDeps, depWithContent{
-				Dependency:  dep,
-				FileContent: content,
-				Kind:        depKindImage,
-			})
-		}
+	contents := make(map[string]string, len(paths))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	for range min(workers, len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for filePath := range jobs {
+				content, err := provider.GetFileContent(ctx, repo, filePath)
+				if err != nil {
+					logger.Warnf("[terraform] Failed to read %s: %v", filePath, err)
+					continue
+				}
+				mu.Lock()
+				contents[filePath] = content
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, filePath := range paths {
+		jobs <- filePath
 	}
+	close(jobs)
+	wg.Wait()
 
-	return allDeps
+	return contents
 }
 
 // determineUpgrades resolves tags and determines which deps need upgrading.
+// Sources listed in opts.TrackBranches target the tag of the configured
+// branch instead of the latest changelog-validated tag.
 func (u *UpdaterRepository) determineUpgrades(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	allDeps []depWithContent,
+	opts entities.UpdateOptions,
 ) []upgradeTask {
-	moduleVersions := make(map[string]resolvedSource)
+	var gitDeps, registryDeps []depWithContent
 	for _, dc := range allDeps {
-		src := dc.Dependency.Source
-		if _, ok := moduleVersions[src]; ok {
-			continue
-		}
-		tags, depRepo := resolveTagsForSource(ctx, provider, repo, src)
-		var latest string
-		if len(tags) > 0 {
-			latest = findLatestChangelogVersion(ctx, provider, depRepo, tags)
+		if dc.Kind == depKindRegistry {
+			registryDeps = append(registryDeps, dc)
+		} else {
+			gitDeps = append(gitDeps, dc)
 		}
-		moduleVersions[src] = resolvedSource{tags: tags, depRepo: depRepo, latestVersion: latest}
 	}
 
+	moduleVersions := resolveSources(ctx, provider, repo, gitDeps, opts)
+
 	var upgrades []upgradeTask
-	for _, dc := range allDeps {
-		resolved := moduleVersions[dc.Dependency.Source]
+	for _, dc := range gitDeps {
+		src := dc.Dependency.Source
+		resolved := moduleVersions[src]
 		if len(resolved.tags) == 0 {
 			continue
 		}
 		latestVersion := resolved.latestVersion
-		if dc.Dependency.CurrentVer == latestVersion {
+		if isPrerelease(dc.Dependency.CurrentVer) {
+			// a dependency already on a prerelease may move to a newer prerelease
+			if resolved.latestPrerelease == "" {
+				resolved.latestPrerelease = findLatestChangelogVersion(ctx, provider, resolved.depRepo, resolved.tags)
+				moduleVersions[src] = resolved
+			}
+			if isNewerVersion(latestVersion, resolved.latestPrerelease) {
+				latestVersion = resolved.latestPrerelease
+			}
+		}
+		latestVersion = capToApprovedVersion(dc.Dependency, resolved.tags, latestVersion, opts.MaxVersions)
+		latestVersion = capToCurrentMajor(dc.Dependency, resolved.tags, latestVersion, opts)
+		if latestVersion == "" {
+			continue
+		}
+		if isSameVersion(dc.Dependency.CurrentVer, latestVersion) {
 			continue
 		}
 		if !isNewerVersion(dc.Dependency.CurrentVer, latestVersion) {
@@ -317,6 +455,49 @@ func (u *UpdaterRepository) determineUpgrades(
 		})
 	}
 
+	return append(upgrades, determineRegistryUpgrades(ctx, registryDeps)...)
+}
+
+// determineRegistryUpgrades advances the version constraint of each registry
+// module to the latest published version it already allows, so `~> 2.1`
+// moves to `~> 2.9` but never to 3.0. Versions are fetched once per module.
+func determineRegistryUpgrades(ctx context.Context, deps []depWithContent) []upgradeTask {
+	versionCache := make(map[string][]string)
+	var upgrades []upgradeTask
+	for _, dc := range deps {
+		src := dc.Dependency.Source
+		versions, ok := versionCache[src]
+		if !ok {
+			fetched, err := fetchRegistryVersionsFunc(ctx, src)
+			if err != nil {
+				logger.Warnf("[terraform] Failed to fetch registry versions for %s: %v", src, err)
+			}
+			versions = fetched
+			versionCache[src] = versions
+		}
+		if len(versions) == 0 {
+			continue
+		}
+
+		latest, err := latestSatisfying(dc.Dependency.CurrentVer, versions)
+		if err != nil {
+			logger.Warnf("[terraform] Skipping module %q in %s: %v", dc.Dependency.Name, dc.Dependency.FilePath, err)
+			continue
+		}
+		if latest == "" {
+			continue
+		}
+		constraint := advanceConstraint(dc.Dependency.CurrentVer, latest)
+		if constraint == dc.Dependency.CurrentVer {
+			continue
+		}
+		upgrades = append(upgrades, upgradeTask{
+			dep:         dc.Dependency,
+			newVersion:  constraint,
+			fileContent: dc.FileContent,
+			kind:        dc.Kind,
+		})
+	}
 	return upgrades
 }
 
@@ -344,28 +525,33 @@ func (u *UpdaterRepository) createUpgradePR(
 	}
 
 	fileChanges := applyUpgrades(upgrades)
-	fileChanges = appendChangelogEntry(ctx, provider, repo, upgrades, fileChanges)
+	changelog := buildChangelogChange(ctx, provider, repo, upgrades, opts.Changelog)
 
 	targetBranch := repo.DefaultBranch
 	if opts.TargetBranch != "" {
 		targetBranch = "refs/heads/" + opts.TargetBranch
 	}
 
+	fileChanges = support.ReconcileChangelogChange(ctx, provider, repo, fileChanges, changelog, opts.ChangelogConflict)
 	err := provider.CreateBranchWithChanges(ctx, repo, entities.BranchInput{
 		BranchName:    branchName,
 		BaseBranch:    targetBranch,
 		Changes:       fileChanges,
-		CommitMessage: generateCommitMessage(upgrades),
+		CommitMessage: generateCommitMessage(upgrades, opts.CommitPrefix()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create branch: %w", err)
 	}
 
+	prTitle, prDescription := opts.RenderPRText(
+		entities.NewPRTemplateData(updaterName, collectChanges(upgrades)),
+		generatePRTitle(upgrades), generatePRDescription(upgrades),
+	)
 	pr, createErr := provider.CreatePullRequest(ctx, repo, entities.PullRequestInput{
 		SourceBranch: "refs/heads/" + branchName,
 		TargetBranch: targetBranch,
-		Title:        generatePRTitle(upgrades),
-		Description:  generatePRDescription(upgrades),
+		Title:        prTitle,
+		Description:  entities.ApplyPRFooter(prDescription, opts.PRFooter),
 		AutoComplete: opts.AutoComplete,
 	})
 	if createErr != nil {
@@ -376,9 +562,29 @@ func (u *UpdaterRepository) createUpgradePR(
 		"[terraform] Created PR #%d for %s/%s: %s",
 		pr.ID, repo.Organization, repo.Name, pr.URL,
 	)
+
+	// Stale PRs are only closed once their replacement exists, so a failed
+	// creation never leaves the dependency without an open upgrade.
+	if opts.SupersedeStale && len(upgrades) == 1 {
+		support.CloseStalePullRequests(ctx, provider, repo, func(branch string) bool {
+			return isSupersededBranch(branch, branchName, extractRepoName(upgrades[0].dep.Source))
+		})
+	}
 	return []entities.PullRequest{*pr}, nil
 }
 
+// isSupersededBranch reports whether branch is an older single-upgrade
+// branch of the same dependency as current, i.e. `chore/upgrade-<name>-`
+// followed by another version. Branches of dependencies whose name merely
+// starts with the same text (vpc vs vpc-endpoints) do not match.
+func isSupersededBranch(branch, current, depName string) bool {
+	if branch == current {
+		return false
+	}
+	version, ok := strings.CutPrefix(branch, fmt.Sprintf(branchSingleFmt, depName, ""))
+	return ok && isSemverLike(version)
+}
+
 // --- internal types ---
 
 type depWithContent struct {
@@ -463,6 +669,57 @@ func scanTerraformFile(content, filePath string) []entities.Dependency {
 	return deps
 }
 
+// scanRegistryModules finds module blocks whose source is a registry address
+// and whose `version` attribute is a constraint, e.g.
+// source = "terraform-aws-modules/vpc/aws" with version = "~> 5.1". The
+// constraint is reported as the current version.
+func scanRegistryModules(content, filePath string) []entities.Dependency {
+	file, diags := hclparse.NewParser().ParseHCL([]byte(content), filePath)
+	if diags.HasErrors() || file == nil || file.Body == nil {
+		return nil
+	}
+	bodyContent, _, partialDiags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "module", LabelNames: []string{"name"}},
+		},
+	})
+	if partialDiags.HasErrors() {
+		return nil
+	}
+
+	var deps []entities.Dependency
+	for _, block := range bodyContent.Blocks {
+		attrs, _ := block.Body.JustAttributes()
+		source := stringAttribute(attrs, "source")
+		version := stringAttribute(attrs, "version")
+		if version == "" || !isRegistryModule(source) {
+			continue
+		}
+		deps = append(deps, entities.Dependency{
+			Name:       block.Labels[0],
+			Source:     source,
+			CurrentVer: version,
+			FilePath:   filePath,
+			Line:       block.DefRange.Start.Line,
+		})
+	}
+	return deps
+}
+
+// stringAttribute returns the literal string value of an HCL attribute, or
+// "" when it is missing or not a constant string.
+func stringAttribute(attrs hcl.Attributes, name string) string {
+	attr, ok := attrs[name]
+	if !ok {
+		return ""
+	}
+	val, diags := attr.Expr.Value(&hcl.EvalContext{})
+	if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+		return ""
+	}
+	return val.AsString()
+}
+
 func scanWithRegex(content, filePath string) []entities.Dependency {
 	var deps []entities.Dependency
 
@@ -503,10 +760,42 @@ func scanWithRegex(content, filePath string) []entities.Dependency {
 	return deps
 }
 
-// scanHCLFile parses a Terragrunt .hcl file for container image references.
+// localCommentPattern matches a local-path module source followed by a
+// version comment, e.g. source = "../modules/net" # ref v1.2.3
+var localCommentPattern = regexp.MustCompile(
+	`(?m)^\s*source\s*=\s*"(\.{1,2}/[^"]+)"\s*#\s*ref\s+(\S+)`,
+)
+
+// scanLocalModuleComments finds vendored modules referenced by a local path
+// whose upstream version is tracked in a trailing `# ref` comment. The
+// module's repository name is the last segment of the local path.
+func scanLocalModuleComments(content, filePath string) []entities.Dependency {
+	var deps []entities.Dependency
+
+	for _, match := range localCommentPattern.FindAllStringSubmatchIndex(content, -1) {
+		source := content[match[2]:match[3]]
+		version := content[match[4]:match[5]]
+		if !isSemverLike(version) {
+			continue
+		}
+
+		deps = append(deps, entities.Dependency{
+			Name:       extractRepoName(source),
+			Source:     source,
+			CurrentVer: version,
+			FilePath:   filePath,
+			Line:       strings.Count(content[:match[0]], "\n") + 1,
+		})
+	}
+
+	return deps
+}
+
+// scanHCLFile parses a Terragrunt .hcl or .tfvars file for container image references.
 // It detects patterns like: relayer_http_image = "relayer-http:0.7.0"
 // where the image name corresponds to a repository in the same organisation
-// and the tag after the colon is a Git tag / semver version.
+// and the tag after the colon is a Git tag / semver version. Images split
+// into a name and a tag variable are picked up by scanSplitImageVars.
 func scanHCLFile(content, filePath string) []entities.Dependency {
 	var deps []entities.Dependency
 
@@ -537,9 +826,58 @@ func scanHCLFile(content, filePath string) []entities.Dependency {
 		})
 	}
 
+	return append(deps, scanSplitImageVars(content, filePath)...)
+}
+
+// scanSplitImageVars detects container images whose name and tag live in
+// separate variables sharing a prefix, such as image_name = "relayer-http"
+// with image_tag = "0.7.0", or relayer_image = "relayer-http" with
+// relayer_tag = "0.7.0". The dependency is named after the tag variable,
+// which is the only one applyImageVersionUpgrade rewrites.
+func scanSplitImageVars(content, filePath string) []entities.Dependency {
+	namePattern := regexp.MustCompile(`\b(\w*image(?:_name)?)\s*=\s*"([a-zA-Z0-9][a-zA-Z0-9._-]*)"`)
+	tagPattern := regexp.MustCompile(`\b(\w*tag)\s*=\s*"([^"]+)"`)
+
+	imageByPrefix := make(map[string]string)
+	for _, match := range namePattern.FindAllStringSubmatch(content, -1) {
+		prefix := splitImageVarPrefix(match[1], "image_name", "image")
+		imageByPrefix[prefix] = match[2]
+	}
+	if len(imageByPrefix) == 0 {
+		return nil
+	}
+
+	var deps []entities.Dependency
+	for _, idx := range tagPattern.FindAllStringSubmatchIndex(content, -1) {
+		varName := content[idx[2]:idx[3]]
+		version := content[idx[4]:idx[5]]
+		imageName, ok := imageByPrefix[splitImageVarPrefix(varName, "image_tag", "tag")]
+		if !ok || !isSemverLike(version) {
+			continue
+		}
+		deps = append(deps, entities.Dependency{
+			Name:       varName,
+			Source:     imageName,
+			CurrentVer: version,
+			FilePath:   filePath,
+			Line:       strings.Count(content[:idx[0]], "\n") + 1,
+		})
+	}
 	return deps
 }
 
+// splitImageVarPrefix strips the first matching suffix (and the underscore
+// before it) from a variable name, so relayer_image_tag, relayer_tag and
+// relayer_image all share the prefix "relayer".
+func splitImageVarPrefix(varName string, suffixes ...string) string {
+	for _, suffix := range suffixes {
+		if prefix, ok := strings.CutSuffix(varName, suffix); ok {
+			return strings.TrimSuffix(prefix, "_")
+		}
+	}
+	return varName
+}
+
 // isSemverLike returns true if the version string looks like a semantic
 // version (e.g. "1.2.3", "v0.7.0"). It rejects tags like "latest".
 func isSemverLike(version string) bool {
@@ -548,6 +886,10 @@ func isSemverLike(version string) bool {
 
 // --- source helpers ---
 
+func isLocalModule(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
 func isGitModule(source string) bool {
 	return strings.HasPrefix(source, "git::") ||
 		strings.HasPrefix(source, "git@") ||
@@ -576,6 +918,9 @@ func removeVersionFromSource(source string) string {
 }
 
 func extractRepoName(source string) string {
+	if addr, ok := parseRegistrySource(source); ok {
+		return addr.Name
+	}
 	parts := strings.Split(source, "/")
 	if len(parts) > 0 {
 		return parts[len(parts)-1]
@@ -594,6 +939,12 @@ func isNewerVersion(current, newVersion string) bool {
 	return newVersion > current
 }
 
+// isSameVersion reports whether two versions differ at most by their "v"
+// prefix, so a dependency pinned at "1.2.3" is up to date with tag "v1.2.3".
+func isSameVersion(a, b string) bool {
+	return normalizeVersion(a) == normalizeVersion(b)
+}
+
 func normalizeVersion(version string) string {
 	version = strings.TrimSpace(version)
 	if strings.HasPrefix(version, "v") {
@@ -602,6 +953,41 @@ func normalizeVersion(version string) string {
 	return "v" + version
 }
 
+// isPrerelease reports whether the version carries a semver prerelease or
+// build segment (e.g. "v2.0.0-rc1", "1.0.0+build.5").
+func isPrerelease(version string) bool {
+	nv := normalizeVersion(version)
+	return semver.IsValid(nv) && (semver.Prerelease(nv) != "" || semver.Build(nv) != "")
+}
+
+// filterStableVersions drops prerelease tags while keeping the original
+// order, so release candidates are never selected as the latest version.
+func filterStableVersions(tags []string) []string {
+	stable := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !isPrerelease(tag) {
+			stable = append(stable, tag)
+		}
+	}
+	return stable
+}
+
+// filterTagsMatching keeps the tags matching the glob pattern (`path.Match`
+// syntax) in their original order, so "v*" drops "nightly-*" and "api/v*"
+// tags. An empty pattern keeps every tag.
+func filterTagsMatching(tags []string, pattern string) []string {
+	if pattern == "" {
+		return tags
+	}
+	matching := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if matched, err := path.Match(pattern, tag); err == nil && matched {
+			matching = append(matching, tag)
+		}
+	}
+	return matching
+}
+
 // --- upgrade application ---
 
 func applyUpgrades(tasks []upgradeTask) []entities.FileChange {
@@ -616,9 +1002,12 @@ func applyUpgrades(tasks []upgradeTask) []entities.FileChange {
 	// Apply each upgrade to the file content, dispatching by dependency kind
 	for _, t := range tasks {
 		content := fileContent[t.dep.FilePath]
-		if t.kind == depKindImage {
+		switch t.kind {
+		case depKindImage:
 			content = applyImageVersionUpgrade(content, t.dep, t.newVersion)
-		} else {
+		case depKindRegistry:
+			content = applyConstraintUpgrade(content, t.dep, t.newVersion)
+		default:
 			content = applyVersionUpgrade(content, t.dep, t.newVersion)
 		}
 		fileContent[t.dep.FilePath] = content
@@ -640,6 +1029,10 @@ func applyVersionUpgrade(
 	dep entities.Dependency,
 	newVersion string,
 ) string {
+	if isLocalModule(dep.Source) {
+		return applyLocalCommentUpgrade(content, dep, newVersion)
+	}
+
 	oldSource := buildSourceWithVersion(dep.Source, dep.CurrentVer)
 	newSource := buildSourceWithVersion(dep.Source, newVersion)
 	if strings.Contains(content, oldSource) {
@@ -664,14 +1057,51 @@ func applyVersionUpgrade(
 	})
 }
 
+// applyLocalCommentUpgrade bumps the `# ref` comment that follows a
+// local-path module source, leaving the path itself untouched.
+func applyLocalCommentUpgrade(
+	content string,
+	dep entities.Dependency,
+	newVersion string,
+) string {
+	pattern := regexp.MustCompile(
+		`(source\s*=\s*"` + regexp.QuoteMeta(dep.Source) + `"\s*#\s*ref\s+)` +
+			regexp.QuoteMeta(dep.CurrentVer) + `(\s|$)`,
+	)
+	return pattern.ReplaceAllString(content, "${1}"+newVersion+"${2}")
+}
+
+// applyConstraintUpgrade replaces the `version` constraint of the registry
+// module block named by dep. Module names are unique within a file, so no
+// other block is touched.
+func applyConstraintUpgrade(
+	content string,
+	dep entities.Dependency,
+	newConstraint string,
+) string {
+	pattern := regexp.MustCompile(
+		`(module\s+"` + regexp.QuoteMeta(dep.Name) + `"\s*\{[^}]*?version\s*=\s*")` +
+			regexp.QuoteMeta(dep.CurrentVer) + `(")`,
+	)
+	return pattern.ReplaceAllString(content, "${1}"+newConstraint+"${2}")
+}
+
 // applyImageVersionUpgrade replaces a container image version reference
 // in a Terragrunt .hcl file. The format is "image-name:oldVersion" →
-// "image-name:newVersion".
+// "image-name:newVersion", or tag_var = "oldVersion" → tag_var =
+// "newVersion" when the image name lives in a separate variable.
 func applyImageVersionUpgrade(
 	content string,
 	dep entities.Dependency,
 	newVersion string,
 ) string {
+	splitPattern := regexp.MustCompile(
+		`(\b` + regexp.QuoteMeta(dep.Name) + `\s*=\s*")` + regexp.QuoteMeta(dep.CurrentVer) + `(")`,
+	)
+	if loc := splitPattern.FindStringSubmatchIndex(content); loc != nil {
+		return content[:loc[3]] + newVersion + content[loc[4]:]
+	}
+
 	old := dep.Source + ":" + dep.CurrentVer
 	replacement := dep.Source + ":" + newVersion
 	if strings.Contains(content, old) {
@@ -702,27 +1132,41 @@ func buildSourceWithVersion(source, version string) string {
 
 // resolvedSource holds the tags, the repository entity, and the changelog-validated
 // latest version for a dependency source. The latestVersion is computed once per
-// unique source to avoid repeated CHANGELOG API calls.
+// unique source to avoid repeated CHANGELOG API calls. latestVersion only considers
+// stable tags; latestPrerelease also considers prereleases and is computed lazily
+// for dependencies whose current version is itself a prerelease.
 type resolvedSource struct {
-	tags          []string
-	depRepo       *entities.Repository
-	latestVersion string
+	tags             []string
+	depRepo          *entities.Repository
+	latestVersion    string
+	latestPrerelease string
 }
 
+// resolveTagsForSource finds the repository a dependency source is released
+// from and lists its tags. The repository is looked up by name in the
+// organization of currentRepo, unless imageSources maps the source (a
+// container image name) to another repository, given as "name" or
+// "org/name".
 func resolveTagsForSource(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	currentRepo entities.Repository,
 	source string,
+	imageSources map[string]string,
 ) ([]string, *entities.Repository) {
+	org := currentRepo.Organization
 	repoName := extractRepoName(source)
+	if mapped, ok := imageSources[source]; ok {
+		repoName = mapped
+		if i := strings.LastIndex(mapped, "/"); i >= 0 {
+			org, repoName = mapped[:i], mapped[i+1:]
+		}
+	}
 	if repoName == "" {
 		return nil, nil
 	}
 
-	allRepos, err := provider.DiscoverRepositories(
-		ctx, currentRepo.Organization,
-	)
+	allRepos, err := provider.DiscoverRepositories(ctx, org)
 	if err != nil {
 		return nil, nil
 	}
@@ -797,30 +1241,173 @@ func findLatestChangelogVersion(
 	return tags[0]
 }
 
+// resolveSources resolves the tags and target version of every unique
+// dependency source. Sources are independent, so they are resolved by a
+// bounded pool of opts.Concurrency workers (defaultTagResolutionWorkers when
+// unset); each source is resolved exactly once, so the result does not
+// depend on scheduling.
+func resolveSources(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	allDeps []depWithContent,
+	opts entities.UpdateOptions,
+) map[string]resolvedSource {
+	var sources []string
+	seen := make(map[string]bool)
+	for _, dc := range allDeps {
+		if !seen[dc.Dependency.Source] {
+			seen[dc.Dependency.Source] = true
+			sources = append(sources, dc.Dependency.Source)
+		}
+	}
+
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = defaultTagResolutionWorkers
+	}
+
+	moduleVersions := make(map[string]resolvedSource, len(sources))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan string)
+	for range min(workers, len(sources)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for src := range jobs {
+				resolved := resolveSource(ctx, provider, repo, src, opts)
+				mu.Lock()
+				moduleVersions[src] = resolved
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, src := range sources {
+		jobs <- src
+	}
+	close(jobs)
+	wg.Wait()
+
+	return moduleVersions
+}
+
+// resolveSource looks up the tags of a single dependency source and picks
+// its target version: the tag of a tracked branch when configured, otherwise
+// the latest changelog-validated stable tag. Tags outside opts.TagPattern
+// are ignored, and images listed in opts.ImageSources are resolved from the
+// mapped repository.
+func resolveSource(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	src string,
+	opts entities.UpdateOptions,
+) resolvedSource {
+	tags, depRepo := resolveTagsForSource(ctx, provider, repo, src, opts.ImageSources)
+	tags = filterTagsMatching(tags, opts.TagPattern)
+	var latest string
+	if len(tags) > 0 {
+		latest = resolveTrackedBranchTag(ctx, provider, depRepo, tags, opts.TrackBranches)
+		if latest == "" {
+			if stable := filterStableVersions(tags); len(stable) > 0 {
+				latest = findLatestChangelogVersion(ctx, provider, depRepo, stable)
+			}
+		}
+	}
+	return resolvedSource{tags: tags, depRepo: depRepo, latestVersion: latest}
+}
+
+// capToApprovedVersion enforces the version policy: when the candidate
+// version is newer than the approved maximum for the dependency, it returns
+// the highest tag that does not exceed that maximum (or "" when none does).
+// Prereleases are only considered when the candidate is itself a prerelease.
+func capToApprovedVersion(
+	dep entities.Dependency,
+	tags []string,
+	candidate string,
+	maxVersions map[string]string,
+) string {
+	name := extractRepoName(dep.Source)
+	maxVersion, ok := maxVersions[name]
+	if !ok || candidate == "" || !isNewerVersion(maxVersion, candidate) {
+		return candidate
+	}
+
+	var capped string
+	for _, tag := range tags {
+		if isNewerVersion(maxVersion, tag) || (isPrerelease(tag) && !isPrerelease(candidate)) {
+			continue
+		}
+		if capped == "" || isNewerVersion(capped, tag) {
+			capped = tag
+		}
+	}
+
+	logger.Warnf("[terraform] %s %s is not approved by the version policy (max %s), capping to %q",
+		name, candidate, maxVersion, capped)
+	return capped
+}
+
+// capToCurrentMajor honours opts.IgnoreMajor: when the candidate version has
+// a newer major than the current one, it returns the highest tag within the
+// current major instead (or "" when there is none). Prereleases are only
+// considered when the candidate is itself a prerelease.
+func capToCurrentMajor(
+	dep entities.Dependency,
+	tags []string,
+	candidate string,
+	opts entities.UpdateOptions,
+) string {
+	if candidate == "" || !opts.IsMajorBumpIgnored(dep.CurrentVer, candidate) {
+		return candidate
+	}
+
+	var capped string
+	for _, tag := range tags {
+		if opts.IsMajorBumpIgnored(dep.CurrentVer, tag) || (isPrerelease(tag) && !isPrerelease(candidate)) {
+			continue
+		}
+		if capped == "" || isNewerVersion(capped, tag) {
+			capped = tag
+		}
+	}
+
+	logger.Infof("[terraform] Skipping major upgrade of %s to %s (ignore_major), using %q",
+		dep.Name, candidate, capped)
+	return capped
+}
+
 // --- PR text generation ---
 
+// branchUnsafeChars matches the characters of a version constraint (such as
+// "~> 2.9" or ">= 1.2, < 2.0") that are not allowed in a branch name.
+var branchUnsafeChars = regexp.MustCompile(`[^0-9A-Za-z._-]+`)
+
 func generateBranchName(tasks []upgradeTask) string {
 	if len(tasks) == 1 {
 		return fmt.Sprintf(
 			branchSingleFmt,
 			extractRepoName(tasks[0].dep.Source),
-			tasks[0].newVersion,
+			branchUnsafeChars.ReplaceAllString(tasks[0].newVersion, ""),
 		)
 	}
 	return fmt.Sprintf(branchBatchFmt, len(tasks))
 }
 
-func generateCommitMessage(tasks []upgradeTask) string {
+func generateCommitMessage(tasks []upgradeTask, prefix string) string {
 	if len(tasks) == 1 {
 		return fmt.Sprintf(
-			"chore(deps): upgraded `%s` from `%s` to `%s`",
+			"%s: upgraded `%s` from `%s` to `%s`",
+			prefix,
 			extractRepoName(tasks[0].dep.Source),
 			tasks[0].dep.CurrentVer,
 			tasks[0].newVersion,
 		)
 	}
 	return fmt.Sprintf(
-		"chore(deps): upgraded %d Terraform dependencies",
+		"%s: upgraded %d Terraform dependencies",
+		prefix,
 		len(tasks),
 	)
 }
@@ -839,28 +1426,40 @@ func generatePRTitle(tasks []upgradeTask) string {
 	)
 }
 
-// appendChangelogEntry reads the target repo's CHANGELOG.md (if it exists),
-// inserts entries describing the Terraform module upgrades, and appends the
-// modified file to the change set.
-func appendChangelogEntry(
+// buildChangelogChange reads the target repo's changelog (if it exists)
+// and inserts entries describing the Terraform module upgrades. The change is
+// added to the change set by support.ReconcileChangelogChange right before
+// the push.
+func buildChangelogChange(
 	ctx context.Context,
 	provider repositories.ProviderRepository,
 	repo entities.Repository,
 	upgrades []upgradeTask,
-	fileChanges []entities.FileChange,
-) []entities.FileChange {
-	if !provider.HasFile(ctx, repo, "CHANGELOG.md") {
-		return fileChanges
-	}
+	changelog entities.ChangelogSettings,
+) *support.ChangelogChange {
+	return support.BuildChangelogChange(ctx, provider, repo, changelogEntries(upgrades), changelog)
+}
 
-	content, err := provider.GetFileContent(ctx, repo, "CHANGELOG.md")
-	if err != nil {
-		logger.Warnf("[terraform] Failed to read CHANGELOG.md: %v", err)
-		return fileChanges
+// changelogEntries returns one changelog bullet per upgrade or, like
+// generatePRDescription, a single summarizing bullet once there are more
+// than maxDetailedUpgrades of them.
+func changelogEntries(tasks []upgradeTask) []string {
+	if len(tasks) > maxDetailedUpgrades {
+		moduleCount, imageCount := countByKind(tasks)
+		var kinds []string
+		if moduleCount > 0 {
+			kinds = append(kinds, fmt.Sprintf("%d module upgrades", moduleCount))
+		}
+		if imageCount > 0 {
+			kinds = append(kinds, fmt.Sprintf("%d container image upgrades", imageCount))
+		}
+		return []string{fmt.Sprintf(
+			"- updated %d Terraform dependencies (%s)", len(tasks), strings.Join(kinds, " and "),
+		)}
 	}
 
-	entries := make([]string, 0, len(upgrades))
-	for _, up := range upgrades {
+	entries := make([]string, 0, len(tasks))
+	for _, up := range tasks {
 		label := "Terraform module"
 		if up.kind == depKindImage {
 			label = "container image"
@@ -870,17 +1469,7 @@ func appendChangelogEntry(
 			label, extractRepoName(up.dep.Source), up.dep.CurrentVer, up.newVersion,
 		))
 	}
-
-	modified := entities.InsertChangelogEntry(content, entries)
-	if modified == content {
-		return fileChanges
-	}
-
-	return append(fileChanges, entities.FileChange{
-		Path:       "CHANGELOG.md",
-		Content:    modified,
-		ChangeType: "edit",
-	})
+	return entries
 }
 
 func generatePRDescription(tasks []upgradeTask) string {
@@ -916,7 +1505,7 @@ func generatePRDescription(tasks []upgradeTask) string {
 	}
 
 	sb.WriteString("\n---\n")
-	sb.WriteString("*This PR was automatically created by [autoupdate](https://github.com/rios0rios0/autoupdate)*\n")
+	sb.WriteString(entities.DefaultPRFooter + "\n")
 	return sb.String()
 }
 
@@ -3,10 +3,18 @@
 package terraform_test
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -272,6 +280,126 @@ func TestCreateUpdatePRs(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, prs)
 	})
+
+	t.Run("should render the configured PR title and body templates for a single module", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{{Organization: "org", Name: "network"}}).
+			WithFiles([]entities.File{{Path: "main.tf"}}).
+			WithFileContents(map[string]string{
+				"main.tf": "module \"network\" {\n  source = \"git::https://github.com/org/network?ref=v1.0.0\"\n}\n",
+			}).
+			WithTags([]string{"v1.2.0", "v1.0.0"}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		opts := entities.UpdateOptions{
+			PRTitleTemplate: "build({{.Ecosystem}}): bump {{.Module}} from {{.OldVersion}} to {{.NewVersion}}",
+			PRBodyTemplate:  "Upgrades {{.Count}} dependency.",
+		}
+
+		// when
+		prs, err := terraform.NewUpdaterRepository().CreateUpdatePRs(t.Context(), provider, repo, opts)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		require.Len(t, provider.PRInputs, 1)
+		assert.Equal(t, "build(terraform): bump network from v1.0.0 to v1.2.0", provider.PRInputs[0].Title)
+		assert.Equal(t, "Upgrades 1 dependency.", provider.PRInputs[0].Description)
+	})
+
+	t.Run("should keep the generated PR title and body without templates", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{{Organization: "org", Name: "network"}}).
+			WithFiles([]entities.File{{Path: "main.tf"}}).
+			WithFileContents(map[string]string{
+				"main.tf": "module \"network\" {\n  source = \"git::https://github.com/org/network?ref=v1.0.0\"\n}\n",
+			}).
+			WithTags([]string{"v1.2.0", "v1.0.0"}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		opts := entities.UpdateOptions{}
+
+		// when
+		_, err := terraform.NewUpdaterRepository().CreateUpdatePRs(t.Context(), provider, repo, opts)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, provider.PRInputs, 1)
+		assert.True(t, strings.HasPrefix(provider.PRInputs[0].Title, "chore(deps):"), provider.PRInputs[0].Title)
+		assert.Contains(t, provider.PRInputs[0].Description, "## Summary")
+	})
+}
+
+func TestPlanUpdates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the planned module upgrades without creating a branch", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelog := `# Changelog
+
+## [Unreleased]
+
+## [1.2.0] - 2026-03-01
+
+### Changed
+- changed something
+`
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{{Organization: "org", Name: "network"}}).
+			WithFiles([]entities.File{{Path: "main.tf"}}).
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			WithFileContents(map[string]string{
+				"main.tf": `module "network" {
+  source = "git::https://github.com/org/network?ref=v1.0.0"
+}
+`,
+				"CHANGELOG.md": changelog,
+			}).
+			WithTags([]string{"v1.2.0", "v1.0.0"}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		changes, err := updater.PlanUpdates(t.Context(), provider, repo, entities.UpdateOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []entities.DependencyChange{{
+			Ecosystem: "terraform",
+			Name:      "network",
+			From:      "v1.0.0",
+			To:        "v1.2.0",
+			File:      "main.tf",
+		}}, changes)
+		assert.Empty(t, provider.BranchInputs)
+	})
+
+	t.Run("should return an empty plan when no Terraform files found", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithFiles([]entities.File{}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		changes, err := updater.PlanUpdates(t.Context(), provider, repo, entities.UpdateOptions{})
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
 }
 
 func TestIsSemverLike(t *testing.T) {
@@ -616,6 +744,38 @@ func TestIsNewerVersion(t *testing.T) {
 	})
 }
 
+func TestIsSameVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should treat versions differing only by the v prefix as equal", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		current := "1.2.3"
+		latest := "v1.2.3"
+
+		// when
+		result := terraform.IsSameVersion(current, latest)
+
+		// then
+		assert.True(t, result)
+	})
+
+	t.Run("should return false for different versions", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		current := "1.2.3"
+		latest := "v1.2.4"
+
+		// when
+		result := terraform.IsSameVersion(current, latest)
+
+		// then
+		assert.False(t, result)
+	})
+}
+
 func TestNormalizeVersion(t *testing.T) {
 	t.Parallel()
 
@@ -730,6 +890,30 @@ func TestApplyVersionUpgrade(t *testing.T) {
 	})
 }
 
+func TestApplyLocalCommentUpgrade(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should bump only the trailing comment of a local module source", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `module "net" {
+  source = "../modules/net" # ref v1.2.3
+}
+`
+		dep := entities.Dependency{Name: "net", Source: "../modules/net", CurrentVer: "v1.2.3"}
+
+		// when
+		result := terraform.ApplyVersionUpgrade(content, dep, "v1.3.0")
+
+		// then
+		assert.Equal(t, `module "net" {
+  source = "../modules/net" # ref v1.3.0
+}
+`, result)
+	})
+}
+
 func TestApplyImageVersionUpgrade(t *testing.T) {
 	t.Parallel()
 
@@ -753,6 +937,34 @@ func TestApplyImageVersionUpgrade(t *testing.T) {
 		assert.Contains(t, result, `"app:2.0.0"`)
 		assert.NotContains(t, result, `"app:1.0.0"`)
 	})
+
+	t.Run("should bump only the tag variable of a split image reference", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `inputs = {
+  image_name = "relayer-http"
+  image_tag  = "0.7.0"
+  replicas   = "0.7.0"
+}`
+		dep := entities.Dependency{
+			Name:       "image_tag",
+			Source:     "relayer-http",
+			CurrentVer: "0.7.0",
+			FilePath:   "terragrunt.hcl",
+			Line:       3,
+		}
+
+		// when
+		result := terraform.ApplyImageVersionUpgrade(content, dep, "0.8.0")
+
+		// then
+		assert.Equal(t, `inputs = {
+  image_name = "relayer-http"
+  image_tag  = "0.8.0"
+  replicas   = "0.7.0"
+}`, result)
+	})
 }
 
 func TestBuildSourceWithVersion(t *testing.T) {
@@ -964,6 +1176,86 @@ second_image = "app-two:2.3.4"`
 		assert.Equal(t, "app-two", deps[1].Source)
 		assert.Equal(t, "2.3.4", deps[1].CurrentVer)
 	})
+
+	t.Run("should correlate image name and tag variables", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `inputs = {
+  image_name    = "relayer-http"
+  image_tag     = "0.7.0"
+  worker_image  = "relayer-worker"
+  worker_tag    = "v1.2.0"
+  unrelated_tag = "3.0.0"
+}`
+
+		// when
+		deps := terraform.ScanHCLFile(content, "terragrunt.hcl")
+
+		// then
+		require.Len(t, deps, 2)
+		assert.Equal(t, "image_tag", deps[0].Name)
+		assert.Equal(t, "relayer-http", deps[0].Source)
+		assert.Equal(t, "0.7.0", deps[0].CurrentVer)
+		assert.Equal(t, 3, deps[0].Line)
+		assert.Equal(t, "worker_tag", deps[1].Name)
+		assert.Equal(t, "relayer-worker", deps[1].Source)
+		assert.Equal(t, "v1.2.0", deps[1].CurrentVer)
+	})
+
+	t.Run("should skip a split image whose tag is not semver", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "image_name = \"relayer-http\"\nimage_tag = \"latest\"\n"
+
+		// when
+		deps := terraform.ScanHCLFile(content, "terragrunt.hcl")
+
+		// then
+		assert.Empty(t, deps)
+	})
+}
+
+func TestScanLocalModuleComments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should extract the version comment of a local module source", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `module "net" {
+  source = "../modules/net" # ref v1.2.3
+}`
+
+		// when
+		deps := terraform.ScanLocalModuleComments(content, "main.tf")
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "net", deps[0].Name)
+		assert.Equal(t, "../modules/net", deps[0].Source)
+		assert.Equal(t, "v1.2.3", deps[0].CurrentVer)
+		assert.Equal(t, 2, deps[0].Line)
+	})
+
+	t.Run("should skip local sources without a semver comment", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := `module "a" {
+  source = "./modules/a"
+}
+module "b" {
+  source = "./modules/b" # ref main
+}`
+
+		// when
+		deps := terraform.ScanLocalModuleComments(content, "main.tf")
+
+		// then
+		assert.Empty(t, deps)
+	})
 }
 
 func TestCountByKind(t *testing.T) {
@@ -1098,6 +1390,25 @@ func TestGenerateCommitMessage(t *testing.T) {
 		// then
 		assert.Equal(t, "chore(deps): upgraded 3 Terraform dependencies", result)
 	})
+
+	t.Run("should use the configured commit type and scope", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tasks := []terraform.UpgradeTask{
+			terraform.NewUpgradeTask(
+				entities.Dependency{Name: "my_mod", Source: "github.com/org/my-module", CurrentVer: "v1.0.0"},
+				"v2.0.0", "", terraform.DepKindModule,
+			),
+		}
+		opts := entities.UpdateOptions{CommitType: "build", CommitScope: "terraform"}
+
+		// when
+		result := terraform.GenerateCommitMessage(tasks, opts)
+
+		// then
+		assert.Equal(t, "build(terraform): upgraded `my-module` from `v1.0.0` to `v2.0.0`", result)
+	})
 }
 
 func TestGeneratePRTitle(t *testing.T) {
@@ -1346,6 +1657,94 @@ func TestAppendChangelogEntry(t *testing.T) {
 		assert.Contains(t, changelogChange.Content, "v2.0.0")
 	})
 
+	t.Run("should add changelog entries to the configured changelog path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelogContent := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"docs/CHANGELOG.md": true}).
+			WithFileContents(map[string]string{"docs/CHANGELOG.md": changelogContent}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		upgrades := []terraform.UpgradeTask{
+			terraform.NewUpgradeTask(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "github.com/org/my-module",
+					CurrentVer: "v1.0.0",
+				},
+				"v2.0.0", "", terraform.DepKindModule,
+			),
+		}
+
+		// when
+		result := terraform.AppendChangelogEntry(
+			t.Context(), provider, repo, upgrades, nil, entities.ChangelogSettings{Path: "docs/CHANGELOG.md"},
+		)
+
+		// then
+		require.Len(t, result, 1)
+		assert.Equal(t, "docs/CHANGELOG.md", result[0].Path)
+		assert.Contains(t, result[0].Content, "my-module")
+		assert.Contains(t, result[0].Content, "v2.0.0")
+	})
+
+	t.Run("should add one entry per upgrade up to the detail threshold", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelogContent := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			WithFileContents(map[string]string{"CHANGELOG.md": changelogContent}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		upgrades := newModuleUpgradeTasks(5)
+
+		// when
+		result := terraform.AppendChangelogEntry(t.Context(), provider, repo, upgrades, nil)
+
+		// then
+		require.Len(t, result, 1)
+		assert.Equal(t, 5, strings.Count(result[0].Content, "- changed the Terraform module"))
+		assert.NotContains(t, result[0].Content, "- updated 5 Terraform dependencies")
+	})
+
+	t.Run("should add a single summarizing entry above the detail threshold", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelogContent := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			WithFileContents(map[string]string{"CHANGELOG.md": changelogContent}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		upgrades := append(newModuleUpgradeTasks(4),
+			terraform.NewUpgradeTask(
+				entities.Dependency{Name: "api", Source: "api", CurrentVer: "1.0.0"},
+				"1.1.0", "", terraform.DepKindImage,
+			),
+			terraform.NewUpgradeTask(
+				entities.Dependency{Name: "worker", Source: "worker", CurrentVer: "1.0.0"},
+				"1.1.0", "", terraform.DepKindImage,
+			),
+		)
+
+		// when
+		result := terraform.AppendChangelogEntry(t.Context(), provider, repo, upgrades, nil)
+
+		// then
+		require.Len(t, result, 1)
+		assert.Contains(
+			t,
+			result[0].Content,
+			"- updated 6 Terraform dependencies (4 module upgrades and 2 container image upgrades)\n",
+		)
+		assert.NotContains(t, result[0].Content, "- changed the Terraform module")
+	})
+
 	t.Run("should skip when no CHANGELOG.md exists", func(t *testing.T) {
 		t.Parallel()
 
@@ -1444,12 +1843,16 @@ func TestLocalScanAllDependencies(t *testing.T) {
 		assert.Equal(t, "main.tf", deps[0].Dependency.FilePath)
 	})
 
-	t.Run("should return empty for directory with no terraform files", func(t *testing.T) {
+	t.Run("should ignore local module comments by default", func(t *testing.T) {
 		t.Parallel()
 
 		// given
 		tmpDir := t.TempDir()
-		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "readme.md"), []byte("# Hello"), 0o600))
+		content := `module "net" {
+  source = "../modules/net" # ref v1.2.3
+}
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
 
 		updater := &terraform.UpdaterRepository{}
 
@@ -1460,50 +1863,930 @@ func TestLocalScanAllDependencies(t *testing.T) {
 		assert.Empty(t, deps)
 	})
 
-	t.Run("should find dependencies in .hcl files", func(t *testing.T) {
+	t.Run("should find local module comments when enabled", func(t *testing.T) {
 		t.Parallel()
 
 		// given
 		tmpDir := t.TempDir()
-		content := `my_image = "app:0.7.0"
-`
-		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "vars.hcl"), []byte(content), 0o600))
+		content := `module "net" {
+  source = "../modules/net" # ref v1.2.3
+}
 
-		updater := &terraform.UpdaterRepository{}
+module "foo" {
+  source = "git::https://github.com/org/mod.git?ref=v1.0.0"
+}
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{UpgradeLocalComments: true}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir, opts)
+
+		// then
+		require.Len(t, deps, 2)
+		assert.Equal(t, "git::https://github.com/org/mod.git", deps[0].Dependency.Source)
+		assert.Equal(t, "../modules/net", deps[1].Dependency.Source)
+		assert.Equal(t, "v1.2.3", deps[1].Dependency.CurrentVer)
+		assert.Equal(t, terraform.DepKindModule, deps[1].Kind)
+	})
+
+	t.Run("should return empty for directory with no terraform files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "readme.md"), []byte("# Hello"), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// then
+		assert.Empty(t, deps)
+	})
+
+	t.Run("should find dependencies in .hcl files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		content := `my_image = "app:0.7.0"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "vars.hcl"), []byte(content), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "my_image", deps[0].Dependency.Name)
+		assert.Equal(t, "app", deps[0].Dependency.Source)
+		assert.Equal(t, "0.7.0", deps[0].Dependency.CurrentVer)
+	})
+
+	t.Run("should only scan files under the configured paths", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		content := `module "foo" {
+  source = "git::https://github.com/org/mod.git?ref=v1.0.0"
+}
+`
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "infra"), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "infra", "main.tf"), []byte(content), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{Paths: []string{"infra"}}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir, opts)
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "infra/main.tf", deps[0].Dependency.FilePath)
+	})
+
+	t.Run("should detect image pins in .tfvars files but not in .tf files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		content := `api_image = "api:1.0.0"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "prod.auto.tfvars"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "prod.auto.tfvars", deps[0].Dependency.FilePath)
+		assert.Equal(t, "api_image", deps[0].Dependency.Name)
+		assert.Equal(t, "api", deps[0].Dependency.Source)
+		assert.Equal(t, terraform.DepKindImage, deps[0].Kind)
+	})
+
+	t.Run("should ignore modules inside vendored directories", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		content := `module "foo" {
+  source = "git::https://github.com/org/mod.git?ref=v1.0.0"
+}
+`
+		vendored := filepath.Join(tmpDir, "vendor", "modules", "foo")
+		require.NoError(t, os.MkdirAll(vendored, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(vendored, "main.tf"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		deps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "main.tf", deps[0].Dependency.FilePath)
+	})
+}
+
+func TestScanAllDependencies(t *testing.T) {
+	t.Parallel()
+
+	content := `module "foo" {
+  source = "git::https://github.com/org/mod.git?ref=v1.0.0"
+}
+`
+
+	t.Run("should ignore modules downloaded into .terraform/modules", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithFiles([]entities.File{
+				{Path: "main.tf"},
+				{Path: ".terraform/modules/foo/main.tf"},
+			}).
+			WithFileContents(map[string]string{
+				"main.tf":                        content,
+				".terraform/modules/foo/main.tf": content,
+			}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		deps := terraform.ScanAllDependencies(t.Context(), updater, provider, repo, entities.UpdateOptions{})
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, "main.tf", deps[0].Dependency.FilePath)
+	})
+
+	t.Run("should scan every directory when the exclusion list is emptied", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithFiles([]entities.File{{Path: ".terraform/modules/foo/main.tf"}}).
+			WithFileContents(map[string]string{".terraform/modules/foo/main.tf": content}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{ExcludedDirs: []string{}}
+
+		// when
+		deps := terraform.ScanAllDependencies(t.Context(), updater, provider, repo, opts)
+
+		// then
+		require.Len(t, deps, 1)
+		assert.Equal(t, ".terraform/modules/foo/main.tf", deps[0].Dependency.FilePath)
+	})
+
+	t.Run("should fetch every file concurrently up to the cap and order dependencies by path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		const fileCount = 10
+		const concurrency = 3
+		var files []entities.File
+		contents := make(map[string]string, fileCount)
+		for i := fileCount - 1; i >= 0; i-- {
+			filePath := fmt.Sprintf("modules/m%02d/main.tf", i)
+			files = append(files, entities.File{Path: filePath})
+			contents[filePath] = content
+		}
+		provider := &peakTrackingProvider{
+			SpyProviderRepository: repositorydoubles.NewSpyProviderRepositoryBuilder().
+				WithFiles(files).
+				WithFileContents(contents).
+				BuildSpy(),
+			limit: concurrency,
+			ready: make(chan struct{}),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{Concurrency: concurrency}
+
+		// when
+		deps := terraform.ScanAllDependencies(t.Context(), updater, provider, repo, opts)
+
+		// then
+		require.Len(t, deps, fileCount)
+		for i, dep := range deps {
+			assert.Equal(t, fmt.Sprintf("modules/m%02d/main.tf", i), dep.Dependency.FilePath)
+		}
+		assert.ElementsMatch(t, slices.Collect(maps.Keys(contents)), provider.fetched)
+		assert.Equal(t, int32(concurrency), provider.peak.Load())
+	})
+}
+
+// peakTrackingProvider is a SpyProviderRepository that records how many
+// GetFileContent calls overlap. Each call waits until limit calls are in
+// flight (or a timeout).
+type peakTrackingProvider struct {
+	*repositorydoubles.SpyProviderRepository
+
+	limit   int32
+	active  atomic.Int32
+	peak    atomic.Int32
+	ready   chan struct{}
+	once    sync.Once
+	mu      sync.Mutex
+	fetched []string
+}
+
+func (p *peakTrackingProvider) GetFileContent(
+	ctx context.Context, repo entities.Repository, path string,
+) (string, error) {
+	current := p.active.Add(1)
+	defer p.active.Add(-1)
+	for {
+		observed := p.peak.Load()
+		if current <= observed || p.peak.CompareAndSwap(observed, current) {
+			break
+		}
+	}
+	if current == p.limit {
+		p.once.Do(func() { close(p.ready) })
+	}
+	select {
+	case <-p.ready:
+	case <-time.After(time.Second):
+	}
+
+	p.mu.Lock()
+	p.fetched = append(p.fetched, path)
+	p.mu.Unlock()
+	return p.SpyProviderRepository.GetFileContent(ctx, repo, path)
+}
+
+func TestFilterStableVersions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should drop prerelease and build tags while keeping order", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tags := []string{"v2.0.0-rc1", "v2.0.0", "1.5.0+build.1", "v1.4.0", "latest"}
+
+		// when
+		result := terraform.FilterStableVersions(tags)
+
+		// then
+		assert.Equal(t, []string{"v2.0.0", "v1.4.0", "latest"}, result)
+	})
+
+	t.Run("should return empty when every tag is a prerelease", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tags := []string{"v2.0.0-rc1", "v2.0.0-beta.1"}
+
+		// when
+		result := terraform.FilterStableVersions(tags)
+
+		// then
+		assert.Empty(t, result)
+	})
+}
+
+func TestFilterTagsMatching(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep only the tags matching the glob in their original order", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tags := []string{"nightly-20260101", "api/v3.0.0", "v2.1.0", "v2.0.0", "api/v2.5.0", "v1.9.0"}
+
+		// when
+		result := terraform.FilterTagsMatching(tags, "v*")
+
+		// then
+		assert.Equal(t, []string{"v2.1.0", "v2.0.0", "v1.9.0"}, result)
+	})
+
+	t.Run("should keep every tag when the pattern is empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tags := []string{"nightly-20260101", "v1.0.0"}
+
+		// when
+		result := terraform.FilterTagsMatching(tags, "")
+
+		// then
+		assert.Equal(t, tags, result)
+	})
+}
+
+// TestDetermineRegistryUpgrades tests are sequential because they override a package-level function variable.
+func TestDetermineRegistryUpgrades(t *testing.T) {
+	content := `module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 2.1"
+}
+
+module "net" {
+  source = "git::https://github.com/org/net.git?ref=v1.0.0"
+}
+`
+
+	t.Run("should advance a registry constraint to the latest 2.x without crossing 3.0", func(t *testing.T) {
+		// given
+		cleanup := terraform.SetFetchRegistryVersionsFunc(func(_ context.Context, source string) ([]string, error) {
+			assert.Equal(t, "terraform-aws-modules/vpc/aws", source)
+			return []string{"2.1.0", "2.9.3", "3.0.0"}, nil
+		})
+		defer cleanup()
+
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{UpgradeRegistryModules: true}
+		allDeps := terraform.LocalScanAllDependencies(updater, tmpDir, opts)
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
+		changes := terraform.ApplyUpgrades(upgrades)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "~> 2.9", terraform.UpgradeTaskNewVersion(upgrades[0]))
+		require.Len(t, changes, 1)
+		assert.Contains(t, changes[0].Content, `version = "~> 2.9"`)
+		assert.Contains(t, changes[0].Content, "?ref=v1.0.0")
+		assert.Equal(t, "chore/upgrade-vpc-2.9", terraform.GenerateBranchName(upgrades))
+	})
+
+	t.Run("should advance the version of a registry module in a Terragrunt .hcl file", func(t *testing.T) {
+		// given
+		hclContent := `include "root" {
+  path = find_in_parent_folders()
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "~> 5.1"
+}
+
+inputs = {
+  relayer_http_image = "relayer-http:0.7.0"
+}
+`
+		cleanup := terraform.SetFetchRegistryVersionsFunc(func(_ context.Context, source string) ([]string, error) {
+			assert.Equal(t, "terraform-aws-modules/vpc/aws", source)
+			return []string{"5.1.0", "5.8.1", "6.0.0"}, nil
+		})
+		defer cleanup()
+
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "terragrunt.hcl"), []byte(hclContent), 0o600))
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{UpgradeRegistryModules: true}
+		allDeps := terraform.LocalScanAllDependencies(updater, tmpDir, opts)
+		var registryDeps []terraform.DepWithContent
+		for _, dc := range allDeps {
+			if dc.Kind == terraform.DepKindRegistry {
+				registryDeps = append(registryDeps, dc)
+			}
+		}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, registryDeps, opts)
+		changes := terraform.ApplyUpgrades(upgrades)
+
+		// then
+		require.Len(t, registryDeps, 1)
+		assert.Equal(t, "vpc", registryDeps[0].Dependency.Name)
+		assert.Equal(t, "terragrunt.hcl", registryDeps[0].Dependency.FilePath)
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "~> 5.8", terraform.UpgradeTaskNewVersion(upgrades[0]))
+		require.Len(t, changes, 1)
+		assert.Equal(t, "terragrunt.hcl", changes[0].Path)
+		assert.Contains(t, changes[0].Content, `version = "~> 5.8"`)
+		assert.Contains(t, changes[0].Content, `relayer_http_image = "relayer-http:0.7.0"`)
+	})
+
+	t.Run("should ignore registry modules when the option is disabled", func(t *testing.T) {
+		// given
+		cleanup := terraform.SetFetchRegistryVersionsFunc(func(_ context.Context, _ string) ([]string, error) {
+			t.Fatal("registry must not be queried")
+			return nil, nil
+		})
+		defer cleanup()
+
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(content), 0o600))
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		allDeps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// then
+		require.Len(t, allDeps, 1)
+		assert.Equal(t, "net", allDeps[0].Dependency.Name)
+	})
+}
+
+func TestDetermineUpgrades(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return an image upgrade task for a .tfvars pin", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		tmpDir := t.TempDir()
+		tfvars := `api_image = "api:1.0.0"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "prod.auto.tfvars"), []byte(tfvars), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.tf"), []byte(tfvars), 0o600))
+
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{{Organization: "org", Name: "api"}}).
+			WithTags([]string{"1.1.0", "1.0.0"}).
+			BuildSpy()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		allDeps := terraform.LocalScanAllDependencies(updater, tmpDir)
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+		changes := terraform.ApplyUpgrades(upgrades)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "1.1.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+		require.Len(t, changes, 1)
+		assert.Equal(t, "prod.auto.tfvars", changes[0].Path)
+		assert.Contains(t, changes[0].Content, `api_image = "api:1.1.0"`)
+	})
+
+	t.Run("should return upgrade when newer tag exists", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelog := `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2026-03-01
+
+### Changed
+- changed something
+`
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0", "v1.0.0"}).
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			WithFileContents(map[string]string{"CHANGELOG.md": changelog}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.0.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+
+	t.Run("should return empty when the latest tag only adds a v prefix to the current version", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v1.2.3", "v1.2.2"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "1.2.3",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=1.2.3"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		assert.Empty(t, upgrades)
+	})
+
+	t.Run("should return empty when already up to date", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		changelog := `# Changelog
+
+## [Unreleased]
+
+## [2.0.0] - 2026-03-01
+`
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0"}).
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			WithFileContents(map[string]string{"CHANGELOG.md": changelog}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v2.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v2.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		assert.Empty(t, upgrades)
+	})
+
+	t.Run("should return empty when no tags are available", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/unknown-mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				"content",
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		assert.Empty(t, upgrades)
+	})
+
+	t.Run("should skip a release candidate tag when the current version is stable", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0-rc1", "v1.1.0", "v1.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v1.1.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+	t.Run("should return empty when only release candidates are newer", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0-rc1", "v1.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		assert.Empty(t, upgrades)
+	})
+	t.Run("should move a release candidate to the newer release candidate", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0-rc2", "v2.0.0-rc1", "v1.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v2.0.0-rc1",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v2.0.0-rc1"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.0.0-rc2", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+	t.Run("should move a release candidate to the final release", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0", "v2.0.0-rc1", "v1.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v2.0.0-rc1",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v2.0.0-rc1"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.0.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+
+	t.Run("should resolve every source concurrently with the same result as a serial run", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		const sourceCount = 12
+		var depRepos []entities.Repository
+		var allDeps []terraform.DepWithContent
+		for i := range sourceCount {
+			name := fmt.Sprintf("mod-%d", i)
+			depRepos = append(depRepos, entities.Repository{Organization: "org", Name: name})
+			allDeps = append(allDeps, terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       name,
+					Source:     "git::https://github.com/org/" + name,
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				"",
+				terraform.DepKindModule,
+			))
+		}
+		newProvider := func() *repositorydoubles.SpyProviderRepository {
+			return repositorydoubles.NewSpyProviderRepositoryBuilder().
+				WithRepositories(depRepos).
+				WithTags([]string{"v1.2.0", "v1.1.0", "v1.0.0"}).
+				BuildSpy()
+		}
+		serialProvider := newProvider()
+		concurrentProvider := newProvider()
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		serial := terraform.DetermineUpgrades(updater, t.Context(), serialProvider, repo, allDeps,
+			entities.UpdateOptions{Concurrency: 1})
+		concurrent := terraform.DetermineUpgrades(updater, t.Context(), concurrentProvider, repo, allDeps)
+
+		// then
+		require.Len(t, concurrent, sourceCount)
+		assert.Equal(t, serial, concurrent)
+		assert.Len(t, concurrentProvider.DiscoveredOrgs, sourceCount)
+		for _, up := range concurrent {
+			assert.Equal(t, "v1.2.0", terraform.UpgradeTaskNewVersion(up))
+		}
+	})
+
+	t.Run("should cap the upgrade to the maximum approved by the version policy", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v3.0.0", "v2.5.0", "v2.0.0", "v1.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{MaxVersions: map[string]string{"mod": "v2.5.0"}}
 
 		// when
-		deps := terraform.LocalScanAllDependencies(updater, tmpDir)
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
 
 		// then
-		require.Len(t, deps, 1)
-		assert.Equal(t, "my_image", deps[0].Dependency.Name)
-		assert.Equal(t, "app", deps[0].Dependency.Source)
-		assert.Equal(t, "0.7.0", deps[0].Dependency.CurrentVer)
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.5.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
 	})
-}
 
-func TestDetermineUpgrades(t *testing.T) {
-	t.Parallel()
+	t.Run("should skip the upgrade when the current version is already the approved maximum", func(t *testing.T) {
+		t.Parallel()
 
-	t.Run("should return upgrade when newer tag exists", func(t *testing.T) {
+		// given
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v3.0.0", "v2.0.0"}).
+			BuildSpy()
+
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v2.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v2.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{MaxVersions: map[string]string{"mod": "2.0.0"}}
+
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
+
+		// then
+		assert.Empty(t, upgrades)
+	})
+
+	t.Run("should skip a new major version when ignore major is set", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		changelog := `# Changelog
+		depRepo := entities.Repository{Organization: "org", Name: "mod"}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v2.0.0", "v1.4.0", "v1.2.0"}).
+			BuildSpy()
 
-## [Unreleased]
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.2.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.2.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{IgnoreMajor: true}
 
-## [2.0.0] - 2026-03-01
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
 
-### Changed
-- changed something
-`
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v1.4.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+
+	t.Run("should skip the upgrade when only a new major version exists and ignore major is set", func(t *testing.T) {
+		t.Parallel()
+
+		// given
 		depRepo := entities.Repository{Organization: "org", Name: "mod"}
 		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
 			WithRepositories([]entities.Repository{depRepo}).
 			WithTags([]string{"v2.0.0", "v1.0.0"}).
-			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
-			WithFileContents(map[string]string{"CHANGELOG.md": changelog}).
 			BuildSpy()
 
 		allDeps := []terraform.DepWithContent{
@@ -1522,31 +2805,63 @@ func TestDetermineUpgrades(t *testing.T) {
 		}
 		repo := entities.Repository{Organization: "org", Name: "repo"}
 		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{IgnoreMajor: true}
 
 		// when
-		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
 
 		// then
-		require.Len(t, upgrades, 1)
-		assert.Equal(t, "v2.0.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+		assert.Empty(t, upgrades)
 	})
 
-	t.Run("should return empty when already up to date", func(t *testing.T) {
+	t.Run("should target the tag of a tracked branch instead of the highest tag", func(t *testing.T) {
 		t.Parallel()
 
 		// given
-		changelog := `# Changelog
+		depRepo := entities.Repository{
+			Organization: "org",
+			Name:         "mod",
+			RemoteURL:    newTaggedModuleRepo(t, "v2.1.0"),
+		}
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v3.0.0", "v2.1.0", "v1.0.0"}).
+			BuildSpy()
 
-## [Unreleased]
+		allDeps := []terraform.DepWithContent{
+			terraform.NewDepWithContent(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/mod",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+				},
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo"}
+		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{TrackBranches: map[string]string{"mod": "stable"}}
 
-## [2.0.0] - 2026-03-01
-`
+		// when
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
+
+		// then
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.1.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
+	})
+
+	t.Run("should select the newest tag matching the tag pattern", func(t *testing.T) {
+		t.Parallel()
+
+		// given
 		depRepo := entities.Repository{Organization: "org", Name: "mod"}
 		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
 			WithRepositories([]entities.Repository{depRepo}).
-			WithTags([]string{"v2.0.0"}).
-			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
-			WithFileContents(map[string]string{"CHANGELOG.md": changelog}).
+			WithTags([]string{"nightly-20260101", "api/v9.0.0", "v2.1.0", "v2.0.0", "v1.0.0"}).
 			BuildSpy()
 
 		allDeps := []terraform.DepWithContent{
@@ -1554,53 +2869,65 @@ func TestDetermineUpgrades(t *testing.T) {
 				entities.Dependency{
 					Name:       "my_mod",
 					Source:     "git::https://github.com/org/mod",
-					CurrentVer: "v2.0.0",
+					CurrentVer: "v1.0.0",
 					FilePath:   "main.tf",
 				},
 				`module "my_mod" {
-  source = "git::https://github.com/org/mod?ref=v2.0.0"
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
 }`,
 				terraform.DepKindModule,
 			),
 		}
 		repo := entities.Repository{Organization: "org", Name: "repo"}
 		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{TagPattern: "v*"}
 
 		// when
-		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
 
 		// then
-		assert.Empty(t, upgrades)
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v2.1.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
 	})
 
-	t.Run("should return empty when no tags are available", func(t *testing.T) {
+	t.Run("should fall back to the highest tag when the tracked branch cannot be resolved", func(t *testing.T) {
 		t.Parallel()
 
 		// given
+		depRepo := entities.Repository{
+			Organization: "org",
+			Name:         "mod",
+			RemoteURL:    newTaggedModuleRepo(t, "v2.1.0"),
+		}
 		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
-			WithRepositories([]entities.Repository{}).
+			WithRepositories([]entities.Repository{depRepo}).
+			WithTags([]string{"v3.0.0", "v2.1.0", "v1.0.0"}).
 			BuildSpy()
 
 		allDeps := []terraform.DepWithContent{
 			terraform.NewDepWithContent(
 				entities.Dependency{
 					Name:       "my_mod",
-					Source:     "git::https://github.com/org/unknown-mod",
+					Source:     "git::https://github.com/org/mod",
 					CurrentVer: "v1.0.0",
 					FilePath:   "main.tf",
 				},
-				"content",
+				`module "my_mod" {
+  source = "git::https://github.com/org/mod?ref=v1.0.0"
+}`,
 				terraform.DepKindModule,
 			),
 		}
 		repo := entities.Repository{Organization: "org", Name: "repo"}
 		updater := &terraform.UpdaterRepository{}
+		opts := entities.UpdateOptions{TrackBranches: map[string]string{"mod": "release"}}
 
 		// when
-		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps)
+		upgrades := terraform.DetermineUpgrades(updater, t.Context(), provider, repo, allDeps, opts)
 
 		// then
-		assert.Empty(t, upgrades)
+		require.Len(t, upgrades, 1)
+		assert.Equal(t, "v3.0.0", terraform.UpgradeTaskNewVersion(upgrades[0]))
 	})
 }
 
@@ -1619,7 +2946,9 @@ func TestResolveTagsForSource(t *testing.T) {
 		currentRepo := entities.Repository{Organization: "org", Name: "repo"}
 
 		// when
-		tags, repo := terraform.ResolveTagsForSource(t.Context(), provider, currentRepo, "git::https://github.com/org/my-module")
+		tags, repo := terraform.ResolveTagsForSource(
+			t.Context(), provider, currentRepo, "git::https://github.com/org/my-module", nil,
+		)
 
 		// then
 		require.NotNil(t, repo)
@@ -1639,13 +2968,60 @@ func TestResolveTagsForSource(t *testing.T) {
 		currentRepo := entities.Repository{Organization: "org", Name: "repo"}
 
 		// when
-		tags, repo := terraform.ResolveTagsForSource(t.Context(), provider, currentRepo, "git::https://github.com/org/my-module")
+		tags, repo := terraform.ResolveTagsForSource(
+			t.Context(), provider, currentRepo, "git::https://github.com/org/my-module", nil,
+		)
 
 		// then
 		assert.Nil(t, repo)
 		assert.Nil(t, tags)
 	})
 
+	t.Run("should resolve tags from the repository an image is mapped to", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{
+				{Organization: "org", Name: "relayer-http"},
+				{Organization: "org", Name: "service-relayer"},
+			}).
+			WithTags([]string{"v0.8.0", "v0.7.0"}).
+			BuildSpy()
+		currentRepo := entities.Repository{Organization: "org", Name: "repo"}
+		imageSources := map[string]string{"relayer-http": "service-relayer"}
+
+		// when
+		tags, repo := terraform.ResolveTagsForSource(t.Context(), provider, currentRepo, "relayer-http", imageSources)
+
+		// then
+		require.NotNil(t, repo)
+		assert.Equal(t, "service-relayer", repo.Name)
+		assert.Equal(t, []string{"v0.8.0", "v0.7.0"}, tags)
+		assert.Equal(t, []string{"org"}, provider.DiscoveredOrgs)
+	})
+
+	t.Run("should discover the organization of an org-qualified image mapping", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithRepositories([]entities.Repository{{Organization: "platform", Name: "service-relayer"}}).
+			WithTags([]string{"v0.8.0"}).
+			BuildSpy()
+		currentRepo := entities.Repository{Organization: "org", Name: "repo"}
+		imageSources := map[string]string{"relayer-http": "platform/service-relayer"}
+
+		// when
+		tags, repo := terraform.ResolveTagsForSource(t.Context(), provider, currentRepo, "relayer-http", imageSources)
+
+		// then
+		require.NotNil(t, repo)
+		assert.Equal(t, "service-relayer", repo.Name)
+		assert.Equal(t, []string{"v0.8.0"}, tags)
+		assert.Equal(t, []string{"platform"}, provider.DiscoveredOrgs)
+	})
+
 	t.Run("should return nil when discover fails", func(t *testing.T) {
 		t.Parallel()
 
@@ -1656,7 +3032,9 @@ func TestResolveTagsForSource(t *testing.T) {
 		currentRepo := entities.Repository{Organization: "org", Name: "repo"}
 
 		// when
-		tags, repo := terraform.ResolveTagsForSource(t.Context(), provider, currentRepo, "git::https://github.com/org/my-module")
+		tags, repo := terraform.ResolveTagsForSource(
+			t.Context(), provider, currentRepo, "git::https://github.com/org/my-module", nil,
+		)
 
 		// then
 		assert.Nil(t, repo)
@@ -1744,6 +3122,89 @@ func TestCreateUpgradePR(t *testing.T) {
 		assert.Empty(t, provider.BranchInputs)
 		assert.Empty(t, provider.PRInputs)
 	})
+
+	t.Run("should close the stale PR of the same module after creating the new one", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithPRExistsResult(false).
+			WithExistingFiles(map[string]bool{}).
+			BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			{PullRequest: entities.PullRequest{ID: 4}, SourceBranch: "refs/heads/chore/upgrade-my-module.git-v1.5.0"},
+			{PullRequest: entities.PullRequest{ID: 5}, SourceBranch: "chore/upgrade-my-module.git-extras-v1.5.0"},
+			{PullRequest: entities.PullRequest{ID: 6}, SourceBranch: "chore/upgrade-other-module.git-v1.5.0"},
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		opts := entities.UpdateOptions{SupersedeStale: true}
+		upgrades := []terraform.UpgradeTask{
+			terraform.NewUpgradeTask(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/my-module.git",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+					Line:       1,
+				},
+				"v2.0.0",
+				`module "my_mod" {
+  source = "git::https://github.com/org/my-module.git?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		prs, err := terraform.CreateUpgradePR(updater, t.Context(), provider, repo, opts, upgrades)
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		require.Len(t, provider.PRInputs, 1)
+		assert.Equal(t, "refs/heads/chore/upgrade-my-module.git-v2.0.0", provider.PRInputs[0].SourceBranch)
+		assert.Equal(t, []int{4}, provider.ClosedPRs)
+		assert.Equal(t, []string{"chore/upgrade-my-module.git-v1.5.0"}, provider.DeletedBranches)
+	})
+
+	t.Run("should leave stale PRs open when SupersedeStale is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithPRExistsResult(false).
+			WithExistingFiles(map[string]bool{}).
+			BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			{PullRequest: entities.PullRequest{ID: 4}, SourceBranch: "chore/upgrade-my-module.git-v1.5.0"},
+		}
+		repo := entities.Repository{Organization: "org", Name: "repo", DefaultBranch: "refs/heads/main"}
+		upgrades := []terraform.UpgradeTask{
+			terraform.NewUpgradeTask(
+				entities.Dependency{
+					Name:       "my_mod",
+					Source:     "git::https://github.com/org/my-module.git",
+					CurrentVer: "v1.0.0",
+					FilePath:   "main.tf",
+					Line:       1,
+				},
+				"v2.0.0",
+				`module "my_mod" {
+  source = "git::https://github.com/org/my-module.git?ref=v1.0.0"
+}`,
+				terraform.DepKindModule,
+			),
+		}
+		updater := &terraform.UpdaterRepository{}
+
+		// when
+		_, err := terraform.CreateUpgradePR(updater, t.Context(), provider, repo, entities.UpdateOptions{}, upgrades)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, provider.ClosedPRs)
+	})
 }
 
 func TestStripVersionPrefix(t *testing.T) {
@@ -1788,3 +3249,50 @@ func TestStripVersionPrefix(t *testing.T) {
 		assert.Equal(t, "1.0.0", result)
 	})
 }
+
+// newModuleUpgradeTasks returns count module upgrades of distinct modules
+// from v1.0.0 to v2.0.0.
+// newTaggedModuleRepo creates a git repository tagged v1.0.0, v2.1.0
+// (annotated) and v3.0.0 on successive commits, with a `stable` branch on
+// the commit of stableTag, and returns its path.
+func newTaggedModuleRepo(t *testing.T, stableTag string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.CommandContext(t.Context(), "git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q", "-b", "main")
+	for _, tag := range []string{"v1.0.0", "v2.1.0", "v3.0.0"} {
+		git("commit", "-q", "--allow-empty", "-m", "release "+tag)
+		if tag == "v2.1.0" {
+			git("tag", "-a", tag, "-m", tag)
+		} else {
+			git("tag", tag)
+		}
+	}
+	git("branch", "stable", stableTag)
+	return dir
+}
+
+func newModuleUpgradeTasks(count int) []terraform.UpgradeTask {
+	tasks := make([]terraform.UpgradeTask, 0, count)
+	for i := range count {
+		tasks = append(tasks, terraform.NewUpgradeTask(
+			entities.Dependency{
+				Name:       fmt.Sprintf("mod_%d", i),
+				Source:     fmt.Sprintf("github.com/org/module-%d", i),
+				CurrentVer: "v1.0.0",
+			},
+			"v2.0.0", "", terraform.DepKindModule,
+		))
+	}
+	return tasks
+}
@@ -0,0 +1,66 @@
+package support
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	logger "github.com/sirupsen/logrus"
+)
+
+const (
+	// AnnotationField is the log field that marks an entry as a GitHub Actions
+	// annotation. Entries carrying it at info level are emitted as notices.
+	AnnotationField = "annotation"
+
+	// AnnotationNotice is the AnnotationField value for notice annotations.
+	AnnotationNotice = "notice"
+
+	annotationWarning = "warning"
+	annotationTitle   = "autoupdate"
+)
+
+// GitHubAnnotationHook is a logrus hook that mirrors selected log entries as
+// GitHub Actions workflow commands (`::notice::` / `::warning::`), so created
+// PRs and failures surface in the Actions UI alongside the regular logs.
+type GitHubAnnotationHook struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewGitHubAnnotationHook creates a hook that writes workflow commands to out.
+func NewGitHubAnnotationHook(out io.Writer) *GitHubAnnotationHook {
+	return &GitHubAnnotationHook{out: out}
+}
+
+// Levels returns the log levels the hook fires for.
+func (h *GitHubAnnotationHook) Levels() []logger.Level {
+	return logger.AllLevels
+}
+
+// Fire writes a warning annotation for error-level entries and a notice
+// annotation for entries explicitly tagged with AnnotationField.
+func (h *GitHubAnnotationHook) Fire(entry *logger.Entry) error {
+	var kind string
+	switch {
+	case entry.Level <= logger.ErrorLevel:
+		kind = annotationWarning
+	case entry.Data[AnnotationField] == AnnotationNotice:
+		kind = AnnotationNotice
+	default:
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprintln(h.out, FormatAnnotation(kind, entry.Message))
+	return err
+}
+
+// FormatAnnotation renders a GitHub Actions workflow command line. The
+// message is escaped so multi-line errors stay on a single command line.
+func FormatAnnotation(kind, message string) string {
+	escaped := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(message)
+	return fmt.Sprintf("::%s title=%s::%s", kind, annotationTitle, escaped)
+}
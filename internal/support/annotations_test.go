@@ -0,0 +1,100 @@
+//go:build unit
+
+package support_test
+
+import (
+	"bytes"
+	"testing"
+
+	logger "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestFormatAnnotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render a workflow command with the autoupdate title", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		message := "Created PR #1"
+
+		// when
+		line := support.FormatAnnotation("notice", message)
+
+		// then
+		assert.Equal(t, "::notice title=autoupdate::Created PR #1", line)
+	})
+
+	t.Run("should escape newlines and percent signs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		message := "failed: 100%\nOutput:\r\nboom"
+
+		// when
+		line := support.FormatAnnotation("warning", message)
+
+		// then
+		assert.Equal(t, "::warning title=autoupdate::failed: 100%25%0AOutput:%0D%0Aboom", line)
+	})
+}
+
+func TestGitHubAnnotationHook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should emit a warning annotation for error entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buf bytes.Buffer
+		hook := support.NewGitHubAnnotationHook(&buf)
+		entry := &logger.Entry{Level: logger.ErrorLevel, Message: "Failed to clone org/repo"}
+
+		// when
+		err := hook.Fire(entry)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "::warning title=autoupdate::Failed to clone org/repo\n", buf.String())
+	})
+
+	t.Run("should emit a notice annotation for tagged info entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buf bytes.Buffer
+		hook := support.NewGitHubAnnotationHook(&buf)
+		entry := &logger.Entry{
+			Level:   logger.InfoLevel,
+			Message: "Created PR #7",
+			Data:    logger.Fields{support.AnnotationField: support.AnnotationNotice},
+		}
+
+		// when
+		err := hook.Fire(entry)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "::notice title=autoupdate::Created PR #7\n", buf.String())
+	})
+
+	t.Run("should ignore untagged info entries", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var buf bytes.Buffer
+		hook := support.NewGitHubAnnotationHook(&buf)
+		entry := &logger.Entry{Level: logger.InfoLevel, Message: "Processing provider: github"}
+
+		// when
+		err := hook.Fire(entry)
+
+		// then
+		require.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,136 @@
+package support
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // git blob ids are SHA-1 by definition, not used for security
+	"encoding/hex"
+	"fmt"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+// changelogConflictRetries bounds how many times a changed CHANGELOG.md is
+// refetched before the entries are dropped instead of pushed.
+const changelogConflictRetries = 3
+
+// ChangelogChange is a CHANGELOG.md FileChange built from content fetched
+// through the provider API. BaseBlobID is the git blob id of that content,
+// so the change can be checked against the remote right before it is pushed.
+type ChangelogChange struct {
+	Change     entities.FileChange
+	Entries    []string
+	Section    string
+	BaseBlobID string
+}
+
+// GitBlobID returns the git blob id (the SHA-1 of "blob <size>\x00<content>")
+// of the given content, as `git hash-object` would compute it.
+func GitBlobID(content string) string {
+	//nolint:gosec // git blob ids are SHA-1 by definition, not used for security
+	sum := sha1.Sum([]byte(fmt.Sprintf("blob %d\x00%s", len(content), content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// BuildChangelogChange fetches the configured changelog file and inserts the
+// entries into it, under the configured subsection of the unreleased section
+// (see entities.InsertChangelogEntryInSection). It returns nil when the
+// repository has no changelog, it cannot be read, or the entries are already
+// present.
+func BuildChangelogChange(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	entries []string,
+	changelog entities.ChangelogSettings,
+) *ChangelogChange {
+	changelogPath := changelog.FilePath()
+	if !provider.HasFile(ctx, repo, changelogPath) {
+		return nil
+	}
+
+	content, err := provider.GetFileContent(ctx, repo, changelogPath)
+	if err != nil {
+		logger.Warnf("Failed to read %s: %v", changelogPath, err)
+		return nil
+	}
+
+	modified := entities.InsertChangelogEntryInSection(content, entries, changelog.Section)
+	if modified == content {
+		return nil
+	}
+
+	return &ChangelogChange{
+		Change: entities.FileChange{
+			Path:       changelogPath,
+			Content:    modified,
+			ChangeType: "edit",
+		},
+		Entries:    entries,
+		Section:    changelog.Section,
+		BaseBlobID: GitBlobID(content),
+	}
+}
+
+// ReconcileChangelogChange refetches CHANGELOG.md right before a push and
+// compares it with the base the change was built from, then appends the
+// change to fileChanges. When someone changed the file in between, the change
+// is never pushed as is, since that would clobber the newer entries: with
+// entities.ChangelogConflictRetry (also when empty) the entries are
+// re-inserted into the refetched content, and with
+// entities.ChangelogConflictSkip the changelog is left out of the push.
+func ReconcileChangelogChange(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	fileChanges []entities.FileChange,
+	change *ChangelogChange,
+	policy string,
+) []entities.FileChange {
+	if change == nil {
+		return fileChanges
+	}
+
+	changelogPath := change.Change.Path
+	for range changelogConflictRetries {
+		current, err := provider.GetFileContent(ctx, repo, changelogPath)
+		if err != nil {
+			logger.Warnf("Failed to refetch %s, leaving it out of the push: %v", changelogPath, err)
+			return fileChanges
+		}
+
+		currentBlobID := GitBlobID(current)
+		if currentBlobID == change.BaseBlobID {
+			return append(fileChanges, change.Change)
+		}
+
+		if policy == entities.ChangelogConflictSkip {
+			logger.Warnf("%s changed since it was fetched (%s -> %s), leaving it out of the push",
+				changelogPath, shortBlobID(change.BaseBlobID), shortBlobID(currentBlobID))
+			return fileChanges
+		}
+
+		logger.Infof("%s changed since it was fetched (%s -> %s), re-inserting the entries",
+			changelogPath, shortBlobID(change.BaseBlobID), shortBlobID(currentBlobID))
+		modified := entities.InsertChangelogEntryInSection(current, change.Entries, change.Section)
+		if modified == current {
+			return fileChanges
+		}
+		change.Change.Content = modified
+		change.BaseBlobID = currentBlobID
+	}
+
+	logger.Warnf("%s kept changing after %d refetches, leaving it out of the push",
+		changelogPath, changelogConflictRetries)
+	return fileChanges
+}
+
+func shortBlobID(id string) string {
+	const shortLen = 7
+	if len(id) > shortLen {
+		return id[:shortLen]
+	}
+	return id
+}
@@ -0,0 +1,239 @@
+//go:build unit
+
+package support_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+	"github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
+)
+
+const baseChangelog = `# Changelog
+
+## [Unreleased]
+
+## [1.0.0] - 2026-01-01
+
+### Added
+
+- added the first release
+`
+
+const mergedChangelog = `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- added a feature merged while the update was running
+
+## [1.0.0] - 2026-01-01
+
+### Added
+
+- added the first release
+`
+
+// changingChangelogProvider serves successive CHANGELOG.md contents, one per
+// GetFileContent call, to simulate merges landing between fetch and push.
+type changingChangelogProvider struct {
+	*repositorydoubles.SpyProviderRepository
+
+	mu       sync.Mutex
+	contents []string
+	err      error
+	fetches  int
+}
+
+func (p *changingChangelogProvider) GetFileContent(
+	_ context.Context, _ entities.Repository, _ string,
+) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.fetches >= len(p.contents) {
+		return "", p.err
+	}
+	content := p.contents[p.fetches]
+	p.fetches++
+	return content, nil
+}
+
+func newChangingChangelogProvider(contents ...string) *changingChangelogProvider {
+	return &changingChangelogProvider{
+		SpyProviderRepository: repositorydoubles.NewSpyProviderRepositoryBuilder().
+			WithExistingFiles(map[string]bool{"CHANGELOG.md": true}).
+			BuildSpy(),
+		contents: contents,
+	}
+}
+
+func TestGitBlobID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should match the id git hash-object computes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		content := "hello\n"
+
+		// when
+		id := support.GitBlobID(content)
+
+		// then
+		assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", id)
+	})
+}
+
+func TestBuildChangelogChange(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should record the blob id of the fetched content as the base", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(baseChangelog)
+		entries := []string{"- changed the Go version to `1.26.0`"}
+
+		// when
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+
+		// then
+		require.NotNil(t, change)
+		assert.Equal(t, support.GitBlobID(baseChangelog), change.BaseBlobID)
+		assert.Equal(t, "CHANGELOG.md", change.Change.Path)
+		assert.Contains(t, change.Change.Content, entries[0])
+	})
+
+	t.Run("should return nil when the repository has no changelog", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+
+		// when
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, []string{"- changed x"}, entities.ChangelogSettings{},
+		)
+
+		// then
+		assert.Nil(t, change)
+	})
+}
+
+func TestReconcileChangelogChange(t *testing.T) {
+	t.Parallel()
+
+	entries := []string{"- changed the Go version to `1.26.0`"}
+	existing := []entities.FileChange{{Path: "go.mod", Content: "module x", ChangeType: "edit"}}
+
+	t.Run("should push the change as built when the base is unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(baseChangelog, baseChangelog)
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+		built := change.Change
+
+		// when
+		result := support.ReconcileChangelogChange(
+			t.Context(), provider, entities.Repository{}, existing, change, entities.ChangelogConflictRetry,
+		)
+
+		// then
+		require.Len(t, result, 2)
+		assert.Equal(t, built, result[1])
+		assert.Equal(t, 2, provider.fetches)
+	})
+
+	t.Run("should refetch and re-insert the entries when the base changed before the push", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(baseChangelog, mergedChangelog, mergedChangelog)
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+
+		// when
+		result := support.ReconcileChangelogChange(
+			t.Context(), provider, entities.Repository{}, existing, change, "",
+		)
+
+		// then
+		require.Len(t, result, 2)
+		assert.Equal(t, 3, provider.fetches)
+		assert.Equal(t, entities.InsertChangelogEntry(mergedChangelog, entries), result[1].Content)
+		assert.Contains(t, result[1].Content, "- added a feature merged while the update was running")
+		assert.Contains(t, result[1].Content, entries[0])
+		assert.Equal(t, support.GitBlobID(mergedChangelog), change.BaseBlobID)
+	})
+
+	t.Run("should leave the changelog out when the base changed and the policy is skip", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(baseChangelog, mergedChangelog)
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+
+		// when
+		result := support.ReconcileChangelogChange(
+			t.Context(), provider, entities.Repository{}, existing, change, entities.ChangelogConflictSkip,
+		)
+
+		// then
+		assert.Equal(t, existing, result)
+	})
+
+	t.Run("should leave the changelog out when it keeps changing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(
+			baseChangelog, mergedChangelog, mergedChangelog+"\n", mergedChangelog+"\n\n",
+		)
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+
+		// when
+		result := support.ReconcileChangelogChange(
+			t.Context(), provider, entities.Repository{}, existing, change, entities.ChangelogConflictRetry,
+		)
+
+		// then
+		assert.Equal(t, existing, result)
+	})
+
+	t.Run("should leave the changelog out when the refetch fails", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := newChangingChangelogProvider(baseChangelog)
+		provider.err = errors.New("boom")
+		change := support.BuildChangelogChange(
+			t.Context(), provider, entities.Repository{}, entries, entities.ChangelogSettings{},
+		)
+
+		// when
+		result := support.ReconcileChangelogChange(
+			t.Context(), provider, entities.Repository{}, existing, change, entities.ChangelogConflictRetry,
+		)
+
+		// then
+		assert.Equal(t, existing, result)
+	})
+}
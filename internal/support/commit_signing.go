@@ -0,0 +1,38 @@
+package support
+
+import (
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// SigningKeyEnv is the variable the upgrade scripts read the signing key from.
+const SigningKeyEnv = "SIGNING_KEY"
+
+// CommitSigningScript returns the script lines configuring git to sign
+// commits with the key in $SIGNING_KEY, or "" when signing has no key.
+// The scripts point GIT_CONFIG_GLOBAL at a temporary file, so the
+// --global writes never leak into the user's own git config.
+func CommitSigningScript(signing entities.Signing) string {
+	if signing.Key == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Sign the upgrade commit\n")
+	if signing.Format == entities.SigningFormatSSH {
+		sb.WriteString("git config --global gpg.format ssh\n")
+	}
+	sb.WriteString("git config --global user.signingkey \"$" + SigningKeyEnv + "\"\n")
+	sb.WriteString("git config --global commit.gpgsign true\n\n")
+	return sb.String()
+}
+
+// GitCommitCommand returns the command the upgrade scripts commit with:
+// "git commit -S" when signing has a key, "git commit" otherwise.
+func GitCommitCommand(signed bool) string {
+	if signed {
+		return "git commit -S"
+	}
+	return "git commit"
+}
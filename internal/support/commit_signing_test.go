@@ -0,0 +1,89 @@
+//go:build unit
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestCommitSigningScript(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should configure gpg signing with the key from the environment", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signing := entities.Signing{Key: "ABC123"}
+
+		// when
+		script := support.CommitSigningScript(signing)
+
+		// then
+		assert.Contains(t, script, `git config --global user.signingkey "$SIGNING_KEY"`)
+		assert.Contains(t, script, "git config --global commit.gpgsign true")
+		assert.NotContains(t, script, "gpg.format")
+		assert.NotContains(t, script, "ABC123")
+	})
+
+	t.Run("should switch git to ssh signing for the ssh format", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signing := entities.Signing{Key: "/keys/id_ed25519.pub", Format: entities.SigningFormatSSH}
+
+		// when
+		script := support.CommitSigningScript(signing)
+
+		// then
+		assert.Contains(t, script, "git config --global gpg.format ssh")
+		assert.Contains(t, script, "git config --global commit.gpgsign true")
+	})
+
+	t.Run("should return nothing when no key is configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signing := entities.Signing{Format: entities.SigningFormatSSH}
+
+		// when
+		script := support.CommitSigningScript(signing)
+
+		// then
+		assert.Empty(t, script)
+	})
+}
+
+func TestGitCommitCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should add -S when signing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signed := true
+
+		// when
+		command := support.GitCommitCommand(signed)
+
+		// then
+		assert.Equal(t, "git commit -S", command)
+	})
+
+	t.Run("should commit plainly when not signing", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		signed := false
+
+		// when
+		command := support.GitCommitCommand(signed)
+
+		// then
+		assert.Equal(t, "git commit", command)
+	})
+}
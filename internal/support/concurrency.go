@@ -0,0 +1,62 @@
+package support
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// minResourceSlots is the lower bound of the process-wide resource guard.
+// Sub-project jobs mostly wait on the network (package registries, module
+// proxies), so small hosts still get some parallelism.
+const minResourceSlots = 4
+
+// resourceGuard caps the number of sub-project jobs running at once across
+// every updater in the process, so per-updater limits cannot oversubscribe
+// the host.
+var resourceGuard = make( //nolint:gochecknoglobals // process-wide semaphore
+	chan struct{}, max(runtime.NumCPU(), minResourceSlots),
+)
+
+// ForEachBounded calls fn for every item with at most limit calls running
+// concurrently. A limit below 1 runs the items sequentially. Each call also
+// holds a slot of the process-wide resource guard while it runs. All items
+// are processed; the first error in item order is returned.
+func ForEachBounded[T any](
+	ctx context.Context,
+	items []T,
+	limit int,
+	fn func(ctx context.Context, index int, item T) error,
+) error {
+	limit = max(limit, 1)
+	errs := make([]error, len(items))
+	slots := make(chan struct{}, limit)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			select {
+			case resourceGuard <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-resourceGuard }()
+
+			errs[i] = fn(ctx, i, item)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
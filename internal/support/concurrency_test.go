@@ -0,0 +1,104 @@
+//go:build unit
+
+package support_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestForEachBounded(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should run items concurrently up to the limit", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		const limit = 2
+		items := []string{"a", "b", "c", "d", "e"}
+		var active, peak atomic.Int32
+		reachedLimit := make(chan struct{})
+		var once sync.Once
+
+		// when
+		err := support.ForEachBounded(t.Context(), items, limit,
+			func(_ context.Context, _ int, _ string) error {
+				current := active.Add(1)
+				defer active.Add(-1)
+				for {
+					observed := peak.Load()
+					if current <= observed || peak.CompareAndSwap(observed, current) {
+						break
+					}
+				}
+				if current == limit {
+					once.Do(func() { close(reachedLimit) })
+				}
+				select {
+				case <-reachedLimit:
+				case <-time.After(time.Second):
+				}
+				return nil
+			},
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, int32(limit), peak.Load())
+	})
+
+	t.Run("should run sequentially when the limit is below one", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		items := []int{1, 2, 3}
+		var mu sync.Mutex
+		var order []int
+
+		// when
+		err := support.ForEachBounded(t.Context(), items, 0,
+			func(_ context.Context, _ int, item int) error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, item)
+				return nil
+			},
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, order)
+	})
+
+	t.Run("should process every item and return the first error in item order", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		items := []string{"ok", "first", "second"}
+		var calls atomic.Int32
+
+		// when
+		err := support.ForEachBounded(t.Context(), items, len(items),
+			func(_ context.Context, _ int, item string) error {
+				calls.Add(1)
+				if item == "ok" {
+					return nil
+				}
+				return errors.New(item)
+			},
+		)
+
+		// then
+		require.EqualError(t, err, "first")
+		assert.Equal(t, int32(3), calls.Load())
+	})
+}
@@ -0,0 +1,67 @@
+package support
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+// contentHashBranchLength is the number of hex digits of the content hash
+// ContentHashBranch appends to a branch name.
+const contentHashBranchLength = 12
+
+// ContentHash returns a SHA-256 over the path, change type and content of
+// every change, independent of their order. Two runs computing the same
+// file changes get the same hash whatever branch they are pushed to.
+func ContentHash(changes []entities.FileChange) string {
+	sorted := make([]entities.FileChange, len(changes))
+	copy(sorted, changes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	hash := sha256.New()
+	for _, change := range sorted {
+		fmt.Fprintf(hash, "%s\x00%s\x00%d\x00%s", change.Path, change.ChangeType, len(change.Content), change.Content)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ContentHashBranch returns branch suffixed with the leading digits of
+// hash, e.g. `chore/autoupdate-2026-10-16-3f2a9c1b7d4e`, so the changes of
+// an open pull request can be recognised from its source branch alone.
+func ContentHashBranch(branch, hash string) string {
+	return branch + "-" + hash[:min(len(hash), contentHashBranchLength)]
+}
+
+// FindPullRequestByContentHash returns the first open pull request whose
+// source branch starts with branchPrefix and was named by ContentHashBranch
+// after hash, or nil when there is none.
+func FindPullRequestByContentHash(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	branchPrefix, hash string,
+) (*entities.PullRequestDetail, error) {
+	lister, ok := provider.(repositories.OpenPullRequestLister)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOpenPullRequestsUnsupported, provider.Name())
+	}
+	prs, err := lister.ListOpenPullRequests(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	suffix := ContentHashBranch("", hash)
+	for _, pr := range prs {
+		branch := strings.TrimPrefix(pr.SourceBranch, "refs/heads/")
+		if strings.HasPrefix(branch, branchPrefix) && strings.HasSuffix(branch, suffix) {
+			return &pr, nil
+		}
+	}
+	return nil, nil
+}
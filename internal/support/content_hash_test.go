@@ -0,0 +1,125 @@
+//go:build unit
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+	"github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
+)
+
+func TestContentHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should not depend on the order of the changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		first := entities.FileChange{Path: "go.mod", Content: "go 1.26\n", ChangeType: "edit"}
+		second := entities.FileChange{Path: "go.sum", Content: "sum\n", ChangeType: "edit"}
+
+		// when
+		forward := support.ContentHash([]entities.FileChange{first, second})
+		backward := support.ContentHash([]entities.FileChange{second, first})
+
+		// then
+		assert.Equal(t, forward, backward)
+		assert.Len(t, forward, 64)
+	})
+
+	t.Run("should change when a file content changes", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		before := []entities.FileChange{{Path: "go.mod", Content: "go 1.26\n", ChangeType: "edit"}}
+		after := []entities.FileChange{{Path: "go.mod", Content: "go 1.27\n", ChangeType: "edit"}}
+
+		// when
+		beforeHash := support.ContentHash(before)
+		afterHash := support.ContentHash(after)
+
+		// then
+		assert.NotEqual(t, beforeHash, afterHash)
+	})
+}
+
+func TestContentHashBranch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should suffix the branch with the leading digits of the hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		hash := support.ContentHash([]entities.FileChange{{Path: "go.mod", Content: "go 1.26\n", ChangeType: "edit"}})
+
+		// when
+		branch := support.ContentHashBranch("chore/autoupdate-2026-10-16", hash)
+
+		// then
+		assert.Equal(t, "chore/autoupdate-2026-10-16-"+hash[:12], branch)
+	})
+}
+
+func TestFindPullRequestByContentHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the open PR whose branch carries the hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		hash := "3f2a9c1b7d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8"
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			openPR(1, support.ContentHashBranch("feature/login", hash), "alice"),
+			openPR(2, "refs/heads/chore/autoupdate-2026-10-01-000000000000", "autoupdate-bot"),
+			openPR(3, "refs/heads/"+support.ContentHashBranch("chore/autoupdate-2026-10-02", hash), "autoupdate-bot"),
+		}
+
+		// when
+		pr, err := support.FindPullRequestByContentHash(
+			t.Context(), provider, entities.Repository{}, "chore/autoupdate-", hash,
+		)
+
+		// then
+		require.NoError(t, err)
+		require.NotNil(t, pr)
+		assert.Equal(t, 3, pr.ID)
+	})
+
+	t.Run("should return nil when no PR branch carries the hash", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{openPR(2, "chore/autoupdate-2026-10-01", "autoupdate-bot")}
+
+		// when
+		pr, err := support.FindPullRequestByContentHash(
+			t.Context(), provider, entities.Repository{}, "chore/autoupdate-", "abc123",
+		)
+
+		// then
+		require.NoError(t, err)
+		assert.Nil(t, pr)
+	})
+
+	t.Run("should fail when the provider cannot list pull requests", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &repositorydoubles.DummyProviderRepository{}
+
+		// when
+		_, err := support.FindPullRequestByContentHash(
+			t.Context(), provider, entities.Repository{}, "chore/autoupdate-", "abc123",
+		)
+
+		// then
+		require.ErrorIs(t, err, support.ErrOpenPullRequestsUnsupported)
+	})
+}
@@ -0,0 +1,44 @@
+package support
+
+import (
+	"strings"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// DiffStatMarker prefixes the line on which the upgrade scripts echo the
+// `git diff --shortstat` of the commit they pushed.
+const DiffStatMarker = "DIFF_STAT="
+
+// DiffStatScript is the script line that emits the marker after the
+// upgrade commit. It never fails the script: a missing parent commit
+// yields an empty stat.
+const DiffStatScript = "echo \"" + DiffStatMarker + "$(git diff --shortstat HEAD~1 HEAD 2>/dev/null || true)\"\n"
+
+// ParseDiffStat returns the stat following the last DiffStatMarker line of
+// a script output, e.g. "3 files changed, 10 insertions(+), 2 deletions(-)",
+// or "" when the script emitted none.
+func ParseDiffStat(output string) string {
+	stat := ""
+	for line := range strings.SplitSeq(output, "\n") {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(line), DiffStatMarker); ok {
+			stat = strings.TrimSpace(rest)
+		}
+	}
+	return stat
+}
+
+// AppendDiffStat adds a files-changed line to a PR description, above the
+// default footer when the description ends with it. An empty stat leaves
+// the description unchanged.
+func AppendDiffStat(description, stat string) string {
+	if stat == "" {
+		return description
+	}
+	line := "\n**Files changed:** " + stat + "\n"
+	footer := "\n---\n" + entities.DefaultPRFooter + "\n"
+	if body, ok := strings.CutSuffix(description, footer); ok {
+		return body + line + footer
+	}
+	return description + line
+}
@@ -0,0 +1,89 @@
+//go:build unit
+
+package support_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestParseDiffStat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the stat echoed after the marker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		output := "Changes detected, committing and pushing...\n" +
+			"DIFF_STAT= 3 files changed, 10 insertions(+), 2 deletions(-)\n" +
+			"CHANGES_PUSHED=true\n"
+
+		// when
+		stat := support.ParseDiffStat(output)
+
+		// then
+		assert.Equal(t, "3 files changed, 10 insertions(+), 2 deletions(-)", stat)
+	})
+
+	t.Run("should return an empty stat when the script emitted no marker", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		output := "No changes detected.\nCHANGES_PUSHED=false\n"
+
+		// when
+		stat := support.ParseDiffStat(output)
+
+		// then
+		assert.Empty(t, stat)
+	})
+}
+
+func TestAppendDiffStat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should insert the files-changed line above the default footer", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		description := "## Summary\n\n---\n" + entities.DefaultPRFooter + "\n"
+
+		// when
+		result := support.AppendDiffStat(description, "1 file changed, 1 insertion(+)")
+
+		// then
+		assert.Equal(t,
+			"## Summary\n\n**Files changed:** 1 file changed, 1 insertion(+)\n\n---\n"+entities.DefaultPRFooter+"\n",
+			result)
+	})
+
+	t.Run("should append the files-changed line when there is no footer", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		description := "## Summary\n"
+
+		// when
+		result := support.AppendDiffStat(description, "2 files changed")
+
+		// then
+		assert.Equal(t, "## Summary\n\n**Files changed:** 2 files changed\n", result)
+	})
+
+	t.Run("should leave the description unchanged without a stat", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		description := "## Summary\n"
+
+		// when
+		result := support.AppendDiffStat(description, "")
+
+		// then
+		assert.Equal(t, description, result)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	logger "github.com/sirupsen/logrus"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 )
 
 // WalkFilesByExtension finds all files under root whose names end with ext.
@@ -67,6 +68,42 @@ func WalkFilesByPredicate(root string, match func(name string) bool) ([]string,
 	return matches, err
 }
 
+// ScopedDirs returns the absolute directories under repoDir an updater should
+// operate in. With no paths configured it returns repoDir itself; otherwise
+// it returns every configured path that stays inside repoDir and contains
+// one of the marker files (e.g. "package.json"), in configuration order.
+func ScopedDirs(repoDir string, paths []string, markers ...string) []string {
+	if len(paths) == 0 {
+		return []string{repoDir}
+	}
+
+	var dirs []string
+	for _, p := range paths {
+		rel := filepath.Clean(filepath.FromSlash(strings.TrimLeft(strings.TrimSpace(p), "/")))
+		if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			logger.Warnf("Ignoring path %q: it escapes the repository root", p)
+			continue
+		}
+		dir := filepath.Join(repoDir, rel)
+		if !hasAnyFile(dir, markers) {
+			logger.Debugf("Skipping path %q: no %s found", p, strings.Join(markers, " or "))
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// hasAnyFile reports whether dir contains at least one of the named files.
+func hasAnyFile(dir string, names []string) bool {
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteFileChanges writes file changes to the filesystem rooted at rootDir.
 func WriteFileChanges(rootDir string, changes []entities.FileChange) error {
 	for _, c := range changes {
@@ -82,18 +119,28 @@ func WriteFileChanges(rootDir string, changes []entities.FileChange) error {
 	return nil
 }
 
-// RedactTokens replaces occurrences of the given tokens with "[REDACTED]"
-// in the input string. This prevents auth tokens from leaking into logs
-// or error messages when script output is captured.
+// RedactTokens replaces occurrences of the given tokens with "***" (the
+// mask GitHub Actions uses for secrets) in the input string. This prevents
+// auth tokens from leaking into logs or error messages when script output
+// is captured, e.g. from a git URL embedding a PAT.
 func RedactTokens(input string, tokens ...string) string {
 	for _, token := range tokens {
 		if token != "" {
-			input = strings.ReplaceAll(input, token, "[REDACTED]")
+			input = strings.ReplaceAll(input, token, "***")
 		}
 	}
 	return input
 }
 
+// ProviderAuthToken returns the token of the given provider, or "" when
+// there is none, so callers can redact it without a nil check.
+func ProviderAuthToken(provider repositories.ProviderRepository) string {
+	if provider == nil {
+		return ""
+	}
+	return provider.AuthToken()
+}
+
 // HasUncommittedChanges returns true when the git working tree at repoDir
 // contains unstaged or untracked modifications. On error (e.g. git not
 // found, not a repo) it returns true to avoid false negatives that would
@@ -109,18 +156,19 @@ func HasUncommittedChanges(ctx context.Context, repoDir string) bool {
 	return len(strings.TrimSpace(string(output))) > 0
 }
 
-// LocalChangelogUpdate reads CHANGELOG.md from repoDir, inserts entries,
+// LocalChangelogUpdate reads the configured changelog file from repoDir,
+// inserts entries under the configured subsection of the unreleased section,
 // and writes it back if modified. Returns true if the file was updated.
-func LocalChangelogUpdate(repoDir string, entries []string) bool {
-	changelogPath := filepath.Clean(filepath.Join(repoDir, "CHANGELOG.md"))
+func LocalChangelogUpdate(repoDir string, entries []string, changelog entities.ChangelogSettings) bool {
+	changelogPath := filepath.Clean(filepath.Join(repoDir, filepath.FromSlash(changelog.FilePath())))
 	data, err := os.ReadFile(changelogPath)
 	if err != nil {
-		logger.Warnf("Failed to read CHANGELOG.md: %v", err)
+		logger.Warnf("Failed to read %s: %v", changelog.FilePath(), err)
 		return false
 	}
 
 	content := string(data)
-	modified := entities.InsertChangelogEntry(content, entries)
+	modified := entities.InsertChangelogEntryInSection(content, entries, changelog.Section)
 	if modified == content {
 		return false
 	}
@@ -131,7 +179,7 @@ func LocalChangelogUpdate(repoDir string, entries []string) bool {
 		0o600,
 	)
 	if writeErr != nil {
-		logger.Warnf("Failed to write CHANGELOG.md: %v", writeErr)
+		logger.Warnf("Failed to write %s: %v", changelog.FilePath(), writeErr)
 		return false
 	}
 	return true
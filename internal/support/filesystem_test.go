@@ -18,7 +18,7 @@ import (
 func TestRedactTokens(t *testing.T) {
 	t.Parallel()
 
-	t.Run("should replace a single token with REDACTED", func(t *testing.T) {
+	t.Run("should replace a single token with ***", func(t *testing.T) {
 		t.Parallel()
 
 		// given
@@ -28,7 +28,7 @@ func TestRedactTokens(t *testing.T) {
 		result := support.RedactTokens(input, "ghp_secret123")
 
 		// then
-		assert.Equal(t, "Authorization: Bearer [REDACTED]", result)
+		assert.Equal(t, "Authorization: Bearer ***", result)
 	})
 
 	t.Run("should replace multiple tokens", func(t *testing.T) {
@@ -41,7 +41,7 @@ func TestRedactTokens(t *testing.T) {
 		result := support.RedactTokens(input, "abc", "xyz")
 
 		// then
-		assert.Equal(t, "token1=[REDACTED] token2=[REDACTED]", result)
+		assert.Equal(t, "token1=*** token2=***", result)
 	})
 
 	t.Run("should skip empty tokens", func(t *testing.T) {
@@ -204,6 +204,69 @@ func TestWalkFilesByPredicate(t *testing.T) {
 	})
 }
 
+func TestScopedDirs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the repository root when no paths are configured", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+
+		// when
+		dirs := support.ScopedDirs(repoDir, nil, "package.json")
+
+		// then
+		assert.Equal(t, []string{repoDir}, dirs)
+	})
+
+	t.Run("should return only configured paths containing the marker file", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "web"), 0o750))
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "docs"), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "web", "package.json"), []byte("{}"), 0o600))
+
+		// when
+		dirs := support.ScopedDirs(repoDir, []string{"web", "docs", "missing"}, "package.json")
+
+		// then
+		assert.Equal(t, []string{filepath.Join(repoDir, "web")}, dirs)
+	})
+
+	t.Run("should return configured paths containing any of the marker files", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "api"), 0o750))
+		require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "jobs"), 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "api", "pyproject.toml"), []byte(""), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "jobs", "requirements.txt"), []byte(""), 0o600))
+
+		// when
+		dirs := support.ScopedDirs(repoDir, []string{"jobs", "api"}, "requirements.txt", "pyproject.toml")
+
+		// then
+		assert.Equal(t, []string{filepath.Join(repoDir, "jobs"), filepath.Join(repoDir, "api")}, dirs)
+	})
+
+	t.Run("should ignore paths that escape the repository root", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+
+		// when
+		dirs := support.ScopedDirs(repoDir, []string{"../outside"}, "package.json")
+
+		// then
+		assert.Empty(t, dirs)
+	})
+}
+
 func TestWriteFileChanges(t *testing.T) {
 	t.Parallel()
 
@@ -279,7 +342,7 @@ func TestLocalChangelogUpdate(t *testing.T) {
 		require.NoError(t, os.WriteFile(filepath.Join(root, "CHANGELOG.md"), []byte(changelog), 0o600))
 
 		// when
-		updated := support.LocalChangelogUpdate(root, []string{"- added new feature"})
+		updated := support.LocalChangelogUpdate(root, []string{"- added new feature"}, entities.ChangelogSettings{})
 
 		// then
 		assert.True(t, updated)
@@ -287,6 +350,25 @@ func TestLocalChangelogUpdate(t *testing.T) {
 		assert.Contains(t, string(data), "- added new feature")
 	})
 
+	t.Run("should insert the entries under the given section", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		root := t.TempDir()
+		changelog := "# Changelog\n\n## [Unreleased]\n\n## [1.0.0] - 2026-01-01\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "CHANGELOG.md"), []byte(changelog), 0o600))
+
+		// when
+		updated := support.LocalChangelogUpdate(root, []string{"- added new feature"},
+			entities.ChangelogSettings{Section: "Dependencies"})
+
+		// then
+		assert.True(t, updated)
+		data, _ := os.ReadFile(filepath.Join(root, "CHANGELOG.md"))
+		assert.Contains(t, string(data), "### Dependencies\n\n- added new feature\n")
+		assert.NotContains(t, string(data), "### Changed")
+	})
+
 	t.Run("should return false when CHANGELOG.md does not exist", func(t *testing.T) {
 		t.Parallel()
 
@@ -294,7 +376,7 @@ func TestLocalChangelogUpdate(t *testing.T) {
 		root := t.TempDir()
 
 		// when
-		updated := support.LocalChangelogUpdate(root, []string{"- added new feature"})
+		updated := support.LocalChangelogUpdate(root, []string{"- added new feature"}, entities.ChangelogSettings{})
 
 		// then
 		assert.False(t, updated)
@@ -309,7 +391,7 @@ func TestLocalChangelogUpdate(t *testing.T) {
 		require.NoError(t, os.WriteFile(filepath.Join(root, "CHANGELOG.md"), []byte(changelog), 0o600))
 
 		// when
-		updated := support.LocalChangelogUpdate(root, []string{"- added something"})
+		updated := support.LocalChangelogUpdate(root, []string{"- added something"}, entities.ChangelogSettings{})
 
 		// then
 		assert.False(t, updated)
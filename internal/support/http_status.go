@@ -0,0 +1,38 @@
+package support
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// azureStatusPattern matches the status code gitforge's Azure DevOps client
+// reports in its API errors, e.g. "API error (status 401): ...".
+var azureStatusPattern = regexp.MustCompile(`API error \(status (\d{3})\)`)
+
+// HTTPStatus returns the HTTP status code of a failed provider API call, or
+// 0 when err does not carry one (e.g. a network error).
+func HTTPStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil {
+		return ghErr.Response.StatusCode
+	}
+
+	var glErr *gitlab.ErrorResponse
+	if errors.As(err, &glErr) && glErr.Response != nil {
+		return glErr.Response.StatusCode
+	}
+
+	if m := azureStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		return status
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+//go:build unit
+
+package support_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/stretchr/testify/assert"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the status of a wrapped GitHub API error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		err := fmt.Errorf("failed to list org repos: %w",
+			&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}})
+
+		// when
+		status := support.HTTPStatus(err)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("should return the status of a wrapped GitLab API error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		err := fmt.Errorf("failed to list group projects: %w",
+			&gitlab.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+
+		// when
+		status := support.HTTPStatus(err)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("should return the status of an Azure DevOps API error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		err := errors.New("failed to get projects: API error (status 401): unauthorized")
+
+		// when
+		status := support.HTTPStatus(err)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("should return zero for an error without a status", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		err := errors.New("dial tcp: connection refused")
+
+		// when
+		status := support.HTTPStatus(err)
+
+		// then
+		assert.Zero(t, status)
+	})
+}
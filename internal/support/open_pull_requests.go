@@ -0,0 +1,97 @@
+package support
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
+)
+
+// UpgradeBranchPrefix is the prefix of every branch an updater opens a
+// pull request from, e.g. `chore/upgrade-go-deps`.
+const UpgradeBranchPrefix = "chore/upgrade-"
+
+// ErrOpenPullRequestsUnsupported is returned by GetOpenPullRequests when the
+// provider cannot list pull requests.
+var ErrOpenPullRequestsUnsupported = errors.New("provider cannot list open pull requests")
+
+// GetOpenPullRequests returns the open pull requests autoupdate opened on
+// the repository: those whose source branch starts with UpgradeBranchPrefix
+// and, when author is not empty, that author created. Source branches are
+// returned without the `refs/heads/` prefix.
+func GetOpenPullRequests(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	author string,
+) ([]entities.PullRequestDetail, error) {
+	lister, ok := provider.(repositories.OpenPullRequestLister)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOpenPullRequestsUnsupported, provider.Name())
+	}
+	prs, err := lister.ListOpenPullRequests(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open pull requests: %w", err)
+	}
+
+	var owned []entities.PullRequestDetail
+	for _, pr := range prs {
+		pr.SourceBranch = strings.TrimPrefix(pr.SourceBranch, "refs/heads/")
+		if !strings.HasPrefix(pr.SourceBranch, UpgradeBranchPrefix) {
+			continue
+		}
+		if author != "" && !strings.EqualFold(pr.Author, author) {
+			continue
+		}
+		owned = append(owned, pr)
+	}
+	return owned, nil
+}
+
+// CloseStalePullRequests closes every open autoupdate pull request whose
+// source branch isStale reports as superseded, and deletes its branch. It
+// is best-effort: failures are logged and the PR is left open. The closed
+// pull requests are returned.
+func CloseStalePullRequests(
+	ctx context.Context,
+	provider repositories.ProviderRepository,
+	repo entities.Repository,
+	isStale func(branch string) bool,
+) []entities.PullRequestDetail {
+	closer, ok := provider.(repositories.PullRequestCloser)
+	if !ok {
+		logger.Warnf("[autoupdate] Provider %q cannot close pull requests, leaving stale PRs open in %s/%s",
+			provider.Name(), repo.Organization, repo.Name)
+		return nil
+	}
+	prs, err := GetOpenPullRequests(ctx, provider, repo, "")
+	if err != nil {
+		logger.Warnf("[autoupdate] Failed to look for stale PRs in %s/%s: %v", repo.Organization, repo.Name, err)
+		return nil
+	}
+
+	var closed []entities.PullRequestDetail
+	for _, pr := range prs {
+		if !isStale(pr.SourceBranch) {
+			continue
+		}
+		if closeErr := closer.ClosePullRequest(ctx, repo, pr.ID); closeErr != nil {
+			logger.Warnf("[autoupdate] Failed to close stale PR #%d in %s/%s: %v",
+				pr.ID, repo.Organization, repo.Name, closeErr)
+			continue
+		}
+		if deleteErr := closer.DeleteBranch(ctx, repo, pr.SourceBranch); deleteErr != nil {
+			logger.Warnf("[autoupdate] Failed to delete branch %q of stale PR #%d in %s/%s: %v",
+				pr.SourceBranch, pr.ID, repo.Organization, repo.Name, deleteErr)
+		}
+		logger.Infof("[autoupdate] Closed stale PR #%d (%s) in %s/%s",
+			pr.ID, pr.SourceBranch, repo.Organization, repo.Name)
+		closed = append(closed, pr)
+	}
+	return closed
+}
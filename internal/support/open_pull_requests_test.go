@@ -0,0 +1,155 @@
+//go:build unit
+
+package support_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+	"github.com/rios0rios0/autoupdate/internal/support"
+	"github.com/rios0rios0/autoupdate/test/infrastructure/repositorydoubles"
+)
+
+func openPR(id int, branch, author string) entities.PullRequestDetail {
+	return entities.PullRequestDetail{
+		PullRequest:  entities.PullRequest{ID: id},
+		SourceBranch: branch,
+		Author:       author,
+	}
+}
+
+func TestGetOpenPullRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should keep only upgrade branches opened by the bot", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			openPR(1, "chore/upgrade-go-deps", "autoupdate-bot"),
+			openPR(2, "feat/new-endpoint", "autoupdate-bot"),
+			openPR(3, "chore/upgrade-terraform-vpc", "alice"),
+			openPR(4, "refs/heads/chore/upgrade-terraform-vpc", "AutoUpdate-Bot"),
+		}
+
+		// when
+		prs, err := support.GetOpenPullRequests(t.Context(), provider, entities.Repository{}, "autoupdate-bot")
+
+		// then
+		require.NoError(t, err)
+		require.Len(t, prs, 2)
+		assert.Equal(t, 1, prs[0].ID)
+		assert.Equal(t, 4, prs[1].ID)
+		assert.Equal(t, "chore/upgrade-terraform-vpc", prs[1].SourceBranch)
+	})
+
+	t.Run("should not filter by author when none is given", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			openPR(1, "chore/upgrade-go-deps", "autoupdate-bot"),
+			openPR(3, "chore/upgrade-terraform-vpc", "alice"),
+		}
+
+		// when
+		prs, err := support.GetOpenPullRequests(t.Context(), provider, entities.Repository{}, "")
+
+		// then
+		require.NoError(t, err)
+		assert.Len(t, prs, 2)
+	})
+
+	t.Run("should return the provider error", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.ListOpenPRsErr = errors.New("rate limited")
+
+		// when
+		prs, err := support.GetOpenPullRequests(t.Context(), provider, entities.Repository{}, "")
+
+		// then
+		require.Error(t, err)
+		assert.Nil(t, prs)
+		assert.Contains(t, err.Error(), "rate limited")
+	})
+
+	t.Run("should return ErrOpenPullRequestsUnsupported when the provider cannot list PRs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &repositorydoubles.DummyProviderRepository{}
+
+		// when
+		_, err := support.GetOpenPullRequests(t.Context(), provider, entities.Repository{}, "")
+
+		// then
+		require.ErrorIs(t, err, support.ErrOpenPullRequestsUnsupported)
+	})
+}
+
+func TestCloseStalePullRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should close the stale PRs and delete their branches", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{
+			openPR(1, "chore/upgrade-vpc-v1.0.0", "autoupdate-bot"),
+			openPR(2, "chore/upgrade-eks-v3.0.0", "autoupdate-bot"),
+		}
+		isStale := func(branch string) bool { return branch == "chore/upgrade-vpc-v1.0.0" }
+
+		// when
+		closed := support.CloseStalePullRequests(t.Context(), provider, entities.Repository{}, isStale)
+
+		// then
+		require.Len(t, closed, 1)
+		assert.Equal(t, 1, closed[0].ID)
+		assert.Equal(t, []int{1}, provider.ClosedPRs)
+		assert.Equal(t, []string{"chore/upgrade-vpc-v1.0.0"}, provider.DeletedBranches)
+	})
+
+	t.Run("should keep the branch when the PR cannot be closed", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := repositorydoubles.NewSpyProviderRepositoryBuilder().BuildSpy()
+		provider.OpenPRs = []entities.PullRequestDetail{openPR(1, "chore/upgrade-vpc-v1.0.0", "autoupdate-bot")}
+		provider.ClosePRErr = errors.New("forbidden")
+
+		// when
+		closed := support.CloseStalePullRequests(t.Context(), provider, entities.Repository{}, func(string) bool {
+			return true
+		})
+
+		// then
+		assert.Empty(t, closed)
+		assert.Empty(t, provider.DeletedBranches)
+	})
+
+	t.Run("should do nothing when the provider cannot close PRs", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		provider := &repositorydoubles.DummyProviderRepository{}
+
+		// when
+		closed := support.CloseStalePullRequests(t.Context(), provider, entities.Repository{}, func(string) bool {
+			return true
+		})
+
+		// then
+		assert.Empty(t, closed)
+	})
+}
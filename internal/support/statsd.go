@@ -0,0 +1,64 @@
+package support
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// statsDDialTimeout bounds the UDP "dial", which only resolves the address.
+const statsDDialTimeout = 5 * time.Second
+
+// StatsDMetric is a single StatsD counter or timer.
+type StatsDMetric struct {
+	Name  string
+	Value int64
+	// Type is the StatsD metric type, e.g. "c" for a counter or "ms" for a
+	// timer in milliseconds.
+	Type string
+}
+
+// StatsDCounter returns a counter metric.
+func StatsDCounter(name string, value int) StatsDMetric {
+	return StatsDMetric{Name: name, Value: int64(value), Type: "c"}
+}
+
+// StatsDTimer returns a timer metric in milliseconds.
+func StatsDTimer(name string, d time.Duration) StatsDMetric {
+	return StatsDMetric{Name: name, Value: d.Milliseconds(), Type: "ms"}
+}
+
+// SendStatsD pushes the metrics to the StatsD server at address (host:port)
+// in a single UDP datagram, each name prefixed with prefix and a dot. UDP is
+// fire-and-forget: a server that is down is not reported as an error.
+func SendStatsD(address, prefix string, metrics []StatsDMetric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	conn, err := net.DialTimeout("udp", address, statsDDialTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to reach StatsD at %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.Write([]byte(RenderStatsD(prefix, metrics))); err != nil {
+		return fmt.Errorf("failed to send metrics to StatsD at %s: %w", address, err)
+	}
+	return nil
+}
+
+// RenderStatsD renders the metrics in the StatsD line protocol, one
+// "prefix.name:value|type" line per metric.
+func RenderStatsD(prefix string, metrics []StatsDMetric) string {
+	lines := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		name := m.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		lines = append(lines, fmt.Sprintf("%s:%d|%s", name, m.Value, m.Type))
+	}
+	return strings.Join(lines, "\n")
+}
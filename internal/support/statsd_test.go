@@ -0,0 +1,85 @@
+//go:build unit
+
+package support_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestRenderStatsD(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should render prefixed counters and timers one per line", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		metrics := []support.StatsDMetric{
+			support.StatsDCounter("prs_created", 3),
+			support.StatsDTimer("run_duration", 1500*time.Millisecond),
+		}
+
+		// when
+		payload := support.RenderStatsD("autoupdate", metrics)
+
+		// then
+		assert.Equal(t, "autoupdate.prs_created:3|c\nautoupdate.run_duration:1500|ms", payload)
+	})
+
+	t.Run("should leave the names unprefixed when the prefix is empty", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		metrics := []support.StatsDMetric{support.StatsDCounter("errors", 0)}
+
+		// when
+		payload := support.RenderStatsD("", metrics)
+
+		// then
+		assert.Equal(t, "errors:0|c", payload)
+	})
+}
+
+func TestSendStatsD(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should push the metrics in a single UDP datagram", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// when
+		sendErr := support.SendStatsD(conn.LocalAddr().String(), "autoupdate",
+			[]support.StatsDMetric{support.StatsDCounter("repos_scanned", 7)})
+
+		// then
+		require.NoError(t, sendErr)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		buf := make([]byte, 1024)
+		n, _, readErr := conn.ReadFrom(buf)
+		require.NoError(t, readErr)
+		assert.Equal(t, "autoupdate.repos_scanned:7|c", string(buf[:n]))
+	})
+
+	t.Run("should return an error for an invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		address := "not-a-host-port"
+
+		// when
+		err := support.SendStatsD(address, "autoupdate", []support.StatsDMetric{support.StatsDCounter("errors", 1)})
+
+		// then
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package support
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/rios0rios0/autoupdate/internal/domain/entities"
+)
+
+// DockerfileImageVersion returns the numeric tag of the first `FROM` line
+// of repoDir/Dockerfile using one of images under any registry path (e.g.
+// "20" for `FROM ghcr.io/org/node:20-alpine`), or "" when there is none.
+func DockerfileImageVersion(repoDir string, images ...string) string {
+	content, err := os.ReadFile(filepath.Join(repoDir, "Dockerfile"))
+	if err != nil || len(images) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(images))
+	for i, image := range images {
+		quoted[i] = regexp.QuoteMeta(image)
+	}
+	re := regexp.MustCompile(`(?mi)^\s*FROM\s+(?:--\S+\s+)*(?:\S*/)?(?:` +
+		strings.Join(quoted, "|") + `):(\d+(?:\.\d+)*)`)
+	if match := re.FindStringSubmatch(string(content)); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// ResolveCurrentVersion picks the current runtime version among sources
+// with entities.ResolveCurrentVersion, warning when they disagree.
+func ResolveCurrentVersion(updater, policy string, sources []entities.VersionSource) string {
+	version, conflict := entities.ResolveCurrentVersion(policy, sources)
+	if conflict {
+		var declared []string
+		for _, source := range sources {
+			if source.Version != "" {
+				declared = append(declared, source.File+"="+source.Version)
+			}
+		}
+		if version == "" {
+			logger.Warnf("[%s] Version files disagree (%s), skipping the version upgrade",
+				updater, strings.Join(declared, ", "))
+		} else {
+			logger.Warnf("[%s] Version files disagree (%s), using %s",
+				updater, strings.Join(declared, ", "), version)
+		}
+	}
+	return version
+}
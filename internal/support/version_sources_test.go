@@ -0,0 +1,61 @@
+//go:build unit
+
+package support_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestDockerfileImageVersion(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return the tag of the image under a registry path", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		dockerfile := "FROM --platform=linux/amd64 ghcr.io/org/node:20-alpine AS build\nFROM nginx:1.27\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "Dockerfile"), []byte(dockerfile), 0o600))
+
+		// when
+		version := support.DockerfileImageVersion(repoDir, "node")
+
+		// then
+		assert.Equal(t, "20", version)
+	})
+
+	t.Run("should match any of the given images", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+		dockerfile := "FROM mirror.local/py-base:3.11.9-slim\n"
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, "Dockerfile"), []byte(dockerfile), 0o600))
+
+		// when
+		version := support.DockerfileImageVersion(repoDir, "python", "mirror.local/py-base")
+
+		// then
+		assert.Equal(t, "3.11.9", version)
+	})
+
+	t.Run("should return an empty version without a Dockerfile", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		repoDir := t.TempDir()
+
+		// when
+		version := support.DockerfileImageVersion(repoDir, "node")
+
+		// then
+		assert.Empty(t, version)
+	})
+}
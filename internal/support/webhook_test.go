@@ -0,0 +1,79 @@
+//go:build unit
+
+package support_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rios0rios0/autoupdate/internal/support"
+)
+
+func TestPostWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should post the payload as JSON", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var contentType, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType = r.Header.Get("Content-Type")
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		// when
+		err := support.PostWebhook(context.Background(), server.URL, nil, map[string]string{"text": "hello"})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", contentType)
+		assert.JSONEq(t, `{"text":"hello"}`, body)
+	})
+
+	t.Run("should send the extra headers", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		var authorization string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		headers := map[string]string{"Authorization": "Bearer secret"}
+
+		// when
+		err := support.PostWebhook(context.Background(), server.URL, headers, map[string]string{})
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer secret", authorization)
+	})
+
+	t.Run("should return an error when the receiver rejects the payload", func(t *testing.T) {
+		t.Parallel()
+
+		// given
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		// when
+		err := support.PostWebhook(context.Background(), server.URL, nil, map[string]string{"text": "hello"})
+
+		// then
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+}
@@ -4,12 +4,15 @@ package repositorydoubles
 
 import (
 	"context"
+	"sync"
 
 	"github.com/rios0rios0/autoupdate/internal/infrastructure/repositories/cmdrunner"
 )
 
 // StubCommandRunner is a test double that returns pre-configured results.
+// It is safe for concurrent use.
 type StubCommandRunner struct {
+	mu      sync.Mutex
 	Calls   []StubCommandCall
 	results []cmdrunner.RunResult
 	errors  []error
@@ -37,10 +40,22 @@ func NewStubCommandRunnerWithError(err error) *StubCommandRunner {
 	}
 }
 
+// NewStubCommandRunnerWithOutputAndError creates a StubCommandRunner that
+// returns both the given result and error, like a script that printed
+// output before failing.
+func NewStubCommandRunnerWithOutputAndError(result cmdrunner.RunResult, err error) *StubCommandRunner {
+	return &StubCommandRunner{
+		results: []cmdrunner.RunResult{result},
+		errors:  []error{err},
+	}
+}
+
 // Run returns the next pre-configured result.
 func (s *StubCommandRunner) Run(
 	_ context.Context, name string, args []string, opts cmdrunner.RunOptions,
 ) (*cmdrunner.RunResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Calls = append(s.Calls, StubCommandCall{Name: name, Args: args, Opts: opts})
 	if s.idx >= len(s.results) {
 		return &cmdrunner.RunResult{}, nil
@@ -7,13 +7,17 @@ package repositorydoubles //nolint:revive,staticcheck // Test package naming fol
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
 )
 
 // SpyProviderRepository implements repositories.ProviderRepository as a configurable spy.
+// Recording methods are safe for concurrent use.
 type SpyProviderRepository struct {
+	mu sync.Mutex
+
 	// --- identity ---
 	ProviderName string
 	Token        string
@@ -51,9 +55,46 @@ type SpyProviderRepository struct {
 	PRExistsResult   bool
 	PRExistsErr      error
 	PRExistsBranches []string
+
+	// --- LastAuthor ---
+	LastAuthors   map[string]string
+	LastAuthorErr error
+
+	// --- AddPullRequestAssignees ---
+	AddAssigneesErr error
+	PRAssignees     map[int][]string
+
+	// --- RequestPullRequestReviewers ---
+	RequestReviewersErr error
+	PRReviewers         map[int][]string
+
+	// --- ListOpenPullRequests ---
+	OpenPRs        []entities.PullRequestDetail
+	ListOpenPRsErr error
+
+	// --- AddPullRequestLabels ---
+	AddLabelsErr error
+	PRLabels     map[int][]string
+
+	// --- ClosePullRequest / DeleteBranch ---
+	ClosePRErr      error
+	ClosedPRs       []int
+	DeletedBranches []string
+
+	// --- ForHost ---
+	Hosts []string
 }
 
-var _ repositories.ProviderRepository = (*SpyProviderRepository)(nil)
+var (
+	_ repositories.ProviderRepository           = (*SpyProviderRepository)(nil)
+	_ repositories.OpenPullRequestLister        = (*SpyProviderRepository)(nil)
+	_ repositories.PullRequestLabeler           = (*SpyProviderRepository)(nil)
+	_ repositories.PullRequestAssigner          = (*SpyProviderRepository)(nil)
+	_ repositories.PullRequestReviewerRequester = (*SpyProviderRepository)(nil)
+	_ repositories.LastAuthorResolver           = (*SpyProviderRepository)(nil)
+	_ repositories.PullRequestCloser            = (*SpyProviderRepository)(nil)
+	_ repositories.SelfHostedProvider           = (*SpyProviderRepository)(nil)
+)
 
 func (p *SpyProviderRepository) Name() string     { return p.ProviderName }
 func (p *SpyProviderRepository) AuthToken() string { return p.Token }
@@ -62,6 +103,8 @@ func (p *SpyProviderRepository) MatchesURL(_ string) bool { return false }
 func (p *SpyProviderRepository) DiscoverRepositories(
 	_ context.Context, org string,
 ) ([]entities.Repository, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.DiscoveredOrgs = append(p.DiscoveredOrgs, org)
 	return p.Repositories, p.DiscoverErr
 }
@@ -104,6 +147,8 @@ func (p *SpyProviderRepository) HasFile(
 func (p *SpyProviderRepository) CreateBranchWithChanges(
 	_ context.Context, _ entities.Repository, input entities.BranchInput,
 ) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.BranchInputs = append(p.BranchInputs, input)
 	return p.CreateBranchErr
 }
@@ -111,6 +156,8 @@ func (p *SpyProviderRepository) CreateBranchWithChanges(
 func (p *SpyProviderRepository) CreatePullRequest(
 	_ context.Context, _ entities.Repository, input entities.PullRequestInput,
 ) (*entities.PullRequest, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.PRInputs = append(p.PRInputs, input)
 	if p.CreatePRErr != nil {
 		return nil, p.CreatePRErr
@@ -128,10 +175,97 @@ func (p *SpyProviderRepository) CreatePullRequest(
 func (p *SpyProviderRepository) PullRequestExists(
 	_ context.Context, _ entities.Repository, branch string,
 ) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	p.PRExistsBranches = append(p.PRExistsBranches, branch)
 	return p.PRExistsResult, p.PRExistsErr
 }
 
+func (p *SpyProviderRepository) AddPullRequestLabels(
+	_ context.Context, _ entities.Repository, pr entities.PullRequest, labels []string,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.AddLabelsErr != nil {
+		return p.AddLabelsErr
+	}
+	if p.PRLabels == nil {
+		p.PRLabels = make(map[int][]string)
+	}
+	p.PRLabels[pr.ID] = append(p.PRLabels[pr.ID], labels...)
+	return nil
+}
+
+func (p *SpyProviderRepository) LastAuthor(
+	_ context.Context, _ entities.Repository, path string,
+) (string, error) {
+	if p.LastAuthorErr != nil {
+		return "", p.LastAuthorErr
+	}
+	return p.LastAuthors[path], nil
+}
+
+func (p *SpyProviderRepository) AddPullRequestAssignees(
+	_ context.Context, _ entities.Repository, pr entities.PullRequest, assignees []string,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.AddAssigneesErr != nil {
+		return p.AddAssigneesErr
+	}
+	if p.PRAssignees == nil {
+		p.PRAssignees = make(map[int][]string)
+	}
+	p.PRAssignees[pr.ID] = append(p.PRAssignees[pr.ID], assignees...)
+	return nil
+}
+
+func (p *SpyProviderRepository) RequestPullRequestReviewers(
+	_ context.Context, _ entities.Repository, pr entities.PullRequest, reviewers []string,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.RequestReviewersErr != nil {
+		return p.RequestReviewersErr
+	}
+	if p.PRReviewers == nil {
+		p.PRReviewers = make(map[int][]string)
+	}
+	p.PRReviewers[pr.ID] = append(p.PRReviewers[pr.ID], reviewers...)
+	return nil
+}
+
+func (p *SpyProviderRepository) ListOpenPullRequests(
+	_ context.Context, _ entities.Repository,
+) ([]entities.PullRequestDetail, error) {
+	return p.OpenPRs, p.ListOpenPRsErr
+}
+
+func (p *SpyProviderRepository) ClosePullRequest(_ context.Context, _ entities.Repository, prID int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ClosePRErr != nil {
+		return p.ClosePRErr
+	}
+	p.ClosedPRs = append(p.ClosedPRs, prID)
+	return nil
+}
+
+func (p *SpyProviderRepository) DeleteBranch(_ context.Context, _ entities.Repository, branch string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.DeletedBranches = append(p.DeletedBranches, branch)
+	return nil
+}
+
+// ForHost records host and returns the spy itself.
+func (p *SpyProviderRepository) ForHost(host string) (repositories.ProviderRepository, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Hosts = append(p.Hosts, host)
+	return p, nil
+}
+
 func (p *SpyProviderRepository) SSHCloneURL(_ entities.Repository, _ string) string { return "" }
 
 func (p *SpyProviderRepository) CloneURL(repo entities.Repository) string {
@@ -4,6 +4,7 @@ package repositorydoubles //nolint:revive,staticcheck // Test package naming fol
 
 import (
 	"context"
+	"sync"
 
 	"github.com/rios0rios0/autoupdate/internal/domain/entities"
 	"github.com/rios0rios0/autoupdate/internal/domain/repositories"
@@ -11,6 +12,8 @@ import (
 
 // SpyUpdaterRepository implements repositories.UpdaterRepository as a configurable spy.
 type SpyUpdaterRepository struct {
+	mu sync.Mutex
+
 	// --- identity ---
 	UpdaterName string
 
@@ -37,6 +40,8 @@ func (u *SpyUpdaterRepository) Name() string { return u.UpdaterName }
 func (u *SpyUpdaterRepository) Detect(
 	_ context.Context, _ repositories.ProviderRepository, repo entities.Repository,
 ) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
 	u.DetectedRepos = append(u.DetectedRepos, repo)
 	return u.DetectResult
 }
@@ -47,6 +52,8 @@ func (u *SpyUpdaterRepository) CreateUpdatePRs(
 	repo entities.Repository,
 	opts entities.UpdateOptions,
 ) ([]entities.PullRequest, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
 	u.CreatePRsCalls = append(u.CreatePRsCalls, CreatePRsCall{Repo: repo, Opts: opts})
 	return u.PRs, u.CreatePRsErr
 }
@@ -14,3 +14,15 @@ type StubVersionFetcher struct {
 func (s *StubVersionFetcher) FetchLatestVersion(_ context.Context) (string, error) {
 	return s.Version, s.Err
 }
+
+// FetchLatestVersionInSeries returns the pre-configured version or error,
+// whatever the series.
+func (s *StubVersionFetcher) FetchLatestVersionInSeries(_ context.Context, _ string) (string, error) {
+	return s.Version, s.Err
+}
+
+// FetchLatestVersionInChannel returns the pre-configured version or error,
+// whatever the channel.
+func (s *StubVersionFetcher) FetchLatestVersionInChannel(_ context.Context, _ string) (string, error) {
+	return s.Version, s.Err
+}